@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	sdkErr "cosmossdk.io/errors"
@@ -14,16 +15,18 @@ import (
 	btcctypes "github.com/babylonlabs-io/babylon/x/btccheckpoint/types"
 	btclctypes "github.com/babylonlabs-io/babylon/x/btclightclient/types"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	checkpointingtypes "github.com/babylonlabs-io/babylon/x/checkpointing/types"
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	incentivetypes "github.com/babylonlabs-io/babylon/x/incentive/types"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
-	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/cosmos/relayer/v2/relayer/provider"
 	"go.uber.org/zap"
@@ -34,11 +37,24 @@ var _ ClientController = &BabylonController{}
 
 var emptyErrs = []*sdkErr.Error{}
 
+// defaultDelegationsPageSize is the number of BTC delegations queried at a time
+const defaultDelegationsPageSize = uint64(100)
+
 type BabylonController struct {
 	bbnClient *bbnclient.Client
-	cfg       *fpcfg.BBNConfig
-	btcParams *chaincfg.Params
-	logger    *zap.Logger
+	// queryClient queries the btcstaking, finality, epoching, and
+	// checkpointing modules over gRPC if enabled, and over the RPC of
+	// bbnClient otherwise
+	queryClient *babylonQueryClient
+	txSender    *babylonTxSender
+	// submitters are the senders of the finality signatures and public
+	// randomness commits, which include the main one unless the key is cold
+	// and the ones of the submitter keys, used in turn
+	submitters    []*babylonTxSender
+	nextSubmitter atomic.Uint64
+	cfg           *fpcfg.BBNConfig
+	btcParams     *chaincfg.Params
+	logger        *zap.Logger
 }
 
 func NewBabylonController(
@@ -63,16 +79,55 @@ func NewBabylonController(
 		return nil, err
 	}
 
-	return &BabylonController{
-		bc,
-		cfg,
-		btcParams,
-		logger,
-	}, nil
+	txSender, err := newBabylonTxSender(cfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Babylon tx sender: %w", err)
+	}
+
+	queryClient, err := newBabylonQueryClient(cfg, bc.QueryClient, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Babylon query client: %w", err)
+	}
+
+	controller := &BabylonController{
+		bbnClient:   bc,
+		queryClient: queryClient,
+		txSender:    txSender,
+		cfg:         cfg,
+		btcParams:   btcParams,
+		logger:      logger,
+	}
+
+	// a cold key only signs the registration and the other administrative
+	// transactions, leaving the routine submissions to the submitter keys
+	if !cfg.ColdKey {
+		controller.submitters = append(controller.submitters, txSender)
+	}
+
+	// makes sure that the submitter keys exist as well
+	for _, key := range cfg.SubmitterKeys {
+		if _, err := controller.keyAddress(key); err != nil {
+			return nil, fmt.Errorf("invalid submitter key %s: %w", key, err)
+		}
+		controller.submitters = append(controller.submitters, txSender.withKey(key))
+	}
+
+	return controller, nil
 }
 
 func (bc *BabylonController) mustGetTxSigner() string {
-	signer := bc.GetKeyAddress()
+	return bc.mustGetKeySigner(bc.cfg.Key)
+}
+
+// mustGetKeySigner returns the bech32 address of the given key
+func (bc *BabylonController) mustGetKeySigner(key string) string {
+	// get key address, retrieves address based on the key name. If this fails,
+	// it means we have a misconfiguration problem and we should panic.
+	// This is checked at the start of BabylonController, so if it fails something is really wrong
+	signer, err := bc.keyAddress(key)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to get key address: %s", err))
+	}
 	prefix := bc.cfg.AccountPrefix
 	return sdk.MustBech32ifyAddressBytes(prefix, signer)
 }
@@ -82,26 +137,29 @@ func (bc *BabylonController) GetKeyAddress() sdk.AccAddress {
 	// cfg *stakercfg.BBNConfig. If this fails, it means we have a misconfiguration problem
 	// and we should panic.
 	// This is checked at the start of BabylonController, so if it fails something is really wrong
-
-	keyRec, err := bc.bbnClient.GetKeyring().Key(bc.cfg.Key)
+	addr, err := bc.keyAddress(bc.cfg.Key)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to get key address: %s", err))
 	}
 
-	addr, err := keyRec.GetAddress()
+	return addr
+}
+
+func (bc *BabylonController) keyAddress(key string) (sdk.AccAddress, error) {
+	keyRec, err := bc.bbnClient.GetKeyring().Key(key)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to get key address: %s", err))
+		return nil, err
 	}
 
-	return addr
+	return keyRec.GetAddress()
 }
 
-func (bc *BabylonController) reliablySendMsg(msg sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
+func (bc *BabylonController) reliablySendMsg(msg sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*txResult, error) {
 	return bc.reliablySendMsgs([]sdk.Msg{msg}, expectedErrs, unrecoverableErrs)
 }
 
-func (bc *BabylonController) reliablySendMsgs(msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*provider.RelayerTxResponse, error) {
-	return bc.bbnClient.ReliablySendMsgs(
+func (bc *BabylonController) reliablySendMsgs(msgs []sdk.Msg, expectedErrs []*sdkErr.Error, unrecoverableErrs []*sdkErr.Error) (*txResult, error) {
+	return bc.txSender.reliablySendMsgs(
 		context.Background(),
 		msgs,
 		expectedErrs,
@@ -141,7 +199,7 @@ func (bc *BabylonController) RegisterFinalityProvider(
 		return nil, err
 	}
 
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events, GasUsed: res.GasUsed, Signer: fpAddr}, nil
 }
 
 // CommitPubRandList commits a list of Schnorr public randomness via a MsgCommitPubRand to Babylon
@@ -153,28 +211,9 @@ func (bc *BabylonController) CommitPubRandList(
 	commitment []byte,
 	sig *schnorr.Signature,
 ) (*types.TxResponse, error) {
-	msg := &finalitytypes.MsgCommitPubRandList{
-		Signer:      bc.mustGetTxSigner(),
-		FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
-		StartHeight: startHeight,
-		NumPubRand:  numPubRand,
-		Commitment:  commitment,
-		Sig:         bbntypes.NewBIP340SignatureFromBTCSig(sig),
-	}
-
-	unrecoverableErrs := []*sdkErr.Error{
-		finalitytypes.ErrInvalidPubRand,
-		finalitytypes.ErrTooFewPubRand,
-		finalitytypes.ErrNoPubRandYet,
-		btcstakingtypes.ErrFpNotFound,
-	}
-
-	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
-	if err != nil {
-		return nil, err
-	}
-
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return bc.NewTxBuilder().
+		AddCommitPubRandList(fpPk, startHeight, numPubRand, commitment, sig).
+		Send()
 }
 
 // SubmitFinalitySig submits the finality signature via a MsgAddVote to Babylon
@@ -185,33 +224,14 @@ func (bc *BabylonController) SubmitFinalitySig(
 	proof []byte, // TODO: have a type for proof
 	sig *btcec.ModNScalar,
 ) (*types.TxResponse, error) {
-	cmtProof := cmtcrypto.Proof{}
-	if err := cmtProof.Unmarshal(proof); err != nil {
-		return nil, err
-	}
-
-	msg := &finalitytypes.MsgAddFinalitySig{
-		Signer:       bc.mustGetTxSigner(),
-		FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
-		BlockHeight:  block.Height,
-		PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRand),
-		Proof:        &cmtProof,
-		BlockAppHash: block.Hash,
-		FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sig),
-	}
-
-	unrecoverableErrs := []*sdkErr.Error{
-		finalitytypes.ErrInvalidFinalitySig,
-		finalitytypes.ErrPubRandNotFound,
-		btcstakingtypes.ErrFpAlreadySlashed,
-	}
-
-	res, err := bc.reliablySendMsg(msg, emptyErrs, unrecoverableErrs)
-	if err != nil {
+	// the vote for the current height is liveness-critical, so it is not
+	// queued behind the bulk transactions
+	txBuilder := bc.NewTxBuilder().WithPriority(TxPriorityHigh)
+	if err := txBuilder.AddFinalitySig(fpPk, block, pubRand, proof, sig); err != nil {
 		return nil, err
 	}
 
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return txBuilder.Send()
 }
 
 // SubmitBatchFinalitySigs submits a batch of finality signatures to Babylon
@@ -226,43 +246,23 @@ func (bc *BabylonController) SubmitBatchFinalitySigs(
 		return nil, fmt.Errorf("the number of blocks %v should match the number of finality signatures %v", len(blocks), len(sigs))
 	}
 
-	msgs := make([]sdk.Msg, 0, len(blocks))
+	// the batches catch up on past blocks, so they give way to the votes
+	// for the current height
+	txBuilder := bc.NewTxBuilder().WithPriority(TxPriorityLow)
 	for i, b := range blocks {
-		cmtProof := cmtcrypto.Proof{}
-		if err := cmtProof.Unmarshal(proofList[i]); err != nil {
+		if err := txBuilder.AddFinalitySig(fpPk, b, pubRandList[i], proofList[i], sigs[i]); err != nil {
 			return nil, err
 		}
-
-		msg := &finalitytypes.MsgAddFinalitySig{
-			Signer:       bc.mustGetTxSigner(),
-			FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
-			BlockHeight:  b.Height,
-			PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRandList[i]),
-			Proof:        &cmtProof,
-			BlockAppHash: b.Hash,
-			FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sigs[i]),
-		}
-		msgs = append(msgs, msg)
-	}
-
-	unrecoverableErrs := []*sdkErr.Error{
-		finalitytypes.ErrInvalidFinalitySig,
-		finalitytypes.ErrPubRandNotFound,
-		btcstakingtypes.ErrFpAlreadySlashed,
 	}
 
-	res, err := bc.reliablySendMsgs(msgs, emptyErrs, unrecoverableErrs)
-	if err != nil {
-		return nil, err
-	}
-
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return txBuilder.Send()
 }
 
 // UnjailFinalityProvider sends an unjail transaction to the consumer chain
 func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	signer := bc.mustGetTxSigner()
 	msg := &finalitytypes.MsgUnjailFinalityProvider{
-		Signer:  bc.mustGetTxSigner(),
+		Signer:  signer,
 		FpBtcPk: bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
 	}
 
@@ -277,12 +277,71 @@ func (bc *BabylonController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*typ
 		return nil, err
 	}
 
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events, GasUsed: res.GasUsed, Signer: signer}, nil
+}
+
+// QueryFinalityProviderRewards returns the finality provider rewards of the
+// signer address which are not withdrawn yet
+func (bc *BabylonController) QueryFinalityProviderRewards() (sdk.Coins, error) {
+	signer := bc.mustGetTxSigner()
+	res, err := bc.bbnClient.QueryClient.RewardGauges(signer)
+	if err != nil {
+		// the gauge only exists once the first rewards are distributed
+		if strings.Contains(err.Error(), incentivetypes.ErrRewardGaugeNotFound.Error()) {
+			return sdk.NewCoins(), nil
+		}
+		return nil, fmt.Errorf("failed to query the rewards of %s: %w", signer, err)
+	}
+
+	gauge, ok := res.RewardGauges[incentivetypes.FinalityProviderType.String()]
+	if !ok {
+		return sdk.NewCoins(), nil
+	}
+
+	return gauge.GetWithdrawableCoins(), nil
+}
+
+// WithdrawFinalityProviderRewards withdraws the finality provider rewards to
+// the signer address. If recipient is not empty, the withdrawn rewards are
+// sent to it within the same transaction. It returns the withdrawn rewards
+func (bc *BabylonController) WithdrawFinalityProviderRewards(recipient string) (*types.TxResponse, sdk.Coins, error) {
+	rewards, err := bc.QueryFinalityProviderRewards()
+	if err != nil {
+		return nil, nil, err
+	}
+	if rewards.IsZero() {
+		return nil, nil, incentivetypes.ErrNoWithdrawableCoins
+	}
+
+	signer := bc.mustGetTxSigner()
+	msgs := []sdk.Msg{&incentivetypes.MsgWithdrawReward{
+		Type:    incentivetypes.FinalityProviderType.String(),
+		Address: signer,
+	}}
+	if recipient != "" && recipient != signer {
+		msgs = append(msgs, &banktypes.MsgSend{
+			FromAddress: signer,
+			ToAddress:   recipient,
+			Amount:      rewards,
+		})
+	}
+
+	unrecoverableErrs := []*sdkErr.Error{
+		incentivetypes.ErrRewardGaugeNotFound,
+		incentivetypes.ErrNoWithdrawableCoins,
+	}
+
+	res, err := bc.reliablySendMsgs(msgs, emptyErrs, unrecoverableErrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events, GasUsed: res.GasUsed, Signer: signer}, rewards, nil
 }
 
 func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (slashed bool, jailed bool, err error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
-	res, err := bc.bbnClient.QueryClient.FinalityProvider(fpPubKey.MarshalHex())
+	res, err := bc.queryClient.FinalityProvider(fpPubKey.MarshalHex())
 	if err != nil {
 		return false, false, fmt.Errorf("failed to query the finality provider %s: %v", fpPubKey.MarshalHex(), err)
 	}
@@ -290,9 +349,54 @@ func (bc *BabylonController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.Pu
 	return res.FinalityProvider.SlashedBtcHeight > 0, res.FinalityProvider.Jailed, nil
 }
 
+// QueryFinalityProviderDelegations returns a page of the BTC delegations to the
+// finality provider
+func (bc *BabylonController) QueryFinalityProviderDelegations(
+	fpPk *btcec.PublicKey,
+	pageKey []byte,
+	limit uint64,
+) ([]*types.Delegation, []byte, error) {
+	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
+	pagination := &sdkquery.PageRequest{
+		Key:   pageKey,
+		Limit: limit,
+	}
+
+	res, err := bc.queryClient.FinalityProviderDelegations(fpPubKey.MarshalHex(), pagination)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query the delegations of %s: %w", fpPubKey.MarshalHex(), err)
+	}
+
+	var dels []*types.Delegation
+	for _, delegatorDels := range res.BtcDelegatorDelegations {
+		for _, d := range delegatorDels.Dels {
+			stakingTx, _, err := bbntypes.NewBTCTxFromHex(d.StakingTxHex)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid staking tx of a delegation to %s: %w", fpPubKey.MarshalHex(), err)
+			}
+			dels = append(dels, &types.Delegation{
+				StakerAddr:    d.StakerAddr,
+				BtcPkHex:      d.BtcPk.MarshalHex(),
+				StakingTxHash: stakingTx.TxHash().String(),
+				TotalSat:      d.TotalSat,
+				StartHeight:   d.StartHeight,
+				EndHeight:     d.EndHeight,
+				Status:        d.StatusDesc,
+			})
+		}
+	}
+
+	var nextKey []byte
+	if res.Pagination != nil {
+		nextKey = res.Pagination.NextKey
+	}
+
+	return dels, nextKey, nil
+}
+
 // QueryFinalityProviderVotingPower queries the voting power of the finality provider at a given height
 func (bc *BabylonController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.FinalityProviderPowerAtHeight(
+	res, err := bc.queryClient.FinalityProviderPowerAtHeight(
 		bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex(),
 		blockHeight,
 	)
@@ -317,7 +421,7 @@ func (bc *BabylonController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey,
 		Reverse: true,
 	}
 
-	res, err := bc.bbnClient.QueryClient.ListPubRandCommit(fpBtcPk.MarshalHex(), pagination)
+	res, err := bc.queryClient.ListPubRandCommit(fpBtcPk.MarshalHex(), pagination)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query committed public randomness: %w", err)
 	}
@@ -344,7 +448,7 @@ func (bc *BabylonController) queryLatestBlocks(startKey []byte, count uint64, st
 		Key:     startKey,
 	}
 
-	res, err := bc.bbnClient.QueryClient.ListBlocks(status, pagination)
+	res, err := bc.queryClient.ListBlocks(status, pagination)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query finalized blocks: %v", err)
 	}
@@ -366,7 +470,7 @@ func getContextWithCancel(timeout time.Duration) (context.Context, context.Cance
 }
 
 func (bc *BabylonController) QueryBlock(height uint64) (*types.BlockInfo, error) {
-	res, err := bc.bbnClient.QueryClient.Block(height)
+	res, err := bc.queryClient.Block(height)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query indexed block at height %v: %w", height, err)
 	}
@@ -379,7 +483,7 @@ func (bc *BabylonController) QueryBlock(height uint64) (*types.BlockInfo, error)
 }
 
 func (bc *BabylonController) QueryActivatedHeight() (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.ActivatedHeight()
+	res, err := bc.queryClient.ActivatedHeight()
 	if err != nil {
 		return 0, fmt.Errorf("failed to query activated height: %w", err)
 	}
@@ -387,6 +491,52 @@ func (bc *BabylonController) QueryActivatedHeight() (uint64, error) {
 	return res.Height, nil
 }
 
+func (bc *BabylonController) QueryChainID() (string, error) {
+	ctx, cancel := getContextWithCancel(bc.cfg.Timeout)
+	defer cancel()
+
+	status, err := bc.bbnClient.RPCClient.Status(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to query the node status: %w", err)
+	}
+
+	return status.NodeInfo.Network, nil
+}
+
+func (bc *BabylonController) QueryGenesisBlockHash() ([]byte, error) {
+	ctx, cancel := getContextWithCancel(bc.cfg.Timeout)
+	defer cancel()
+
+	height := int64(1)
+	res, err := bc.bbnClient.RPCClient.Header(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the block at height 1, which might be pruned by the node: %w", err)
+	}
+
+	return res.Header.Hash(), nil
+}
+
+func (bc *BabylonController) QueryNodeVersion() (string, error) {
+	ctx, cancel := getContextWithCancel(bc.cfg.Timeout)
+	defer cancel()
+
+	res, err := bc.bbnClient.RPCClient.ABCIInfo(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to query the application info of the node: %w", err)
+	}
+
+	return res.Response.Version, nil
+}
+
+func (bc *BabylonController) QueryFinalityParams() (*finalitytypes.Params, error) {
+	res, err := bc.queryClient.FinalityParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the finality params: %w", err)
+	}
+
+	return &res.Params, nil
+}
+
 func (bc *BabylonController) QueryBestBlock() (*types.BlockInfo, error) {
 	blocks, err := bc.queryLatestBlocks(nil, 1, finalitytypes.QueriedBlockStatus_ANY, true)
 	if err != nil || len(blocks) != 1 {
@@ -420,6 +570,10 @@ func (bc *BabylonController) queryCometBestBlock() (*types.BlockInfo, error) {
 }
 
 func (bc *BabylonController) Close() error {
+	if err := bc.queryClient.Close(); err != nil {
+		return err
+	}
+
 	if !bc.bbnClient.IsRunning() {
 		return nil
 	}
@@ -487,7 +641,7 @@ func (bc *BabylonController) InsertBtcBlockHeaders(headers []bbntypes.BTCHeaderB
 		return nil, err
 	}
 
-	return res, nil
+	return res.RelayerTxResponse, nil
 }
 
 func (bc *BabylonController) QueryFinalityProviders() ([]*btcstakingtypes.FinalityProviderResponse, error) {
@@ -497,7 +651,7 @@ func (bc *BabylonController) QueryFinalityProviders() ([]*btcstakingtypes.Finali
 	}
 
 	for {
-		res, err := bc.bbnClient.QueryClient.FinalityProviders(pagination)
+		res, err := bc.queryClient.FinalityProviders(pagination)
 		if err != nil {
 			return nil, fmt.Errorf("failed to query finality providers: %v", err)
 		}
@@ -514,7 +668,7 @@ func (bc *BabylonController) QueryFinalityProviders() ([]*btcstakingtypes.Finali
 
 func (bc *BabylonController) QueryFinalityProvider(fpPk *btcec.PublicKey) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
 	fpPubKey := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk)
-	res, err := bc.bbnClient.QueryClient.FinalityProvider(fpPubKey.MarshalHex())
+	res, err := bc.queryClient.FinalityProvider(fpPubKey.MarshalHex())
 	if err != nil {
 		return nil, fmt.Errorf("failed to query the finality provider %s: %v", fpPubKey.MarshalHex(), err)
 	}
@@ -570,7 +724,7 @@ func (bc *BabylonController) EditFinalityProvider(fpPk *btcec.PublicKey,
 
 	_, err = bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query the finality provider %s: %v", fpPk.SerializeCompressed(), err)
+		return nil, fmt.Errorf("failed to edit the finality provider %s: %w", fpPubKey.MarshalHex(), err)
 	}
 
 	return msg, nil
@@ -586,18 +740,28 @@ func (bc *BabylonController) QueryBtcLightClientTip() (*btclctypes.BTCHeaderInfo
 }
 
 func (bc *BabylonController) QueryCurrentEpoch() (uint64, error) {
-	res, err := bc.bbnClient.QueryClient.CurrentEpoch()
+	res, err := bc.queryClient.CurrentEpoch()
 	if err != nil {
-		return 0, fmt.Errorf("failed to query BTC tip: %v", err)
+		return 0, fmt.Errorf("failed to query the current epoch: %w", err)
 	}
 
 	return res.CurrentEpoch, nil
 }
 
+func (bc *BabylonController) QueryLastFinalizedEpoch() (uint64, error) {
+	// the epoch 0 is finalized at genesis, so there is always one
+	res, err := bc.queryClient.LastCheckpointWithStatus(checkpointingtypes.Finalized)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query the last finalized epoch: %w", err)
+	}
+
+	return res.RawCheckpoint.EpochNum, nil
+}
+
 func (bc *BabylonController) QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error) {
-	res, err := bc.bbnClient.QueryClient.VotesAtHeight(height)
+	res, err := bc.queryClient.VotesAtHeight(height)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query BTC delegations: %w", err)
+		return nil, fmt.Errorf("failed to query the votes at height %v: %w", height, err)
 	}
 
 	return res.BtcPks, nil
@@ -611,20 +775,50 @@ func (bc *BabylonController) QueryActiveDelegations(limit uint64) ([]*btcstaking
 	return bc.queryDelegationsWithStatus(btcstakingtypes.BTCDelegationStatus_ACTIVE, limit)
 }
 
-// queryDelegationsWithStatus queries BTC delegations
-// with the given status (either pending or unbonding)
-// it is only used when the program is running in Covenant mode
+// queryDelegationsWithStatus queries at most limit BTC delegations with the
+// given status, page by page
 func (bc *BabylonController) queryDelegationsWithStatus(status btcstakingtypes.BTCDelegationStatus, limit uint64) ([]*btcstakingtypes.BTCDelegationResponse, error) {
-	pagination := &sdkquery.PageRequest{
-		Limit: limit,
+	var dels []*btcstakingtypes.BTCDelegationResponse
+	err := bc.IterateDelegationsWithStatus(status, min(limit, defaultDelegationsPageSize), func(del *btcstakingtypes.BTCDelegationResponse) (bool, error) {
+		dels = append(dels, del)
+		return uint64(len(dels)) < limit, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := bc.bbnClient.QueryClient.BTCDelegations(status, pagination)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query BTC delegations: %v", err)
+	return dels, nil
+}
+
+// IterateDelegationsWithStatus calls fn on each BTC delegation with the given
+// status, which are queried pageSize at a time so that the responses stay
+// small and the delegations are not all loaded in memory. The iteration stops
+// once fn returns false or an error
+func (bc *BabylonController) IterateDelegationsWithStatus(
+	status btcstakingtypes.BTCDelegationStatus,
+	pageSize uint64,
+	fn func(del *btcstakingtypes.BTCDelegationResponse) (bool, error),
+) error {
+	pagination := &sdkquery.PageRequest{
+		Limit: pageSize,
 	}
 
-	return res.BtcDelegations, nil
+	for {
+		res, err := bc.queryClient.BTCDelegations(status, pagination)
+		if err != nil {
+			return fmt.Errorf("failed to query BTC delegations: %w", err)
+		}
+		for _, del := range res.BtcDelegations {
+			next, err := fn(del)
+			if err != nil || !next {
+				return err
+			}
+		}
+		if res.Pagination == nil || res.Pagination.NextKey == nil {
+			return nil
+		}
+		pagination.Key = res.Pagination.NextKey
+	}
 }
 
 func (bc *BabylonController) QueryStakingParams() (*types.StakingParams, error) {
@@ -635,7 +829,7 @@ func (bc *BabylonController) QueryStakingParams() (*types.StakingParams, error)
 	}
 
 	// query btc staking params
-	stakingParamRes, err := bc.bbnClient.QueryClient.BTCStakingParams()
+	stakingParamRes, err := bc.queryClient.BTCStakingParams()
 	if err != nil {
 		return nil, fmt.Errorf("failed to query staking params: %v", err)
 	}
@@ -679,12 +873,13 @@ func (bc *BabylonController) SubmitCovenantSigs(
 		SlashingUnbondingTxSigs: unbondingSlashingSigs,
 	}
 
-	res, err := bc.reliablySendMsg(msg, emptyErrs, emptyErrs)
+	ctx := withTxPriority(context.Background(), TxPriorityLow)
+	res, err := bc.txSender.reliablySendMsgs(ctx, []sdk.Msg{msg}, emptyErrs, emptyErrs)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events}, nil
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events, GasUsed: res.GasUsed}, nil
 }
 
 func (bc *BabylonController) GetBBNClient() *bbnclient.Client {
@@ -702,5 +897,5 @@ func (bc *BabylonController) InsertSpvProofs(submitter string, proofs []*btcctyp
 		return nil, err
 	}
 
-	return res, nil
+	return res.RelayerTxResponse, nil
 }
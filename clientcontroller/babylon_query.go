@@ -0,0 +1,379 @@
+package clientcontroller
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	bbnapp "github.com/babylonlabs-io/babylon/app"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	checkpointingtypes "github.com/babylonlabs-io/babylon/x/checkpointing/types"
+	epochingtypes "github.com/babylonlabs-io/babylon/x/epoching/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkquerytypes "github.com/cosmos/cosmos-sdk/types/query"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// moduleQuerier runs the queries to the modules of Babylon, which is
+// implemented by the query client of the Babylon client over CometBFT RPC
+type moduleQuerier interface {
+	QueryBTCStaking(f func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error) error
+	QueryFinality(f func(ctx context.Context, queryClient finalitytypes.QueryClient) error) error
+	QueryEpoching(f func(ctx context.Context, queryClient epochingtypes.QueryClient) error) error
+	QueryCheckpointing(f func(ctx context.Context, queryClient checkpointingtypes.QueryClient) error) error
+}
+
+// babylonQueryClient queries the btcstaking, finality, epoching, and
+// checkpointing modules of Babylon over native gRPC through a pool of
+// connections, and falls back to CometBFT RPC if the gRPC server is
+// unavailable. Without connections, all the queries go through CometBFT RPC
+type babylonQueryClient struct {
+	rpc     moduleQuerier
+	conns   []*grpc.ClientConn
+	next    atomic.Uint64
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+func newBabylonQueryClient(cfg *fpcfg.BBNConfig, rpc moduleQuerier, logger *zap.Logger) (*babylonQueryClient, error) {
+	c := &babylonQueryClient{
+		rpc:     rpc,
+		timeout: cfg.Timeout,
+		logger:  logger,
+	}
+	if !cfg.GRPCQueries {
+		return c, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.GRPCTLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	encCfg := bbnapp.GetEncodingConfig()
+	grpcCodec := codec.NewProtoCodec(encCfg.InterfaceRegistry).GRPCCodec()
+
+	// the connections are established lazily, so an unavailable gRPC
+	// server does not prevent the queries from falling back to RPC
+	for i := uint32(0); i < cfg.GRPCPoolSize; i++ {
+		conn, err := grpc.Dial(
+			grpcTarget(cfg.GRPCAddr),
+			grpc.WithTransportCredentials(creds),
+			grpc.WithDefaultCallOptions(grpc.ForceCodec(grpcCodec)),
+		)
+		if err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("failed to connect to the gRPC server %s: %w", cfg.GRPCAddr, err)
+		}
+		c.conns = append(c.conns, conn)
+	}
+
+	return c, nil
+}
+
+// grpcTarget strips the scheme of the gRPC address, which is commonly set as
+// a URL in the Babylon configs
+func grpcTarget(addr string) string {
+	for _, scheme := range []string{"https://", "http://", "tcp://"} {
+		if strings.HasPrefix(addr, scheme) {
+			return strings.TrimPrefix(addr, scheme)
+		}
+	}
+
+	return addr
+}
+
+// conn returns the next connection of the pool, or nil if gRPC is disabled
+func (c *babylonQueryClient) conn() *grpc.ClientConn {
+	if len(c.conns) == 0 {
+		return nil
+	}
+
+	return c.conns[(c.next.Add(1)-1)%uint64(len(c.conns))]
+}
+
+// grpcQuery runs the gRPC query, and returns whether it has to fall back to
+// RPC as the gRPC server is unavailable
+func (c *babylonQueryClient) grpcQuery(query func(ctx context.Context) error) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	err := query(ctx)
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Unimplemented:
+		c.logger.Debug("the gRPC query failed, falling back to RPC", zap.Error(err))
+		return true, err
+	default:
+		return false, err
+	}
+}
+
+func (c *babylonQueryClient) queryBTCStaking(f func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error) error {
+	if conn := c.conn(); conn != nil {
+		fallback, err := c.grpcQuery(func(ctx context.Context) error {
+			return f(ctx, btcstakingtypes.NewQueryClient(conn))
+		})
+		if !fallback {
+			return err
+		}
+	}
+
+	return c.rpc.QueryBTCStaking(f)
+}
+
+func (c *babylonQueryClient) queryFinality(f func(ctx context.Context, queryClient finalitytypes.QueryClient) error) error {
+	if conn := c.conn(); conn != nil {
+		fallback, err := c.grpcQuery(func(ctx context.Context) error {
+			return f(ctx, finalitytypes.NewQueryClient(conn))
+		})
+		if !fallback {
+			return err
+		}
+	}
+
+	return c.rpc.QueryFinality(f)
+}
+
+func (c *babylonQueryClient) queryEpoching(f func(ctx context.Context, queryClient epochingtypes.QueryClient) error) error {
+	if conn := c.conn(); conn != nil {
+		fallback, err := c.grpcQuery(func(ctx context.Context) error {
+			return f(ctx, epochingtypes.NewQueryClient(conn))
+		})
+		if !fallback {
+			return err
+		}
+	}
+
+	return c.rpc.QueryEpoching(f)
+}
+
+func (c *babylonQueryClient) queryCheckpointing(f func(ctx context.Context, queryClient checkpointingtypes.QueryClient) error) error {
+	if conn := c.conn(); conn != nil {
+		fallback, err := c.grpcQuery(func(ctx context.Context) error {
+			return f(ctx, checkpointingtypes.NewQueryClient(conn))
+		})
+		if !fallback {
+			return err
+		}
+	}
+
+	return c.rpc.QueryCheckpointing(f)
+}
+
+// Close closes the gRPC connections
+func (c *babylonQueryClient) Close() error {
+	var closeErr error
+	for _, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			closeErr = err
+		}
+	}
+
+	return closeErr
+}
+
+func (c *babylonQueryClient) BTCStakingParams() (*btcstakingtypes.QueryParamsResponse, error) {
+	var resp *btcstakingtypes.QueryParamsResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.Params(ctx, &btcstakingtypes.QueryParamsRequest{})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) FinalityProvider(fpBtcPkHex string) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	var resp *btcstakingtypes.QueryFinalityProviderResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.FinalityProvider(ctx, &btcstakingtypes.QueryFinalityProviderRequest{
+			FpBtcPkHex: fpBtcPkHex,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) FinalityProviders(pagination *sdkquerytypes.PageRequest) (*btcstakingtypes.QueryFinalityProvidersResponse, error) {
+	var resp *btcstakingtypes.QueryFinalityProvidersResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.FinalityProviders(ctx, &btcstakingtypes.QueryFinalityProvidersRequest{
+			Pagination: pagination,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) FinalityProviderDelegations(
+	fpBtcPkHex string,
+	pagination *sdkquerytypes.PageRequest,
+) (*btcstakingtypes.QueryFinalityProviderDelegationsResponse, error) {
+	var resp *btcstakingtypes.QueryFinalityProviderDelegationsResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.FinalityProviderDelegations(ctx, &btcstakingtypes.QueryFinalityProviderDelegationsRequest{
+			FpBtcPkHex: fpBtcPkHex,
+			Pagination: pagination,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) BTCDelegations(
+	delStatus btcstakingtypes.BTCDelegationStatus,
+	pagination *sdkquerytypes.PageRequest,
+) (*btcstakingtypes.QueryBTCDelegationsResponse, error) {
+	var resp *btcstakingtypes.QueryBTCDelegationsResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.BTCDelegations(ctx, &btcstakingtypes.QueryBTCDelegationsRequest{
+			Status:     delStatus,
+			Pagination: pagination,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) FinalityProviderPowerAtHeight(
+	fpBtcPkHex string,
+	height uint64,
+) (*btcstakingtypes.QueryFinalityProviderPowerAtHeightResponse, error) {
+	var resp *btcstakingtypes.QueryFinalityProviderPowerAtHeightResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.FinalityProviderPowerAtHeight(ctx, &btcstakingtypes.QueryFinalityProviderPowerAtHeightRequest{
+			FpBtcPkHex: fpBtcPkHex,
+			Height:     height,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) ActivatedHeight() (*btcstakingtypes.QueryActivatedHeightResponse, error) {
+	var resp *btcstakingtypes.QueryActivatedHeightResponse
+	err := c.queryBTCStaking(func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.ActivatedHeight(ctx, &btcstakingtypes.QueryActivatedHeightRequest{})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) FinalityParams() (*finalitytypes.QueryParamsResponse, error) {
+	var resp *finalitytypes.QueryParamsResponse
+	err := c.queryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.Params(ctx, &finalitytypes.QueryParamsRequest{})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) VotesAtHeight(height uint64) (*finalitytypes.QueryVotesAtHeightResponse, error) {
+	var resp *finalitytypes.QueryVotesAtHeightResponse
+	err := c.queryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.VotesAtHeight(ctx, &finalitytypes.QueryVotesAtHeightRequest{
+			Height: height,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) ListPubRandCommit(
+	fpBtcPkHex string,
+	pagination *sdkquerytypes.PageRequest,
+) (*finalitytypes.QueryListPubRandCommitResponse, error) {
+	var resp *finalitytypes.QueryListPubRandCommitResponse
+	err := c.queryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.ListPubRandCommit(ctx, &finalitytypes.QueryListPubRandCommitRequest{
+			FpBtcPkHex: fpBtcPkHex,
+			Pagination: pagination,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) ListBlocks(
+	blockStatus finalitytypes.QueriedBlockStatus,
+	pagination *sdkquerytypes.PageRequest,
+) (*finalitytypes.QueryListBlocksResponse, error) {
+	var resp *finalitytypes.QueryListBlocksResponse
+	err := c.queryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.ListBlocks(ctx, &finalitytypes.QueryListBlocksRequest{
+			Status:     blockStatus,
+			Pagination: pagination,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) Block(height uint64) (*finalitytypes.QueryBlockResponse, error) {
+	var resp *finalitytypes.QueryBlockResponse
+	err := c.queryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.Block(ctx, &finalitytypes.QueryBlockRequest{
+			Height: height,
+		})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) CurrentEpoch() (*epochingtypes.QueryCurrentEpochResponse, error) {
+	var resp *epochingtypes.QueryCurrentEpochResponse
+	err := c.queryEpoching(func(ctx context.Context, queryClient epochingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.CurrentEpoch(ctx, &epochingtypes.QueryCurrentEpochRequest{})
+		return err
+	})
+
+	return resp, err
+}
+
+func (c *babylonQueryClient) LastCheckpointWithStatus(
+	ckptStatus checkpointingtypes.CheckpointStatus,
+) (*checkpointingtypes.QueryLastCheckpointWithStatusResponse, error) {
+	var resp *checkpointingtypes.QueryLastCheckpointWithStatusResponse
+	err := c.queryCheckpointing(func(ctx context.Context, queryClient checkpointingtypes.QueryClient) error {
+		var err error
+		resp, err = queryClient.LastCheckpointWithStatus(ctx, &checkpointingtypes.QueryLastCheckpointWithStatusRequest{
+			Status: ckptStatus,
+		})
+		return err
+	})
+
+	return resp, err
+}
@@ -0,0 +1,162 @@
+package clientcontroller
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	bbnapp "github.com/babylonlabs-io/babylon/app"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	checkpointingtypes "github.com/babylonlabs-io/babylon/x/checkpointing/types"
+	epochingtypes "github.com/babylonlabs-io/babylon/x/epoching/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkquerytypes "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// btcStakingServer serves the activated height over gRPC
+type btcStakingServer struct {
+	btcstakingtypes.UnimplementedQueryServer
+	height uint64
+}
+
+func (s *btcStakingServer) ActivatedHeight(context.Context, *btcstakingtypes.QueryActivatedHeightRequest) (*btcstakingtypes.QueryActivatedHeightResponse, error) {
+	return &btcstakingtypes.QueryActivatedHeightResponse{Height: s.height}, nil
+}
+
+func (s *btcStakingServer) FinalityProvider(context.Context, *btcstakingtypes.QueryFinalityProviderRequest) (*btcstakingtypes.QueryFinalityProviderResponse, error) {
+	return nil, status.Error(codes.NotFound, btcstakingtypes.ErrFpNotFound.Error())
+}
+
+// rpcQuerier answers the queries falling back to RPC
+type rpcQuerier struct {
+	btcstakingtypes.QueryClient
+	height  uint64
+	dels    []*btcstakingtypes.BTCDelegationResponse
+	queries int
+}
+
+func (q *rpcQuerier) BTCDelegations(_ context.Context, req *btcstakingtypes.QueryBTCDelegationsRequest, _ ...grpc.CallOption) (*btcstakingtypes.QueryBTCDelegationsResponse, error) {
+	start := 0
+	if req.Pagination.Key != nil {
+		start = int(req.Pagination.Key[0])
+	}
+	end := min(start+int(req.Pagination.Limit), len(q.dels))
+	res := &btcstakingtypes.QueryBTCDelegationsResponse{
+		BtcDelegations: q.dels[start:end],
+		Pagination:     &sdkquerytypes.PageResponse{},
+	}
+	if end < len(q.dels) {
+		res.Pagination.NextKey = []byte{byte(end)}
+	}
+	return res, nil
+}
+
+func (q *rpcQuerier) ActivatedHeight(context.Context, *btcstakingtypes.QueryActivatedHeightRequest, ...grpc.CallOption) (*btcstakingtypes.QueryActivatedHeightResponse, error) {
+	return &btcstakingtypes.QueryActivatedHeightResponse{Height: q.height}, nil
+}
+
+func (q *rpcQuerier) QueryBTCStaking(f func(ctx context.Context, queryClient btcstakingtypes.QueryClient) error) error {
+	q.queries++
+	return f(context.Background(), q)
+}
+
+func (q *rpcQuerier) QueryFinality(func(ctx context.Context, queryClient finalitytypes.QueryClient) error) error {
+	panic("not expected")
+}
+
+func (q *rpcQuerier) QueryEpoching(func(ctx context.Context, queryClient epochingtypes.QueryClient) error) error {
+	panic("not expected")
+}
+
+func (q *rpcQuerier) QueryCheckpointing(func(ctx context.Context, queryClient checkpointingtypes.QueryClient) error) error {
+	panic("not expected")
+}
+
+func TestBabylonQueryClient(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	encCfg := bbnapp.GetEncodingConfig()
+	server := grpc.NewServer(grpc.ForceServerCodec(codec.NewProtoCodec(encCfg.InterfaceRegistry).GRPCCodec()))
+	btcstakingtypes.RegisterQueryServer(server, &btcStakingServer{height: 10})
+	go func() {
+		_ = server.Serve(lis)
+	}()
+
+	cfg := fpcfg.DefaultBBNConfig()
+	cfg.GRPCAddr = "https://" + lis.Addr().String()
+	cfg.GRPCQueries = true
+	cfg.GRPCPoolSize = 2
+	cfg.Timeout = time.Second
+	rpc := &rpcQuerier{height: 20}
+	qc, err := newBabylonQueryClient(&cfg, rpc, zap.NewNop())
+	require.NoError(t, err)
+	defer qc.Close()
+	require.Len(t, qc.conns, 2)
+
+	// the queries go through gRPC over the connections in turn
+	for i := 0; i < 3; i++ {
+		res, err := qc.ActivatedHeight()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), res.Height)
+	}
+	require.Equal(t, uint64(3), qc.next.Load())
+	require.Zero(t, rpc.queries)
+
+	// the errors of the chain are returned without falling back
+	_, err = qc.FinalityProvider("pk")
+	require.ErrorContains(t, err, btcstakingtypes.ErrFpNotFound.Error())
+	require.Zero(t, rpc.queries)
+
+	// the queries fall back to RPC once the gRPC server is unavailable
+	server.Stop()
+	res, err := qc.ActivatedHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), res.Height)
+	require.Equal(t, 1, rpc.queries)
+
+	// all the queries go through RPC if gRPC is disabled
+	cfg.GRPCQueries = false
+	qc, err = newBabylonQueryClient(&cfg, rpc, zap.NewNop())
+	require.NoError(t, err)
+	require.Empty(t, qc.conns)
+	_, err = qc.ActivatedHeight()
+	require.NoError(t, err)
+	require.Equal(t, 2, rpc.queries)
+}
+
+func TestQueryDelegationsWithStatus(t *testing.T) {
+	rpc := &rpcQuerier{}
+	for i := 0; i < 250; i++ {
+		rpc.dels = append(rpc.dels, &btcstakingtypes.BTCDelegationResponse{TotalSat: uint64(i)})
+	}
+	bc := &BabylonController{queryClient: &babylonQueryClient{rpc: rpc}}
+
+	// the delegations are queried page by page up to the limit
+	dels, err := bc.QueryPendingDelegations(1000)
+	require.NoError(t, err)
+	require.Len(t, dels, 250)
+	require.Equal(t, 3, rpc.queries)
+	dels, err = bc.QueryPendingDelegations(10)
+	require.NoError(t, err)
+	require.Len(t, dels, 10)
+	require.Equal(t, 4, rpc.queries)
+
+	// the iteration stops once the callback returns false
+	var seen int
+	err = bc.IterateDelegationsWithStatus(btcstakingtypes.BTCDelegationStatus_PENDING, 20, func(del *btcstakingtypes.BTCDelegationResponse) (bool, error) {
+		seen++
+		return del.TotalSat < 30, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 31, seen)
+	require.Equal(t, 6, rpc.queries)
+}
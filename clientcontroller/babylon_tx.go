@@ -0,0 +1,575 @@
+package clientcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	sdkErr "cosmossdk.io/errors"
+	"github.com/avast/retry-go/v4"
+	bbnapp "github.com/babylonlabs-io/babylon/app"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/cometbft/cometbft/mempool"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/relayer/v2/relayer/chains/cosmos"
+	"github.com/cosmos/relayer/v2/relayer/provider"
+	"github.com/juju/fslock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// Variables used for retrying the tx submission, the same as the Babylon client
+var (
+	txRtyAttNum = uint(5)
+	txRtyAtt    = retry.Attempts(txRtyAttNum)
+	txRtyDel    = retry.Delay(time.Millisecond * 400)
+	txRtyErr    = retry.LastErrorOnly(true)
+)
+
+const txInclusionPollInterval = 100 * time.Millisecond
+
+var errTxInclusionTimeout = errors.New("timed out waiting for the tx to be included")
+
+// babylonTxSender builds, signs, and broadcasts Babylon transactions, and waits
+// for their inclusion. Unlike the Babylon client, the gas limit of each
+// transaction is derived from the simulated gas and the gas options of the
+// message types it contains
+type babylonTxSender struct {
+	// key is the name of the key in the keyring that signs the transactions
+	key string
+	// seqs tracks the account sequence of the key, so that a transaction
+	// can be broadcast while the previous ones wait for their inclusion.
+	// Each key has its own sender, so the submissions of different keys
+	// do not wait for each other at all
+	seqs   *sequenceManager
+	cp     *cosmos.CosmosProvider
+	cfg    *fpcfg.BBNConfig
+	logger *zap.Logger
+}
+
+func newBabylonTxSender(cfg *fpcfg.BBNConfig, logger *zap.Logger) (*babylonTxSender, error) {
+	bbnCfg := fpcfg.BBNConfigToBabylonConfig(cfg)
+	p, err := bbnCfg.ToCosmosProviderConfig().NewProvider(
+		logger,
+		"",
+		true,
+		"babylon",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	cp := p.(*cosmos.CosmosProvider)
+	cp.PCfg.KeyDirectory = cfg.KeyDirectory
+
+	// the codecs of the Babylon app are needed to encode the Babylon messages
+	encCfg := bbnapp.GetEncodingConfig()
+	cp.Cdc = cosmos.Codec{
+		InterfaceRegistry: encCfg.InterfaceRegistry,
+		Marshaler:         encCfg.Codec,
+		TxConfig:          encCfg.TxConfig,
+		Amino:             encCfg.Amino,
+	}
+
+	if err := cp.Init(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return newBabylonTxSenderWithKey(cfg.Key, cp, cfg, logger), nil
+}
+
+func newBabylonTxSenderWithKey(key string, cp *cosmos.CosmosProvider, cfg *fpcfg.BBNConfig, logger *zap.Logger) *babylonTxSender {
+	s := &babylonTxSender{
+		key:    key,
+		cp:     cp,
+		cfg:    cfg,
+		logger: logger,
+	}
+	s.seqs = newSequenceManager(s.queryAccount)
+
+	return s
+}
+
+// withKey returns a sender of the transactions signed by the given key, which
+// shares the connection to the node with this sender
+func (s *babylonTxSender) withKey(key string) *babylonTxSender {
+	return newBabylonTxSenderWithKey(key, s.cp, s.cfg, s.logger.With(zap.String("key", key)))
+}
+
+// reliablySendMsgs sends the messages in a single transaction and waits for its
+// inclusion, retrying upon failures other than the expected and unrecoverable
+// errors. It returns nil response and nil error if an expected error occurred
+func (s *babylonTxSender) reliablySendMsgs(
+	ctx context.Context,
+	msgs []sdk.Msg,
+	expectedErrs []*sdkErr.Error,
+	unrecoverableErrs []*sdkErr.Error,
+) (*txResult, error) {
+	var (
+		res *txResult
+		// pending is the tx whose broadcast had an ambiguous result, which is
+		// re-broadcast instead of building a new one so that the messages are
+		// not submitted twice
+		pending *signedTx
+	)
+	if err := retry.Do(func() error {
+		var sendErr error
+		res, sendErr = s.sendMsgs(ctx, msgs, &pending)
+		if sendErr == nil {
+			return nil
+		}
+		if errorContained(sendErr, unrecoverableErrs) {
+			sendErr = retry.Unrecoverable(sendErr)
+		}
+		if !retry.IsRecoverable(sendErr) {
+			s.logger.Error("unrecoverable err when submitting the tx, skip retrying", zap.Error(sendErr))
+			return sendErr
+		}
+		if errorContained(sendErr, expectedErrs) {
+			s.logger.Error("expected err when submitting the tx, skip retrying", zap.Error(sendErr))
+			res = nil
+			return nil
+		}
+		return sendErr
+	}, retry.Context(ctx), txRtyAtt, txRtyDel, txRtyErr, retry.OnRetry(func(n uint, err error) {
+		s.logger.Debug("retrying", zap.Uint("attempt", n+1), zap.Uint("max_attempts", txRtyAttNum), zap.Error(err))
+	})); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// txResult is the response of a transaction together with the gas it used,
+// which is not part of the relayer response
+type txResult struct {
+	*provider.RelayerTxResponse
+	GasUsed int64
+}
+
+// signedTx is a signed transaction together with its hash, which is computed
+// locally so that the transaction can be queried regardless of the result of
+// the broadcast
+type signedTx struct {
+	bytes []byte
+	hash  []byte
+}
+
+// sendMsgs builds and broadcasts a transaction with the given messages and
+// waits for its inclusion. If pending is set, the pending transaction is looked
+// up on the node and re-broadcast instead of building a new transaction. When
+// the broadcast result is ambiguous, e.g., due to a network error, pending is
+// set to the broadcast transaction
+func (s *babylonTxSender) sendMsgs(
+	ctx context.Context,
+	msgs []sdk.Msg,
+	pending **signedTx,
+) (*txResult, error) {
+	if s.cfg.DryRun {
+		var (
+			res *txResult
+			err error
+		)
+		if krErr := s.accessKeyWithLock(func() {
+			res, err = s.simulateMsgs(ctx, msgs)
+		}); krErr != nil {
+			return nil, retry.Unrecoverable(krErr)
+		}
+		return res, err
+	}
+
+	stx := *pending
+	if stx != nil {
+		// the pending tx may have been included despite the failed broadcast
+		if res, err := s.cp.RPCClient.Tx(ctx, stx.hash, false); err == nil {
+			*pending = nil
+			s.logger.Debug("the pending tx is already included", zap.String("tx_hash", res.Hash.String()))
+			return txResponse(res)
+		}
+		if rejected, err := s.broadcastTx(ctx, stx); err != nil {
+			if rejected {
+				// the sequence of the tx is outdated if it is rejected,
+				// so a new one has to be built
+				*pending = nil
+				if errorContained(err, wrongSequenceErrs) {
+					s.seqs.resync()
+				}
+			}
+			return nil, err
+		}
+	} else {
+		var err error
+		if stx, err = s.buildAndBroadcastTx(ctx, msgs); err != nil {
+			// the broadcast result is ambiguous if the tx is built, in
+			// which case it is re-broadcast upon retry
+			*pending = stx
+			return nil, err
+		}
+	}
+
+	// the tx is in the mempool but may not be included before the timeout,
+	// in which case it is looked up again upon retry
+	*pending = stx
+	res, err := s.waitForTx(ctx, stx.hash)
+	if err == nil || !errors.Is(err, errTxInclusionTimeout) {
+		*pending = nil
+	}
+
+	return res, err
+}
+
+// buildAndBroadcastTx builds a transaction with the given messages and the next
+// sequence of the key, and broadcasts it. The sequence is committed unless the
+// transaction is rejected by the node, as it may be in the mempool even if its
+// broadcast failed, e.g., due to a network error. If the broadcast fails, the
+// built transaction is returned together with the error unless it is rejected
+func (s *babylonTxSender) buildAndBroadcastTx(ctx context.Context, msgs []sdk.Msg) (*signedTx, error) {
+	accNum, seq, err := s.seqs.reserve(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var txBytes []byte
+	if krErr := s.accessKeyWithLock(func() {
+		txBytes, err = s.buildTx(ctx, msgs, accNum, seq)
+	}); krErr != nil {
+		s.seqs.rollback(krErr)
+		return nil, retry.Unrecoverable(krErr)
+	}
+	if err != nil {
+		s.seqs.rollback(err)
+		return nil, err
+	}
+
+	stx := &signedTx{bytes: txBytes, hash: cmttypes.Tx(txBytes).Hash()}
+	rejected, err := s.broadcastTx(ctx, stx)
+	if rejected {
+		s.seqs.rollback(err)
+		return nil, err
+	}
+	s.seqs.commit()
+
+	return stx, err
+}
+
+// broadcastTx broadcasts the signed transaction to the mempool of the node. It
+// returns whether the transaction is rejected by the node, in which case it can
+// never be included
+func (s *babylonTxSender) broadcastTx(ctx context.Context, stx *signedTx) (bool, error) {
+	syncRes, err := s.cp.RPCClient.BroadcastTxSync(ctx, stx.bytes)
+	if err != nil {
+		// the node already has the tx in its mempool, so it only needs to
+		// be waited for
+		if strings.Contains(err.Error(), mempool.ErrTxInCache.Error()) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to broadcast the tx %X: %w", stx.hash, err)
+	}
+	if syncRes.Code != 0 {
+		return true, txError(syncRes.Codespace, syncRes.Code, syncRes.Log)
+	}
+
+	return false, nil
+}
+
+// queryAccount queries the account number and the current sequence of the key
+// from the chain
+func (s *babylonTxSender) queryAccount(_ context.Context) (uint64, uint64, error) {
+	var (
+		txf tx.Factory
+		err error
+	)
+	if krErr := s.accessKeyWithLock(func() {
+		done := s.cp.SetSDKContext()
+		defer done()
+
+		txf, err = s.cp.PrepareFactory(s.cp.TxFactory(), s.key)
+	}); krErr != nil {
+		return 0, 0, retry.Unrecoverable(krErr)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return txf.AccountNumber(), txf.Sequence(), nil
+}
+
+// simulateMsgs builds, simulates, and signs a transaction with the given
+// messages without broadcasting it, and logs the result. A failed simulation
+// is not returned as an error so that the loops keep running in dry-run mode
+func (s *babylonTxSender) simulateMsgs(ctx context.Context, msgs []sdk.Msg) (*txResult, error) {
+	done := s.cp.SetSDKContext()
+	defer done()
+
+	msgTypes := make([]string, 0, len(msgs))
+	for _, msg := range msgs {
+		msgTypes = append(msgTypes, sdk.MsgTypeURL(msg))
+	}
+
+	txf, err := s.cp.PrepareFactory(s.cp.TxFactory(), s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	gas, err := s.estimateGas(ctx, txf, msgs)
+	if err != nil {
+		s.logger.Warn("dry run: the simulation of the tx failed",
+			zap.Strings("msgs", msgTypes),
+			zap.Error(err),
+		)
+		return &txResult{RelayerTxResponse: &provider.RelayerTxResponse{}}, nil
+	}
+
+	txBytes, err := s.signTx(ctx, txf.WithGas(gas), msgs)
+	if err != nil {
+		return nil, err
+	}
+	txHash := fmt.Sprintf("%X", cmttypes.Tx(txBytes).Hash())
+
+	s.logger.Info("dry run: the tx is simulated but not broadcast",
+		zap.String("tx_hash", txHash),
+		zap.Strings("msgs", msgTypes),
+		zap.Uint64("gas", gas),
+	)
+
+	return &txResult{RelayerTxResponse: &provider.RelayerTxResponse{TxHash: txHash}}, nil
+}
+
+// buildTx builds and signs a transaction with the given messages, signed with
+// the given account number and sequence
+func (s *babylonTxSender) buildTx(ctx context.Context, msgs []sdk.Msg, accNum uint64, seq uint64) ([]byte, error) {
+	done := s.cp.SetSDKContext()
+	defer done()
+
+	txf, err := s.cp.PrepareFactory(s.cp.TxFactory().WithAccountNumber(accNum).WithSequence(seq), s.key)
+	if err != nil {
+		return nil, err
+	}
+	// the factory queries the sequence from the chain if it is 0, which
+	// does not count the txs in the mempool
+	txf = txf.WithSequence(seq)
+
+	gas, err := s.estimateGas(ctx, txf, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.signTx(ctx, txf.WithGas(gas), msgs)
+}
+
+// signTx builds and signs a transaction with the given messages and returns
+// its encoded bytes
+func (s *babylonTxSender) signTx(ctx context.Context, txf tx.Factory, msgs []sdk.Msg) ([]byte, error) {
+	txb, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Sign(ctx, txf, s.key, txb, false); err != nil {
+		return nil, err
+	}
+
+	return s.cp.Cdc.TxConfig.TxEncoder()(txb.GetTx())
+}
+
+// estimateGas simulates the transaction and returns the gas limit derived from
+// the simulated gas and the gas options of the messages. The static gas limit
+// is used if the simulation cannot be done, e.g., due to a network error. If
+// the simulation fails because the transaction is rejected, the error is
+// returned as broadcasting the transaction would fail as well
+func (s *babylonTxSender) estimateGas(ctx context.Context, txf tx.Factory, msgs []sdk.Msg) (uint64, error) {
+	limits := gasLimitsForMsgs(s.cfg, msgs)
+
+	simRes, _, err := s.cp.CalculateGas(ctx, txf, s.key, msgs...)
+	if err != nil {
+		if _, ok := status.FromError(err); ok || limits.staticGas == 0 {
+			return 0, fmt.Errorf("failed to simulate the tx: %w", err)
+		}
+		s.logger.Warn("failed to simulate the tx, using the static gas limit",
+			zap.Uint64("gas", limits.staticGas),
+			zap.Error(err),
+		)
+		return limits.staticGas, nil
+	}
+
+	return limits.adjust(simRes.GasInfo.GasUsed)
+}
+
+// waitForTx polls the transaction until it is included in a block or the block
+// timeout is reached
+func (s *babylonTxSender) waitForTx(ctx context.Context, txHash []byte) (*txResult, error) {
+	timeout := time.After(s.cfg.BlockTimeout)
+	for {
+		select {
+		case <-timeout:
+			return nil, fmt.Errorf("%w: waited %v for the tx %X", errTxInclusionTimeout, s.cfg.BlockTimeout, txHash)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(txInclusionPollInterval):
+			res, err := s.cp.RPCClient.Tx(ctx, txHash, false)
+			if err != nil {
+				if strings.Contains(err.Error(), "transaction indexing is disabled") {
+					return nil, fmt.Errorf("cannot determine the result of the tx as transaction indexing is disabled on the node")
+				}
+				continue
+			}
+
+			return txResponse(res)
+		}
+	}
+}
+
+// txResponse converts the result of an included transaction to a response,
+// or returns the error of the transaction if it failed
+func txResponse(res *coretypes.ResultTx) (*txResult, error) {
+	if res.TxResult.Code != 0 {
+		return nil, txError(res.TxResult.Codespace, res.TxResult.Code, res.TxResult.Log)
+	}
+
+	events := make([]provider.RelayerEvent, 0, len(res.TxResult.Events))
+	for _, e := range res.TxResult.Events {
+		attributes := make(map[string]string, len(e.Attributes))
+		for _, a := range e.Attributes {
+			attributes[a.Key] = a.Value
+		}
+		events = append(events, provider.RelayerEvent{EventType: e.Type, Attributes: attributes})
+	}
+
+	return &txResult{
+		RelayerTxResponse: &provider.RelayerTxResponse{
+			Height:    res.Height,
+			TxHash:    res.Hash.String(),
+			Codespace: res.TxResult.Codespace,
+			Code:      res.TxResult.Code,
+			Data:      fmt.Sprintf("%X", res.TxResult.Data),
+			Events:    events,
+		},
+		GasUsed: res.TxResult.GasUsed,
+	}, nil
+}
+
+// accessKeyWithLock guards the access to the keyring with the same file lock
+// as the Babylon client, so that they can share the keyring
+func (s *babylonTxSender) accessKeyWithLock(accessFunc func()) error {
+	lockFilePath := path.Join(s.cp.PCfg.KeyDirectory, "keys.lock")
+	lock := fslock.New(lockFilePath)
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire file system lock (%s): %w", lockFilePath, err)
+	}
+
+	accessFunc()
+
+	if err := lock.Unlock(); err != nil {
+		return fmt.Errorf("error unlocking file system lock (%s), please manually delete", lockFilePath)
+	}
+
+	return nil
+}
+
+// txError returns the registered SDK error of the failed transaction if any,
+// so that it can be matched with the expected and unrecoverable errors
+func txError(codespace string, code uint32, log string) error {
+	err := errors.Unwrap(sdkErr.ABCIError(codespace, code, log))
+	if err != nil && err.Error() != "unknown" {
+		return fmt.Errorf("%w: %s", err, log)
+	}
+
+	return fmt.Errorf("transaction failed to execute: codespace: %s, code: %d, log: %s", codespace, code, log)
+}
+
+func errorContained(err error, errList []*sdkErr.Error) bool {
+	for _, e := range errList {
+		if strings.Contains(err.Error(), e.Error()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// gasLimits are the gas options of a transaction
+type gasLimits struct {
+	adjustment float64
+	// maxGas is the maximum gas limit, no limit if 0
+	maxGas uint64
+	// staticGas is the gas limit used if the simulation cannot be done,
+	// no fallback if 0
+	staticGas uint64
+}
+
+// gasLimitsForMsg returns the gas options of the message type of the given message
+func gasLimitsForMsg(cfg *fpcfg.BBNConfig, msg sdk.Msg) gasLimits {
+	limits := gasLimits{
+		adjustment: cfg.GasAdjustment,
+		maxGas:     cfg.MaxGas,
+		staticGas:  cfg.StaticGas,
+	}
+
+	switch msg.(type) {
+	case *finalitytypes.MsgAddFinalitySig:
+		limits.maxGas = cfg.FinalitySigMaxGas
+		limits.staticGas = cfg.FinalitySigStaticGas
+		if cfg.FinalitySigGasAdjustment > 0 {
+			limits.adjustment = cfg.FinalitySigGasAdjustment
+		}
+	case *finalitytypes.MsgCommitPubRandList:
+		limits.maxGas = cfg.PubRandMaxGas
+		limits.staticGas = cfg.PubRandStaticGas
+		if cfg.PubRandGasAdjustment > 0 {
+			limits.adjustment = cfg.PubRandGasAdjustment
+		}
+	}
+
+	return limits
+}
+
+// gasLimitsForMsgs returns the gas options of a transaction with the given
+// messages. The adjustment is the largest one of the messages, while the max
+// and static gas limits are the sums of the ones of the messages, unless any
+// of the messages has no limit
+func gasLimitsForMsgs(cfg *fpcfg.BBNConfig, msgs []sdk.Msg) gasLimits {
+	var limits gasLimits
+	hasMaxGas, hasStaticGas := true, true
+	for _, msg := range msgs {
+		l := gasLimitsForMsg(cfg, msg)
+		if l.adjustment > limits.adjustment {
+			limits.adjustment = l.adjustment
+		}
+		hasMaxGas = hasMaxGas && l.maxGas > 0
+		hasStaticGas = hasStaticGas && l.staticGas > 0
+		limits.maxGas += l.maxGas
+		limits.staticGas += l.staticGas
+	}
+	if !hasMaxGas {
+		limits.maxGas = 0
+	}
+	if !hasStaticGas {
+		limits.staticGas = 0
+	}
+
+	return limits
+}
+
+// adjust returns the gas limit for the simulated gas, which is the simulated
+// gas multiplied by the adjustment and capped by the max gas limit. An error
+// is returned if the simulated gas itself exceeds the max gas limit
+func (l gasLimits) adjust(gasUsed uint64) (uint64, error) {
+	if l.maxGas > 0 && gasUsed > l.maxGas {
+		return 0, fmt.Errorf("simulated gas %d exceeds the max gas limit %d", gasUsed, l.maxGas)
+	}
+
+	gas := uint64(float64(gasUsed) * l.adjustment)
+	if l.maxGas > 0 && gas > l.maxGas {
+		gas = l.maxGas
+	}
+
+	return gas, nil
+}
@@ -0,0 +1,161 @@
+package clientcontroller
+
+import (
+	"context"
+	"fmt"
+
+	sdkErr "cosmossdk.io/errors"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+var (
+	commitPubRandUnrecoverableErrs = []*sdkErr.Error{
+		finalitytypes.ErrInvalidPubRand,
+		finalitytypes.ErrTooFewPubRand,
+		finalitytypes.ErrNoPubRandYet,
+		btcstakingtypes.ErrFpNotFound,
+	}
+	finalitySigUnrecoverableErrs = []*sdkErr.Error{
+		finalitytypes.ErrInvalidFinalitySig,
+		finalitytypes.ErrPubRandNotFound,
+		btcstakingtypes.ErrFpAlreadySlashed,
+	}
+)
+
+// BabylonTxBuilder packs heterogeneous messages, e.g., finality signatures and
+// public randomness commitments, into a single Babylon transaction. The gas
+// limit of the transaction is derived from the simulated gas and the gas
+// options of all the messages it contains
+type BabylonTxBuilder struct {
+	signer            string
+	sender            *babylonTxSender
+	priority          TxPriority
+	msgs              []sdk.Msg
+	unrecoverableErrs []*sdkErr.Error
+}
+
+// NewTxBuilder returns an empty transaction builder. The key and the submitter
+// keys of the controller sign the transactions in turn, as the messages of the
+// builder can be signed by any funded account, unless the key is cold
+func (bc *BabylonController) NewTxBuilder() *BabylonTxBuilder {
+	n := uint64(len(bc.submitters))
+	sender := bc.submitters[(bc.nextSubmitter.Add(1)-1)%n]
+
+	return newBabylonTxBuilder(bc.mustGetKeySigner(sender.key), sender)
+}
+
+func newBabylonTxBuilder(signer string, sender *babylonTxSender) *BabylonTxBuilder {
+	return &BabylonTxBuilder{
+		signer:   signer,
+		sender:   sender,
+		priority: TxPriorityNormal,
+	}
+}
+
+// WithPriority sets the priority of the transaction among the ones waiting to
+// be broadcast by the same key, which is normal by default
+func (b *BabylonTxBuilder) WithPriority(p TxPriority) *BabylonTxBuilder {
+	b.priority = p
+
+	return b
+}
+
+// AddCommitPubRandList adds a MsgCommitPubRandList to the transaction
+func (b *BabylonTxBuilder) AddCommitPubRandList(
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	sig *schnorr.Signature,
+) *BabylonTxBuilder {
+	b.addMsg(&finalitytypes.MsgCommitPubRandList{
+		Signer:      b.signer,
+		FpBtcPk:     bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+		Commitment:  commitment,
+		Sig:         bbntypes.NewBIP340SignatureFromBTCSig(sig),
+	}, commitPubRandUnrecoverableErrs)
+
+	return b
+}
+
+// AddFinalitySig adds a MsgAddFinalitySig over the given block to the
+// transaction
+func (b *BabylonTxBuilder) AddFinalitySig(
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) error {
+	cmtProof := cmtcrypto.Proof{}
+	if err := cmtProof.Unmarshal(proof); err != nil {
+		return err
+	}
+
+	b.addMsg(&finalitytypes.MsgAddFinalitySig{
+		Signer:       b.signer,
+		FpBtcPk:      bbntypes.NewBIP340PubKeyFromBTCPK(fpPk),
+		BlockHeight:  block.Height,
+		PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRand),
+		Proof:        &cmtProof,
+		BlockAppHash: block.Hash,
+		FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sig),
+	}, finalitySigUnrecoverableErrs)
+
+	return nil
+}
+
+// addMsg adds the message together with the errors that fail the transaction
+// without retrying
+func (b *BabylonTxBuilder) addMsg(msg sdk.Msg, unrecoverableErrs []*sdkErr.Error) {
+	b.msgs = append(b.msgs, msg)
+	for _, e := range unrecoverableErrs {
+		if !errorListed(e, b.unrecoverableErrs) {
+			b.unrecoverableErrs = append(b.unrecoverableErrs, e)
+		}
+	}
+}
+
+// Len returns the number of messages added to the transaction
+func (b *BabylonTxBuilder) Len() int {
+	return len(b.msgs)
+}
+
+// Send sends all the added messages in a single transaction and waits for its
+// inclusion. The transaction fails as a whole if any of the messages fails
+func (b *BabylonTxBuilder) Send() (*types.TxResponse, error) {
+	if len(b.msgs) == 0 {
+		return nil, fmt.Errorf("the transaction has no messages")
+	}
+
+	ctx := withTxPriority(context.Background(), b.priority)
+	res, err := b.sender.reliablySendMsgs(ctx, b.msgs, emptyErrs, b.unrecoverableErrs)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+
+	return &types.TxResponse{TxHash: res.TxHash, Events: res.Events, GasUsed: res.GasUsed, Signer: b.signer}, nil
+}
+
+func errorListed(err *sdkErr.Error, errList []*sdkErr.Error) bool {
+	for _, e := range errList {
+		if e == err {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+package clientcontroller
+
+import (
+	"testing"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestBabylonTxBuilder(t *testing.T) {
+	signer := "bbn1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5gua0e9"
+	sk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	sig, err := schnorr.Sign(sk, make([]byte, 32))
+	require.NoError(t, err)
+	proof, err := (&cmtcrypto.Proof{Total: 1}).Marshal()
+	require.NoError(t, err)
+
+	txBuilder := newBabylonTxBuilder(signer, nil)
+	_, err = txBuilder.Send()
+	require.Error(t, err)
+
+	// heterogeneous messages are packed in order
+	txBuilder.AddCommitPubRandList(sk.PubKey(), 100, 1000, make([]byte, 32), sig)
+	for height := uint64(100); height < 102; height++ {
+		err = txBuilder.AddFinalitySig(sk.PubKey(), &types.BlockInfo{Height: height, Hash: make([]byte, 32)},
+			new(btcec.FieldVal).SetInt(1), proof, new(btcec.ModNScalar).SetInt(1))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, txBuilder.Len())
+
+	commitMsg, ok := txBuilder.msgs[0].(*finalitytypes.MsgCommitPubRandList)
+	require.True(t, ok)
+	require.Equal(t, signer, commitMsg.Signer)
+	require.Equal(t, uint64(100), commitMsg.StartHeight)
+	for i, height := range []uint64{100, 101} {
+		sigMsg, ok := txBuilder.msgs[i+1].(*finalitytypes.MsgAddFinalitySig)
+		require.True(t, ok)
+		require.Equal(t, signer, sigMsg.Signer)
+		require.Equal(t, height, sigMsg.BlockHeight)
+	}
+
+	// the unrecoverable errors of all the message types are kept once
+	require.Len(t, txBuilder.unrecoverableErrs,
+		len(commitPubRandUnrecoverableErrs)+len(finalitySigUnrecoverableErrs))
+
+	// an invalid proof is rejected without adding the message
+	err = txBuilder.AddFinalitySig(sk.PubKey(), &types.BlockInfo{Height: 102},
+		new(btcec.FieldVal).SetInt(1), []byte{0xff}, new(btcec.ModNScalar).SetInt(1))
+	require.Error(t, err)
+	require.Equal(t, 3, txBuilder.Len())
+}
@@ -0,0 +1,55 @@
+package clientcontroller
+
+import (
+	"testing"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestGasLimitsForMsgs(t *testing.T) {
+	cfg := fpcfg.DefaultBBNConfig()
+	cfg.GasAdjustment = 1.5
+	cfg.FinalitySigGasAdjustment = 1.1
+	cfg.FinalitySigMaxGas = 100000
+	cfg.FinalitySigStaticGas = 80000
+	cfg.PubRandGasAdjustment = 0
+	cfg.PubRandMaxGas = 0
+	cfg.PubRandStaticGas = 200000
+
+	finalitySig := &finalitytypes.MsgAddFinalitySig{}
+	pubRand := &finalitytypes.MsgCommitPubRandList{}
+
+	// the options of the message type are used
+	limits := gasLimitsForMsgs(&cfg, []sdk.Msg{finalitySig})
+	require.Equal(t, gasLimits{adjustment: 1.1, maxGas: 100000, staticGas: 80000}, limits)
+
+	// a zero adjustment falls back to the default one
+	limits = gasLimitsForMsgs(&cfg, []sdk.Msg{pubRand})
+	require.Equal(t, gasLimits{adjustment: 1.5, maxGas: 0, staticGas: 200000}, limits)
+
+	// the limits of batched messages add up
+	limits = gasLimitsForMsgs(&cfg, []sdk.Msg{finalitySig, finalitySig})
+	require.Equal(t, gasLimits{adjustment: 1.1, maxGas: 200000, staticGas: 160000}, limits)
+
+	// no limit if any of the messages has no limit
+	limits = gasLimitsForMsgs(&cfg, []sdk.Msg{finalitySig, pubRand})
+	require.Equal(t, gasLimits{adjustment: 1.5, maxGas: 0, staticGas: 280000}, limits)
+
+	limits = gasLimits{adjustment: 1.5, maxGas: 100000}
+	gas, err := limits.adjust(50000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(75000), gas)
+
+	// the adjusted gas is capped
+	gas, err = limits.adjust(80000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100000), gas)
+
+	// the simulated gas exceeding the cap is rejected
+	_, err = limits.adjust(100001)
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package clientcontroller
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/mod/semver"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+var (
+	ErrChainMismatch    = errors.New("the node of the consumer chain belongs to another network than the configured one")
+	ErrIncompatibleNode = errors.New("the node of the consumer chain is incompatible with this build of the finality provider")
+)
+
+// VerifyNode checks the node of the consumer chain with VerifyChain and
+// VerifyNodeCompatibility before any submission. The incompatibilities are
+// only logged if AllowIncompatibleNode is set
+func VerifyNode(cc ClientController, cfg *fpcfg.Config, logger *zap.Logger) error {
+	if err := VerifyChain(cc, cfg.BabylonConfig.ChainID, cfg.BabylonConfig.GenesisHash); err != nil {
+		return err
+	}
+
+	err := VerifyNodeCompatibility(cc, version.BabylonVersion(), uint64(cfg.NumPubRand), logger)
+	if errors.Is(err, ErrIncompatibleNode) && cfg.BabylonConfig.AllowIncompatibleNode {
+		logger.Warn("running against an incompatible node as allowed by the config", zap.Error(err))
+		return nil
+	}
+
+	return err
+}
+
+// VerifyChain checks that the node of the consumer chain reports the given
+// chain id and, if the given genesis hash is not empty, that its block at
+// height 1 has that hash. It is meant to be called before any submission, so
+// that a misconfigured node address can't make the finality provider sign
+// for another network
+func VerifyChain(cc ClientController, chainID, genesisHashHex string) error {
+	nodeChainID, err := cc.QueryChainID()
+	if err != nil {
+		return fmt.Errorf("failed to query the chain id of the node: %w", err)
+	}
+	if nodeChainID != chainID {
+		return fmt.Errorf("%w: the node reports chain id %s while %s is configured", ErrChainMismatch, nodeChainID, chainID)
+	}
+
+	if genesisHashHex == "" {
+		return nil
+	}
+	genesisHash, err := hex.DecodeString(genesisHashHex)
+	if err != nil {
+		return fmt.Errorf("invalid genesis hash %s: %w", genesisHashHex, err)
+	}
+	nodeGenesisHash, err := cc.QueryGenesisBlockHash()
+	if err != nil {
+		return fmt.Errorf("failed to query the genesis hash of the node: %w", err)
+	}
+	if !bytes.Equal(nodeGenesisHash, genesisHash) {
+		return fmt.Errorf("%w: the block at height 1 of the node has hash %X while %s is configured",
+			ErrChainMismatch, nodeGenesisHash, genesisHashHex)
+	}
+
+	return nil
+}
+
+// VerifyNodeCompatibility checks that the node of the consumer chain runs a
+// release of Babylon whose messages are compatible with the given version
+// the finality provider is built against, and that the finality module
+// accepts the commits of numPubRand public randomness. The releases are
+// compatible if they have the same major version, or the same minor version
+// before v1, as the earlier releases break the messages in minor versions.
+// The version check is skipped with a warning if either version is not a
+// semantic version, e.g., for a development build of the node
+func VerifyNodeCompatibility(cc ClientController, builtVersion string, numPubRand uint64, logger *zap.Logger) error {
+	nodeVersion, err := cc.QueryNodeVersion()
+	if err != nil {
+		return fmt.Errorf("failed to query the version of the node: %w", err)
+	}
+
+	nodeSemver, builtSemver := canonicalVersion(nodeVersion), canonicalVersion(builtVersion)
+	switch {
+	case nodeSemver == "" || builtSemver == "":
+		logger.Warn("skipping the version check of the node as the versions are unknown",
+			zap.String("node_version", nodeVersion), zap.String("built_version", builtVersion))
+	case compatibilityLine(nodeSemver) != compatibilityLine(builtSemver):
+		return fmt.Errorf("%w: the node runs Babylon %s while the finality provider is built against %s",
+			ErrIncompatibleNode, nodeVersion, builtVersion)
+	}
+
+	params, err := cc.QueryFinalityParams()
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: the node does not serve the params of the finality module: %v", ErrIncompatibleNode, err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query the params of the finality module: %w", err)
+	}
+	if params.MinPubRand > numPubRand {
+		return fmt.Errorf("%w: the finality module requires commits of at least %d public randomness while NumPubRand is %d",
+			ErrIncompatibleNode, params.MinPubRand, numPubRand)
+	}
+
+	return nil
+}
+
+// canonicalVersion returns the given version in the canonical semantic
+// version form, or an empty string if it is not a semantic version. The
+// versions reported by the nodes may lack the v prefix
+func canonicalVersion(v string) string {
+	if v != "" && !strings.HasPrefix(v, "v") {
+		v = "v" + v
+	}
+
+	return semver.Canonical(v)
+}
+
+// compatibilityLine returns the part of the given canonical version which
+// changes with the breaking releases
+func compatibilityLine(v string) string {
+	if semver.Major(v) == "v0" {
+		return semver.MajorMinor(v)
+	}
+
+	return semver.Major(v)
+}
@@ -0,0 +1,71 @@
+package clientcontroller
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+)
+
+func TestVerifyChain(t *testing.T) {
+	mc := NewMockConsumerController("chain-test", time.Second, zap.NewNop())
+	genesisHash := hex.EncodeToString(mockBlockHash(1))
+
+	require.NoError(t, VerifyChain(mc, "chain-test", ""))
+	require.NoError(t, VerifyChain(mc, "chain-test", genesisHash))
+
+	err := VerifyChain(mc, "bbn-1", "")
+	require.ErrorIs(t, err, ErrChainMismatch)
+
+	// another network with the same chain id
+	err = VerifyChain(mc, "chain-test", hex.EncodeToString(mockBlockHash(2)))
+	require.ErrorIs(t, err, ErrChainMismatch)
+}
+
+func TestVerifyNodeCompatibility(t *testing.T) {
+	testCases := []struct {
+		name         string
+		nodeVersion  string
+		builtVersion string
+		minPubRand   uint64
+		paramsErr    error
+		expectedErr  error
+	}{
+		{"same release", "v0.12.0", "v0.12.0", 100, nil, nil},
+		{"patch release without prefix", "0.12.3", "v0.12.0", 100, nil, nil},
+		{"release candidate", "v0.12.1-rc.0", "v0.12.0", 100, nil, nil},
+		{"development build", "main-2f0a1c", "v0.12.0", 100, nil, nil},
+		{"minor release before v1", "v0.13.0", "v0.12.0", 100, nil, ErrIncompatibleNode},
+		{"minor release after v1", "v1.2.0", "v1.0.0", 100, nil, nil},
+		{"major release", "v2.0.0", "v1.0.0", 100, nil, ErrIncompatibleNode},
+		{"randomness below the minimum", "v0.12.0", "v0.12.0", 2000, nil, ErrIncompatibleNode},
+		{"finality params not served", "v0.12.0", "v0.12.0", 100,
+			status.Error(codes.Unimplemented, "unknown method Params"), ErrIncompatibleNode},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			cc := mocks.NewMockClientController(ctl)
+			cc.EXPECT().QueryNodeVersion().Return(tc.nodeVersion, nil)
+			params := finalitytypes.DefaultParams()
+			params.MinPubRand = tc.minPubRand
+			cc.EXPECT().QueryFinalityParams().Return(&params, tc.paramsErr).AnyTimes()
+
+			err := VerifyNodeCompatibility(cc, tc.builtVersion, 1000, zap.NewNop())
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package clientcontroller
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// ErrCircuitOpen is returned by the submissions while the circuit breaker is open
+var ErrCircuitOpen = errors.New("the submissions are paused as the previous ones to the consumer chain kept failing")
+
+// CircuitBreaker is implemented by the client controllers which pause the
+// submissions to the consumer chain after consecutive failures, until the
+// node is healthy again
+type CircuitBreaker interface {
+	// IsCircuitOpen returns true if the submissions are paused
+	IsCircuitOpen() bool
+
+	// CircuitClosed returns a channel which is closed once the submissions
+	// are resumed, or is already closed if they are not paused
+	CircuitClosed() <-chan struct{}
+}
+
+// circuitBreakerController wraps a client controller and trips after a number
+// of consecutive failed submissions. While tripped, the submissions fail with
+// ErrCircuitOpen without reaching the node, which is probed periodically
+// until it responds again
+type circuitBreakerController struct {
+	ClientController
+
+	threshold     uint32
+	probeInterval time.Duration
+	logger        *zap.Logger
+
+	mu       sync.Mutex
+	failures uint32
+	open     bool
+	// closed is closed while the breaker is closed, and replaced upon tripping
+	closed chan struct{}
+}
+
+var _ CircuitBreaker = &circuitBreakerController{}
+
+// NewCircuitBreakerController wraps the given client controller with a circuit
+// breaker which trips after threshold consecutive failed submissions, and
+// probes the node every probeInterval while tripped
+func NewCircuitBreakerController(
+	cc ClientController,
+	threshold uint32,
+	probeInterval time.Duration,
+	logger *zap.Logger,
+) ClientController {
+	closed := make(chan struct{})
+	close(closed)
+
+	return &circuitBreakerController{
+		ClientController: cc,
+		threshold:        threshold,
+		probeInterval:    probeInterval,
+		logger:           logger,
+		closed:           closed,
+	}
+}
+
+func (c *circuitBreakerController) IsCircuitOpen() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.open
+}
+
+func (c *circuitBreakerController) CircuitClosed() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *circuitBreakerController) RegisterFinalityProvider(
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	return c.submit(func() (*types.TxResponse, error) {
+		return c.ClientController.RegisterFinalityProvider(fpPk, pop, commission, description)
+	})
+}
+
+func (c *circuitBreakerController) CommitPubRandList(
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	sig *schnorr.Signature,
+) (*types.TxResponse, error) {
+	return c.submit(func() (*types.TxResponse, error) {
+		return c.ClientController.CommitPubRandList(fpPk, startHeight, numPubRand, commitment, sig)
+	})
+}
+
+func (c *circuitBreakerController) SubmitFinalitySig(
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return c.submit(func() (*types.TxResponse, error) {
+		return c.ClientController.SubmitFinalitySig(fpPk, block, pubRand, proof, sig)
+	})
+}
+
+func (c *circuitBreakerController) SubmitBatchFinalitySigs(
+	fpPk *btcec.PublicKey,
+	blocks []*types.BlockInfo,
+	pubRandList []*btcec.FieldVal,
+	proofList [][]byte,
+	sigs []*btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return c.submit(func() (*types.TxResponse, error) {
+		return c.ClientController.SubmitBatchFinalitySigs(fpPk, blocks, pubRandList, proofList, sigs)
+	})
+}
+
+func (c *circuitBreakerController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	return c.submit(func() (*types.TxResponse, error) {
+		return c.ClientController.UnjailFinalityProvider(fpPk)
+	})
+}
+
+func (c *circuitBreakerController) EditFinalityProvider(
+	fpPk *btcec.PublicKey,
+	commission *math.LegacyDec,
+	description []byte,
+) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	if c.IsCircuitOpen() {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err := c.ClientController.EditFinalityProvider(fpPk, commission, description)
+	c.record(err)
+
+	return res, err
+}
+
+// submit runs the submission unless the breaker is open, and records whether
+// it failed
+func (c *circuitBreakerController) submit(fn func() (*types.TxResponse, error)) (*types.TxResponse, error) {
+	if c.IsCircuitOpen() {
+		return nil, ErrCircuitOpen
+	}
+
+	res, err := fn()
+	c.record(err)
+
+	return res, err
+}
+
+func (c *circuitBreakerController) record(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the errors returned by the chain, e.g., the finality provider being
+	// jailed, show that the node is healthy
+	if err == nil || IsUnrecoverable(err) || IsExpected(err) {
+		c.failures = 0
+		return
+	}
+
+	c.failures++
+	if c.open || c.failures < c.threshold {
+		return
+	}
+
+	c.open = true
+	c.closed = make(chan struct{})
+	c.logger.Error("pausing the submissions to the consumer chain after consecutive failures until the node is healthy",
+		zap.Uint32("failures", c.failures),
+		zap.Duration("probe_interval", c.probeInterval),
+		zap.Error(err),
+	)
+
+	// the probe is not stopped upon Close as the wrapped controller may
+	// still be used afterwards, and it stops once the node responds
+	go c.probe()
+}
+
+// probe queries the node periodically and resets the breaker once it responds
+func (c *circuitBreakerController) probe() {
+	ticker := time.NewTicker(c.probeInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := c.ClientController.QueryBestBlock(); err != nil {
+			c.logger.Debug("the consumer chain node is still unavailable", zap.Error(err))
+			continue
+		}
+
+		c.mu.Lock()
+		c.open = false
+		c.failures = 0
+		close(c.closed)
+		c.mu.Unlock()
+
+		c.logger.Info("resuming the submissions as the consumer chain node is healthy again")
+		return
+	}
+}
@@ -0,0 +1,73 @@
+package clientcontroller
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// failingController fails the submissions and the queries while the node is down
+type failingController struct {
+	ClientController
+	down        atomic.Bool
+	submissions atomic.Int32
+}
+
+func (c *failingController) SubmitFinalitySig(*btcec.PublicKey, *types.BlockInfo, *btcec.FieldVal, []byte, *btcec.ModNScalar) (*types.TxResponse, error) {
+	c.submissions.Add(1)
+	if c.down.Load() {
+		return nil, errors.New("connection refused")
+	}
+	return &types.TxResponse{TxHash: "hash"}, nil
+}
+
+func (c *failingController) QueryBestBlock() (*types.BlockInfo, error) {
+	if c.down.Load() {
+		return nil, errors.New("connection refused")
+	}
+	return &types.BlockInfo{Height: 1}, nil
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	inner := &failingController{}
+	inner.down.Store(true)
+	cc := NewCircuitBreakerController(inner, 3, 10*time.Millisecond, zap.NewNop())
+	cb := cc.(CircuitBreaker)
+
+	// the breaker trips after the threshold of consecutive failures
+	for i := 0; i < 3; i++ {
+		require.False(t, cb.IsCircuitOpen())
+		_, err := cc.SubmitFinalitySig(nil, nil, nil, nil, nil)
+		require.ErrorContains(t, err, "connection refused")
+	}
+	require.True(t, cb.IsCircuitOpen())
+
+	// the submissions do not reach the node while the breaker is open
+	_, err := cc.SubmitFinalitySig(nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	require.Equal(t, int32(3), inner.submissions.Load())
+	select {
+	case <-cb.CircuitClosed():
+		t.Fatal("the breaker should be open")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the breaker is closed once the node responds to the probe
+	inner.down.Store(false)
+	select {
+	case <-cb.CircuitClosed():
+	case <-time.After(time.Second):
+		t.Fatal("the breaker should be closed")
+	}
+	require.False(t, cb.IsCircuitOpen())
+	res, err := cc.SubmitFinalitySig(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "hash", res.TxHash)
+}
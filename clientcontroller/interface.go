@@ -3,10 +3,12 @@ package clientcontroller
 import (
 	"cosmossdk.io/math"
 	"fmt"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
 	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/chaincfg"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"go.uber.org/zap"
 
 	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
@@ -17,6 +19,7 @@ import (
 
 const (
 	babylonConsumerChainName = "babylon"
+	mockConsumerChainName    = "mock"
 )
 
 type ClientController interface {
@@ -34,15 +37,31 @@ type ClientController interface {
 	// it returns tx hash and error
 	CommitPubRandList(fpPk *btcec.PublicKey, startHeight uint64, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types.TxResponse, error)
 
-	// SubmitFinalitySig submits the finality signature to the consumer chain
+	// SubmitFinalitySig submits the finality signature to the consumer chain,
+	// ahead of the bulk transactions waiting to be broadcast
 	SubmitFinalitySig(fpPk *btcec.PublicKey, block *types.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types.TxResponse, error)
 
-	// SubmitBatchFinalitySigs submits a batch of finality signatures to the consumer chain
+	// SubmitBatchFinalitySigs submits a batch of finality signatures to the consumer chain,
+	// e.g., to catch up, after the other transactions waiting to be broadcast
 	SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types.TxResponse, error)
 
 	// UnjailFinalityProvider sends an unjail transaction to the consumer chain
 	UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error)
 
+	// QueryFinalityProviderRewards returns the finality provider rewards
+	// which can be withdrawn by the signer
+	QueryFinalityProviderRewards() (sdk.Coins, error)
+
+	// WithdrawFinalityProviderRewards withdraws the finality provider rewards
+	// of the signer and sends them to the recipient address if not empty.
+	// It returns the withdrawn rewards
+	WithdrawFinalityProviderRewards(recipient string) (*types.TxResponse, sdk.Coins, error)
+
+	// QueryFinalityProviderDelegations returns a page of at most limit BTC
+	// delegations to the finality provider starting from pageKey, together
+	// with the key of the next page, which is nil on the last page
+	QueryFinalityProviderDelegations(fpPk *btcec.PublicKey, pageKey []byte, limit uint64) ([]*types.Delegation, []byte, error)
+
 	// QueryFinalityProviderVotingPower queries the voting power of the finality provider at a given height
 	QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error)
 
@@ -61,6 +80,10 @@ type ClientController interface {
 	// QueryBlock queries the block at the given height
 	QueryBlock(height uint64) (*types.BlockInfo, error)
 
+	// QueryVotesAtHeight returns the BTC public keys of the finality providers
+	// that voted for the block at the given height
+	QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error)
+
 	// QueryBlocks returns a list of blocks from startHeight to endHeight
 	QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error)
 
@@ -71,6 +94,28 @@ type ClientController interface {
 	// error will be returned if the consumer chain has not been activated
 	QueryActivatedHeight() (uint64, error)
 
+	// QueryChainID returns the chain id reported by the node of the consumer chain
+	QueryChainID() (string, error)
+
+	// QueryGenesisBlockHash returns the hash of the block at height 1 of the
+	// consumer chain, which identifies the chain beyond its chain id
+	QueryGenesisBlockHash() ([]byte, error)
+
+	// QueryNodeVersion returns the application version reported by the node
+	// of the consumer chain, e.g., v0.12.0
+	QueryNodeVersion() (string, error)
+
+	// QueryFinalityParams returns the current parameters of the finality module
+	QueryFinalityParams() (*finalitytypes.Params, error)
+
+	// QueryCurrentEpoch returns the current epoch of the consumer chain
+	QueryCurrentEpoch() (uint64, error)
+
+	// QueryLastFinalizedEpoch returns the last epoch of the consumer chain
+	// finalized on Bitcoin, from which on the messages included in the epoch
+	// take effect
+	QueryLastFinalizedEpoch() (uint64, error)
+
 	Close() error
 }
 
@@ -86,8 +131,23 @@ func NewClientController(chainName string, bbnConfig *fpcfg.BBNConfig, netParams
 		if err != nil {
 			return nil, fmt.Errorf("failed to create Babylon rpc client: %w", err)
 		}
+		if len(bbnConfig.LightClientWitnesses) > 0 {
+			cc, err = NewLightClientController(cc, bbnConfig, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the light client of Babylon: %w", err)
+			}
+		}
+		if bbnConfig.QueryTimeout > 0 || bbnConfig.BroadcastTimeout > 0 {
+			cc = NewTimeoutController(cc, bbnConfig.QueryTimeout, bbnConfig.BroadcastTimeout)
+		}
+		if bbnConfig.CircuitBreakerThreshold > 0 {
+			cc = NewCircuitBreakerController(cc, bbnConfig.CircuitBreakerThreshold, bbnConfig.CircuitBreakerProbeInterval, logger)
+		}
+	case mockConsumerChainName:
+		logger.Warn("using the in-process mock consumer chain, which is only meant for local development")
+		cc = NewMockConsumerController(bbnConfig.ChainID, defaultMockBlockInterval, logger)
 	default:
-		return nil, fmt.Errorf("unsupported consumer chain")
+		return nil, fmt.Errorf("unsupported consumer chain %s", chainName)
 	}
 
 	return cc, err
@@ -0,0 +1,149 @@
+package clientcontroller
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/light"
+	lightdb "github.com/cometbft/cometbft/light/store/db"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// ErrUnverifiedBlock is returned by the block queries if a block does not
+// match the header verified by the light client
+var ErrUnverifiedBlock = errors.New("the queried block does not match the header verified by the light client")
+
+// headerVerifier verifies the headers of the consumer chain, which is
+// implemented by the CometBFT light client
+type headerVerifier interface {
+	VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*cmttypes.LightBlock, error)
+}
+
+// lightClientController wraps a client controller and verifies each queried
+// block against the header at its height verified by a CometBFT light client,
+// which cross-checks the headers of the node with independent witnesses. As
+// the votes are cast over the queried blocks, a compromised node cannot make
+// the finality provider vote for a block that is not on the chain
+type lightClientController struct {
+	ClientController
+
+	verifier headerVerifier
+	timeout  time.Duration
+	logger   *zap.Logger
+}
+
+// NewLightClientController wraps the given client controller with the light
+// client verification of the queried blocks. The light client trusts the
+// configured header and uses the node as its primary
+func NewLightClientController(cc ClientController, cfg *fpcfg.BBNConfig, logger *zap.Logger) (ClientController, error) {
+	trustedHash, err := hex.DecodeString(cfg.LightClientTrustedHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted hash: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	// the verified headers are kept in memory, so the trusted header must
+	// be within the trusting period whenever the daemon starts
+	lc, err := light.NewHTTPClient(
+		ctx,
+		cfg.ChainID,
+		light.TrustOptions{
+			Period: cfg.LightClientTrustingPeriod,
+			Height: int64(cfg.LightClientTrustedHeight),
+			Hash:   trustedHash,
+		},
+		cfg.RPCAddr,
+		cfg.LightClientWitnesses,
+		lightdb.New(dbm.NewMemDB(), cfg.ChainID),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the light client: %w", err)
+	}
+
+	return newLightClientController(cc, lc, cfg.Timeout, logger), nil
+}
+
+func newLightClientController(cc ClientController, verifier headerVerifier, timeout time.Duration, logger *zap.Logger) *lightClientController {
+	return &lightClientController{
+		ClientController: cc,
+		verifier:         verifier,
+		timeout:          timeout,
+		logger:           logger,
+	}
+}
+
+func (c *lightClientController) QueryBlock(height uint64) (*types.BlockInfo, error) {
+	block, err := c.ClientController.QueryBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyBlock(block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (c *lightClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	blocks, err := c.ClientController.QueryBlocks(startHeight, endHeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range blocks {
+		if err := c.verifyBlock(b); err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+func (c *lightClientController) QueryBestBlock() (*types.BlockInfo, error) {
+	block, err := c.ClientController.QueryBestBlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.verifyBlock(block); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// verifyBlock checks that the hash of the block is the app hash of the header
+// at its height verified by the light client
+func (c *lightClientController) verifyBlock(block *types.BlockInfo) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	lb, err := c.verifier.VerifyLightBlockAtHeight(ctx, int64(block.Height), time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to verify the header at height %d: %w", block.Height, err)
+	}
+
+	if !bytes.Equal(lb.AppHash, block.Hash) {
+		c.logger.Error("the queried block does not match the verified header, the node may be compromised",
+			zap.Uint64("height", block.Height),
+			zap.String("block_hash", hex.EncodeToString(block.Hash)),
+			zap.String("verified_app_hash", lb.AppHash.String()),
+		)
+		return fmt.Errorf("%w: the hash of the block at height %d is %X while the verified app hash is %s",
+			ErrUnverifiedBlock, block.Height, block.Hash, lb.AppHash)
+	}
+
+	return nil
+}
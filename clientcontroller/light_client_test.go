@@ -0,0 +1,72 @@
+package clientcontroller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// blocksController returns the blocks of the given app hashes
+type blocksController struct {
+	ClientController
+	hashes map[uint64][]byte
+}
+
+func (c *blocksController) QueryBlock(height uint64) (*types.BlockInfo, error) {
+	return &types.BlockInfo{Height: height, Hash: c.hashes[height]}, nil
+}
+
+func (c *blocksController) QueryBlocks(startHeight, endHeight uint64, _ uint32) ([]*types.BlockInfo, error) {
+	var blocks []*types.BlockInfo
+	for h := startHeight; h <= endHeight; h++ {
+		blocks = append(blocks, &types.BlockInfo{Height: h, Hash: c.hashes[h]})
+	}
+	return blocks, nil
+}
+
+// headersVerifier verifies the headers of the given app hashes
+type headersVerifier struct {
+	appHashes map[uint64][]byte
+}
+
+func (v *headersVerifier) VerifyLightBlockAtHeight(_ context.Context, height int64, _ time.Time) (*cmttypes.LightBlock, error) {
+	appHash, ok := v.appHashes[uint64(height)]
+	if !ok {
+		return nil, errors.New("no witness has the header")
+	}
+	return &cmttypes.LightBlock{
+		SignedHeader: &cmttypes.SignedHeader{Header: &cmttypes.Header{Height: height, AppHash: appHash}},
+	}, nil
+}
+
+func TestLightClientController(t *testing.T) {
+	verifier := &headersVerifier{appHashes: map[uint64][]byte{1: {0x01}, 2: {0x02}, 3: {0x03}}}
+	inner := &blocksController{hashes: map[uint64][]byte{1: {0x01}, 2: {0x02}, 3: {0xff}, 4: {0x04}}}
+	cc := newLightClientController(inner, verifier, time.Second, zap.NewNop())
+
+	// the blocks matching the verified headers are returned
+	block, err := cc.QueryBlock(1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01}, block.Hash)
+	blocks, err := cc.QueryBlocks(1, 2, 10)
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+
+	// a fake block is rejected
+	_, err = cc.QueryBlock(3)
+	require.ErrorIs(t, err, ErrUnverifiedBlock)
+	_, err = cc.QueryBlocks(1, 3, 10)
+	require.ErrorIs(t, err, ErrUnverifiedBlock)
+
+	// a block is rejected if its header cannot be verified
+	_, err = cc.QueryBlock(4)
+	require.ErrorContains(t, err, "failed to verify the header at height 4")
+	require.NotErrorIs(t, err, ErrUnverifiedBlock)
+}
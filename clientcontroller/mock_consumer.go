@@ -0,0 +1,491 @@
+package clientcontroller
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	incentivetypes "github.com/babylonlabs-io/babylon/x/incentive/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/types"
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+const (
+	// defaultMockBlockInterval is the interval at which the mock consumer
+	// chain fabricates blocks
+	defaultMockBlockInterval = 2 * time.Second
+	// mockActivatedHeight is the height at which the BTC staking of the mock
+	// consumer chain is activated
+	mockActivatedHeight = 1
+)
+
+type mockFinalityProvider struct {
+	commission  *math.LegacyDec
+	description *sttypes.Description
+	// pubRandCommits maps the start height of each commitment to it
+	pubRandCommits map[uint64]*finalitytypes.PubRandCommitResponse
+}
+
+// MockConsumerController is an in-process consumer chain for local development.
+// It fabricates a block every block interval and accepts the registrations,
+// public randomness commitments and finality signatures in memory, so that the
+// finality provider can run end-to-end without a Babylon node. The registered
+// finality providers have a voting power of 1 at every height, and a block is
+// finalized once any of them has voted for it. The finality signatures are not
+// verified
+type MockConsumerController struct {
+	chainID       string
+	blockInterval time.Duration
+	genesisTime   time.Time
+	logger        *zap.Logger
+
+	mu  sync.Mutex
+	fps map[string]*mockFinalityProvider
+	// votes maps a height to the finality providers that voted for it
+	votes   map[uint64]map[string]struct{}
+	txCount uint64
+}
+
+var _ ClientController = &MockConsumerController{}
+
+// NewMockConsumerController returns a mock consumer chain with the given chain
+// id whose first block is produced now, and the next ones every blockInterval
+func NewMockConsumerController(chainID string, blockInterval time.Duration, logger *zap.Logger) *MockConsumerController {
+	return &MockConsumerController{
+		chainID:       chainID,
+		blockInterval: blockInterval,
+		genesisTime:   time.Now(),
+		logger:        logger,
+		fps:           make(map[string]*mockFinalityProvider),
+		votes:         make(map[uint64]map[string]struct{}),
+	}
+}
+
+func (mc *MockConsumerController) RegisterFinalityProvider(
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	var bbnPop btcstakingtypes.ProofOfPossessionBTC
+	if err := bbnPop.Unmarshal(pop); err != nil {
+		return nil, fmt.Errorf("invalid proof-of-possession: %w", err)
+	}
+
+	var sdkDescription sttypes.Description
+	if err := sdkDescription.Unmarshal(description); err != nil {
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	pkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	if _, ok := mc.fps[pkHex]; ok {
+		return nil, btcstakingtypes.ErrFpRegistered
+	}
+	mc.fps[pkHex] = &mockFinalityProvider{
+		commission:     commission,
+		description:    &sdkDescription,
+		pubRandCommits: make(map[uint64]*finalitytypes.PubRandCommitResponse),
+	}
+	mc.logger.Info("the mock consumer chain registered a finality provider", zap.String("pk", pkHex))
+
+	return mc.newTxResponse(), nil
+}
+
+// CommitPubRandList accepts a commitment of public randomness if it starts
+// above the last commitment of the finality provider
+func (mc *MockConsumerController) CommitPubRandList(
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	_ *schnorr.Signature,
+) (*types.TxResponse, error) {
+	if numPubRand == 0 {
+		return nil, finalitytypes.ErrTooFewPubRand
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	fp, err := mc.getFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+	for start, c := range fp.pubRandCommits {
+		if startHeight < start+c.NumPubRand {
+			return nil, fmt.Errorf("%w: the start height %d is not above the last committed height %d",
+				finalitytypes.ErrInvalidPubRand, startHeight, start+c.NumPubRand-1)
+		}
+	}
+	fp.pubRandCommits[startHeight] = &finalitytypes.PubRandCommitResponse{
+		NumPubRand: numPubRand,
+		Commitment: commitment,
+	}
+
+	return mc.newTxResponse(), nil
+}
+
+func (mc *MockConsumerController) SubmitFinalitySig(
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return mc.SubmitBatchFinalitySigs(
+		fpPk, []*types.BlockInfo{block}, []*btcec.FieldVal{pubRand}, [][]byte{proof}, []*btcec.ModNScalar{sig})
+}
+
+// SubmitBatchFinalitySigs records the votes of the finality provider for the
+// given blocks, which must exist and be covered by its public randomness
+func (mc *MockConsumerController) SubmitBatchFinalitySigs(
+	fpPk *btcec.PublicKey,
+	blocks []*types.BlockInfo,
+	_ []*btcec.FieldVal,
+	_ [][]byte,
+	sigs []*btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	if len(blocks) != len(sigs) {
+		return nil, fmt.Errorf("the number of blocks %v should match the number of finality signatures %v", len(blocks), len(sigs))
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	fp, err := mc.getFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+	tip := mc.tipHeight()
+	for _, b := range blocks {
+		if b.Height > tip {
+			return nil, fmt.Errorf("%w: the block at height %d does not exist", finalitytypes.ErrBlockNotFound, b.Height)
+		}
+		if string(b.Hash) != string(mockBlockHash(b.Height)) {
+			return nil, fmt.Errorf("%w: the block hash at height %d does not match", finalitytypes.ErrInvalidFinalitySig, b.Height)
+		}
+		if !fp.hasPubRand(b.Height) {
+			return nil, fmt.Errorf("%w: no public randomness is committed for height %d", finalitytypes.ErrPubRandNotFound, b.Height)
+		}
+	}
+
+	pkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	for _, b := range blocks {
+		if mc.votes[b.Height] == nil {
+			mc.votes[b.Height] = make(map[string]struct{})
+		}
+		mc.votes[b.Height][pkHex] = struct{}{}
+	}
+
+	return mc.newTxResponse(), nil
+}
+
+// UnjailFinalityProvider always fails as the finality providers are never
+// jailed on the mock consumer chain
+func (mc *MockConsumerController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, err := mc.getFinalityProvider(fpPk); err != nil {
+		return nil, err
+	}
+
+	return nil, btcstakingtypes.ErrFpNotJailed
+}
+
+// QueryFinalityProviderRewards always returns no rewards as the mock consumer
+// chain does not distribute any
+func (mc *MockConsumerController) QueryFinalityProviderRewards() (sdk.Coins, error) {
+	return sdk.NewCoins(), nil
+}
+
+func (mc *MockConsumerController) WithdrawFinalityProviderRewards(recipient string) (*types.TxResponse, sdk.Coins, error) {
+	return nil, nil, incentivetypes.ErrNoWithdrawableCoins
+}
+
+// QueryFinalityProviderDelegations always returns no delegations as the voting
+// power of the mock consumer chain is not backed by BTC stake
+func (mc *MockConsumerController) QueryFinalityProviderDelegations(
+	fpPk *btcec.PublicKey,
+	pageKey []byte,
+	limit uint64,
+) ([]*types.Delegation, []byte, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, err := mc.getFinalityProvider(fpPk); err != nil {
+		return nil, nil, err
+	}
+
+	return nil, nil, nil
+}
+
+func (mc *MockConsumerController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, err := mc.getFinalityProvider(fpPk); err != nil {
+		return 0, nil
+	}
+
+	return 1, nil
+}
+
+func (mc *MockConsumerController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, err := mc.getFinalityProvider(fpPk); err != nil {
+		return false, false, fmt.Errorf("failed to query the finality provider: %w", err)
+	}
+
+	return false, false, nil
+}
+
+// EditFinalityProvider updates the non-empty fields of the description, and
+// the commission if given
+func (mc *MockConsumerController) EditFinalityProvider(
+	fpPk *btcec.PublicKey,
+	commission *math.LegacyDec,
+	description []byte,
+) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	var reqDesc proto.Description
+	if err := protobuf.Unmarshal(description, &reqDesc); err != nil {
+		return nil, err
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	fp, err := mc.getFinalityProvider(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	getValueOrDefault := func(reqValue, defaultValue string) string {
+		if reqValue != "" {
+			return reqValue
+		}
+		return defaultValue
+	}
+	fp.description = &sttypes.Description{
+		Moniker:         getValueOrDefault(reqDesc.Moniker, fp.description.Moniker),
+		Identity:        getValueOrDefault(reqDesc.Identity, fp.description.Identity),
+		Website:         getValueOrDefault(reqDesc.Website, fp.description.Website),
+		SecurityContact: getValueOrDefault(reqDesc.SecurityContact, fp.description.SecurityContact),
+		Details:         getValueOrDefault(reqDesc.Details, fp.description.Details),
+	}
+	if commission != nil {
+		fp.commission = commission
+	}
+
+	return &btcstakingtypes.MsgEditFinalityProvider{
+		BtcPk:       bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MustMarshal(),
+		Description: fp.description,
+		Commission:  fp.commission,
+	}, nil
+}
+
+func (mc *MockConsumerController) QueryLatestFinalizedBlocks(count uint64) ([]*types.BlockInfo, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	heights := make([]uint64, 0, len(mc.votes))
+	for h := range mc.votes {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	if uint64(len(heights)) > count {
+		heights = heights[:count]
+	}
+
+	blocks := make([]*types.BlockInfo, 0, len(heights))
+	for _, h := range heights {
+		blocks = append(blocks, mc.block(h))
+	}
+
+	return blocks, nil
+}
+
+func (mc *MockConsumerController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	res := make(map[uint64]*finalitytypes.PubRandCommitResponse)
+	fp, err := mc.getFinalityProvider(fpPk)
+	if err != nil {
+		// Babylon returns no commitment for an unknown finality provider
+		return res, nil
+	}
+
+	heights := make([]uint64, 0, len(fp.pubRandCommits))
+	for h := range fp.pubRandCommits {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	for i := 0; i < len(heights) && uint64(i) < count; i++ {
+		res[heights[i]] = fp.pubRandCommits[heights[i]]
+	}
+
+	return res, nil
+}
+
+func (mc *MockConsumerController) QueryBlock(height uint64) (*types.BlockInfo, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if height == 0 || height > mc.tipHeight() {
+		return nil, fmt.Errorf("failed to query indexed block at height %v: %w", height, finalitytypes.ErrBlockNotFound)
+	}
+
+	return mc.block(height), nil
+}
+
+func (mc *MockConsumerController) QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	pks := make([]bbntypes.BIP340PubKey, 0, len(mc.votes[height]))
+	for pkHex := range mc.votes[height] {
+		pk, err := bbntypes.NewBIP340PubKeyFromHex(pkHex)
+		if err != nil {
+			return nil, err
+		}
+		pks = append(pks, *pk)
+	}
+
+	return pks, nil
+}
+
+func (mc *MockConsumerController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	if endHeight < startHeight {
+		return nil, fmt.Errorf("the startHeight %v should not be higher than the endHeight %v", startHeight, endHeight)
+	}
+
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if tip := mc.tipHeight(); endHeight > tip {
+		endHeight = tip
+	}
+	if startHeight == 0 {
+		startHeight = 1
+	}
+	var blocks []*types.BlockInfo
+	for h := startHeight; h <= endHeight && uint32(len(blocks)) < limit; h++ {
+		blocks = append(blocks, mc.block(h))
+	}
+
+	return blocks, nil
+}
+
+func (mc *MockConsumerController) QueryBestBlock() (*types.BlockInfo, error) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return mc.block(mc.tipHeight()), nil
+}
+
+func (mc *MockConsumerController) QueryActivatedHeight() (uint64, error) {
+	return mockActivatedHeight, nil
+}
+
+func (mc *MockConsumerController) QueryChainID() (string, error) {
+	return mc.chainID, nil
+}
+
+func (mc *MockConsumerController) QueryGenesisBlockHash() ([]byte, error) {
+	return mockBlockHash(1), nil
+}
+
+// QueryNodeVersion returns the version of Babylon the daemon is built
+// against, so that the mock chain is always compatible
+func (mc *MockConsumerController) QueryNodeVersion() (string, error) {
+	return version.BabylonVersion(), nil
+}
+
+func (mc *MockConsumerController) QueryFinalityParams() (*finalitytypes.Params, error) {
+	params := finalitytypes.DefaultParams()
+	return &params, nil
+}
+
+// QueryCurrentEpoch returns the epoch 0 as the mock consumer chain has no
+// epochs
+func (mc *MockConsumerController) QueryCurrentEpoch() (uint64, error) {
+	return 0, nil
+}
+
+// QueryLastFinalizedEpoch returns the epoch 0, which is finalized from the
+// start, so that the messages take effect at once
+func (mc *MockConsumerController) QueryLastFinalizedEpoch() (uint64, error) {
+	return 0, nil
+}
+
+func (mc *MockConsumerController) Close() error {
+	return nil
+}
+
+// tipHeight returns the height of the last fabricated block
+func (mc *MockConsumerController) tipHeight() uint64 {
+	return uint64(time.Since(mc.genesisTime)/mc.blockInterval) + 1
+}
+
+func (mc *MockConsumerController) block(height uint64) *types.BlockInfo {
+	return &types.BlockInfo{
+		Height:    height,
+		Hash:      mockBlockHash(height),
+		Finalized: len(mc.votes[height]) > 0,
+	}
+}
+
+func (mc *MockConsumerController) getFinalityProvider(fpPk *btcec.PublicKey) (*mockFinalityProvider, error) {
+	pkHex := bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex()
+	fp, ok := mc.fps[pkHex]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", btcstakingtypes.ErrFpNotFound, pkHex)
+	}
+
+	return fp, nil
+}
+
+// newTxResponse fabricates the response of a transaction
+func (mc *MockConsumerController) newTxResponse() *types.TxResponse {
+	mc.txCount++
+	hash := sha256.Sum256(binary.BigEndian.AppendUint64([]byte("tx"), mc.txCount))
+
+	return &types.TxResponse{TxHash: fmt.Sprintf("%X", hash)}
+}
+
+func (fp *mockFinalityProvider) hasPubRand(height uint64) bool {
+	for start, c := range fp.pubRandCommits {
+		if height >= start && height < start+c.NumPubRand {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mockBlockHash derives the hash of a fabricated block from its height
+func mockBlockHash(height uint64) []byte {
+	hash := sha256.Sum256(binary.BigEndian.AppendUint64([]byte("block"), height))
+	return hash[:]
+}
@@ -0,0 +1,74 @@
+package clientcontroller
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMockConsumerController(t *testing.T) {
+	mc := NewMockConsumerController("mock-chain", 10*time.Millisecond, zap.NewNop())
+	sk, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	fpPk := sk.PubKey()
+
+	// the blocks are fabricated over time
+	b1, err := mc.QueryBestBlock()
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		b, err := mc.QueryBestBlock()
+		return err == nil && b.Height >= b1.Height+3
+	}, time.Second, 5*time.Millisecond)
+	_, err = mc.QueryBlock(1000)
+	require.ErrorIs(t, err, finalitytypes.ErrBlockNotFound)
+
+	// the finality provider has no voting power until registered
+	power, err := mc.QueryFinalityProviderVotingPower(fpPk, 1)
+	require.NoError(t, err)
+	require.Zero(t, power)
+
+	pop, err := (&btcstakingtypes.ProofOfPossessionBTC{}).Marshal()
+	require.NoError(t, err)
+	desc, err := (&sttypes.Description{Moniker: "mock"}).Marshal()
+	require.NoError(t, err)
+	commission := sdkmath.LegacyZeroDec()
+	_, err = mc.RegisterFinalityProvider(fpPk, pop, &commission, desc)
+	require.NoError(t, err)
+	_, err = mc.RegisterFinalityProvider(fpPk, pop, &commission, desc)
+	require.ErrorIs(t, err, btcstakingtypes.ErrFpRegistered)
+	power, err = mc.QueryFinalityProviderVotingPower(fpPk, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), power)
+
+	// a vote requires committed public randomness
+	b, err := mc.QueryBlock(2)
+	require.NoError(t, err)
+	require.False(t, b.Finalized)
+	_, err = mc.SubmitFinalitySig(fpPk, b, nil, nil, nil)
+	require.ErrorIs(t, err, finalitytypes.ErrPubRandNotFound)
+
+	_, err = mc.CommitPubRandList(fpPk, 1, 100, []byte("commitment"), nil)
+	require.NoError(t, err)
+	_, err = mc.CommitPubRandList(fpPk, 50, 100, []byte("overlapping"), nil)
+	require.ErrorIs(t, err, finalitytypes.ErrInvalidPubRand)
+	commits, err := mc.QueryLastCommittedPublicRand(fpPk, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), commits[1].NumPubRand)
+
+	_, err = mc.SubmitFinalitySig(fpPk, b, nil, nil, nil)
+	require.NoError(t, err)
+	b, err = mc.QueryBlock(2)
+	require.NoError(t, err)
+	require.True(t, b.Finalized)
+	finalized, err := mc.QueryLatestFinalizedBlocks(1)
+	require.NoError(t, err)
+	require.Len(t, finalized, 1)
+	require.Equal(t, uint64(2), finalized[0].Height)
+}
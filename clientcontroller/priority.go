@@ -0,0 +1,108 @@
+package clientcontroller
+
+import (
+	"context"
+	"sync"
+)
+
+// TxPriority is the priority of a transaction waiting for its key to build
+// and broadcast it. The transactions of a higher priority are broadcast first,
+// so that the liveness-critical votes do not queue behind large backlogs
+type TxPriority int
+
+const (
+	// TxPriorityLow is the priority of the bulk transactions, i.e., the
+	// batches of catch-up votes and the covenant signatures
+	TxPriorityLow TxPriority = iota
+	// TxPriorityNormal is the priority of the public randomness commits and
+	// the other transactions
+	TxPriorityNormal
+	// TxPriorityHigh is the priority of the votes for the current height
+	TxPriorityHigh
+
+	numTxPriorities = int(TxPriorityHigh) + 1
+)
+
+type txPriorityKey struct{}
+
+// withTxPriority returns a context carrying the priority of the transaction
+// sent within it
+func withTxPriority(ctx context.Context, p TxPriority) context.Context {
+	return context.WithValue(ctx, txPriorityKey{}, p)
+}
+
+// txPriority returns the priority of the transaction sent within the context,
+// which is normal by default
+func txPriority(ctx context.Context) TxPriority {
+	if p, ok := ctx.Value(txPriorityKey{}).(TxPriority); ok {
+		return p
+	}
+
+	return TxPriorityNormal
+}
+
+// priorityLock is a mutex granted to the waiter of the highest priority once
+// released, and to the waiters of the same priority in turn
+type priorityLock struct {
+	mu      sync.Mutex
+	held    bool
+	waiters [numTxPriorities][]chan struct{}
+}
+
+// lock waits for the lock to be granted, or for the context to be done
+func (l *priorityLock) lock(ctx context.Context, p TxPriority) error {
+	if p < TxPriorityLow || p > TxPriorityHigh {
+		p = TxPriorityNormal
+	}
+
+	l.mu.Lock()
+	if !l.held {
+		l.held = true
+		l.mu.Unlock()
+		return nil
+	}
+	granted := make(chan struct{})
+	l.waiters[p] = append(l.waiters[p], granted)
+	l.mu.Unlock()
+
+	select {
+	case <-granted:
+		return nil
+	case <-ctx.Done():
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i, w := range l.waiters[p] {
+		if w == granted {
+			l.waiters[p] = append(l.waiters[p][:i], l.waiters[p][i+1:]...)
+			return ctx.Err()
+		}
+	}
+
+	// the lock was granted in the meantime, so it is passed on
+	l.unlockLocked()
+
+	return ctx.Err()
+}
+
+// unlock grants the lock to the next waiter of the highest priority
+func (l *priorityLock) unlock() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.unlockLocked()
+}
+
+func (l *priorityLock) unlockLocked() {
+	for p := numTxPriorities - 1; p >= 0; p-- {
+		if len(l.waiters[p]) > 0 {
+			next := l.waiters[p][0]
+			l.waiters[p] = l.waiters[p][1:]
+			close(next)
+			return
+		}
+	}
+
+	l.held = false
+}
@@ -21,6 +21,21 @@ var unrecoverableErrors = []*sdkErr.Error{
 	btcstakingtypes.ErrFpAlreadyJailed,
 }
 
+// IsPubRandMissing returns true when the consumer chain rejected a finality
+// signature as no public randomness is committed at its height
+func IsPubRandMissing(err error) bool {
+	return strings.Contains(err.Error(), finalitytypes.ErrPubRandNotFound.Error()) ||
+		strings.Contains(err.Error(), finalitytypes.ErrNoPubRandYet.Error())
+}
+
+// IsPubRandNotTimestamped returns true when the consumer chain rejected a
+// finality signature as the public randomness at its height was committed in
+// an epoch that is not finalized on Bitcoin yet, which only resolves once the
+// epoch is checkpointed
+func IsPubRandNotTimestamped(err error) bool {
+	return strings.Contains(err.Error(), finalitytypes.ErrPubRandCommitNotBTCTimestamped.Error())
+}
+
 // IsUnrecoverable returns true when the error is in the unrecoverableErrors list
 func IsUnrecoverable(err error) bool {
 	for _, e := range unrecoverableErrors {
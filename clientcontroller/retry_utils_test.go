@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -13,3 +14,19 @@ func TestExpectedErr(t *testing.T) {
 	wrappedErr := fmt.Errorf("expected: %w", expectedErr)
 	require.True(t, IsExpected(wrappedErr))
 }
+
+func TestPubRandMissingErr(t *testing.T) {
+	err := fmt.Errorf("failed to submit: %s", finalitytypes.ErrPubRandNotFound.Wrapf("height %d", 10).Error())
+	require.True(t, IsPubRandMissing(err))
+	require.True(t, IsUnrecoverable(err))
+	require.True(t, IsPubRandMissing(finalitytypes.ErrNoPubRandYet))
+	require.False(t, IsPubRandMissing(finalitytypes.ErrInvalidFinalitySig))
+}
+
+func TestPubRandNotTimestampedErr(t *testing.T) {
+	err := fmt.Errorf("failed to submit: %s", finalitytypes.ErrPubRandCommitNotBTCTimestamped.Wrapf("no finalized epoch yet").Error())
+	require.True(t, IsPubRandNotTimestamped(err))
+	require.False(t, IsPubRandMissing(err))
+	require.False(t, IsUnrecoverable(err))
+	require.False(t, IsPubRandNotTimestamped(finalitytypes.ErrPubRandNotFound))
+}
@@ -0,0 +1,98 @@
+package clientcontroller
+
+import (
+	"context"
+	"sync"
+
+	sdkErr "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// wrongSequenceErrs are the errors of a transaction signed with an account
+// sequence that differs from the one on the chain
+var wrongSequenceErrs = []*sdkErr.Error{sdkerrors.ErrWrongSequence}
+
+// accountQuerier queries the account number and the current sequence of a key
+// from the chain
+type accountQuerier func(ctx context.Context) (accNum uint64, seq uint64, err error)
+
+// sequenceManager tracks the account sequence of a key across its
+// transactions, so that a transaction can be built as soon as the previous one
+// is accepted in the mempool rather than after it is included.
+//
+// A sequence is reserved to build and broadcast a transaction, and is then
+// committed if the transaction is accepted in the mempool, or rolled back
+// otherwise. Only one sequence is reserved at a time, so that the transactions
+// are broadcast in the order of their sequences, while the transactions of the
+// highest priority, as given by the context of the reservation, are the first
+// to reserve the next sequence. The sequence is queried from the chain again
+// if a transaction is rejected due to a sequence mismatch
+type sequenceManager struct {
+	// reserved is held from reserving a sequence until it is committed or
+	// rolled back
+	reserved priorityLock
+
+	mu     sync.Mutex
+	synced bool
+	accNum uint64
+	next   uint64
+
+	query accountQuerier
+}
+
+func newSequenceManager(query accountQuerier) *sequenceManager {
+	return &sequenceManager{query: query}
+}
+
+// reserve waits for the previously reserved sequence to be released, and
+// returns the account number and the next sequence of the key, which must be
+// either committed or rolled back
+func (m *sequenceManager) reserve(ctx context.Context) (uint64, uint64, error) {
+	if err := m.reserved.lock(ctx, txPriority(ctx)); err != nil {
+		return 0, 0, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		accNum, seq, err := m.query(ctx)
+		if err != nil {
+			m.reserved.unlock()
+			return 0, 0, err
+		}
+		m.accNum, m.next, m.synced = accNum, seq, true
+	}
+
+	return m.accNum, m.next, nil
+}
+
+// commit releases the reserved sequence after the transaction signed with it
+// is accepted in the mempool, so that the next transaction uses the following
+// sequence
+func (m *sequenceManager) commit() {
+	m.mu.Lock()
+	m.next++
+	m.mu.Unlock()
+
+	m.reserved.unlock()
+}
+
+// rollback releases the reserved sequence after the transaction signed with it
+// fails to be built or is rejected with the given error, so that the next
+// transaction uses the same sequence. The sequence is queried from the chain
+// again if the error is a sequence mismatch
+func (m *sequenceManager) rollback(err error) {
+	if err != nil && errorContained(err, wrongSequenceErrs) {
+		m.resync()
+	}
+
+	m.reserved.unlock()
+}
+
+// resync makes the next reservation query the sequence from the chain
+func (m *sequenceManager) resync() {
+	m.mu.Lock()
+	m.synced = false
+	m.mu.Unlock()
+}
@@ -0,0 +1,103 @@
+package clientcontroller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceManager(t *testing.T) {
+	ctx := context.Background()
+	chainSeq, queries := uint64(5), 0
+	seqs := newSequenceManager(func(context.Context) (uint64, uint64, error) {
+		queries++
+		return 1, chainSeq, nil
+	})
+
+	// the sequence is queried from the chain only once
+	accNum, seq, err := seqs.reserve(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), accNum)
+	require.Equal(t, uint64(5), seq)
+	seqs.commit()
+	_, seq, err = seqs.reserve(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), seq)
+	require.Equal(t, 1, queries)
+
+	// the next reservation waits for the reserved sequence to be released
+	reserved := make(chan uint64)
+	go func() {
+		_, seq, err := seqs.reserve(ctx)
+		require.NoError(t, err)
+		reserved <- seq
+	}()
+	select {
+	case <-reserved:
+		t.Fatal("the sequence should be reserved only once at a time")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the sequence is reused after a rollback
+	seqs.rollback(errors.New("insufficient fees"))
+	require.Equal(t, uint64(6), <-reserved)
+	require.Equal(t, 1, queries)
+
+	// the sequence is queried again after a mismatch
+	chainSeq = 8
+	seqs.rollback(fmt.Errorf("%w: account sequence mismatch, expected 8, got 6", sdkerrors.ErrWrongSequence))
+	_, seq, err = seqs.reserve(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), seq)
+	require.Equal(t, 2, queries)
+	seqs.commit()
+
+	// a failed query releases the reservation
+	seqs.resync()
+	queryErr := errors.New("connection refused")
+	seqs.query = func(context.Context) (uint64, uint64, error) {
+		return 0, 0, queryErr
+	}
+	_, _, err = seqs.reserve(ctx)
+	require.ErrorIs(t, err, queryErr)
+	_, _, err = seqs.reserve(ctx)
+	require.ErrorIs(t, err, queryErr)
+}
+
+func TestSequenceManagerPriority(t *testing.T) {
+	seqs := newSequenceManager(func(context.Context) (uint64, uint64, error) {
+		return 1, 0, nil
+	})
+	_, _, err := seqs.reserve(context.Background())
+	require.NoError(t, err)
+
+	// the waiting reservations are granted by priority, and in turn within
+	// the same priority
+	reserved := make(chan TxPriority, 4)
+	for _, p := range []TxPriority{TxPriorityLow, TxPriorityNormal, TxPriorityLow, TxPriorityHigh} {
+		p := p
+		go func() {
+			_, _, err := seqs.reserve(withTxPriority(context.Background(), p))
+			require.NoError(t, err)
+			reserved <- p
+		}()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// a canceled reservation stops waiting
+	ctx, cancel := context.WithCancel(withTxPriority(context.Background(), TxPriorityHigh))
+	cancel()
+	_, _, err = seqs.reserve(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	seqs.commit()
+	for _, expected := range []TxPriority{TxPriorityHigh, TxPriorityNormal, TxPriorityLow, TxPriorityLow} {
+		require.Equal(t, expected, <-reserved)
+		seqs.commit()
+	}
+}
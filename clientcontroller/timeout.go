@@ -0,0 +1,373 @@
+package clientcontroller
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/math"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	finalitytypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// ErrCallTimeout is returned by the calls to the consumer chain which exceed
+// their deadline
+var ErrCallTimeout = errors.New("the call to the consumer chain timed out")
+
+// timeoutController wraps a client controller and fails each call which
+// exceeds the deadline of its kind, so that a hung node cannot block the
+// callers indefinitely. The calls cannot be cancelled, so a timed out call
+// keeps running in the background, e.g., a timed out submission may still be
+// included afterwards
+type timeoutController struct {
+	ClientController
+
+	queryTimeout     time.Duration
+	broadcastTimeout time.Duration
+}
+
+// NewTimeoutController wraps the given client controller with the deadlines
+// of the queries and the submissions. A zero timeout disables the deadline of
+// its kind
+func NewTimeoutController(cc ClientController, queryTimeout, broadcastTimeout time.Duration) ClientController {
+	return &timeoutController{
+		ClientController: cc,
+		queryTimeout:     queryTimeout,
+		broadcastTimeout: broadcastTimeout,
+	}
+}
+
+func (c *timeoutController) RegisterFinalityProvider(
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	return c.submit("RegisterFinalityProvider", func() (*types.TxResponse, error) {
+		return c.ClientController.RegisterFinalityProvider(fpPk, pop, commission, description)
+	})
+}
+
+func (c *timeoutController) CommitPubRandList(
+	fpPk *btcec.PublicKey,
+	startHeight uint64,
+	numPubRand uint64,
+	commitment []byte,
+	sig *schnorr.Signature,
+) (*types.TxResponse, error) {
+	return c.submit("CommitPubRandList", func() (*types.TxResponse, error) {
+		return c.ClientController.CommitPubRandList(fpPk, startHeight, numPubRand, commitment, sig)
+	})
+}
+
+func (c *timeoutController) SubmitFinalitySig(
+	fpPk *btcec.PublicKey,
+	block *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proof []byte,
+	sig *btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return c.submit("SubmitFinalitySig", func() (*types.TxResponse, error) {
+		return c.ClientController.SubmitFinalitySig(fpPk, block, pubRand, proof, sig)
+	})
+}
+
+func (c *timeoutController) SubmitBatchFinalitySigs(
+	fpPk *btcec.PublicKey,
+	blocks []*types.BlockInfo,
+	pubRandList []*btcec.FieldVal,
+	proofList [][]byte,
+	sigs []*btcec.ModNScalar,
+) (*types.TxResponse, error) {
+	return c.submit("SubmitBatchFinalitySigs", func() (*types.TxResponse, error) {
+		return c.ClientController.SubmitBatchFinalitySigs(fpPk, blocks, pubRandList, proofList, sigs)
+	})
+}
+
+func (c *timeoutController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	return c.submit("UnjailFinalityProvider", func() (*types.TxResponse, error) {
+		return c.ClientController.UnjailFinalityProvider(fpPk)
+	})
+}
+
+func (c *timeoutController) QueryFinalityProviderRewards() (sdk.Coins, error) {
+	var rewards sdk.Coins
+	if err := c.call("QueryFinalityProviderRewards", c.queryTimeout, func() (err error) {
+		rewards, err = c.ClientController.QueryFinalityProviderRewards()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return rewards, nil
+}
+
+func (c *timeoutController) WithdrawFinalityProviderRewards(recipient string) (*types.TxResponse, sdk.Coins, error) {
+	var (
+		res     *types.TxResponse
+		rewards sdk.Coins
+	)
+	if err := c.call("WithdrawFinalityProviderRewards", c.broadcastTimeout, func() (err error) {
+		res, rewards, err = c.ClientController.WithdrawFinalityProviderRewards(recipient)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return res, rewards, nil
+}
+
+func (c *timeoutController) QueryFinalityProviderDelegations(
+	fpPk *btcec.PublicKey,
+	pageKey []byte,
+	limit uint64,
+) ([]*types.Delegation, []byte, error) {
+	var (
+		dels        []*types.Delegation
+		nextPageKey []byte
+	)
+	if err := c.call("QueryFinalityProviderDelegations", c.queryTimeout, func() (err error) {
+		dels, nextPageKey, err = c.ClientController.QueryFinalityProviderDelegations(fpPk, pageKey, limit)
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return dels, nextPageKey, nil
+}
+
+func (c *timeoutController) QueryFinalityProviderVotingPower(fpPk *btcec.PublicKey, blockHeight uint64) (uint64, error) {
+	var power uint64
+	if err := c.call("QueryFinalityProviderVotingPower", c.queryTimeout, func() (err error) {
+		power, err = c.ClientController.QueryFinalityProviderVotingPower(fpPk, blockHeight)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return power, nil
+}
+
+func (c *timeoutController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
+	var slashed, jailed bool
+	if err := c.call("QueryFinalityProviderSlashedOrJailed", c.queryTimeout, func() (err error) {
+		slashed, jailed, err = c.ClientController.QueryFinalityProviderSlashedOrJailed(fpPk)
+		return err
+	}); err != nil {
+		return false, false, err
+	}
+
+	return slashed, jailed, nil
+}
+
+func (c *timeoutController) EditFinalityProvider(
+	fpPk *btcec.PublicKey,
+	commission *math.LegacyDec,
+	description []byte,
+) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	var msg *btcstakingtypes.MsgEditFinalityProvider
+	if err := c.call("EditFinalityProvider", c.broadcastTimeout, func() (err error) {
+		msg, err = c.ClientController.EditFinalityProvider(fpPk, commission, description)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (c *timeoutController) QueryLatestFinalizedBlocks(count uint64) ([]*types.BlockInfo, error) {
+	return c.queryBlocks("QueryLatestFinalizedBlocks", func() ([]*types.BlockInfo, error) {
+		return c.ClientController.QueryLatestFinalizedBlocks(count)
+	})
+}
+
+func (c *timeoutController) QueryLastCommittedPublicRand(
+	fpPk *btcec.PublicKey,
+	count uint64,
+) (map[uint64]*finalitytypes.PubRandCommitResponse, error) {
+	var commits map[uint64]*finalitytypes.PubRandCommitResponse
+	if err := c.call("QueryLastCommittedPublicRand", c.queryTimeout, func() (err error) {
+		commits, err = c.ClientController.QueryLastCommittedPublicRand(fpPk, count)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+func (c *timeoutController) QueryBlock(height uint64) (*types.BlockInfo, error) {
+	return c.queryBlock("QueryBlock", func() (*types.BlockInfo, error) {
+		return c.ClientController.QueryBlock(height)
+	})
+}
+
+func (c *timeoutController) QueryVotesAtHeight(height uint64) ([]bbntypes.BIP340PubKey, error) {
+	var votes []bbntypes.BIP340PubKey
+	if err := c.call("QueryVotesAtHeight", c.queryTimeout, func() (err error) {
+		votes, err = c.ClientController.QueryVotesAtHeight(height)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func (c *timeoutController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types.BlockInfo, error) {
+	return c.queryBlocks("QueryBlocks", func() ([]*types.BlockInfo, error) {
+		return c.ClientController.QueryBlocks(startHeight, endHeight, limit)
+	})
+}
+
+func (c *timeoutController) QueryBestBlock() (*types.BlockInfo, error) {
+	return c.queryBlock("QueryBestBlock", c.ClientController.QueryBestBlock)
+}
+
+func (c *timeoutController) QueryChainID() (string, error) {
+	var chainID string
+	if err := c.call("QueryChainID", c.queryTimeout, func() (err error) {
+		chainID, err = c.ClientController.QueryChainID()
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return chainID, nil
+}
+
+func (c *timeoutController) QueryGenesisBlockHash() ([]byte, error) {
+	var hash []byte
+	if err := c.call("QueryGenesisBlockHash", c.queryTimeout, func() (err error) {
+		hash, err = c.ClientController.QueryGenesisBlockHash()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return hash, nil
+}
+
+func (c *timeoutController) QueryNodeVersion() (string, error) {
+	var nodeVersion string
+	if err := c.call("QueryNodeVersion", c.queryTimeout, func() (err error) {
+		nodeVersion, err = c.ClientController.QueryNodeVersion()
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return nodeVersion, nil
+}
+
+func (c *timeoutController) QueryFinalityParams() (*finalitytypes.Params, error) {
+	var params *finalitytypes.Params
+	if err := c.call("QueryFinalityParams", c.queryTimeout, func() (err error) {
+		params, err = c.ClientController.QueryFinalityParams()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+func (c *timeoutController) QueryActivatedHeight() (uint64, error) {
+	var height uint64
+	if err := c.call("QueryActivatedHeight", c.queryTimeout, func() (err error) {
+		height, err = c.ClientController.QueryActivatedHeight()
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+func (c *timeoutController) QueryCurrentEpoch() (uint64, error) {
+	var epoch uint64
+	if err := c.call("QueryCurrentEpoch", c.queryTimeout, func() (err error) {
+		epoch, err = c.ClientController.QueryCurrentEpoch()
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return epoch, nil
+}
+
+func (c *timeoutController) QueryLastFinalizedEpoch() (uint64, error) {
+	var epoch uint64
+	if err := c.call("QueryLastFinalizedEpoch", c.queryTimeout, func() (err error) {
+		epoch, err = c.ClientController.QueryLastFinalizedEpoch()
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return epoch, nil
+}
+
+func (c *timeoutController) submit(method string, fn func() (*types.TxResponse, error)) (*types.TxResponse, error) {
+	var res *types.TxResponse
+	if err := c.call(method, c.broadcastTimeout, func() (err error) {
+		res, err = fn()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (c *timeoutController) queryBlock(method string, fn func() (*types.BlockInfo, error)) (*types.BlockInfo, error) {
+	var block *types.BlockInfo
+	if err := c.call(method, c.queryTimeout, func() (err error) {
+		block, err = fn()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (c *timeoutController) queryBlocks(method string, fn func() ([]*types.BlockInfo, error)) ([]*types.BlockInfo, error) {
+	var blocks []*types.BlockInfo
+	if err := c.call(method, c.queryTimeout, func() (err error) {
+		blocks, err = fn()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// call runs the call and waits for it until the timeout. The results set by
+// the call must not be read if an error is returned, as a timed out call
+// keeps running in the background
+func (c *timeoutController) call(method string, timeout time.Duration, fn func() error) error {
+	if timeout == 0 {
+		return fn()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return fmt.Errorf("%w: %s did not return within %v", ErrCallTimeout, method, timeout)
+	}
+}
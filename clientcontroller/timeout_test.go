@@ -0,0 +1,53 @@
+package clientcontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// hangingController hangs on the submissions and the queries until released
+type hangingController struct {
+	ClientController
+	release chan struct{}
+}
+
+func (c *hangingController) SubmitFinalitySig(*btcec.PublicKey, *types.BlockInfo, *btcec.FieldVal, []byte, *btcec.ModNScalar) (*types.TxResponse, error) {
+	<-c.release
+	return &types.TxResponse{TxHash: "hash"}, nil
+}
+
+func (c *hangingController) QueryBestBlock() (*types.BlockInfo, error) {
+	<-c.release
+	return &types.BlockInfo{Height: 1}, nil
+}
+
+func TestTimeoutController(t *testing.T) {
+	inner := &hangingController{release: make(chan struct{})}
+	cc := NewTimeoutController(inner, 10*time.Millisecond, 50*time.Millisecond)
+
+	// the queries and the submissions have their own deadlines
+	start := time.Now()
+	_, err := cc.QueryBestBlock()
+	require.ErrorIs(t, err, ErrCallTimeout)
+	require.ErrorContains(t, err, "QueryBestBlock")
+	require.Less(t, time.Since(start), 50*time.Millisecond)
+
+	start = time.Now()
+	_, err = cc.SubmitFinalitySig(nil, nil, nil, nil, nil)
+	require.ErrorIs(t, err, ErrCallTimeout)
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// the calls returning in time are not affected
+	close(inner.release)
+	block, err := cc.QueryBestBlock()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), block.Height)
+	res, err := cc.SubmitFinalitySig(nil, nil, nil, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "hash", res.TxHash)
+}
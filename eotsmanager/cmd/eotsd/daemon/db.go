@@ -0,0 +1,120 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+)
+
+// dbLockTimeout is the time to wait for the database to be unlocked by a
+// running eotsd before giving up
+const dbLockTimeout = 10 * time.Second
+
+var DbCommands = []cli.Command{
+	{
+		Name:     "db",
+		Usage:    "Command sets of maintaining the EOTS database.",
+		Category: "Database maintenance",
+		Subcommands: []cli.Command{
+			CompactDbCmd,
+			VerifyDbCmd,
+		},
+	},
+}
+
+var CompactDbCmd = cli.Command{
+	Name:  "compact",
+	Usage: "Compact the EOTS database.",
+	Description: `Rewrite the EOTS database into a fresh file, which drops the pages freed by the
+	deleted records as the database file never shrinks otherwise. The original file is only
+	replaced once the new one is complete. Note that eotsd should be stopped beforehand as it
+	locks the database.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  homeFlag,
+			Usage: "The path to the eotsd home directory",
+			Value: config.DefaultEOTSDir,
+		},
+	},
+	Action: compactDb,
+}
+
+func compactDb(ctx *cli.Context) error {
+	homePath, err := getHomeFlag(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	// do not wait for the default timeout if the database is locked
+	dbCfg := *cfg.DatabaseConfig
+	dbCfg.DBTimeout = dbLockTimeout
+	before, after, err := dbCfg.Compact()
+	if err != nil {
+		return fmt.Errorf("%w, check that eotsd is stopped", err)
+	}
+
+	fmt.Printf("compacted %s from %d to %d bytes\n", dbCfg.DBFilePath(), before, after)
+	return nil
+}
+
+var VerifyDbCmd = cli.Command{
+	Name:  "verify",
+	Usage: "Check the integrity of the EOTS database.",
+	Description: `Walk all the records of the EOTS database and report the anomalies: the key names
+	not stored under a valid BTC public key, and the key derivations with an invalid HD path or
+	without a stored key name. The command fails if any anomaly is found. Note that eotsd should
+	be stopped beforehand as it locks the database.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  homeFlag,
+			Usage: "The path to the eotsd home directory",
+			Value: config.DefaultEOTSDir,
+		},
+	},
+	Action: verifyDb,
+}
+
+func verifyDb(ctx *cli.Context) error {
+	homePath, err := getHomeFlag(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	// do not wait for the default timeout if the database is locked
+	dbCfg := *cfg.DatabaseConfig
+	dbCfg.DBTimeout = dbLockTimeout
+	db, err := dbCfg.GetDbBackend()
+	if err != nil {
+		return fmt.Errorf("failed to open the database, check that eotsd is stopped: %w", err)
+	}
+	defer db.Close()
+
+	anomalies, err := store.VerifyDb(db)
+	if err != nil {
+		return fmt.Errorf("failed to verify the database: %w", err)
+	}
+
+	for _, a := range anomalies {
+		fmt.Println(a.String())
+	}
+	if len(anomalies) > 0 {
+		return fmt.Errorf("found %d anomalies in %s", len(anomalies), dbCfg.DBFilePath())
+	}
+
+	fmt.Printf("no anomaly found in %s\n", dbCfg.DBFilePath())
+	return nil
+}
@@ -15,6 +15,8 @@ const (
 	hdPathFlag         = "hd-path"
 	keyringBackendFlag = "keyring-backend"
 	recoverFlag        = "recover"
+	countFlag          = "count"
+	accountFlag        = "account"
 
 	defaultKeyringBackend = keyring.BackendTest
 	defaultHdPath         = ""
@@ -2,11 +2,15 @@ package daemon
 
 import (
 	"bufio"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/cosmos/go-bip39"
 	"github.com/urfave/cli"
@@ -14,6 +18,7 @@ import (
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	eotstypes "github.com/babylonlabs-io/finality-provider/eotsmanager/types"
 	"github.com/babylonlabs-io/finality-provider/log"
 )
 
@@ -21,6 +26,7 @@ type KeyOutput struct {
 	Name      string `json:"name" yaml:"name"`
 	PubKeyHex string `json:"pub_key_hex" yaml:"pub_key_hex"`
 	Mnemonic  string `json:"mnemonic,omitempty" yaml:"mnemonic"`
+	HDPath    string `json:"hd_path,omitempty" yaml:"hd_path"`
 }
 
 var KeysCommands = []cli.Command{
@@ -30,6 +36,8 @@ var KeysCommands = []cli.Command{
 		Category: "Key management",
 		Subcommands: []cli.Command{
 			AddKeyCmd,
+			ImportKeyCmd,
+			RecoverKeysCmd,
 		},
 	},
 }
@@ -54,8 +62,9 @@ var AddKeyCmd = cli.Command{
 			Value: defaultPassphrase,
 		},
 		cli.StringFlag{
-			Name:  hdPathFlag,
-			Usage: "The hd path used to derive the private key",
+			Name: hdPathFlag,
+			Usage: `The hd path used to derive the private key, e.g., m/86'/0'/0'/0/<index>
+	to derive all the keys from a single mnemonic with --recover`,
 			Value: defaultHdPath,
 		},
 		cli.StringFlag{
@@ -112,11 +121,232 @@ func addKey(ctx *cli.Context) error {
 			Name:      keyName,
 			PubKeyHex: eotsPk.MarshalHex(),
 			Mnemonic:  mnemonic,
+			HDPath:    ctx.String(hdPathFlag),
 		},
 	)
 	return nil
 }
 
+var ImportKeyCmd = cli.Command{
+	Name:  "import",
+	Usage: "Import a BTC private key generated elsewhere into the EOTS manager keyring.",
+	Description: `Prompt for the private key, either in WIF or as the hex of its 32 bytes,
+	and import it under the given key name. The key has no mnemonic, so it must be
+	backed up separately.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  homeFlag,
+			Usage: "Path to the keyring directory",
+			Value: config.DefaultEOTSDir,
+		},
+		cli.StringFlag{
+			Name:     keyNameFlag,
+			Usage:    "The name of the key to be imported",
+			Required: true,
+		},
+		cli.StringFlag{
+			Name:  passphraseFlag,
+			Usage: "The pass phrase used to encrypt the keys",
+			Value: defaultPassphrase,
+		},
+		cli.StringFlag{
+			Name:  keyringBackendFlag,
+			Usage: "The backend of the keyring",
+			Value: defaultKeyringBackend,
+		},
+	},
+	Action: importKey,
+}
+
+func importKey(ctx *cli.Context) error {
+	keyName := ctx.String(keyNameFlag)
+	passphrase := ctx.String(passphraseFlag)
+	keyringBackend := ctx.String(keyringBackendFlag)
+
+	// the key is not taken as a flag to keep it out of the shell history
+	reader := bufio.NewReader(os.Stdin)
+	privKeyStr, err := input.GetString("Enter the private key in WIF or hex", reader)
+	if err != nil {
+		return fmt.Errorf("failed to read the private key from stdin: %w", err)
+	}
+	privKey, err := parsePrivKey(privKeyStr)
+	if err != nil {
+		return err
+	}
+
+	homePath, err := getHomeFlag(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	logger, err := log.NewRootLoggerWithFile(config.LogFile(homePath), cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to load the logger")
+	}
+
+	dbBackend, err := cfg.DatabaseConfig.GetDbBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer dbBackend.Close()
+
+	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, keyringBackend, dbBackend, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create EOTS manager: %w", err)
+	}
+
+	eotsPk, err := eotsManager.ImportKey(keyName, passphrase, privKey)
+	if err != nil {
+		return fmt.Errorf("failed to import key: %w", err)
+	}
+
+	printRespJSON(
+		KeyOutput{
+			Name:      keyName,
+			PubKeyHex: eotsPk.MarshalHex(),
+		},
+	)
+	return nil
+}
+
+// parsePrivKey parses a BTC private key either in WIF or as the hex of its
+// 32 bytes
+func parsePrivKey(privKeyStr string) (*btcec.PrivateKey, error) {
+	if wif, err := btcutil.DecodeWIF(privKeyStr); err == nil {
+		return wif.PrivKey, nil
+	}
+
+	privKeyBz, err := hex.DecodeString(strings.TrimPrefix(privKeyStr, "0x"))
+	if err != nil || len(privKeyBz) != btcec.PrivKeyBytesLen {
+		return nil, errors.New("invalid private key: expected a WIF or the hex of a 32-byte key")
+	}
+
+	privKey, _ := btcec.PrivKeyFromBytes(privKeyBz)
+	return privKey, nil
+}
+
+var RecoverKeysCmd = cli.Command{
+	Name:  "recover",
+	Usage: "Recover the keys derived from a mnemonic into the EOTS manager keyring.",
+	Description: `Prompt for the mnemonic and derive again the keys created from it
+	whose derivation metadata is in the database, but that are missing in the keyring.
+	If --count is set, also derive the keys of the first BIP-86 indexes of --account,
+	named <key-name>-<index>, which needs no database, e.g., on a new machine.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  homeFlag,
+			Usage: "Path to the keyring directory",
+			Value: config.DefaultEOTSDir,
+		},
+		cli.StringFlag{
+			Name:  keyNameFlag,
+			Usage: "The prefix of the names of the keys derived with --count",
+		},
+		cli.StringFlag{
+			Name:  passphraseFlag,
+			Usage: "The pass phrase used to encrypt the keys",
+			Value: defaultPassphrase,
+		},
+		cli.StringFlag{
+			Name:  keyringBackendFlag,
+			Usage: "The backend of the keyring",
+			Value: defaultKeyringBackend,
+		},
+		cli.UintFlag{
+			Name:  countFlag,
+			Usage: "The number of BIP-86 indexes to derive the keys of",
+		},
+		cli.UintFlag{
+			Name:  accountFlag,
+			Usage: "The BIP-86 account of the keys derived with --count",
+		},
+	},
+	Action: recoverKeys,
+}
+
+func recoverKeys(ctx *cli.Context) error {
+	keyName := ctx.String(keyNameFlag)
+	passphrase := ctx.String(passphraseFlag)
+	keyringBackend := ctx.String(keyringBackendFlag)
+	count := uint32(ctx.Uint(countFlag))
+	account := uint32(ctx.Uint(accountFlag))
+	if count > 0 && keyName == "" {
+		return fmt.Errorf("the flag %s is required with %s", keyNameFlag, countFlag)
+	}
+
+	mnemonic, err := readMnemonic()
+	if err != nil {
+		return err
+	}
+
+	homePath, err := getHomeFlag(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	logger, err := log.NewRootLoggerWithFile(config.LogFile(homePath), cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("failed to load the logger")
+	}
+
+	dbBackend, err := cfg.DatabaseConfig.GetDbBackend()
+	if err != nil {
+		return fmt.Errorf("failed to create db backend: %w", err)
+	}
+	defer dbBackend.Close()
+
+	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, keyringBackend, dbBackend, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create EOTS manager: %w", err)
+	}
+
+	recovered, err := eotsManager.RecoverKeys(passphrase, mnemonic)
+	if err != nil {
+		return fmt.Errorf("failed to recover keys: %w", err)
+	}
+
+	keys := make([]KeyOutput, 0, len(recovered))
+	for _, d := range recovered {
+		keys = append(keys, KeyOutput{
+			Name:      d.KeyName,
+			PubKeyHex: hex.EncodeToString(d.BtcPk),
+			HDPath:    d.HDPath,
+		})
+	}
+
+	for i := uint32(0); i < count; i++ {
+		name := fmt.Sprintf("%s-%d", keyName, i)
+		hdPath := eotsmanager.BIP86HDPath(account, i)
+		eotsPk, err := eotsManager.CreateKeyWithMnemonic(name, passphrase, hdPath, mnemonic)
+		if errors.Is(err, eotstypes.ErrFinalityProviderAlreadyExisted) {
+			// the key or its name is already in the keyring
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to derive key %s: %w", name, err)
+		}
+
+		keys = append(keys, KeyOutput{
+			Name:      name,
+			PubKeyHex: eotsPk.MarshalHex(),
+			HDPath:    hdPath,
+		})
+	}
+
+	printRespJSON(keys)
+	return nil
+}
+
 // createKey checks if recover flag is set to create a key from mnemonic or if not set, randomly creates it.
 func createKey(
 	ctx *cli.Context,
@@ -140,21 +370,25 @@ func createKey(
 
 func getMnemonic(ctx *cli.Context) (string, error) {
 	if ctx.Bool(recoverFlag) {
-		reader := bufio.NewReader(os.Stdin)
-		mnemonic, err := input.GetString("Enter your mnemonic", reader)
-		if err != nil {
-			return "", fmt.Errorf("failed to read mnemonic from stdin: %w", err)
-		}
-		if !bip39.IsMnemonicValid(mnemonic) {
-			return "", errors.New("invalid mnemonic")
-		}
-
-		return mnemonic, nil
+		return readMnemonic()
 	}
 
 	return eotsmanager.NewMnemonic()
 }
 
+func readMnemonic() (string, error) {
+	reader := bufio.NewReader(os.Stdin)
+	mnemonic, err := input.GetString("Enter your mnemonic", reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to read mnemonic from stdin: %w", err)
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", errors.New("invalid mnemonic")
+	}
+
+	return mnemonic, nil
+}
+
 func printRespJSONKeys(resp interface{}) {
 	jsonBytes, err := json.MarshalIndent(resp, "", "    ")
 	if err != nil {
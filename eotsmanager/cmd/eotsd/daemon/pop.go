@@ -1,7 +1,10 @@
 package daemon
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -137,3 +140,55 @@ func ExportPoP(ctx *cli.Context) error {
 
 	return nil
 }
+
+var VerifyPoPCommand = cli.Command{
+	Name:      "pop-verify",
+	Usage:     "Verifies a Proof of Possession exported by pop-export.",
+	UsageText: "pop-verify [file-path]",
+	Description: `Parse the Proof of Possession exported as JSON by pop-export in
+	the given file and verify that it was signed over the Babylon address by the
+	EOTS private key of the public key. It needs no key, so a PoP generated on an
+	air-gapped machine can be checked before the finality provider is registered.`,
+	Action: VerifyPoP,
+}
+
+func VerifyPoP(ctx *cli.Context) error {
+	args := ctx.Args()
+	inputFilePath := args.First()
+	if len(inputFilePath) == 0 {
+		return errors.New("invalid argument, please provide a valid file path as input argument")
+	}
+
+	bz, err := os.ReadFile(inputFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", inputFilePath, err)
+	}
+
+	var popExport PoPExport
+	if err := json.Unmarshal(bz, &popExport); err != nil {
+		return fmt.Errorf("failed to parse the exported PoP: %w", err)
+	}
+
+	bbnAddr, err := sdk.AccAddressFromBech32(popExport.BabylonAddress)
+	if err != nil {
+		return fmt.Errorf("invalid babylon address %s: %w", popExport.BabylonAddress, err)
+	}
+
+	pubKey, err := bbn.NewBIP340PubKeyFromHex(popExport.PubKeyHex)
+	if err != nil {
+		return fmt.Errorf("invalid finality-provider public key %s: %w", popExport.PubKeyHex, err)
+	}
+
+	pop, err := btcstktypes.NewPoPBTCFromHex(popExport.PoPHex)
+	if err != nil {
+		return fmt.Errorf("invalid PoP %s: %w", popExport.PoPHex, err)
+	}
+
+	// Babylon only accepts BIP-340 signatures in the PoPs of finality providers
+	if err := pop.VerifyBIP340(bbnAddr, pubKey); err != nil {
+		return fmt.Errorf("invalid PoP: %w", err)
+	}
+
+	fmt.Print("Verification is successful!")
+	return nil
+}
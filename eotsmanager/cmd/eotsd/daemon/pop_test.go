@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -54,6 +55,18 @@ func FuzzPoPExport(f *testing.F) {
 		btcPubKey, err := bbn.NewBIP340PubKeyFromHex(exportedPoP.PubKeyHex)
 		require.NoError(t, err)
 		require.NoError(t, pop.Verify(bbnAddr, btcPubKey, &chaincfg.SigNetParams))
+
+		// the exported PoP is verified without any key
+		popPath := filepath.Join(tempDir, "pop.json")
+		writePoPToFile(t, popPath, exportedPoP)
+		err = app.Run([]string{"eotsd", "pop-verify", popPath})
+		require.NoError(t, err)
+
+		// a PoP over another address is rejected
+		exportedPoP.BabylonAddress = datagen.GenRandomAccount().GetAddress().String()
+		writePoPToFile(t, popPath, exportedPoP)
+		err = app.Run([]string{"eotsd", "pop-verify", popPath})
+		require.ErrorContains(t, err, "invalid PoP")
 	})
 }
 
@@ -69,3 +82,10 @@ func appRunPoPExport(r *rand.Rand, t *testing.T, app *cli.App, arguments []strin
 
 	return dataSigned
 }
+
+func writePoPToFile(t *testing.T, popPath string, popExport dcli.PoPExport) {
+	bz, err := json.Marshal(popExport)
+	require.NoError(t, err)
+	err = os.WriteFile(popPath, bz, 0600)
+	require.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
+)
+
+var RemoteSignerKeyCommand = cli.Command{
+	Name:  "remote-signer-key",
+	Usage: "Print the public key that eotsd authenticates with as a remote signer.",
+	Description: `Print the hex-encoded ed25519 public key that eotsd authenticates with to the
+	finality provider daemon over the remote signer protocol, generating the key at
+	remotesignerkeyfile if it does not exist. Set it as remotesignerpubkey in the config
+	of the finality provider daemon.`,
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  homeFlag,
+			Usage: "The path to the eotsd home directory",
+			Value: config.DefaultEOTSDir,
+		},
+	},
+	Action: printRemoteSignerKey,
+}
+
+func printRemoteSignerKey(ctx *cli.Context) error {
+	homePath, err := getHomeFlag(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load home flag: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+	keyFile := cfg.RemoteSignerKeyFile
+	if keyFile == "" {
+		keyFile = config.RemoteSignerKeyFile(homePath)
+	}
+
+	key, err := remotesigner.LoadOrGenKey(keyFile)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(remotesigner.PubKeyHex(key))
+
+	return nil
+}
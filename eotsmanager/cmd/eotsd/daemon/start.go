@@ -12,6 +12,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
 	eotsservice "github.com/babylonlabs-io/finality-provider/eotsmanager/service"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
@@ -62,6 +63,7 @@ func startFn(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create db backend: %w", err)
 	}
+	dbBackend = metrics.InstrumentDb(dbBackend, metrics.EotsDbName)
 
 	eotsManager, err := eotsmanager.NewLocalEOTSManager(homePath, cfg.KeyringBackend, dbBackend, logger)
 	if err != nil {
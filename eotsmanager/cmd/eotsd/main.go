@@ -20,9 +20,10 @@ func main() {
 	app.Usage = "Extractable One Time Signature Daemon (eotsd)."
 	app.Commands = append(
 		app.Commands, dcli.StartCommand, dcli.InitCommand, dcli.SignSchnorrSig, dcli.VerifySchnorrSig,
-		dcli.ExportPoPCommand,
+		dcli.ExportPoPCommand, dcli.VerifyPoPCommand, dcli.RemoteSignerKeyCommand,
 	)
 	app.Commands = append(app.Commands, dcli.KeysCommands...)
+	app.Commands = append(app.Commands, dcli.DbCommands...)
 
 	if err := app.Run(os.Args); err != nil {
 		fatal(err)
@@ -5,6 +5,7 @@ import (
 	"net"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
@@ -15,13 +16,15 @@ import (
 )
 
 const (
-	defaultLogLevel       = "debug"
-	defaultDataDirname    = "data"
-	defaultLogDirname     = "logs"
-	defaultLogFilename    = "eotsd.log"
-	defaultConfigFileName = "eotsd.conf"
-	DefaultRPCPort        = 12582
-	defaultKeyringBackend = keyring.BackendTest
+	defaultLogLevel                  = "debug"
+	defaultDataDirname               = "data"
+	defaultLogDirname                = "logs"
+	defaultLogFilename               = "eotsd.log"
+	defaultConfigFileName            = "eotsd.conf"
+	defaultRemoteSignerKeyFilename   = "remote_signer_key.json"
+	defaultRemoteSignerRetryInterval = 5 * time.Second
+	DefaultRPCPort                   = 12582
+	defaultKeyringBackend            = keyring.BackendTest
 )
 
 var (
@@ -40,6 +43,11 @@ type Config struct {
 	RpcListener    string          `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
 	Metrics        *metrics.Config `group:"metrics" namespace:"metrics"`
 
+	RemoteSignerAddress       string        `long:"remotesigneraddress" description:"the address of the finality provider daemon to dial and serve the signatures to over the remote signer protocol, e.g., 10.0.0.2:12584; Empty if disabled"`
+	RemoteSignerPeerPubKey    string        `long:"remotesignerpeerpubkey" description:"the hex-encoded ed25519 public key the finality provider daemon must authenticate with, as printed by fpd remote-signer-key"`
+	RemoteSignerKeyFile       string        `long:"remotesignerkeyfile" description:"the path of the ed25519 key to authenticate with to the finality provider daemon, which is generated if it does not exist"`
+	RemoteSignerRetryInterval time.Duration `long:"remotesignerretryinterval" description:"the interval between each attempt to connect to the finality provider daemon"`
+
 	DatabaseConfig *DBConfig `group:"dbconfig" namespace:"dbconfig"`
 }
 
@@ -97,6 +105,21 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid metrics config")
 	}
 
+	if cfg.RemoteSignerAddress != "" {
+		if _, err := net.ResolveTCPAddr("tcp", cfg.RemoteSignerAddress); err != nil {
+			return fmt.Errorf("invalid remote signer address %s, %w", cfg.RemoteSignerAddress, err)
+		}
+		if cfg.RemoteSignerPeerPubKey == "" {
+			return fmt.Errorf("the public key of the finality provider daemon should be set with remotesignerpeerpubkey to serve it as a remote signer")
+		}
+		if cfg.RemoteSignerKeyFile == "" {
+			return fmt.Errorf("the remote signer key file should not be empty")
+		}
+		if cfg.RemoteSignerRetryInterval <= 0 {
+			return fmt.Errorf("the remote signer retry interval should be positive")
+		}
+	}
+
 	return nil
 }
 
@@ -112,6 +135,10 @@ func LogFile(homePath string) string {
 	return filepath.Join(LogDir(homePath), defaultLogFilename)
 }
 
+func RemoteSignerKeyFile(homePath string) string {
+	return filepath.Join(homePath, defaultRemoteSignerKeyFilename)
+}
+
 func DataDir(homePath string) string {
 	return filepath.Join(homePath, defaultDataDirname)
 }
@@ -127,6 +154,9 @@ func DefaultConfigWithHomePath(homePath string) *Config {
 		DatabaseConfig: DefaultDBConfigWithHomePath(homePath),
 		RpcListener:    defaultRpcListener,
 		Metrics:        metrics.DefaultEotsConfig(),
+
+		RemoteSignerKeyFile:       RemoteSignerKeyFile(homePath),
+		RemoteSignerRetryInterval: defaultRemoteSignerRetryInterval,
 	}
 	if err := cfg.Validate(); err != nil {
 		panic(err)
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/lightningnetwork/lnd/kvdb"
@@ -35,6 +38,11 @@ type DBConfig struct {
 	// be considered again.
 	AutoCompactMinAge time.Duration `long:"autocompactminage" description:"Specifies the minimum time that must have passed since a bolt database file was last compacted for the compaction to be considered again."`
 
+	// AutoCompactMinSize specifies the minimum size in bytes of the
+	// database file for the automatic compaction to be considered. Zero
+	// means that the size is disregarded.
+	AutoCompactMinSize uint64 `long:"autocompactminsize" description:"Specifies the minimum size in bytes of the database file for the automatic compaction to be considered. Zero means that the size is disregarded."`
+
 	// DBTimeout specifies the timeout value to use when opening the wallet
 	// database.
 	DBTimeout time.Duration `long:"dbtimeout" description:"Specifies the timeout value to use when opening the wallet database."`
@@ -67,5 +75,60 @@ func (db *DBConfig) DBConfigToBoltBackendConfig() *kvdb.BoltBackendConfig {
 }
 
 func (db *DBConfig) GetDbBackend() (kvdb.Backend, error) {
-	return kvdb.GetBoltBackend(db.DBConfigToBoltBackendConfig())
+	cfg := db.DBConfigToBoltBackendConfig()
+
+	// the file is only compacted once it grows above the minimum size
+	if cfg.AutoCompact && db.AutoCompactMinSize > 0 {
+		size, err := fileSize(db.DBFilePath())
+		if err != nil || size < db.AutoCompactMinSize {
+			cfg.AutoCompact = false
+		}
+	}
+
+	return kvdb.GetBoltBackend(cfg)
+}
+
+// DBFilePath returns the path of the database file
+func (db *DBConfig) DBFilePath() string {
+	return filepath.Join(db.DBPath, db.DBFileName)
+}
+
+// Compact rewrites the database into a fresh file, which drops the pages freed
+// by the deleted records, and returns the sizes of the file before and after.
+// The original file is only replaced once the new one is complete. It fails if
+// the database is locked by a running daemon
+func (db *DBConfig) Compact() (before uint64, after uint64, err error) {
+	path := db.DBFilePath()
+	before, err = fileSize(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read the database file %s: %w", path, err)
+	}
+
+	cfg := db.DBConfigToBoltBackendConfig()
+	cfg.AutoCompact = true
+	// a zero minimum age forces the compaction
+	cfg.AutoCompactMinAge = 0
+	backend, err := kvdb.GetBoltBackend(cfg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compact the database file %s: %w", path, err)
+	}
+	if err := backend.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	after, err = fileSize(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read the database file %s: %w", path, err)
+	}
+
+	return before, after, nil
+}
+
+func fileSize(path string) (uint64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(info.Size()), nil
 }
@@ -1,6 +1,7 @@
 package eotsmanager
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -29,6 +30,14 @@ const (
 	MnemonicEntropySize = 256
 )
 
+// BIP86HDPath returns the BIP-86 path of the EOTS key of the given index in
+// the given account, so that all the keys of an operator can be derived from
+// a single mnemonic. The coin type is always 0 as EOTS keys do not depend on
+// the BTC network
+func BIP86HDPath(account, index uint32) string {
+	return fmt.Sprintf("m/86'/0'/%d'/0/%d", account, index)
+}
+
 var _ EOTSManager = &LocalEOTSManager{}
 
 type LocalEOTSManager struct {
@@ -115,6 +124,16 @@ func (lm *LocalEOTSManager) CreateKeyWithMnemonic(name, passphrase, hdPath, mnem
 		return nil, err
 	}
 
+	// check the public key first to not leave a duplicate key in the keyring
+	privKeyBz, err := algo.Derive()(mnemonic, passphrase, hdPath)
+	if err != nil {
+		return nil, err
+	}
+	privKey, _ := btcec.PrivKeyFromBytes(privKeyBz)
+	if _, err := lm.es.GetEOTSKeyName(schnorr.SerializePubKey(privKey.PubKey())); err == nil {
+		return nil, fmt.Errorf("%w: the key is already created", eotstypes.ErrFinalityProviderAlreadyExisted)
+	}
+
 	// we need to repeat the passphrase to mock the re-entry
 	// as when creating an account, passphrase will be asked twice
 	// by the keyring
@@ -133,6 +152,12 @@ func (lm *LocalEOTSManager) CreateKeyWithMnemonic(name, passphrase, hdPath, mnem
 		return nil, err
 	}
 
+	// only the HD path is kept, as the key can be derived again from the
+	// mnemonic
+	if err := lm.es.SaveKeyDerivation(eotsPk.MustToBTCPK(), hdPath); err != nil {
+		return nil, err
+	}
+
 	lm.logger.Info(
 		"successfully created an EOTS key",
 		zap.String("key name", name),
@@ -143,6 +168,88 @@ func (lm *LocalEOTSManager) CreateKeyWithMnemonic(name, passphrase, hdPath, mnem
 	return eotsPk, nil
 }
 
+// RecoverKeys derives again from the mnemonic the keys created from it whose
+// derivation metadata is stored, but that are missing in the keyring, e.g.,
+// after the keyring was lost. The keys derived from another mnemonic are
+// skipped. It returns the derivations of the recovered keys
+func (lm *LocalEOTSManager) RecoverKeys(passphrase, mnemonic string) ([]*store.KeyDerivation, error) {
+	derivations, err := lm.es.GetKeyDerivations()
+	if err != nil {
+		return nil, err
+	}
+
+	keyringAlgos, _ := lm.kr.SupportedAlgorithms()
+	algo, err := keyring.NewSigningAlgoFromString(secp256k1Type, keyringAlgos)
+	if err != nil {
+		return nil, err
+	}
+
+	var recovered []*store.KeyDerivation
+	for _, d := range derivations {
+		if lm.keyExists(d.KeyName) {
+			continue
+		}
+
+		// the passphrase is also the BIP-39 passphrase of the mnemonic
+		privKeyBz, err := algo.Derive()(mnemonic, passphrase, d.HDPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key %s: %w", d.KeyName, err)
+		}
+		privKey, _ := btcec.PrivKeyFromBytes(privKeyBz)
+		eotsPk := bbntypes.NewBIP340PubKeyFromBTCPK(privKey.PubKey())
+		if !bytes.Equal(eotsPk.MustMarshal(), d.BtcPk) {
+			lm.logger.Debug("skipping the key derived from another mnemonic", zap.String("key name", d.KeyName))
+			continue
+		}
+
+		lm.input.Reset(passphrase + "\n" + passphrase)
+		if _, err := lm.kr.NewAccount(d.KeyName, mnemonic, passphrase, d.HDPath, algo); err != nil {
+			return nil, fmt.Errorf("failed to recover key %s: %w", d.KeyName, err)
+		}
+
+		lm.logger.Info(
+			"successfully recovered an EOTS key",
+			zap.String("key name", d.KeyName),
+			zap.String("pk", eotsPk.MarshalHex()),
+		)
+		recovered = append(recovered, d)
+	}
+
+	return recovered, nil
+}
+
+// ImportKey imports the given private key generated elsewhere into the
+// keyring under the given name
+func (lm *LocalEOTSManager) ImportKey(name, passphrase string, privKey *btcec.PrivateKey) (*bbntypes.BIP340PubKey, error) {
+	if lm.keyExists(name) {
+		return nil, eotstypes.ErrFinalityProviderAlreadyExisted
+	}
+
+	// check the public key first to not leave a duplicate key in the keyring
+	eotsPk := bbntypes.NewBIP340PubKeyFromBTCPK(privKey.PubKey())
+	if _, err := lm.es.GetEOTSKeyName(eotsPk.MustMarshal()); err == nil {
+		return nil, fmt.Errorf("%w: the key is already imported", eotstypes.ErrFinalityProviderAlreadyExisted)
+	}
+
+	lm.input.Reset(passphrase + "\n" + passphrase)
+	if err := lm.kr.ImportPrivKeyHex(name, hex.EncodeToString(privKey.Serialize()), secp256k1Type); err != nil {
+		return nil, err
+	}
+
+	if err := lm.es.AddEOTSKeyName(eotsPk.MustToBTCPK(), name); err != nil {
+		return nil, err
+	}
+
+	lm.logger.Info(
+		"successfully imported an EOTS key",
+		zap.String("key name", name),
+		zap.String("pk", eotsPk.MarshalHex()),
+	)
+	lm.metrics.IncrementEotsCreatedKeysCounter()
+
+	return eotsPk, nil
+}
+
 func loadBIP340PubKeyFromKeyringRecord(record *keyring.Record) (*bbntypes.BIP340PubKey, error) {
 	pubKey, err := record.GetPubKey()
 	if err != nil {
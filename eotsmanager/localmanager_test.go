@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
@@ -57,6 +58,98 @@ func FuzzCreateKey(f *testing.F) {
 	})
 }
 
+// FuzzImportKey tests the import of a BTC private key generated elsewhere
+func FuzzImportKey(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		fpName := testutil.GenRandomHexStr(r, 4)
+		homeDir := filepath.Join(t.TempDir(), "eots-home")
+		eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+		dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+		require.NoError(t, err)
+		defer dbBackend.Close()
+
+		lm, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, zap.NewNop())
+		require.NoError(t, err)
+
+		privKey, pubKey, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+		fpPk, err := lm.ImportKey(fpName, passphrase, privKey)
+		require.NoError(t, err)
+		require.Equal(t, bbntypes.NewBIP340PubKeyFromBTCPK(pubKey), fpPk)
+
+		// the imported key signs for its public key
+		fpRecord, err := lm.KeyRecord(fpPk.MustMarshal(), passphrase)
+		require.NoError(t, err)
+		require.Equal(t, fpName, fpRecord.Name)
+		msg := datagen.GenRandomByteArray(r, 32)
+		sig, err := lm.SignSchnorrSig(fpPk.MustMarshal(), msg, passphrase)
+		require.NoError(t, err)
+		require.True(t, sig.Verify(msg, pubKey))
+
+		// the same key can't be imported twice, even under another name
+		_, err = lm.ImportKey(fpName, passphrase, privKey)
+		require.ErrorIs(t, err, types.ErrFinalityProviderAlreadyExisted)
+		_, err = lm.ImportKey(fpName+"-other", passphrase, privKey)
+		require.ErrorIs(t, err, types.ErrFinalityProviderAlreadyExisted)
+	})
+}
+
+// FuzzRecoverKeys tests the recovery of the keys derived from a mnemonic
+// after the keyring is lost
+func FuzzRecoverKeys(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 5)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		homeDir := filepath.Join(t.TempDir(), "eots-home")
+		eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+		dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+		require.NoError(t, err)
+		defer dbBackend.Close()
+
+		lm, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, zap.NewNop())
+		require.NoError(t, err)
+
+		// two keys are derived from the same mnemonic, and one from another
+		mnemonic, err := eotsmanager.NewMnemonic()
+		require.NoError(t, err)
+		fpPk0, err := lm.CreateKeyWithMnemonic("fp-0", passphrase, eotsmanager.BIP86HDPath(0, 0), mnemonic)
+		require.NoError(t, err)
+		fpPk1, err := lm.CreateKeyWithMnemonic("fp-1", passphrase, eotsmanager.BIP86HDPath(0, 1), mnemonic)
+		require.NoError(t, err)
+		require.NotEqual(t, fpPk0, fpPk1)
+		_, err = lm.CreateKey(testutil.GenRandomHexStr(r, 4), passphrase, hdPath)
+		require.NoError(t, err)
+
+		// the same key can't be created twice, even under another name
+		_, err = lm.CreateKeyWithMnemonic("fp-2", passphrase, eotsmanager.BIP86HDPath(0, 0), mnemonic)
+		require.ErrorIs(t, err, types.ErrFinalityProviderAlreadyExisted)
+
+		// the keyring is lost, but the database is kept
+		lm, err = eotsmanager.NewLocalEOTSManager(filepath.Join(t.TempDir(), "new-home"), eotsCfg.KeyringBackend, dbBackend, zap.NewNop())
+		require.NoError(t, err)
+		recovered, err := lm.RecoverKeys(passphrase, mnemonic)
+		require.NoError(t, err)
+		require.Len(t, recovered, 2)
+
+		// the recovered keys sign for their public keys
+		for _, fpPk := range []*bbntypes.BIP340PubKey{fpPk0, fpPk1} {
+			msg := datagen.GenRandomByteArray(r, 32)
+			sig, err := lm.SignSchnorrSig(fpPk.MustMarshal(), msg, passphrase)
+			require.NoError(t, err)
+			require.True(t, sig.Verify(msg, fpPk.MustToBTCPK()))
+		}
+
+		// the keys are only recovered once
+		recovered, err = lm.RecoverKeys(passphrase, mnemonic)
+		require.NoError(t, err)
+		require.Empty(t, recovered)
+	})
+}
+
 func FuzzCreateRandomnessPairList(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
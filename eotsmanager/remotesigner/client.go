@@ -0,0 +1,282 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cometbft/cometbft/crypto"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+var (
+	// ErrNotServed is returned for the operations involving the private keys,
+	// which never leave the remote signer
+	ErrNotServed = errors.New("not served by the remote signer: the EOTS keys are managed on the remote signer")
+	// ErrNoSigner is returned if no remote signer is connected
+	ErrNoSigner = errors.New("no remote signer is connected")
+)
+
+var _ eotsmanager.EOTSManager = &Client{}
+
+// Client is the EOTS manager of the finality provider daemon whose randomness
+// and signatures are served by a remote signer. It listens for the remote
+// signer to dial in and sends it the requests over the latest authenticated
+// connection, one at a time
+type Client struct {
+	lis      net.Listener
+	key      crypto.PrivKey
+	signerPk crypto.PubKey
+	timeout  time.Duration
+	logger   *zap.Logger
+
+	mu   sync.Mutex
+	conn *signerConn
+	// connected is closed once a remote signer connects
+	connected chan struct{}
+	// reqMu serializes the requests to the remote signer
+	reqMu sync.Mutex
+}
+
+// NewClient listens at the given address for the remote signer that
+// authenticates with signerPk, and waits up to timeout for it to connect. The
+// timeout also bounds each request to the remote signer
+func NewClient(listenAddr string, key crypto.PrivKey, signerPk crypto.PubKey, timeout time.Duration, logger *zap.Logger) (*Client, error) {
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", listenAddr, err)
+	}
+
+	c := &Client{
+		lis:       lis,
+		key:       key,
+		signerPk:  signerPk,
+		timeout:   timeout,
+		logger:    logger,
+		connected: make(chan struct{}),
+	}
+	go c.acceptLoop()
+
+	logger.Info("waiting for the remote signer to connect",
+		zap.String("address", lis.Addr().String()), zap.String("key", PubKeyHex(key)))
+	if err := c.Ping(); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("the remote signer is not responding: %w", err)
+	}
+
+	return c, nil
+}
+
+// Addr returns the address the client listens at for the remote signer
+func (c *Client) Addr() net.Addr {
+	return c.lis.Addr()
+}
+
+// acceptLoop accepts the connections of the remote signer until the listener
+// is closed. A new authenticated connection replaces the previous one, so the
+// remote signer can reconnect at any time
+func (c *Client) acceptLoop() {
+	for {
+		netConn, err := c.lis.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			sc, err := handshake(netConn, c.key, c.signerPk, c.timeout)
+			if err != nil {
+				c.logger.Warn("rejected a remote signer connection", zap.Error(err))
+				return
+			}
+
+			c.mu.Lock()
+			if c.conn != nil {
+				c.conn.Close()
+			}
+			c.conn = sc
+			close(c.connected)
+			c.connected = make(chan struct{})
+			c.mu.Unlock()
+
+			c.logger.Info("the remote signer is connected", zap.String("address", netConn.RemoteAddr().String()))
+		}()
+	}
+}
+
+// signerConn returns the connection of the remote signer, waiting up to the
+// timeout for it to connect
+func (c *Client) signerConn() (*signerConn, error) {
+	deadline := time.NewTimer(c.timeout)
+	defer deadline.Stop()
+
+	for {
+		c.mu.Lock()
+		sc, connected := c.conn, c.connected
+		c.mu.Unlock()
+		if sc != nil {
+			return sc, nil
+		}
+
+		select {
+		case <-connected:
+		case <-deadline.C:
+			return nil, ErrNoSigner
+		}
+	}
+}
+
+// dropConn closes the given connection if it is still the current one, so
+// that the next request waits for the remote signer to reconnect
+func (c *Client) dropConn(sc *signerConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == sc {
+		c.conn = nil
+	}
+	sc.Close()
+}
+
+func (c *Client) request(req *proto.SignerRequest) (*proto.SignerResponse, error) {
+	c.reqMu.Lock()
+	defer c.reqMu.Unlock()
+
+	sc, err := c.signerConn()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.SignerResponse{}
+	err = sc.SetDeadline(time.Now().Add(c.timeout))
+	if err == nil {
+		err = sc.writeMsg(req)
+	}
+	if err == nil {
+		err = sc.readMsg(res)
+	}
+	if err != nil {
+		c.dropConn(sc)
+		return nil, fmt.Errorf("failed to request the remote signer: %w", err)
+	}
+
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+
+	return res, nil
+}
+
+func (c *Client) Ping() error {
+	res, err := c.request(&proto.SignerRequest{
+		Request: &proto.SignerRequest_Ping{Ping: &proto.PingRequest{}},
+	})
+	if err != nil {
+		return err
+	}
+	if res.GetPing() == nil {
+		return fmt.Errorf("unexpected response of the remote signer: %v", res)
+	}
+
+	return nil
+}
+
+func (c *Client) CreateKey(_, _, _ string) ([]byte, error) {
+	return nil, ErrNotServed
+}
+
+func (c *Client) CreateRandomnessPairList(uid, chainID []byte, startHeight uint64, num uint32, passphrase string) ([]*btcec.FieldVal, error) {
+	res, err := c.request(&proto.SignerRequest{
+		Request: &proto.SignerRequest_CreateRandomnessPairList{CreateRandomnessPairList: &proto.CreateRandomnessPairListRequest{
+			Uid:         uid,
+			ChainId:     chainID,
+			StartHeight: startHeight,
+			Num:         num,
+			Passphrase:  passphrase,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	randRes := res.GetCreateRandomnessPairList()
+	if randRes == nil || len(randRes.PubRandList) != int(num) {
+		return nil, fmt.Errorf("unexpected response of the remote signer: %v", res)
+	}
+
+	pubRandFieldValList := make([]*btcec.FieldVal, 0, len(randRes.PubRandList))
+	for _, r := range randRes.PubRandList {
+		var fieldVal btcec.FieldVal
+		fieldVal.SetByteSlice(r)
+		pubRandFieldValList = append(pubRandFieldValList, &fieldVal)
+	}
+
+	return pubRandFieldValList, nil
+}
+
+func (c *Client) KeyRecord(_ []byte, _ string) (*types.KeyRecord, error) {
+	return nil, ErrNotServed
+}
+
+func (c *Client) SignEOTS(uid, chainID, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+	res, err := c.request(&proto.SignerRequest{
+		Request: &proto.SignerRequest_SignEots{SignEots: &proto.SignEOTSRequest{
+			Uid:        uid,
+			ChainId:    chainID,
+			Msg:        msg,
+			Height:     height,
+			Passphrase: passphrase,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sigRes := res.GetSignEots()
+	if sigRes == nil {
+		return nil, fmt.Errorf("unexpected response of the remote signer: %v", res)
+	}
+
+	var s btcec.ModNScalar
+	s.SetByteSlice(sigRes.Sig)
+
+	return &s, nil
+}
+
+func (c *Client) SignSchnorrSig(uid, msg []byte, passphrase string) (*schnorr.Signature, error) {
+	res, err := c.request(&proto.SignerRequest{
+		Request: &proto.SignerRequest_SignSchnorrSig{SignSchnorrSig: &proto.SignSchnorrSigRequest{
+			Uid:        uid,
+			Msg:        msg,
+			Passphrase: passphrase,
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	sigRes := res.GetSignSchnorrSig()
+	if sigRes == nil {
+		return nil, fmt.Errorf("unexpected response of the remote signer: %v", res)
+	}
+
+	return schnorr.ParseSignature(sigRes.Sig)
+}
+
+// Close stops listening for the remote signer and closes its connection
+func (c *Client) Close() error {
+	err := c.lis.Close()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+
+	return err
+}
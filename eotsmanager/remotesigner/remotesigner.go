@@ -0,0 +1,104 @@
+// Package remotesigner implements the remote signer protocol, through which
+// the EOTS signatures and Schnorr signatures of the finality provider daemon
+// are served by a signer running on another machine, e.g., a hardened signer
+// box.
+//
+// Like the validators of CometBFT with tmkms, the daemon listens for the
+// remote signer to dial in, so the signer does not need to accept any
+// inbound connection. Both ends authenticate each other with their ed25519
+// keys over a CometBFT secret connection, which also encrypts the traffic.
+// The daemon then sends length-delimited SignerRequest messages and the
+// signer answers each with a SignerResponse, one at a time.
+package remotesigner
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/p2p/conn"
+	"google.golang.org/protobuf/encoding/protodelim"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// ErrUnauthorizedPeer is returned if the peer authenticates with another key
+// than the configured one
+var ErrUnauthorizedPeer = errors.New("the peer is not authorized")
+
+// LoadOrGenKey loads the ed25519 key used to authenticate over the remote
+// signer protocol from the given file, generating it if it does not exist
+func LoadOrGenKey(keyFile string) (crypto.PrivKey, error) {
+	nodeKey, err := p2p.LoadOrGenNodeKey(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the remote signer key from %s: %w", keyFile, err)
+	}
+
+	return nodeKey.PrivKey, nil
+}
+
+// ParsePubKey parses the hex-encoded ed25519 public key of a peer
+func ParsePubKey(pkHex string) (crypto.PubKey, error) {
+	pk, err := hex.DecodeString(pkHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex-encoded public key: %w", err)
+	}
+	if len(pk) != ed25519.PubKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key of %d bytes, expected %d", len(pk), ed25519.PubKeySize)
+	}
+
+	return ed25519.PubKey(pk), nil
+}
+
+// PubKeyHex returns the hex-encoded public key of the given key, which the
+// peer is configured with
+func PubKeyHex(key crypto.PrivKey) string {
+	return hex.EncodeToString(key.PubKey().Bytes())
+}
+
+// signerConn is an authenticated connection between the daemon and the remote
+// signer
+type signerConn struct {
+	*conn.SecretConnection
+	reader *bufio.Reader
+}
+
+// handshake authenticates the connection with the given key and checks that
+// the peer authenticated with peerPk. The connection is closed on failure
+func handshake(c net.Conn, key crypto.PrivKey, peerPk crypto.PubKey, timeout time.Duration) (*signerConn, error) {
+	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	sc, err := conn.MakeSecretConnection(c, key)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to authenticate the connection with %s: %w", c.RemoteAddr(), err)
+	}
+	if !sc.RemotePubKey().Equals(peerPk) {
+		sc.Close()
+		return nil, fmt.Errorf("%w: %s authenticated with the key %X", ErrUnauthorizedPeer, c.RemoteAddr(), sc.RemotePubKey().Bytes())
+	}
+
+	if err := c.SetDeadline(time.Time{}); err != nil {
+		sc.Close()
+		return nil, err
+	}
+
+	return &signerConn{SecretConnection: sc, reader: bufio.NewReader(sc)}, nil
+}
+
+func (sc *signerConn) writeMsg(msg protobuf.Message) error {
+	_, err := protodelim.MarshalTo(sc, msg)
+	return err
+}
+
+func (sc *signerConn) readMsg(msg protobuf.Message) error {
+	return protodelim.UnmarshalFrom(sc.reader, msg)
+}
@@ -0,0 +1,107 @@
+package remotesigner_test
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// eotsServer serves the signatures of an EOTS manager
+type eotsServer struct {
+	proto.UnimplementedEOTSManagerServer
+	em eotsmanager.EOTSManager
+}
+
+func (s *eotsServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{}, nil
+}
+
+func (s *eotsServer) SignSchnorrSig(_ context.Context, req *proto.SignSchnorrSigRequest) (*proto.SignSchnorrSigResponse, error) {
+	sig, err := s.em.SignSchnorrSig(req.Uid, req.Msg, req.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.SignSchnorrSigResponse{Sig: sig.Serialize()}, nil
+}
+
+// freeAddr returns a local address that is not listened at
+func freeAddr(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	return lis.Addr().String()
+}
+
+func TestRemoteSigner(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	homeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(homeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(homeDir, eotsCfg.KeyringBackend, dbBackend, zap.NewNop())
+	require.NoError(t, err)
+	fpPk, err := em.CreateKey(testutil.GenRandomHexStr(r, 4), "", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	addr := freeAddr(t)
+	daemonKey := ed25519.GenPrivKey()
+	signerKey := ed25519.GenPrivKey()
+	signer := remotesigner.NewSigner(addr, signerKey, daemonKey.PubKey(), &eotsServer{em: em}, 100*time.Millisecond, zap.NewNop())
+	go signer.Run(ctx)
+
+	// the daemon waits for the signer to connect
+	client, err := remotesigner.NewClient(addr, daemonKey, signerKey.PubKey(), 5*time.Second, zap.NewNop())
+	require.NoError(t, err)
+	defer client.Close()
+
+	// the signatures are served by the signer
+	msg := datagen.GenRandomByteArray(r, 32)
+	sig, err := client.SignSchnorrSig(fpPk, msg, "")
+	require.NoError(t, err)
+	btcPk, err := schnorr.ParsePubKey(fpPk)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(msg, btcPk))
+
+	// the errors of the signer are returned
+	_, err = client.SignSchnorrSig(datagen.GenRandomByteArray(r, 32), msg, "")
+	require.Error(t, err)
+	_, err = client.SignEOTS(fpPk, []byte("chain-test"), msg, 1, "")
+	require.ErrorContains(t, err, "not implemented")
+
+	// the private keys never leave the signer
+	_, err = client.KeyRecord(fpPk, "")
+	require.ErrorIs(t, err, remotesigner.ErrNotServed)
+	_, err = client.CreateKey("key", "", "")
+	require.ErrorIs(t, err, remotesigner.ErrNotServed)
+
+	// a signer authenticating with another key is rejected, while the
+	// authorized one keeps serving the signatures
+	rogue := remotesigner.NewSigner(addr, ed25519.GenPrivKey(), daemonKey.PubKey(), &eotsServer{em: em}, 100*time.Millisecond, zap.NewNop())
+	rogueCtx, rogueCancel := context.WithCancel(ctx)
+	go rogue.Run(rogueCtx)
+	time.Sleep(300 * time.Millisecond)
+	rogueCancel()
+	sig, err = client.SignSchnorrSig(fpPk, msg, "")
+	require.NoError(t, err)
+	require.True(t, sig.Verify(msg, btcPk))
+}
@@ -0,0 +1,133 @@
+package remotesigner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/cometbft/cometbft/crypto"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/proto"
+)
+
+// Signer serves the randomness and the signatures of an EOTS manager to a
+// finality provider daemon over the remote signer protocol. It dials the
+// daemon, serves its requests, and dials it again whenever the connection is
+// lost. The requests are handled by the given server, e.g., the RPC server of
+// the EOTS daemon, while the private keys are never served
+type Signer struct {
+	addr          string
+	key           crypto.PrivKey
+	peerPk        crypto.PubKey
+	server        proto.EOTSManagerServer
+	retryInterval time.Duration
+	logger        *zap.Logger
+}
+
+// NewSigner returns a signer serving the daemon at the given address, which
+// authenticates with peerPk. The connection is retried every retryInterval
+func NewSigner(addr string, key crypto.PrivKey, peerPk crypto.PubKey, server proto.EOTSManagerServer, retryInterval time.Duration, logger *zap.Logger) *Signer {
+	return &Signer{
+		addr:          addr,
+		key:           key,
+		peerPk:        peerPk,
+		server:        server,
+		retryInterval: retryInterval,
+		logger:        logger,
+	}
+}
+
+// Run serves the daemon until the context is done
+func (s *Signer) Run(ctx context.Context) {
+	s.logger.Info("serving the finality provider daemon as a remote signer",
+		zap.String("address", s.addr), zap.String("key", PubKeyHex(s.key)))
+
+	for {
+		err := s.serve(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+		s.logger.Warn("lost the connection to the finality provider daemon, retrying",
+			zap.String("address", s.addr), zap.Duration("retry_interval", s.retryInterval), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.retryInterval):
+		}
+	}
+}
+
+// serve dials the daemon and serves its requests until the connection fails
+func (s *Signer) serve(ctx context.Context) error {
+	var d net.Dialer
+	netConn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	sc, err := handshake(netConn, s.key, s.peerPk, s.retryInterval)
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		}
+		sc.Close()
+	}()
+
+	s.logger.Info("connected to the finality provider daemon", zap.String("address", s.addr))
+
+	for {
+		req := &proto.SignerRequest{}
+		if err := sc.readMsg(req); err != nil {
+			return fmt.Errorf("failed to read the request: %w", err)
+		}
+
+		if err := sc.writeMsg(s.handle(ctx, req)); err != nil {
+			return fmt.Errorf("failed to write the response: %w", err)
+		}
+	}
+}
+
+func (s *Signer) handle(ctx context.Context, req *proto.SignerRequest) *proto.SignerResponse {
+	res := &proto.SignerResponse{}
+	var err error
+
+	switch r := req.Request.(type) {
+	case *proto.SignerRequest_Ping:
+		var pingRes *proto.PingResponse
+		pingRes, err = s.server.Ping(ctx, r.Ping)
+		res.Response = &proto.SignerResponse_Ping{Ping: pingRes}
+	case *proto.SignerRequest_CreateRandomnessPairList:
+		var randRes *proto.CreateRandomnessPairListResponse
+		randRes, err = s.server.CreateRandomnessPairList(ctx, r.CreateRandomnessPairList)
+		res.Response = &proto.SignerResponse_CreateRandomnessPairList{CreateRandomnessPairList: randRes}
+	case *proto.SignerRequest_SignEots:
+		var sigRes *proto.SignEOTSResponse
+		sigRes, err = s.server.SignEOTS(ctx, r.SignEots)
+		res.Response = &proto.SignerResponse_SignEots{SignEots: sigRes}
+	case *proto.SignerRequest_SignSchnorrSig:
+		var sigRes *proto.SignSchnorrSigResponse
+		sigRes, err = s.server.SignSchnorrSig(ctx, r.SignSchnorrSig)
+		res.Response = &proto.SignerResponse_SignSchnorrSig{SignSchnorrSig: sigRes}
+	default:
+		err = fmt.Errorf("unknown request %T", req.Request)
+	}
+
+	if err != nil {
+		s.logger.Warn("failed to serve the request of the finality provider daemon", zap.Error(err))
+		return &proto.SignerResponse{Error: err.Error()}
+	}
+
+	return res
+}
@@ -6,6 +6,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/babylonlabs-io/finality-provider/metrics"
 
@@ -16,6 +17,7 @@ import (
 
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
 )
 
 // Server is the main daemon construct for the EOTS manager server. It handles
@@ -101,6 +103,22 @@ func (s *Server) RunUntilShutdown() error {
 		return fmt.Errorf("failed to start gRPC listener: %v", err)
 	}
 
+	if s.cfg.RemoteSignerAddress != "" {
+		signer, err := s.newRemoteSigner()
+		if err != nil {
+			return err
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go signer.Run(ctx)
+	}
+
+	if s.cfg.Metrics.DbStatsInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.dbStatsLoop(ctx)
+	}
+
 	s.logger.Info("EOTS Manager Daemon is fully active!")
 
 	// Wait for shutdown signal from either a graceful server stop or from
@@ -110,6 +128,43 @@ func (s *Server) RunUntilShutdown() error {
 	return nil
 }
 
+// dbStatsLoop periodically records the size of the database and the number of
+// keys of its buckets until the context is done
+func (s *Server) dbStatsLoop(ctx context.Context) {
+	interval := s.cfg.Metrics.DbStatsInterval
+	statsTicker := time.NewTicker(interval)
+	defer statsTicker.Stop()
+
+	dbMetrics := metrics.NewDbMetrics()
+	path := s.cfg.DatabaseConfig.DBFilePath()
+	for {
+		select {
+		case <-statsTicker.C:
+			if err := dbMetrics.UpdateDbStats(metrics.EotsDbName, s.db, path); err != nil {
+				s.logger.Error("failed to update the db metrics", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// newRemoteSigner returns the remote signer serving the signatures to the
+// finality provider daemon configured with remotesigneraddress. The private
+// keys are not served
+func (s *Server) newRemoteSigner() (*remotesigner.Signer, error) {
+	key, err := remotesigner.LoadOrGenKey(s.cfg.RemoteSignerKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	peerPk, err := remotesigner.ParsePubKey(s.cfg.RemoteSignerPeerPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remotesignerpeerpubkey: %w", err)
+	}
+
+	return remotesigner.NewSigner(s.cfg.RemoteSignerAddress, key, peerPk, s.rpcServer, s.cfg.RemoteSignerRetryInterval, s.logger), nil
+}
+
 // startGrpcListen starts the GRPC server on the passed listeners.
 func (s *Server) startGrpcListen(grpcServer *grpc.Server, listeners []net.Listener) error {
 
@@ -11,8 +11,18 @@ import (
 
 var (
 	eotsBucketName = []byte("fpKeyNames")
+	// the bucket of the HD paths of the keys derived from a mnemonic
+	keyDerivationBucketName = []byte("keyDerivations")
 )
 
+// KeyDerivation is the derivation metadata of an EOTS key created from a
+// mnemonic, from which the key can be derived again
+type KeyDerivation struct {
+	BtcPk   []byte
+	KeyName string
+	HDPath  string
+}
+
 type EOTSStore struct {
 	db kvdb.Backend
 }
@@ -33,6 +43,11 @@ func (s *EOTSStore) initBuckets() error {
 			return err
 		}
 
+		_, err = tx.CreateTopLevelBucket(keyDerivationBucketName)
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -93,3 +108,52 @@ func (s *EOTSStore) GetEOTSKeyName(pk []byte) (string, error) {
 
 	return keyName, nil
 }
+
+// SaveKeyDerivation saves the HD path the key was derived at from its mnemonic
+func (s *EOTSStore) SaveKeyDerivation(btcPk *btcec.PublicKey, hdPath string) error {
+	pkBytes := schnorr.SerializePubKey(btcPk)
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		derivationBucket := tx.ReadWriteBucket(keyDerivationBucketName)
+		if derivationBucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return derivationBucket.Put(pkBytes, []byte(hdPath))
+	})
+}
+
+// GetKeyDerivations returns the derivation metadata of all the keys created
+// from a mnemonic
+func (s *EOTSStore) GetKeyDerivations() ([]*KeyDerivation, error) {
+	var derivations []*KeyDerivation
+	err := s.db.View(func(tx kvdb.RTx) error {
+		eotsBucket := tx.ReadBucket(eotsBucketName)
+		derivationBucket := tx.ReadBucket(keyDerivationBucketName)
+		if eotsBucket == nil || derivationBucket == nil {
+			return ErrCorruptedEOTSDb
+		}
+
+		return derivationBucket.ForEach(func(k, v []byte) error {
+			keyName := eotsBucket.Get(k)
+			if keyName == nil {
+				return ErrEOTSKeyNameNotFound
+			}
+
+			derivations = append(derivations, &KeyDerivation{
+				BtcPk:   append([]byte{}, k...),
+				KeyName: string(keyName),
+				HDPath:  string(v),
+			})
+			return nil
+		})
+	}, func() {
+		derivations = nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return derivations, nil
+}
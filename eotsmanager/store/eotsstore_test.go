@@ -61,5 +61,17 @@ func FuzzEOTSStore(f *testing.F) {
 		require.NoError(t, err)
 		_, err = vs.GetEOTSKeyName(schnorr.SerializePubKey(randomBtcPk))
 		require.ErrorIs(t, err, store.ErrEOTSKeyNameNotFound)
+
+		// the derivation metadata is returned with the key name
+		hdPath := "m/86'/0'/0'/0/1"
+		err = vs.SaveKeyDerivation(btcPk, hdPath)
+		require.NoError(t, err)
+		derivations, err := vs.GetKeyDerivations()
+		require.NoError(t, err)
+		require.Equal(t, []*store.KeyDerivation{{
+			BtcPk:   schnorr.SerializePubKey(btcPk),
+			KeyName: expectedKeyName,
+			HDPath:  hdPath,
+		}}, derivations)
 	})
 }
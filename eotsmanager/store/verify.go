@@ -0,0 +1,80 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// verifyMasterKey and verifyChainCode are an arbitrary master key to check
+// that the HD paths can be derived
+var verifyMasterKey, verifyChainCode = hd.ComputeMastersFromSeed([]byte("eotsd db verify"))
+
+// Anomaly is an inconsistency found in a record of the db
+type Anomaly struct {
+	Bucket string
+	Key    []byte
+	Msg    string
+}
+
+func (a *Anomaly) String() string {
+	return fmt.Sprintf("bucket %s, key %x: %s", a.Bucket, a.Key, a.Msg)
+}
+
+// VerifyDb walks all the records of the given db and returns the anomalies
+// found. Each key name must be stored under a valid BTC public key, and each
+// key derivation must have a valid HD path and refer to a stored key name.
+// It only fails if the db itself can't be read
+func VerifyDb(db kvdb.Backend) ([]*Anomaly, error) {
+	var anomalies []*Anomaly
+	report := func(bucket, key []byte, format string, args ...interface{}) {
+		anomalies = append(anomalies, &Anomaly{
+			Bucket: string(bucket),
+			Key:    append([]byte{}, key...),
+			Msg:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	err := db.View(func(tx kvdb.RTx) error {
+		// the buckets are created by the store, so a missing one only means
+		// that the store was never opened
+		eotsBucket := tx.ReadBucket(eotsBucketName)
+		if eotsBucket != nil {
+			if err := eotsBucket.ForEach(func(k, v []byte) error {
+				if _, err := schnorr.ParsePubKey(k); err != nil {
+					report(eotsBucketName, k, "invalid BTC public key: %v", err)
+				}
+				if len(v) == 0 {
+					report(eotsBucketName, k, "empty key name")
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		derivationBucket := tx.ReadBucket(keyDerivationBucketName)
+		if derivationBucket == nil {
+			return nil
+		}
+		return derivationBucket.ForEach(func(k, v []byte) error {
+			if eotsBucket == nil || eotsBucket.Get(k) == nil {
+				report(keyDerivationBucketName, k, "the key name is not stored")
+			}
+			// the path is checked by deriving a key at it as the keyring does
+			if _, err := hd.DerivePrivateKeyForPath(verifyMasterKey, verifyChainCode, string(v)); err != nil {
+				report(keyDerivationBucketName, k, "invalid HD path %q: %v", string(v), err)
+			}
+			return nil
+		})
+	}, func() {
+		anomalies = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return anomalies, nil
+}
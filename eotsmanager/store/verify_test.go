@@ -0,0 +1,49 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzVerifyDb tests that a consistent db has no anomaly and that the key
+// derivations without a key name or with an invalid HD path are reported
+func FuzzVerifyDb(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer db.Close()
+		s, err := store.NewEOTSStore(db)
+		require.NoError(t, err)
+
+		_, btcPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+		require.NoError(t, s.AddEOTSKeyName(btcPk, testutil.GenRandomHexStr(r, 10)))
+		require.NoError(t, s.SaveKeyDerivation(btcPk, eotsmanager.BIP86HDPath(0, uint32(r.Int31n(100)))))
+
+		anomalies, err := store.VerifyDb(db)
+		require.NoError(t, err)
+		require.Empty(t, anomalies)
+
+		_, otherPk, err := datagen.GenRandomBTCKeyPair(r)
+		require.NoError(t, err)
+		require.NoError(t, s.SaveKeyDerivation(otherPk, "m/86'/x"))
+
+		anomalies, err = store.VerifyDb(db)
+		require.NoError(t, err)
+		require.Len(t, anomalies, 2)
+		require.Contains(t, anomalies[0].Msg, "not stored")
+		require.Contains(t, anomalies[1].Msg, "invalid HD path")
+	})
+}
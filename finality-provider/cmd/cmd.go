@@ -12,6 +12,21 @@ import (
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 )
 
+// FlagConfig is the flag of the config file path, which defaults to the
+// config file under the home directory
+const FlagConfig = "config"
+
+// LoadConfig loads the config from the file given by the config flag, or from
+// the default config file under the home directory if the flag is not set.
+func LoadConfig(cmd *cobra.Command, homePath string) (*fpcfg.Config, error) {
+	cfgFile, err := cmd.Flags().GetString(FlagConfig)
+	if err != nil || cfgFile == "" {
+		cfgFile = fpcfg.ConfigFile(homePath)
+	}
+
+	return fpcfg.LoadConfigFromFile(homePath, cfgFile)
+}
+
 // PersistClientCtx persist some vars from the cmd or config to the client context.
 // It gives preferences to flags over the values in the config. If the flag is not set
 // and exists a value in the config that could be used, it will be set in the ctx.
@@ -41,7 +56,7 @@ func PersistClientCtx(ctx client.Context) func(cmd *cobra.Command, _ []string) e
 
 		ctx = client.GetClientContextFromCmd(cmd)
 		// check the config file exists
-		cfg, err := fpcfg.LoadConfig(ctx.HomeDir)
+		cfg, err := LoadConfig(cmd, ctx.HomeDir)
 		if err != nil {
 			return nil // if no conifg is found just stop.
 		}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandExportAuditLog returns the export-audit-log command of fpd daemon.
+func CommandExportAuditLog() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export-audit-log",
+		Short: "Verify and export the audit log of the signing operations.",
+		Long: `Export the audit log of the finality signatures and the commitments of public randomness
+signed by the finality provider keys as JSON, after verifying that its entries form a hash chain.
+The command fails if the log was tampered with, i.e., an entry was modified, removed, or reordered.
+It can be run while fpd is running.`,
+		Example: `fpd export-audit-log --home /home/user/.fpd > audit.json`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandExportAuditLog),
+	}
+	return cmd
+}
+
+func runCommandExportAuditLog(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+	if cfg.AuditLogFile == "" {
+		return fmt.Errorf("the audit log is disabled as AuditLogFile is empty in the config")
+	}
+
+	entries, err := service.ReadAuditLog(cfg.AuditLogFile)
+	if err != nil {
+		return err
+	}
+	if entries == nil {
+		entries = []*service.AuditEntry{}
+	}
+
+	jsonBytes, err := json.MarshalIndent(entries, "", "    ")
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", jsonBytes)
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package daemon
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+const (
+	iKnowWhatIAmDoingFlag = "i-know-what-i-am-doing"
+
+	// restoreVoteScanDepth is the number of the latest blocks of the consumer
+	// chain whose votes are checked before restoring a backup. The older
+	// blocks are expected to be finalized, so they can't be voted again for a
+	// conflicting block
+	restoreVoteScanDepth = 1000
+)
+
+// CommandRestore returns the restore command of fpd daemon.
+func CommandRestore() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restore [backup-file]",
+		Short: "Restore the finality provider database from a backup.",
+		Long: `Replace the finality provider database with a backup written by the daemon into
+the backup directory. The restore is refused if a finality provider in the backup belongs to
+another chain, or, unless --i-know-what-i-am-doing is set, if its last voted height in the backup
+is behind the current database or behind its votes on the consumer chain, as it could then
+vote again at the heights in between and be slashed. The replaced database is kept next to it.
+Note that fpd should be stopped beforehand as it locks the database.`,
+		Example: `fpd restore /home/user/.fpd/backups/finality-provider-20241016T092114.000Z.db --home /home/user/.fpd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandRestore),
+	}
+	cmd.Flags().Bool(iKnowWhatIAmDoingFlag, false, "Skip the checks of the last voted heights against the current database and the consumer chain")
+	return cmd
+}
+
+func runCommandRestore(ctx client.Context, cmd *cobra.Command, args []string) error {
+	skipChecks, err := cmd.Flags().GetBool(iKnowWhatIAmDoingFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", iKnowWhatIAmDoingFlag, err)
+	}
+
+	cfg, db, err := loadConfigAndDb(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	currentFps, err := readFinalityProviders(db)
+	if closeErr := db.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the current database: %w", err)
+	}
+
+	// the backup is copied next to the database to be moved in place at once
+	dbPath := cfg.DatabaseConfig.DBFilePath()
+	restorePath := dbPath + ".restore"
+	if err := copyFile(args[0], restorePath); err != nil {
+		return err
+	}
+	defer os.Remove(restorePath)
+
+	backupFps, err := readBackupFinalityProviders(cfg.DatabaseConfig, restorePath)
+	if err != nil {
+		return fmt.Errorf("invalid backup %s: %w", args[0], err)
+	}
+
+	for _, fp := range backupFps {
+		if fp.ChainID != cfg.BabylonConfig.ChainID {
+			return fmt.Errorf("finality provider %s in the backup belongs to chain %s instead of %s",
+				fp.GetBIP340BTCPK().MarshalHex(), fp.ChainID, cfg.BabylonConfig.ChainID)
+		}
+	}
+
+	if !skipChecks {
+		if err := checkBackupNotBehind(currentFps, backupFps); err != nil {
+			return err
+		}
+		if err := checkBackupNotBehindChain(cfg, backupFps); err != nil {
+			return err
+		}
+	}
+
+	replacedPath := fmt.Sprintf("%s.pre-restore-%s", dbPath, time.Now().UTC().Format("20060102T150405Z"))
+	if err := os.Rename(dbPath, replacedPath); err != nil {
+		return fmt.Errorf("failed to move the current database aside: %w", err)
+	}
+	if err := os.Rename(restorePath, dbPath); err != nil {
+		return fmt.Errorf("failed to move the backup in place, the database is kept at %s: %w", replacedPath, err)
+	}
+
+	cmd.Printf("restored %d finality provider(s) from %s, the replaced database is kept at %s\n",
+		len(backupFps), args[0], replacedPath)
+	return nil
+}
+
+// checkBackupNotBehind returns an error if a finality provider of the current
+// database has voted above its last voted height in the backup
+func checkBackupNotBehind(currentFps, backupFps []*store.StoredFinalityProvider) error {
+	current := make(map[string]*store.StoredFinalityProvider, len(currentFps))
+	for _, fp := range currentFps {
+		current[fp.GetBIP340BTCPK().MarshalHex()] = fp
+	}
+
+	for _, fp := range backupFps {
+		pkHex := fp.GetBIP340BTCPK().MarshalHex()
+		if curr, ok := current[pkHex]; ok && curr.LastVotedHeight > fp.LastVotedHeight {
+			return fmt.Errorf("%w: finality provider %s has voted at height %d while the backup is at height %d, "+
+				"use --%s to restore it anyway", store.ErrSnapshotBehind, pkHex, curr.LastVotedHeight, fp.LastVotedHeight,
+				iKnowWhatIAmDoingFlag)
+		}
+	}
+
+	return nil
+}
+
+// checkBackupNotBehindChain returns an error if a finality provider of the
+// backup has voted on the consumer chain above its last voted height in the
+// backup within the latest blocks
+func checkBackupNotBehindChain(cfg *fpcfg.Config, fps []*store.StoredFinalityProvider) error {
+	cc, err := clientcontroller.NewClientController(cfg.ChainName, cfg.BabylonConfig, &cfg.BTCNetParams, zap.NewNop())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the consumer chain, use --%s to skip the check: %w", iKnowWhatIAmDoingFlag, err)
+	}
+	defer cc.Close()
+
+	tip, err := cc.QueryBestBlock()
+	if err != nil {
+		return fmt.Errorf("failed to query the consumer chain, use --%s to skip the check: %w", iKnowWhatIAmDoingFlag, err)
+	}
+
+	for _, fp := range fps {
+		height, err := lastVotedHeightOnChain(cc, fp, tip.Height)
+		if err != nil {
+			return fmt.Errorf("failed to query the votes of finality provider %s, use --%s to skip the check: %w",
+				fp.GetBIP340BTCPK().MarshalHex(), iKnowWhatIAmDoingFlag, err)
+		}
+		if height > fp.LastVotedHeight {
+			return fmt.Errorf("%w: finality provider %s has voted at height %d on the consumer chain while the backup is at height %d, "+
+				"use --%s to restore it anyway", store.ErrSnapshotBehind, fp.GetBIP340BTCPK().MarshalHex(), height,
+				fp.LastVotedHeight, iKnowWhatIAmDoingFlag)
+		}
+	}
+
+	return nil
+}
+
+// lastVotedHeightOnChain returns the highest height above the last voted
+// height of the finality provider, within the latest restoreVoteScanDepth
+// blocks, at which it voted on the consumer chain, or 0 if there is none
+func lastVotedHeightOnChain(cc clientcontroller.ClientController, fp *store.StoredFinalityProvider, tipHeight uint64) (uint64, error) {
+	lowestHeight := fp.LastVotedHeight + 1
+	if tipHeight >= restoreVoteScanDepth && tipHeight-restoreVoteScanDepth+1 > lowestHeight {
+		lowestHeight = tipHeight - restoreVoteScanDepth + 1
+	}
+
+	fpPk := fp.GetBIP340BTCPK()
+	for height := tipHeight; height >= lowestHeight && height > 0; height-- {
+		voters, err := cc.QueryVotesAtHeight(height)
+		if err != nil {
+			return 0, err
+		}
+		for _, pk := range voters {
+			if pk.Equals(fpPk) {
+				return height, nil
+			}
+		}
+	}
+
+	return 0, nil
+}
+
+// readBackupFinalityProviders reads the finality providers of the backup at
+// the given path with the options of the database
+func readBackupFinalityProviders(dbCfg *fpcfg.DBConfig, path string) ([]*store.StoredFinalityProvider, error) {
+	backupCfg := *dbCfg
+	backupCfg.DBPath = filepath.Dir(path)
+	backupCfg.DBFileName = filepath.Base(path)
+	backupCfg.AutoCompact = false
+	backupCfg.DBTimeout = dbLockTimeout
+	db, err := backupCfg.GetDbBackend()
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return readFinalityProviders(db)
+}
+
+func readFinalityProviders(db kvdb.Backend) ([]*store.StoredFinalityProvider, error) {
+	fpStore, err := store.NewFinalityProviderStore(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return fpStore.GetAllStoredFinalityProviders()
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open the backup: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to copy the backup: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to copy the backup: %w", err)
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("failed to copy the backup: %w", err)
+	}
+
+	return out.Close()
+}
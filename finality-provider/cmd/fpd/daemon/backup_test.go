@@ -0,0 +1,74 @@
+package daemon_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+func TestRestoreCmd(t *testing.T) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	rootCmdBuff := new(bytes.Buffer)
+	root := rootCmd(rootCmdBuff)
+
+	tempHome := filepath.Join(t.TempDir(), "homefprestore")
+	homeFlag := fmt.Sprintf("--home=%s", tempHome)
+	exec(t, root, rootCmdBuff, "init", homeFlag)
+
+	cfg, err := fpcfg.LoadConfig(tempHome)
+	require.NoError(t, err)
+
+	// back up the database at the last voted height 10 before voting at 20
+	db, err := cfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	fpStore, err := store.NewFinalityProviderStore(db)
+	require.NoError(t, err)
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	err = fpStore.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.Commission,
+		fp.KeyName, cfg.BabylonConfig.ChainID, fp.Pop.BtcSig)
+	require.NoError(t, err)
+	require.NoError(t, fpStore.SetFpLastVotedHeight(fp.BtcPk, 10))
+	backupPath, err := store.WriteBackup(db, cfg.BackupConfig.Dir, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, fpStore.SetFpLastVotedHeight(fp.BtcPk, 20))
+	require.NoError(t, db.Close())
+
+	restore := func(args ...string) (string, error) {
+		root := rootCmd(rootCmdBuff)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs(append([]string{"restore", backupPath, homeFlag}, args...))
+		_, err := root.ExecuteC()
+		return buf.String(), err
+	}
+
+	// the backup is behind the current database
+	_, err = restore()
+	require.ErrorIs(t, err, store.ErrSnapshotBehind)
+
+	output, err := restore("--i-know-what-i-am-doing")
+	require.NoError(t, err)
+	require.Contains(t, output, "restored 1 finality provider(s)")
+
+	db, err = cfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer db.Close()
+	fpStore, err = store.NewFinalityProviderStore(db)
+	require.NoError(t, err)
+	restoredFp, err := fpStore.GetFinalityProvider(fp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), restoredFp.LastVotedHeight)
+}
@@ -0,0 +1,157 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	goflags "github.com/jessevdk/go-flags"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandDumpDefaultConfig returns the command that prints the default config
+// in TOML format.
+func CommandDumpDefaultConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "dump-default-config",
+		Short: "Print the default config in TOML format.",
+		Long: `Print the default config in TOML format, which can be saved into a .toml file and
+loaded through the --config flag. Each option can also be overridden by the environment
+variable named after the option with the FPD_ prefix, e.g., FPD_NUMPUBRAND or FPD_BABYLON_KEY.
+With --network, the config is filled in with the built-in preset of the network.`,
+		Example: `fpd dump-default-config --network testnet --home /home/user/.fpd > /home/user/.fpd/fpd.toml`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runDumpDefaultConfigCmd),
+	}
+	cmd.Flags().String(networkFlag, "", fmt.Sprintf("The network to fill in the config for, one of %s; the defaults if empty",
+		strings.Join(fpcfg.Networks(), ", ")))
+	return cmd
+}
+
+func runDumpDefaultConfigCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	network, err := cmd.Flags().GetString(networkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", networkFlag, err)
+	}
+	defaultConfig, err := fpcfg.DefaultConfigForNetwork(homePath, network)
+	if err != nil {
+		return err
+	}
+
+	return fpcfg.WriteTOML(cmd.OutOrStdout(), &defaultConfig)
+}
+
+// CommandConfig returns the config commands of fpd daemon.
+func CommandConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "config",
+		Short: "Maintain the config file of the finality provider daemon.",
+	}
+	cmd.AddCommand(CommandMigrateConfig())
+	return cmd
+}
+
+// CommandMigrateConfig returns the config migrate command of fpd daemon.
+func CommandMigrateConfig() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade the config file written for an earlier release to the current schema.",
+		Long: `Upgrade the config file written for an earlier release, which fails to parse once its options
+are renamed or removed, to the current schema. The renamed options are moved to their current names,
+the removed and unknown ones are dropped, and the options added since are filled in with their defaults.
+The changes are printed as a diff before the file is rewritten in the same format, and the original file
+is kept next to it. With --dry-run, the diff is printed only.`,
+		Example: `fpd config migrate --dry-run --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runMigrateConfigCmd),
+	}
+	cmd.Flags().Bool(dryRunFlag, false, "Print the changes without rewriting the config file")
+	return cmd
+}
+
+func runMigrateConfigCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	dryRun, err := cmd.Flags().GetBool(dryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", dryRunFlag, err)
+	}
+
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfgFile, err := cmd.Flags().GetString(fpcmd.FlagConfig)
+	if err != nil || cfgFile == "" {
+		cfgFile = fpcfg.ConfigFile(homePath)
+	}
+
+	cfg, notes, err := fpcfg.MigrateConfigFile(homePath, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	var migrated bytes.Buffer
+	if strings.EqualFold(filepath.Ext(cfgFile), ".toml") {
+		if err := fpcfg.WriteTOML(&migrated, cfg); err != nil {
+			return fmt.Errorf("failed to write the migrated config: %w", err)
+		}
+	} else {
+		goflags.NewIniParser(goflags.NewParser(cfg, goflags.Default)).
+			Write(&migrated, goflags.IniIncludeComments|goflags.IniIncludeDefaults)
+	}
+
+	original, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(original, migrated.Bytes()) {
+		cmd.Printf("%s is up to date\n", cfgFile)
+		return nil
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(original)),
+		B:        difflib.SplitLines(migrated.String()),
+		FromFile: cfgFile,
+		ToFile:   cfgFile + " (migrated)",
+		Context:  1,
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Print(diff)
+	for _, note := range notes {
+		cmd.Printf("note: %s\n", note)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	keptPath := fmt.Sprintf("%s.pre-migrate-%s", cfgFile, time.Now().UTC().Format("20060102T150405Z"))
+	if err := os.Rename(cfgFile, keptPath); err != nil {
+		return fmt.Errorf("failed to move the config file aside: %w", err)
+	}
+	if err := os.WriteFile(cfgFile, migrated.Bytes(), 0600); err != nil {
+		return fmt.Errorf("failed to write the migrated config, the original is kept at %s: %w", keptPath, err)
+	}
+
+	cmd.Printf("migrated %s, the original is kept at %s\n", cfgFile, keptPath)
+	return nil
+}
@@ -0,0 +1,79 @@
+package daemon_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestMigrateConfigCmd(t *testing.T) {
+	rootCmdBuff := new(bytes.Buffer)
+	root := rootCmd(rootCmdBuff)
+
+	tempHome := filepath.Join(t.TempDir(), "homefpmigrate")
+	homeFlag := fmt.Sprintf("--home=%s", tempHome)
+	exec(t, root, rootCmdBuff, "init", homeFlag)
+
+	// the config file written by init is up to date
+	migrate := func(args ...string) string {
+		root := rootCmd(rootCmdBuff)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs(append([]string{"config", "migrate", homeFlag}, args...))
+		_, err := root.ExecuteC()
+		require.NoError(t, err)
+		return buf.String()
+	}
+	require.Contains(t, migrate(), "is up to date")
+
+	// an option removed since an earlier release fails the parsing
+	cfgFile := fpcfg.ConfigFile(tempHome)
+	original, err := os.ReadFile(cfgFile)
+	require.NoError(t, err)
+	outdated := bytes.Replace(original, []byte("[Application Options]\n"),
+		[]byte("[Application Options]\nMaxNumFinalityProviders = 3\n"), 1)
+	err = os.WriteFile(cfgFile, outdated, 0600)
+	require.NoError(t, err)
+	_, err = fpcfg.LoadConfig(tempHome)
+	require.Error(t, err)
+
+	// the dry run only prints the diff
+	output := migrate("--dry-run")
+	require.Contains(t, output, "-MaxNumFinalityProviders = 3")
+	content, err := os.ReadFile(cfgFile)
+	require.NoError(t, err)
+	require.Equal(t, outdated, content)
+
+	output = migrate()
+	require.Contains(t, output, "migrated "+cfgFile)
+	_, err = fpcfg.LoadConfig(tempHome)
+	require.NoError(t, err)
+	content, err = os.ReadFile(cfgFile)
+	require.NoError(t, err)
+	require.Equal(t, original, content)
+	kept, err := filepath.Glob(cfgFile + ".pre-migrate-*")
+	require.NoError(t, err)
+	require.Len(t, kept, 1)
+}
+
+func TestInitNetworkCmd(t *testing.T) {
+	rootCmdBuff := new(bytes.Buffer)
+	root := rootCmd(rootCmdBuff)
+
+	tempHome := filepath.Join(t.TempDir(), "homefpnetwork")
+	exec(t, root, rootCmdBuff, "init", fmt.Sprintf("--home=%s", tempHome), "--network=testnet")
+
+	cfg, err := fpcfg.LoadConfig(tempHome)
+	require.NoError(t, err)
+	preset, err := fpcfg.GetNetworkPreset(fpcfg.NetworkTestnet)
+	require.NoError(t, err)
+	require.Equal(t, preset.ChainID, cfg.BabylonConfig.ChainID)
+	require.Equal(t, preset.BitcoinNetwork, cfg.BitcoinNetwork)
+}
@@ -11,6 +11,7 @@ import (
 	"cosmossdk.io/math"
 	"github.com/babylonlabs-io/babylon/types"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
@@ -76,7 +77,14 @@ func CommandCreateFP() *cobra.Command {
 		It needs to have an operating EOTS manager available and running.
 
 		If the flag %s is set, it will ask for the key record from the EOTS manager for the
-		corresponding EOTS public key. If it is not set, it will create a new EOTS key`, fpEotsPkFlag),
+		corresponding EOTS public key. If it is not set, it will create a new EOTS key.
+
+		If the flag %s is also set to the proof of possession exported by eotsd pop-export,
+		the EOTS key is not loaded at all, so it can be kept offline during the registration.
+
+		If the flag %s is set, the command runs without the fpd daemon against the database and
+		the consumer chain of the config, so fpd should be stopped beforehand as it locks the database`,
+			fpEotsPkFlag, popFlag, oneShotFlag),
 		Example: fmt.Sprintf(`fpd create-finality-provider --daemon-address %s ...`, defaultFpdDaemonAddress),
 		Args:    cobra.NoArgs,
 		RunE:    fpcmd.RunEWithClientCtx(runCommandCreateFP),
@@ -90,12 +98,16 @@ func CommandCreateFP() *cobra.Command {
 	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
 	f.String(hdPathFlag, "", "The hd path used to derive the private key")
 	f.String(commissionRateFlag, "0.05", "The commission rate for the finality provider, e.g., 0.05")
+	f.String(maxCommissionFlag, "", "The optional maximum commission rate the finality provider can ever charge, e.g., 0.2")
 	f.String(monikerFlag, "", "A human-readable name for the finality provider")
 	f.String(identityFlag, "", "An optional identity signature (ex. UPort or Keybase)")
 	f.String(websiteFlag, "", "An optional website link")
 	f.String(securityContactFlag, "", "An email for security contact")
 	f.String(detailsFlag, "", "Other optional details")
 	f.String(fpEotsPkFlag, "", "Optional hex EOTS public key, if not provided a new one will be created")
+	f.String(popFlag, "", fmt.Sprintf("Optional hex proof of possession of the key of %s exported by eotsd pop-export, "+
+		"if provided the EOTS key is not loaded by the daemon", fpEotsPkFlag))
+	f.Bool(oneShotFlag, false, "Run without the fpd daemon, which must be stopped as it locks the database")
 
 	return cmd
 }
@@ -116,6 +128,19 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("invalid commission rate: %w", err)
 	}
 
+	maxCommissionRateStr, err := flags.GetString(maxCommissionFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", maxCommissionFlag, err)
+	}
+	var maxCommissionRate *math.LegacyDec
+	if maxCommissionRateStr != "" {
+		rate, err := math.LegacyNewDecFromStr(maxCommissionRateStr)
+		if err != nil {
+			return fmt.Errorf("invalid max commission rate: %w", err)
+		}
+		maxCommissionRate = &rate
+	}
+
 	description, err := getDescriptionFromFlags(flags)
 	if err != nil {
 		return fmt.Errorf("invalid description: %w", err)
@@ -126,15 +151,10 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		return fmt.Errorf("not able to load key name: %w", err)
 	}
 
-	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	oneShot, err := flags.GetBool(oneShotFlag)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to read flag %s: %w", oneShotFlag, err)
 	}
-	defer func() {
-		if err := cleanUp(); err != nil {
-			fmt.Printf("Failed to clean up grpc client: %v\n", err)
-		}
-	}()
 
 	chainId, err := flags.GetString(chainIdFlag)
 	if err != nil {
@@ -164,15 +184,71 @@ func runCommandCreateFP(ctx client.Context, cmd *cobra.Command, _ []string) erro
 		}
 	}
 
+	popHex, err := flags.GetString(popFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", popFlag, err)
+	}
+
+	if len(popHex) > 0 {
+		if len(eotsPkHex) == 0 {
+			return fmt.Errorf("the flag %s is required with %s", fpEotsPkFlag, popFlag)
+		}
+		if _, err := bstypes.NewPoPBTCFromHex(popHex); err != nil {
+			return fmt.Errorf("invalid proof of possession %s: %w", popHex, err)
+		}
+	}
+
+	if oneShot {
+		var (
+			eotsPk *bbntypes.BIP340PubKey
+			pop    *bstypes.ProofOfPossessionBTC
+		)
+		if len(eotsPkHex) > 0 {
+			eotsPk, _ = bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
+		}
+		if len(popHex) > 0 {
+			pop, _ = bstypes.NewPoPBTCFromHex(popHex)
+		}
+
+		app, cleanUp, err := newOneShotApp(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer cleanUp()
+
+		res, err := app.CreateFinalityProvider(
+			keyName, chainId, passphrase, hdPath, eotsPk, pop,
+			&description, &commissionRate, maxCommissionRate,
+		)
+		if err != nil {
+			return err
+		}
+
+		printRespJSON(res.FpInfo)
+		return nil
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
 	info, err := client.CreateFinalityProvider(
 		context.Background(),
 		keyName,
 		chainId,
 		eotsPkHex,
+		popHex,
 		passphrase,
 		hdPath,
 		description,
 		&commissionRate,
+		maxCommissionRate,
 	)
 	if err != nil {
 		return err
@@ -340,17 +416,22 @@ func CommandRegisterFP() *cobra.Command {
 		Use:     "register-finality-provider [fp-eots-pk-hex]",
 		Aliases: []string{"rfp"},
 		Short:   "Register a created finality provider to Babylon.",
+		Long: fmt.Sprintf(`Register a created finality provider to Babylon, after which the daemon starts
+handling it. If the flag %s is set, the command runs without the fpd daemon against the database
+and the consumer chain of the config, so fpd should be stopped beforehand as it locks the database,
+and the finality provider is only handled once fpd is started with it.`, oneShotFlag),
 		Example: fmt.Sprintf(`fpd register-finality-provider --daemon-address %s`, defaultFpdDaemonAddress),
 		Args:    cobra.ExactArgs(1),
-		RunE:    runCommandRegisterFP,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandRegisterFP),
 	}
 	f := cmd.Flags()
 	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
 	f.String(passphraseFlag, "", "The pass phrase used to encrypt the keys")
+	f.Bool(oneShotFlag, false, "Run without the fpd daemon, which must be stopped as it locks the database")
 	return cmd
 }
 
-func runCommandRegisterFP(cmd *cobra.Command, args []string) error {
+func runCommandRegisterFP(ctx client.Context, cmd *cobra.Command, args []string) error {
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
 	if err != nil {
 		return err
@@ -362,6 +443,27 @@ func runCommandRegisterFP(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
 	}
 
+	oneShot, err := flags.GetBool(oneShotFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", oneShotFlag, err)
+	}
+
+	if oneShot {
+		app, cleanUp, err := newOneShotApp(ctx, cmd)
+		if err != nil {
+			return err
+		}
+		defer cleanUp()
+
+		res, err := app.RegisterFinalityProvider(fpPk.MarshalHex())
+		if err != nil {
+			return fmt.Errorf("failed to register the finality-provider to Babylon: %w", err)
+		}
+		printRespJSON(&proto.RegisterFinalityProviderResponse{TxHash: res.TxHash})
+
+		return nil
+	}
+
 	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
 	if err != nil {
 		return err
@@ -455,7 +557,9 @@ func CommandEditFinalityDescription() *cobra.Command {
 		Long: "Edit the details of a finality provider using the specified BTC public key. " +
 			"\nThe provided [btc_pk] must correspond to the Babylon address controlled by the key specified in fpd.conf. " +
 			"\nIf one or more optional flags are passed (such as --moniker, --website, etc.), " +
-			"the corresponding values are updated, while unchanged fields retain their current values from the Babylon Node.",
+			"the corresponding values are updated, while unchanged fields retain their current values from the Babylon Node. " +
+			"\nThe commission rate can't exceed the max commission rate set when the finality provider was created. " +
+			"\nThe updated fields are also synced into the local store of the daemon.",
 		Example: fmt.Sprintf(`fpd edit-finality-provider [btc_pk] --daemon-address %s --moniker "new-moniker"`, defaultFpdDaemonAddress),
 		Args:    cobra.ExactArgs(1),
 		RunE:    runCommandEditFinalityDescription,
@@ -537,9 +641,9 @@ func loadKeyName(homeDir string, cmd *cobra.Command) (string, error) {
 
 	// we add the following check to ensure that the chain key is created
 	// beforehand
-	cfg, err := fpcfg.LoadConfig(homeDir)
+	cfg, err := fpcmd.LoadConfig(cmd, homeDir)
 	if err != nil {
-		return "", fmt.Errorf("failed to load config from %s: %w", fpcfg.ConfigFile(homeDir), err)
+		return "", fmt.Errorf("failed to load config from %s: %w", homeDir, err)
 	}
 
 	keyName = cfg.BabylonConfig.Key
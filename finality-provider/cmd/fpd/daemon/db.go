@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandDb returns the db commands of fpd daemon.
+func CommandDb() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "db",
+		Short: "Maintain the finality provider database.",
+	}
+	cmd.AddCommand(CommandCompactDb(), CommandVerifyDb())
+	return cmd
+}
+
+// CommandCompactDb returns the db compact command of fpd daemon.
+func CommandCompactDb() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "compact",
+		Short: "Compact the finality provider database.",
+		Long: `Rewrite the finality provider database into a fresh file, which drops the pages
+freed by the deleted records as the database file never shrinks otherwise. The original file
+is only replaced once the new one is complete. Note that fpd should be stopped beforehand as it
+locks the database.`,
+		Example: `fpd db compact --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandCompactDb),
+	}
+	return cmd
+}
+
+func runCommandCompactDb(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	// do not wait for the default timeout if the database is locked
+	dbCfg := *cfg.DatabaseConfig
+	dbCfg.DBTimeout = dbLockTimeout
+	before, after, err := dbCfg.Compact()
+	if err != nil {
+		return fmt.Errorf("%w, check that fpd is stopped", err)
+	}
+
+	cmd.Printf("compacted %s from %d to %d bytes\n", dbCfg.DBFilePath(), before, after)
+	return nil
+}
+
+// CommandVerifyDb returns the db verify command of fpd daemon.
+func CommandVerifyDb() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Check the integrity of the finality provider database.",
+		Long: `Walk all the records of the finality provider database and report the anomalies: the
+records that can't be decoded, the commitments of public randomness that overlap or are not
+contiguous, the voted blocks protecting against double signing that are above the committed
+randomness, and the records of finality providers that are not stored. The command fails if any
+anomaly is found. Note that fpd should be stopped beforehand as it locks the database.`,
+		Example: `fpd db verify --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandVerifyDb),
+	}
+	return cmd
+}
+
+func runCommandVerifyDb(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	cfg, db, err := loadConfigAndDb(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	anomalies, err := store.VerifyDb(db)
+	if err != nil {
+		return fmt.Errorf("failed to verify the database: %w", err)
+	}
+
+	for _, a := range anomalies {
+		cmd.Println(a.String())
+	}
+	if len(anomalies) > 0 {
+		return fmt.Errorf("found %d anomalies in %s", len(anomalies), cfg.DatabaseConfig.DBFilePath())
+	}
+
+	cmd.Printf("no anomaly found in %s\n", cfg.DatabaseConfig.DBFilePath())
+	return nil
+}
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const (
+	statusFlag  = "status"
+	pageKeyFlag = "page-key"
+)
+
+// CommandDelegations returns the delegations command by connecting to the fpd daemon.
+func CommandDelegations() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "delegations [fp-eots-pk-hex]",
+		Short: "List the BTC delegations to the finality provider.",
+		Long: `List the BTC delegations backing the finality provider as queried from the consumer
+		chain, with the status one of active, pending if the delegation is waiting for the
+		covenant signatures or the inclusion of its staking tx, or unbonding if its stake
+		no longer counts. The next page is listed with the printed --page-key.`,
+		Example: fmt.Sprintf(`fpd delegations [fp-eots-pk-hex] --status active --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandDelegations,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(statusFlag, "", "The status of the delegations to list, one of active, pending, or unbonding; all if empty")
+	cmd.Flags().String(pageKeyFlag, "", "The hex key of the page to list, as printed by the previous page")
+	cmd.Flags().Uint64(limitFlag, 100, "The maximum number of delegations to list")
+	cmd.Flags().String(outputFlag, outputText, fmt.Sprintf("The output format, either %s or %s", outputText, outputJSON))
+	return cmd
+}
+
+func runCommandDelegations(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	status, err := cmd.Flags().GetString(statusFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", statusFlag, err)
+	}
+	pageKeyHex, err := cmd.Flags().GetString(pageKeyFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", pageKeyFlag, err)
+	}
+	pageKey, err := hex.DecodeString(pageKeyHex)
+	if err != nil {
+		return fmt.Errorf("invalid page key %s: %w", pageKeyHex, err)
+	}
+	limit, err := cmd.Flags().GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid output format %s, it should be either %s or %s", output, outputText, outputJSON)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.ListDelegations(context.Background(), fpPk, status, pageKey, limit)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		printRespJSON(resp)
+		return nil
+	}
+
+	return printDelegationsTable(cmd.OutOrStdout(), resp)
+}
+
+func printDelegationsTable(out io.Writer, resp *proto.ListDelegationsResponse) error {
+	if len(resp.Delegations) == 0 {
+		_, err := fmt.Fprintln(out, "no delegation")
+		return err
+	}
+
+	var totalSat uint64
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STAKING TX HASH\tSTAKER\tAMOUNT (SAT)\tSTATUS\tBTC HEIGHTS")
+	for _, d := range resp.Delegations {
+		totalSat += d.TotalSat
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%d-%d\n",
+			d.StakingTxHash, d.StakerAddr, d.TotalSat, d.Status, d.StartHeight, d.EndHeight)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(out, "%d delegations of %d sat in total\n", len(resp.Delegations), totalSat); err != nil {
+		return err
+	}
+	if len(resp.NextPageKey) > 0 {
+		_, err := fmt.Fprintf(out, "next page: --%s %s\n", pageKeyFlag, hex.EncodeToString(resp.NextPageKey))
+		return err
+	}
+
+	return nil
+}
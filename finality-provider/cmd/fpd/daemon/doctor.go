@@ -0,0 +1,529 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotsclient "github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
+	"github.com/babylonlabs-io/finality-provider/util"
+	"github.com/babylonlabs-io/finality-provider/version"
+)
+
+const (
+	// doctorTimeout is the timeout of each check that connects to a service
+	doctorTimeout = 10 * time.Second
+	// maxClockDrift is the clock drift from the Babylon node above which a
+	// warning is reported
+	maxClockDrift = 10 * time.Second
+)
+
+type severity int
+
+const (
+	severityCritical severity = iota
+	severityWarning
+	severitySkipped
+	severityOK
+)
+
+func (s severity) String() string {
+	switch s {
+	case severityCritical:
+		return "CRITICAL"
+	case severityWarning:
+		return "WARNING"
+	case severitySkipped:
+		return "SKIPPED"
+	default:
+		return "OK"
+	}
+}
+
+// finding is the result of a diagnostic check with a suggested fix if the
+// check did not pass
+type finding struct {
+	check    string
+	severity severity
+	message  string
+	fix      string
+}
+
+// doctor runs the diagnostics and collects their findings. Each check may
+// keep the resources it opened for the subsequent checks, which are skipped
+// if the resources they depend on are unavailable
+type doctor struct {
+	cfg        *fpcfg.Config
+	passphrase string
+	logger     *zap.Logger
+
+	db  kvdb.Backend
+	fps []*store.StoredFinalityProvider
+	cc  clientcontroller.ClientController
+	em  eotsmanager.EOTSManager
+
+	findings []finding
+}
+
+// CommandDoctor returns the doctor command of fpd daemon.
+func CommandDoctor() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Run the diagnostics of the finality provider and suggest fixes.",
+		Long: `Run the diagnostics of the finality provider, including the validation of the config,
+the integrity of the database, the health of the keys, the connectivity to the consumer chain
+and the EOTS manager, the reconciliation of the committed public randomness, and the clock
+drift from the Babylon node. The findings are printed by priority with suggested fixes, and
+the command fails if any of them is critical. Note that fpd should be stopped beforehand as
+it locks the database, while eotsd should be running.`,
+		Example: `fpd doctor --home /home/user/.fpd --passphrase pass`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runDoctorCmd),
+	}
+	cmd.Flags().String(passphraseFlag, "", "The pass phrase used to decrypt the keys")
+	return cmd
+}
+
+func runDoctorCmd(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	passphrase, err := cmd.Flags().GetString(passphraseFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	d := &doctor{passphrase: passphrase, logger: zap.NewNop()}
+	defer d.close()
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		d.report("config", severityCritical, err.Error(),
+			"fix the option named in the error, or run `fpd init --force` to write a default config")
+	} else {
+		d.cfg = cfg
+		d.report("config", severityOK, "the config is valid", "")
+	}
+
+	d.checkDatabase()
+	d.checkChainKeys()
+	d.checkConsumerChain()
+	d.checkEOTSManager()
+	d.checkRandomness()
+	d.checkClockDrift()
+
+	return d.print(cmd.OutOrStdout())
+}
+
+func (d *doctor) report(check string, sev severity, message, fix string) {
+	d.findings = append(d.findings, finding{check: check, severity: sev, message: message, fix: fix})
+}
+
+func (d *doctor) skip(check, reason string) {
+	d.report(check, severitySkipped, reason, "")
+}
+
+func (d *doctor) close() {
+	if d.em != nil {
+		_ = d.em.Close()
+	}
+	if d.cc != nil {
+		_ = d.cc.Close()
+	}
+	if d.db != nil {
+		_ = d.db.Close()
+	}
+}
+
+func (d *doctor) checkDatabase() {
+	const check = "database"
+	if d.cfg == nil {
+		d.skip(check, "the config is invalid")
+		return
+	}
+
+	// do not wait for the default timeout if the database is locked
+	dbCfg := *d.cfg.DatabaseConfig
+	dbCfg.DBTimeout = doctorTimeout
+	db, err := dbCfg.GetDbBackend()
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to open the database: %v", err),
+			"stop the running fpd, which locks the database, and check the permissions of "+dbCfg.DBPath)
+		return
+	}
+	d.db = db
+
+	fpStore, err := store.NewFinalityProviderStore(db)
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to open the finality provider store: %v", err),
+			"restore the database from a backup")
+		return
+	}
+	fps, err := fpStore.GetAllStoredFinalityProviders()
+	if err != nil {
+		var corruptErr *store.ErrCorruptRecord
+		if errors.As(err, &corruptErr) {
+			d.report(check, severityCritical, err.Error(),
+				"restore the database from a backup, or recreate the finality provider with `fpd create-finality-provider`")
+			return
+		}
+		d.report(check, severityCritical, fmt.Sprintf("failed to read the finality providers: %v", err),
+			"restore the database from a backup")
+		return
+	}
+	d.fps = fps
+
+	if len(fps) == 0 {
+		d.report(check, severityWarning, "no finality provider is stored",
+			"create one with `fpd create-finality-provider`")
+		return
+	}
+	d.report(check, severityOK, fmt.Sprintf("%d finality provider(s) stored", len(fps)), "")
+}
+
+func (d *doctor) checkChainKeys() {
+	const check = "keys"
+	if d.cfg == nil {
+		d.skip(check, "the config is invalid")
+		return
+	}
+
+	bbnCfg := d.cfg.BabylonConfig
+	kr, err := fpkr.CreateKeyring(bbnCfg.KeyDirectory, bbnCfg.ChainID, bbnCfg.KeyringBackend, strings.NewReader(d.passphrase))
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to open the keyring: %v", err),
+			"check KeyDirectory and KeyringBackend in the [babylon] section of the config")
+		return
+	}
+
+	found := true
+	if _, err := kr.Key(bbnCfg.Key); err != nil {
+		found = false
+		d.report(check, severityCritical, fmt.Sprintf("the key %s of the config is not found in the keyring: %v", bbnCfg.Key, err),
+			fmt.Sprintf("add the key with `fpd keys add %s`, or set Key in the [babylon] section of the config", bbnCfg.Key))
+	}
+
+	for _, fp := range d.fps {
+		record, err := kr.Key(fp.KeyName)
+		if err != nil {
+			found = false
+			d.report(check, severityCritical,
+				fmt.Sprintf("the key %s of finality provider %s is not found in the keyring: %v", fp.KeyName, fp.GetBIP340BTCPK().MarshalHex(), err),
+				fmt.Sprintf("recover the key with `fpd keys add %s --recover`", fp.KeyName))
+			continue
+		}
+		addr, err := record.GetAddress()
+		if err != nil {
+			found = false
+			d.report(check, severityCritical, fmt.Sprintf("the key %s is invalid: %v", fp.KeyName, err),
+				fmt.Sprintf("recover the key with `fpd keys add %s --recover`", fp.KeyName))
+			continue
+		}
+		if addr.String() != fp.FPAddr {
+			found = false
+			d.report(check, severityCritical,
+				fmt.Sprintf("the address %s of the key %s differs from the address %s of finality provider %s",
+					addr.String(), fp.KeyName, fp.FPAddr, fp.GetBIP340BTCPK().MarshalHex()),
+				fmt.Sprintf("recover the key %s from the mnemonic of %s", fp.KeyName, fp.FPAddr))
+		}
+	}
+
+	if found {
+		d.report(check, severityOK, "the keys are found in the keyring", "")
+	}
+}
+
+func (d *doctor) checkConsumerChain() {
+	const check = "consumer chain"
+	if d.cfg == nil {
+		d.skip(check, "the config is invalid")
+		return
+	}
+
+	var cc clientcontroller.ClientController
+	err := runWithTimeout(func() error {
+		c, err := clientcontroller.NewClientController(d.cfg.ChainName, d.cfg.BabylonConfig, &d.cfg.BTCNetParams, d.logger)
+		if err != nil {
+			return err
+		}
+		if _, err := c.QueryBestBlock(); err != nil {
+			_ = c.Close()
+			return err
+		}
+		cc = c
+		return nil
+	})
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to query the consumer chain %s: %v", d.cfg.ChainName, err),
+			"check RPCAddr and GRPCAddr in the [babylon] section of the config and that the node is reachable")
+		return
+	}
+	d.cc = cc
+
+	err = runWithTimeout(func() error {
+		return clientcontroller.VerifyChain(cc, d.cfg.BabylonConfig.ChainID, d.cfg.BabylonConfig.GenesisHash)
+	})
+	if err != nil {
+		d.report(check, severityCritical, err.Error(),
+			"check that RPCAddr in the [babylon] section of the config points to a node of the network of ChainID")
+		return
+	}
+
+	err = runWithTimeout(func() error {
+		return clientcontroller.VerifyNodeCompatibility(cc, version.BabylonVersion(), uint64(d.cfg.NumPubRand), d.logger)
+	})
+	switch {
+	case errors.Is(err, clientcontroller.ErrIncompatibleNode) && d.cfg.BabylonConfig.AllowIncompatibleNode:
+		d.report(check, severityWarning, err.Error(), "upgrade fpd or the node to compatible releases")
+		return
+	case err != nil:
+		d.report(check, severityCritical, err.Error(),
+			"upgrade fpd or the node to compatible releases, or set AllowIncompatibleNode in the [babylon] section of the config to only warn")
+		return
+	}
+
+	d.report(check, severityOK, "the consumer chain is reachable", "")
+}
+
+func (d *doctor) checkEOTSManager() {
+	const check = "EOTS manager"
+	if d.cfg == nil {
+		d.skip(check, "the config is invalid")
+		return
+	}
+	if d.cfg.RemoteSignerListener != "" {
+		// the remote signer dials in to the running daemon only
+		d.skip(check, "the signatures are served by a remote signer")
+		return
+	}
+
+	var em eotsmanager.EOTSManager
+	err := runWithTimeout(func() error {
+		c, err := eotsclient.NewEOTSManagerGRpcClient(d.cfg.EOTSManagerAddress)
+		if err != nil {
+			return err
+		}
+		em = c
+		return nil
+	})
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to connect to the EOTS manager at %s: %v", d.cfg.EOTSManagerAddress, err),
+			"start eotsd, or set EOTSManagerAddress in the config to the address it listens to")
+		return
+	}
+	d.em = em
+
+	found := true
+	for _, fp := range d.fps {
+		if _, err := d.em.KeyRecord(schnorr.SerializePubKey(fp.BtcPk), d.passphrase); err != nil {
+			found = false
+			d.report(check, severityCritical,
+				fmt.Sprintf("the EOTS key of finality provider %s is not available: %v", fp.GetBIP340BTCPK().MarshalHex(), err),
+				"check that eotsd uses the keyring with the EOTS key and that the passphrase is correct")
+		}
+	}
+
+	if found {
+		d.report(check, severityOK, "the EOTS manager is reachable and holds the EOTS keys", "")
+	}
+}
+
+func (d *doctor) checkRandomness() {
+	const check = "randomness"
+	if d.db == nil || d.cc == nil || d.em == nil {
+		d.skip(check, "the database, the consumer chain, or the EOTS manager is unavailable")
+		return
+	}
+
+	pubRandStore, err := store.NewPubRandProofStore(d.db)
+	if err != nil {
+		d.report(check, severityCritical, fmt.Sprintf("failed to open the public randomness store: %v", err),
+			"restore the database from a backup")
+		return
+	}
+
+	tip, err := d.cc.QueryBestBlock()
+	if err != nil {
+		d.skip(check, fmt.Sprintf("failed to query the consumer chain: %v", err))
+		return
+	}
+
+	reconciled := true
+	for _, fp := range d.fps {
+		if fp.Status == proto.FinalityProviderStatus_CREATED {
+			continue
+		}
+		fpPkHex := fp.GetBIP340BTCPK().MarshalHex()
+
+		commits, err := d.cc.QueryLastCommittedPublicRand(fp.BtcPk, 1)
+		if err != nil {
+			reconciled = false
+			d.report(check, severityWarning,
+				fmt.Sprintf("failed to query the committed public randomness of finality provider %s: %v", fpPkHex, err), "")
+			continue
+		}
+		if len(commits) == 0 {
+			reconciled = false
+			d.report(check, severityWarning,
+				fmt.Sprintf("finality provider %s has not committed public randomness", fpPkHex),
+				"start fpd with the finality provider so that it commits public randomness")
+			continue
+		}
+
+		for startHeight, commit := range commits {
+			// the randomness at the start height is regenerated to check
+			// that its proof is stored
+			pubRandList, err := d.em.CreateRandomnessPairList(
+				schnorr.SerializePubKey(fp.BtcPk), []byte(fp.ChainID), startHeight, 1, d.passphrase)
+			if err != nil {
+				reconciled = false
+				d.report(check, severityWarning,
+					fmt.Sprintf("failed to generate the public randomness of finality provider %s: %v", fpPkHex, err), "")
+				continue
+			}
+			if _, err := pubRandStore.GetPubRandProof(pubRandList[0]); err != nil {
+				reconciled = false
+				d.report(check, missingProofSeverity(err),
+					fmt.Sprintf("the proofs of the public randomness of finality provider %s committed at height %d are not stored: %v",
+						fpPkHex, startHeight, err),
+					"restore the database from a backup, otherwise the finality provider cannot vote until the committed randomness is exhausted")
+			}
+
+			endHeight := startHeight + commit.NumPubRand - 1
+			if endHeight < tip.Height {
+				reconciled = false
+				d.report(check, severityWarning,
+					fmt.Sprintf("the committed public randomness of finality provider %s ends at height %d, below the tip height %d",
+						fpPkHex, endHeight, tip.Height),
+					"start fpd with the finality provider so that it commits new public randomness")
+			}
+		}
+	}
+
+	if reconciled {
+		d.report(check, severityOK, "the committed public randomness is reconciled with the local proofs", "")
+	}
+}
+
+// missingProofSeverity returns the severity of an error reading the proof of
+// committed randomness, which is critical if the proof is not stored as the
+// finality provider cannot vote with the randomness
+func missingProofSeverity(err error) severity {
+	if errors.Is(err, store.ErrPubRandProofNotFound) {
+		return severityCritical
+	}
+	return severityWarning
+}
+
+func (d *doctor) checkClockDrift() {
+	const check = "clock"
+	if d.cfg == nil {
+		d.skip(check, "the config is invalid")
+		return
+	}
+
+	var drift time.Duration
+	err := runWithTimeout(func() error {
+		var err error
+		drift, err = clockDrift(d.cfg.BabylonConfig.RPCAddr)
+		return err
+	})
+	if err != nil {
+		d.skip(check, fmt.Sprintf("failed to get the time of the Babylon node: %v", err))
+		return
+	}
+
+	if drift > maxClockDrift || drift < -maxClockDrift {
+		d.report(check, severityWarning,
+			fmt.Sprintf("the local clock drifts by %v from the Babylon node", drift.Round(time.Second)),
+			"synchronize the local clock with NTP, e.g., enable systemd-timesyncd or chrony")
+		return
+	}
+
+	d.report(check, severityOK, fmt.Sprintf("the local clock drifts by %v from the Babylon node", drift.Round(time.Second)), "")
+}
+
+// clockDrift returns the drift of the local clock from the clock of the node
+// at the given RPC address, which is read from the Date header of its response
+func clockDrift(rpcAddr string) (time.Duration, error) {
+	sent := time.Now()
+	res, err := http.Get(strings.TrimSuffix(rpcAddr, "/") + "/health")
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	received := time.Now()
+
+	nodeTime, err := http.ParseTime(res.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid Date header: %w", err)
+	}
+
+	// the Date header has a precision of one second, so the round trip time
+	// is negligible unless the node is far away
+	localTime := sent.Add(received.Sub(sent) / 2)
+
+	return localTime.Sub(nodeTime), nil
+}
+
+// print prints the findings by priority and returns an error if any of them
+// is critical
+func (d *doctor) print(w io.Writer) error {
+	sort.SliceStable(d.findings, func(i, j int) bool {
+		return d.findings[i].severity < d.findings[j].severity
+	})
+
+	counts := make(map[severity]int)
+	for _, f := range d.findings {
+		counts[f.severity]++
+		fmt.Fprintf(w, "[%s] %s: %s\n", f.severity, f.check, f.message)
+		if f.fix != "" {
+			fmt.Fprintf(w, "    fix: %s\n", f.fix)
+		}
+	}
+	fmt.Fprintf(w, "\n%d critical, %d warning(s), %d skipped, %d passed\n",
+		counts[severityCritical], counts[severityWarning], counts[severitySkipped], counts[severityOK])
+
+	if counts[severityCritical] > 0 {
+		return fmt.Errorf("found %d critical issue(s)", counts[severityCritical])
+	}
+
+	return nil
+}
+
+// runWithTimeout runs fn and returns an error if it does not complete within
+// doctorTimeout, in which case fn keeps running in the background
+func runWithTimeout(fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(doctorTimeout):
+		return fmt.Errorf("no response within %v", doctorTimeout)
+	}
+}
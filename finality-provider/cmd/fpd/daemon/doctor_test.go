@@ -0,0 +1,54 @@
+package daemon_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestDoctorCmd(t *testing.T) {
+	rootCmdBuff := new(bytes.Buffer)
+	root := rootCmd(rootCmdBuff)
+
+	tempHome := filepath.Join(t.TempDir(), "homefpdoctor")
+	homeFlag := fmt.Sprintf("--home=%s", tempHome)
+	exec(t, root, rootCmdBuff, "init", homeFlag)
+
+	doctor := func() (string, error) {
+		// the output of the subcommands is set at their first execution
+		root := rootCmd(rootCmdBuff)
+		buf := new(bytes.Buffer)
+		root.SetOut(buf)
+		root.SetErr(buf)
+		root.SetArgs([]string{"doctor", homeFlag})
+		_, err := root.ExecuteC()
+		return buf.String(), err
+	}
+
+	// neither the key nor the consumer chain are available
+	output, err := doctor()
+	require.Error(t, err)
+	require.Contains(t, output, "[OK] config: the config is valid")
+	require.Contains(t, output, "[WARNING] database: no finality provider is stored")
+	require.Contains(t, output, "[CRITICAL] keys:")
+	require.Contains(t, output, "[CRITICAL] consumer chain:")
+	require.Contains(t, output, "[SKIPPED] randomness:")
+	// the critical findings are printed first
+	require.Less(t, strings.Index(output, "[CRITICAL]"), strings.Index(output, "[WARNING]"))
+	require.Less(t, strings.Index(output, "[WARNING]"), strings.Index(output, "[OK]"))
+
+	// the checks depending on the config are skipped if it is invalid
+	err = os.WriteFile(fpcfg.ConfigFile(tempHome), []byte("[Application Options]\nNumPubRand = 0\n"), 0600)
+	require.NoError(t, err)
+	output, err = doctor()
+	require.Error(t, err)
+	require.Contains(t, output, "[CRITICAL] config:")
+	require.Contains(t, output, "[SKIPPED] database: the config is invalid")
+}
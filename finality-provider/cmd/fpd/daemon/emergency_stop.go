@@ -0,0 +1,152 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	reasonFlag         = "reason"
+	adminTokenFileFlag = "admin-token-file"
+)
+
+// CommandEmergencyStop returns the emergency-stop command by connecting to the fpd daemon.
+func CommandEmergencyStop() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "emergency-stop",
+		Short: "Halt all the signing and the submissions of the daemon at once.",
+		Long: `Halt all the signing and the submissions of the daemon at once, e.g., if a key is
+		suspected to be compromised, and stop the running finality provider. The daemon stays
+		stopped across restarts until it is resumed with fpd resume. The request is authenticated
+		with the admin token of the daemon, which is read from the admin_token file of the home
+		directory unless --admin-token-file is set, and the reason is recorded in the audit log.`,
+		Example: fmt.Sprintf(`fpd emergency-stop --reason "suspected key leak" --home /home/user/.fpd --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: fpcmd.RunEWithClientCtx(runCommandEmergencyStop),
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(reasonFlag, "", "Why the daemon is stopped, which is recorded in the audit log")
+	f.String(adminTokenFileFlag, "", "The path of the admin token of the daemon; the admin_token file of the home directory if empty")
+	_ = cmd.MarkFlagRequired(reasonFlag)
+	return cmd
+}
+
+func runCommandEmergencyStop(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	client, cleanUp, token, reason, err := newAdminClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	if err := client.EmergencyStop(context.Background(), token, reason); err != nil {
+		return err
+	}
+	cmd.Println("the daemon is stopped in emergency, nothing is signed or submitted until it is resumed with fpd resume")
+
+	return nil
+}
+
+// CommandResume returns the resume command by connecting to the fpd daemon.
+func CommandResume() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "resume",
+		Short: "Lift the emergency stop of the daemon.",
+		Long: `Lift the emergency stop of the daemon and start the finality provider again if its
+		status allows it. The passphrase unlocks the EOTS key if the finality provider has not
+		been started since the daemon started. The request is authenticated with the admin token
+		like fpd emergency-stop, and the reason is recorded in the audit log.`,
+		Example: fmt.Sprintf(`fpd resume --reason "the key was rotated" --home /home/user/.fpd --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.NoArgs,
+		RunE: fpcmd.RunEWithClientCtx(runCommandResume),
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(reasonFlag, "", "Why the daemon is resumed, which is recorded in the audit log")
+	f.String(adminTokenFileFlag, "", "The path of the admin token of the daemon; the admin_token file of the home directory if empty")
+	f.String(passphraseFlag, "", "The pass phrase used to decrypt the keys")
+	_ = cmd.MarkFlagRequired(reasonFlag)
+	return cmd
+}
+
+func runCommandResume(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	passphrase, err := cmd.Flags().GetString(passphraseFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	client, cleanUp, token, reason, err := newAdminClient(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	if err := client.Resume(context.Background(), token, reason, passphrase); err != nil {
+		return err
+	}
+	cmd.Println("the emergency stop of the daemon is lifted")
+
+	return nil
+}
+
+// newAdminClient connects to the fpd daemon and returns the admin token and
+// the reason to authenticate and record an administrative request with
+func newAdminClient(ctx client.Context, cmd *cobra.Command) (
+	*dc.FinalityProviderServiceGRpcClient, func() error, string, string, error) {
+
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	reason, err := flags.GetString(reasonFlag)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to read flag %s: %w", reasonFlag, err)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, nil, "", "", fmt.Errorf("--%s must not be empty", reasonFlag)
+	}
+	tokenFile, err := flags.GetString(adminTokenFileFlag)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to read flag %s: %w", adminTokenFileFlag, err)
+	}
+	if tokenFile == "" {
+		homePath, err := filepath.Abs(ctx.HomeDir)
+		if err != nil {
+			return nil, nil, "", "", err
+		}
+		tokenFile = fpcfg.AdminTokenFile(util.CleanAndExpandPath(homePath))
+	}
+	bz, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return nil, nil, "", "", fmt.Errorf("failed to read the admin token: %w", err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return nil, nil, "", "", err
+	}
+
+	return client, cleanUp, strings.TrimSpace(string(bz)), reason, nil
+}
@@ -0,0 +1,163 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const fromSeqFlag = "from-seq"
+
+// CommandEvents returns the events command by connecting to the fpd daemon.
+func CommandEvents() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "events [fp-eots-pk-hex]",
+		Short: "Stream the events of the daemon as they happen.",
+		Long: `Stream the events of the daemon, optionally of the given finality provider only, as
+		one JSON object per line until interrupted. The events are the submitted txs, typed
+		finality_sig, pub_rand_commit, registration, or unjail, the status changes of the
+		finality providers, typed status_change, the critical errors, typed error, the pauses
+		and resumptions of the finality providers, and the starts and stops of the daemon.
+		With --from-seq, the events recorded in the event journal from that sequence number
+		on are replayed first.`,
+		Example: fmt.Sprintf(`fpd events [fp-eots-pk-hex] --type finality_sig --type status_change --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCommandEvents,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().StringSlice(typeFlag, nil, "The types of the events to stream; all if empty")
+	cmd.Flags().Uint64(fromSeqFlag, 0, "The sequence number of the first event of the journal to replay; 0 to stream the new events only")
+	return cmd
+}
+
+func runCommandEvents(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	types, err := cmd.Flags().GetStringSlice(typeFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", typeFlag, err)
+	}
+	fromSeq, err := cmd.Flags().GetUint64(fromSeqFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fromSeqFlag, err)
+	}
+	fpPk := ""
+	if len(args) == 1 {
+		fpPk = args[0]
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	stream, err := client.StreamEvents(ctx, fpPk, types, fromSeq)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	for {
+		ev, err := stream.Recv()
+		if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+}
+
+// CommandEventJournal returns the event-journal command by connecting to the fpd daemon.
+func CommandEventJournal() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "event-journal [fp-eots-pk-hex]",
+		Short: "List the events recorded in the event journal of the daemon.",
+		Long: `List the events recorded in the append-only event journal of the daemon in the order
+		they happened, optionally of the given finality provider only, as one JSON object per
+		line, to reconstruct what the daemon did and when. The sequence number to list the
+		next events from is printed to stderr.`,
+		Example: fmt.Sprintf(`fpd event-journal [fp-eots-pk-hex] --from-seq 1 --limit 100 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.MaximumNArgs(1),
+		RunE: runCommandEventJournal,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().StringSlice(typeFlag, nil, "The types of the events to list; all if empty")
+	cmd.Flags().Uint64(fromSeqFlag, 1, "The sequence number of the first event to list")
+	cmd.Flags().Uint64(limitFlag, 100, "The maximum number of events to list, 0 for no limit")
+	return cmd
+}
+
+func runCommandEventJournal(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	types, err := cmd.Flags().GetStringSlice(typeFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", typeFlag, err)
+	}
+	fromSeq, err := cmd.Flags().GetUint64(fromSeqFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fromSeqFlag, err)
+	}
+	limit, err := cmd.Flags().GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+	fpPk := ""
+	if len(args) == 1 {
+		fpPk = args[0]
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.QueryEvents(context.Background(), fromSeq, fpPk, types, limit)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	for _, ev := range res.Events {
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+	cmd.PrintErrf("next sequence: %d\n", res.NextSeq)
+
+	return nil
+}
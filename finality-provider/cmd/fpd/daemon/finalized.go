@@ -0,0 +1,92 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// CommandFinalized returns the finalized command by connecting to the fpd daemon.
+func CommandFinalized() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "finalized [height]",
+		Short: "Show whether a block is finalized and who voted for it.",
+		Long: `Show whether the block at the given height is finalized by the finality gadget of
+		the consumer chain, the finality providers that voted for it, and whether each of
+		the finality providers managed by the daemon is among them.`,
+		Example: fmt.Sprintf(`fpd finalized 100 --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandFinalized,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(outputFlag, outputText, fmt.Sprintf("The output format, either %s or %s", outputText, outputJSON))
+	return cmd
+}
+
+func runCommandFinalized(cmd *cobra.Command, args []string) error {
+	height, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid height %s: %w", args[0], err)
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid output format %s, it should be either %s or %s", output, outputText, outputJSON)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.QueryBlockFinalization(context.Background(), height)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		printRespJSON(resp)
+		return nil
+	}
+
+	return printBlockFinalization(cmd.OutOrStdout(), resp)
+}
+
+func printBlockFinalization(out io.Writer, resp *proto.QueryBlockFinalizationResponse) error {
+	fmt.Fprintf(out, "height:     %d\n", resp.Height)
+	fmt.Fprintf(out, "hash:       %s\n", resp.Hash)
+	fmt.Fprintf(out, "finalized:  %t\n", resp.Finalized)
+	fmt.Fprintf(out, "voters:     %d\n", len(resp.Voters))
+	if len(resp.ManagedVotes) == 0 {
+		_, err := fmt.Fprintln(out, "no managed finality provider")
+		return err
+	}
+
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BTC PK\tSTATUS\tVOTED")
+	for _, v := range resp.ManagedVotes {
+		fmt.Fprintf(w, "%s\t%s\t%t\n", v.BtcPkHex, v.Status, v.Voted)
+	}
+
+	return w.Flush()
+}
@@ -3,7 +3,9 @@ package daemon
 const (
 	forceFlag            = "force"
 	fpEotsPkFlag         = "eots-pk"
+	popFlag              = "pop"
 	rpcListenerFlag      = "rpc-listener"
+	restListenerFlag     = "rest-listener"
 	fpdDaemonAddressFlag = "daemon-address"
 	keyNameFlag          = "key-name"
 	appHashFlag          = "app-hash"
@@ -11,6 +13,12 @@ const (
 	hdPathFlag           = "hd-path"
 	chainIdFlag          = "chain-id"
 	signedFlag           = "signed"
+	outputFlag           = "output"
+	dryRunFlag           = "dry-run"
+	recipientFlag        = "recipient"
+	oneShotFlag          = "oneshot"
+	networkFlag          = "network"
+	readOnlyFlag         = "read-only"
 
 	// flags for description
 	monikerFlag         = "moniker"
@@ -19,4 +27,5 @@ const (
 	securityContactFlag = "security-contact"
 	detailsFlag         = "details"
 	commissionRateFlag  = "commission-rate"
+	maxCommissionFlag   = "max-commission-rate"
 )
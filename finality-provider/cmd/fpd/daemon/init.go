@@ -3,6 +3,7 @@ package daemon
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/jessevdk/go-flags"
@@ -16,14 +17,18 @@ import (
 // CommandInit returns the init command of fpd daemon that starts the config dir.
 func CommandInit() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:     "init",
-		Short:   "Initialize a finality-provider home directory.",
-		Long:    `Creates a new finality-provider home directory with default config`,
-		Example: `fpd init --home /home/user/.fpd --force`,
+		Use:   "init",
+		Short: "Initialize a finality-provider home directory.",
+		Long: `Creates a new finality-provider home directory with default config. With --network, the
+config is filled in with the built-in preset of the network, i.e., its chain id, Bitcoin network,
+node endpoints, gas prices, and randomness sizing, which can still be edited in the config file.`,
+		Example: `fpd init --network testnet --home /home/user/.fpd --force`,
 		Args:    cobra.NoArgs,
 		RunE:    fpcmd.RunEWithClientCtx(runInitCmd),
 	}
 	cmd.Flags().Bool(forceFlag, false, "Override existing configuration")
+	cmd.Flags().String(networkFlag, "", fmt.Sprintf("The network to fill in the config for, one of %s; the defaults if empty",
+		strings.Join(fpcfg.Networks(), ", ")))
 	return cmd
 }
 
@@ -38,6 +43,14 @@ func runInitCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", forceFlag, err)
 	}
+	network, err := cmd.Flags().GetString(networkFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", networkFlag, err)
+	}
+	defaultConfig, err := fpcfg.DefaultConfigForNetwork(homePath, network)
+	if err != nil {
+		return err
+	}
 
 	if util.FileExists(homePath) && !force {
 		return fmt.Errorf("home path %s already exists", homePath)
@@ -52,7 +65,6 @@ func runInitCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	defaultConfig := fpcfg.DefaultConfigWithHome(homePath)
 	fileParser := flags.NewParser(&defaultConfig, flags.Default)
 
 	return flags.NewIniParser(fileParser).WriteFile(fpcfg.ConfigFile(homePath), flags.IniIncludeComments|flags.IniIncludeDefaults)
@@ -24,7 +24,8 @@ func CommandKeys() *cobra.Command {
 
 	keyAddCmd.PostRunE = helper.RunEWithClientCtx(func(ctx client.Context, cmd *cobra.Command, args []string) error {
 		// check the config file exists
-		cfg, err := fpcfg.LoadConfig(ctx.HomeDir)
+		// the environment overrides are not applied to not persist them
+		cfg, err := fpcfg.ParseConfigFile(ctx.HomeDir, fpcfg.ConfigFile(ctx.HomeDir))
 		if err != nil {
 			return nil // config does not exist, so does not update it
 		}
@@ -0,0 +1,99 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const (
+	timeoutFlag               = "timeout"
+	defaultMaintenanceTimeout = 5 * time.Minute
+)
+
+// CommandEnterMaintenance returns the enter-maintenance command by connecting to the fpd daemon.
+func CommandEnterMaintenance() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "enter-maintenance",
+		Short: "Stop generating new work and wait for the in-flight submissions to finish.",
+		Long: `Stop polling new blocks and committing public randomness or withdrawing rewards
+		on schedule, and wait until the submissions in flight are finished and confirmed, e.g.,
+		before upgrading the node. The blocks polled before are held until the maintenance is
+		exited with fpd exit-maintenance. If the submissions are not finished within the timeout,
+		the daemon stays in maintenance and the command can be run again to keep waiting.`,
+		Example: fmt.Sprintf(`fpd enter-maintenance --timeout 10m --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandEnterMaintenance,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.Duration(timeoutFlag, defaultMaintenanceTimeout, "The maximum time to wait for the in-flight submissions to finish")
+	return cmd
+}
+
+func runCommandEnterMaintenance(cmd *cobra.Command, _ []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	timeout, err := flags.GetDuration(timeoutFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", timeoutFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := client.EnterMaintenance(ctx); err != nil {
+		return err
+	}
+	cmd.Println("the daemon is in maintenance and no submission is in flight")
+
+	return nil
+}
+
+// CommandExitMaintenance returns the exit-maintenance command by connecting to the fpd daemon.
+func CommandExitMaintenance() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "exit-maintenance",
+		Short:   "Resume the work stopped by fpd enter-maintenance.",
+		Example: fmt.Sprintf(`fpd exit-maintenance --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandExitMaintenance,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	return cmd
+}
+
+func runCommandExitMaintenance(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return client.ExitMaintenance(context.Background())
+}
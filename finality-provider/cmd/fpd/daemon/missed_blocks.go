@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const (
+	startHeightFlag = "start-height"
+	endHeightFlag   = "end-height"
+	limitFlag       = "limit"
+	skippedFlag     = "skipped"
+)
+
+// CommandMissedBlocks returns the missed-blocks command by connecting to the fpd daemon.
+func CommandMissedBlocks() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "missed-blocks [fp-eots-pk-hex]",
+		Short: "List the blocks that the finality provider failed to vote for.",
+		Long: `List the blocks that the finality provider failed to vote for together with the reason,
+		one of no_randomness if its public randomness was not committed, rpc_failure if the
+		finality signature could not be submitted, or late if the block was finalized before.
+		With --skipped, list the blocks whose votes were skipped on purpose as they were
+		already finalized instead.`,
+		Example: fmt.Sprintf(`fpd missed-blocks [fp-eots-pk-hex] --start-height 100 --end-height 200 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandMissedBlocks,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().Uint64(startHeightFlag, 0, "The lowest height of the missed blocks to list")
+	cmd.Flags().Uint64(endHeightFlag, 0, "The highest height of the missed blocks to list, 0 for no upper bound")
+	cmd.Flags().Uint64(limitFlag, 100, "The maximum number of missed blocks to list, 0 for no limit")
+	cmd.Flags().Bool(skippedFlag, false, "List the blocks skipped on purpose as already finalized instead of the missed ones")
+	cmd.Flags().String(outputFlag, outputText, fmt.Sprintf("The output format, either %s or %s", outputText, outputJSON))
+	return cmd
+}
+
+func runCommandMissedBlocks(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	startHeight, err := cmd.Flags().GetUint64(startHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", startHeightFlag, err)
+	}
+	endHeight, err := cmd.Flags().GetUint64(endHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", endHeightFlag, err)
+	}
+	limit, err := cmd.Flags().GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+	skipped, err := cmd.Flags().GetBool(skippedFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", skippedFlag, err)
+	}
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid output format %s, it should be either %s or %s", output, outputText, outputJSON)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.QueryMissedBlocks(context.Background(), fpPk, startHeight, endHeight, limit, skipped)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		printRespJSON(resp)
+		return nil
+	}
+
+	return printMissedBlocksTable(cmd.OutOrStdout(), resp)
+}
+
+func printMissedBlocksTable(out io.Writer, resp *proto.QueryMissedBlocksResponse) error {
+	if resp.Total == 0 {
+		_, err := fmt.Fprintln(out, "no missed block")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HEIGHT\tREASON\tTIME\tERROR")
+	for _, mb := range resp.MissedBlocks {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n",
+			mb.Height, mb.Reason, time.Unix(mb.Timestamp, 0).UTC().Format(time.RFC3339), mb.Error)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(out, "%d of %d missed blocks\n", len(resp.MissedBlocks), resp.Total)
+	return err
+}
@@ -0,0 +1,119 @@
+package daemon
+
+import (
+	"fmt"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+// CommandCommitPubRand returns the commit-pubrand command, which runs against
+// the database and the consumer chain without the fpd daemon.
+func CommandCommitPubRand() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:     "commit-pubrand [fp-eots-pk-hex]",
+		Aliases: []string{"cpr"},
+		Short:   "Commit public randomness for a registered finality provider once.",
+		Long: `Commit public randomness for a registered finality provider once if it is running out of
+randomness at the current tip of the consumer chain, following the randomness options of the config.
+The command runs without the fpd daemon, so that the randomness can be committed from scripts, and
+fpd should be stopped beforehand as it locks the database, while eotsd should be running.`,
+		Example: `fpd commit-pubrand [fp-eots-pk-hex] --home /home/user/.fpd --passphrase pass`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandCommitPubRand),
+	}
+	cmd.Flags().String(passphraseFlag, "", "The pass phrase used to decrypt the keys")
+	return cmd
+}
+
+func runCommandCommitPubRand(ctx client.Context, cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := cmd.Flags().GetString(passphraseFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	app, cleanUp, err := newOneShotApp(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer cleanUp()
+
+	res, err := app.CommitPubRand(fpPk, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to commit public randomness: %w", err)
+	}
+	if res == nil {
+		cmd.Println("the finality provider has sufficient public randomness, nothing is committed")
+		return nil
+	}
+
+	printRespJSON(struct {
+		TxHash string `json:"tx_hash"`
+	}{TxHash: res.TxHash})
+	return nil
+}
+
+// newOneShotApp creates a finality provider app from the config of the home
+// directory to serve a single command without the fpd daemon. Only the loops
+// handling the requests are started, so nothing is voted or committed in the
+// background. The returned function stops the app and releases its resources
+func newOneShotApp(ctx client.Context, cmd *cobra.Command) (*service.FinalityProviderApp, func(), error) {
+	cfg, db, err := loadConfigAndDb(ctx, cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger := zap.NewNop()
+	cc, err := clientcontroller.NewClientController(cfg.ChainName, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
+	if err != nil {
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainName, err)
+	}
+	if err := clientcontroller.VerifyNode(cc, cfg, logger); err != nil {
+		_ = cc.Close()
+		_ = db.Close()
+		return nil, nil, err
+	}
+
+	em, err := service.NewEOTSManager(cfg, logger)
+	if err != nil {
+		_ = cc.Close()
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to connect to the EOTS manager: %w", err)
+	}
+
+	app, err := service.NewFinalityProviderApp(cfg, cc, em, db, logger)
+	if err != nil {
+		_ = em.Close()
+		_ = cc.Close()
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to create finality-provider app: %w", err)
+	}
+	if err := app.StartOneShot(); err != nil {
+		_ = em.Close()
+		_ = cc.Close()
+		_ = db.Close()
+		return nil, nil, fmt.Errorf("failed to start the finality provider app: %w", err)
+	}
+
+	cleanUp := func() {
+		if err := app.Stop(); err != nil {
+			cmd.PrintErrf("Failed to stop the finality provider app: %v\n", err)
+		}
+		_ = cc.Close()
+		_ = db.Close()
+	}
+
+	return app, cleanUp, nil
+}
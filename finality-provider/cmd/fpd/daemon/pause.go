@@ -0,0 +1,87 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// CommandPauseFP returns the pause-finality-provider command by connecting to the fpd daemon.
+func CommandPauseFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "pause-finality-provider [eots-pk]",
+		Short: "Pause the submissions of the given finality provider.",
+		Long: `Stop voting and committing public randomness for the given finality provider
+		without stopping the daemon. The finality provider stays paused across restarts of
+		the daemon until it is resumed.`,
+		Example: fmt.Sprintf(`fpd pause-finality-provider [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandPauseFP,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	return cmd
+}
+
+func runCommandPauseFP(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return client.PauseFinalityProvider(context.Background(), args[0])
+}
+
+// CommandResumeFP returns the resume-finality-provider command by connecting to the fpd daemon.
+func CommandResumeFP() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "resume-finality-provider [eots-pk]",
+		Short: "Resume the submissions of the given paused finality provider.",
+		Long: `Resume voting and committing public randomness for the given paused finality
+		provider. The passphrase unlocks the EOTS key if the finality provider has not been
+		started since the daemon started.`,
+		Example: fmt.Sprintf(`fpd resume-finality-provider [eots-pk] --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runCommandResumeFP,
+	}
+	f := cmd.Flags()
+	f.String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	f.String(passphraseFlag, "", "The pass phrase used to decrypt the keys")
+	return cmd
+}
+
+func runCommandResumeFP(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	daemonAddress, err := flags.GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	passphrase, err := flags.GetString(passphraseFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return client.ResumeFinalityProvider(context.Background(), args[0], passphrase)
+}
@@ -0,0 +1,66 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/spf13/cobra"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// CommandProveVote returns the prove-vote command by connecting to the fpd daemon.
+func CommandProveVote() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "prove-vote [fp-eots-pk-hex] [height]",
+		Short: "Print the proof of the vote of the finality provider at a height.",
+		Long: `Print as JSON the proof of the vote of the finality provider for the block at the
+		given height, as recorded by the daemon when it submitted the vote: the block hash,
+		the public randomness with its inclusion proof, the EOTS signature, and the commitment
+		of the public randomness covering the height. Anyone can verify the signature with the
+		BTC public key and the randomness, and the randomness against the commitment on the
+		consumer chain, e.g., to settle a reward dispute.`,
+		Example: fmt.Sprintf(`fpd prove-vote [fp-eots-pk-hex] 100 --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.ExactArgs(2),
+		RunE:    runCommandProveVote,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	return cmd
+}
+
+func runCommandProveVote(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+	height, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid height %s: %w", args[1], err)
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.ProveVote(context.Background(), fpPk, height)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(resp)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package daemon
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// CommandRemoteSignerKey returns the command that prints the public key the
+// daemon authenticates with to the remote signer
+func CommandRemoteSignerKey() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "remote-signer-key",
+		Short: "Print the public key that fpd authenticates with to the remote signer.",
+		Long: `Print the hex-encoded ed25519 public key that fpd authenticates with to the remote signer
+over the remote signer protocol, generating the key at RemoteSignerKeyFile if it does not exist.
+Set it as RemoteSignerPeerPubKey in the config of the remote signer.`,
+		Example: `fpd remote-signer-key --home /home/user/.fpd`,
+		Args:    cobra.NoArgs,
+		RunE:    fpcmd.RunEWithClientCtx(runCommandRemoteSignerKey),
+	}
+	return cmd
+}
+
+func runCommandRemoteSignerKey(ctx client.Context, cmd *cobra.Command, _ []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		return fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+	keyFile := cfg.RemoteSignerKeyFile
+	if keyFile == "" {
+		keyFile = fpcfg.RemoteSignerKeyFile(homePath)
+	}
+
+	key, err := remotesigner.LoadOrGenKey(keyFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), remotesigner.PubKeyHex(key))
+	return err
+}
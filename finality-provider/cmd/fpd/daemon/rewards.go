@@ -0,0 +1,97 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+// CommandWithdrawRewards returns the withdraw-rewards command by connecting to the fpd daemon.
+func CommandWithdrawRewards() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "withdraw-rewards",
+		Short: "Withdraw the accumulated finality provider rewards on Babylon.",
+		Long: `Withdraw the finality provider rewards accumulated by the Babylon key of the daemon.
+		The rewards are sent to the address given by --recipient, or to the
+		reward address of the finality providers, or to the configured rewardrecipient, or
+		are kept in the address of the Babylon key if none is set.`,
+		Example: fmt.Sprintf(`fpd withdraw-rewards --recipient bbn1... --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandWithdrawRewards,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(recipientFlag, "", "The Babylon address to send the rewards to; the reward address of the finality providers is used if empty")
+	return cmd
+}
+
+func runCommandWithdrawRewards(cmd *cobra.Command, _ []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	recipient, err := cmd.Flags().GetString(recipientFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", recipientFlag, err)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	res, err := client.WithdrawRewards(context.Background(), recipient)
+	if err != nil {
+		return err
+	}
+
+	printRespJSON(res)
+
+	return nil
+}
+
+// CommandSetRewardAddress returns the set-reward-address command by connecting to the fpd daemon.
+func CommandSetRewardAddress() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "set-reward-address [eots-pk] [address]",
+		Short: "Set the Babylon address the rewards of the finality provider are sent to.",
+		Long: `Set the Babylon address the rewards of the finality provider are sent to upon
+		withdrawal. Without the address, the reward address is cleared and the configured
+		rewardrecipient is used instead.`,
+		Example: fmt.Sprintf(`fpd set-reward-address [eots-pk] bbn1... --daemon-address %s`, defaultFpdDaemonAddress),
+		Args:    cobra.RangeArgs(1, 2),
+		RunE:    runCommandSetRewardAddress,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	return cmd
+}
+
+func runCommandSetRewardAddress(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	rewardAddress := ""
+	if len(args) == 2 {
+		rewardAddress = args[1]
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	return client.SetRewardAddress(context.Background(), args[0], rewardAddress)
+}
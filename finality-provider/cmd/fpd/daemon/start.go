@@ -1,6 +1,7 @@
 package daemon
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -13,13 +14,28 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
 	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
 
+const (
+	// startupReportPath is the path of the metrics server that serves the
+	// readiness of the subsystems at startup
+	startupReportPath = "/startup"
+
+	startupDatabase         = "database"
+	startupConsumerChain    = "consumer chain"
+	startupEOTSManager      = "EOTS manager"
+	startupApp              = "finality provider app"
+	startupFinalityProvider = "finality provider instance"
+)
+
 // CommandStart returns the start command of fpd daemon.
 func CommandStart() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -33,6 +49,9 @@ func CommandStart() *cobra.Command {
 	cmd.Flags().String(fpEotsPkFlag, "", "The EOTS public key of the finality-provider to start")
 	cmd.Flags().String(passphraseFlag, "", "The pass phrase used to decrypt the private key")
 	cmd.Flags().String(rpcListenerFlag, "", "The address that the RPC server listens to")
+	cmd.Flags().String(restListenerFlag, "", "The address that the REST gateway listens to")
+	cmd.Flags().Bool(dryRunFlag, false, "Simulate the transactions to Babylon instead of broadcasting them")
+	cmd.Flags().Bool(readOnlyFlag, false, "Serve the queries of the state only, with all the signing and the submissions disabled")
 	return cmd
 }
 
@@ -54,12 +73,27 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to read flag %s: %w", rpcListenerFlag, err)
 	}
 
+	restListener, err := flags.GetString(restListenerFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", restListenerFlag, err)
+	}
+
 	passphrase, err := flags.GetString(passphraseFlag)
 	if err != nil {
 		return fmt.Errorf("failed to read flag %s: %w", passphraseFlag, err)
 	}
 
-	cfg, err := fpcfg.LoadConfig(homePath)
+	dryRun, err := flags.GetBool(dryRunFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", dryRunFlag, err)
+	}
+
+	readOnly, err := flags.GetBool(readOnlyFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", readOnlyFlag, err)
+	}
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
 	if err != nil {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
@@ -72,23 +106,52 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
 		cfg.RpcListener = rpcListener
 	}
 
-	logger, err := log.NewRootLoggerWithFile(fpcfg.LogFile(homePath), cfg.LogLevel)
+	if restListener != "" {
+		_, err := net.ResolveTCPAddr("tcp", restListener)
+		if err != nil {
+			return fmt.Errorf("invalid REST listener address %s, %w", restListener, err)
+		}
+		cfg.RestListener = restListener
+	}
+
+	if dryRun {
+		cfg.BabylonConfig.DryRun = true
+	}
+
+	if readOnly {
+		cfg.ReadOnly = true
+	}
+
+	logLevel, err := log.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	atomicLogLevel := zap.NewAtomicLevelAt(logLevel)
+	logger, err := log.NewRootLoggerWithFileAndLevel(fpcfg.LogFile(homePath), atomicLogLevel)
 	if err != nil {
 		return fmt.Errorf("failed to initialize the logger: %w", err)
 	}
 
-	dbBackend, err := cfg.DatabaseConfig.GetDbBackend()
-	if err != nil {
-		return fmt.Errorf("failed to create db backend: %w", err)
+	if cfg.BabylonConfig.DryRun {
+		logger.Warn("running in dry-run mode: the transactions to Babylon are simulated but not broadcast")
 	}
 
-	fpApp, err := loadApp(logger, cfg, dbBackend)
+	if cfg.ReadOnly {
+		logger.Warn("running in read-only mode: the state is served but nothing is signed or submitted")
+	}
+
+	promAddr, err := cfg.Metrics.Address()
 	if err != nil {
-		return fmt.Errorf("failed to load app: %w", err)
+		return fmt.Errorf("failed to get prometheus address: %w", err)
 	}
+	// the metrics server is started first to serve the readiness report
+	// while the other subsystems are starting
+	metricsServer := metrics.Start(promAddr, logger)
 
-	if err := startApp(fpApp, fpStr, passphrase); err != nil {
-		return fmt.Errorf("failed to start app: %w", err)
+	fpApp, dbBackend, err := startSubsystems(logger, cfg, metricsServer, fpStr, passphrase)
+	if err != nil {
+		metricsServer.Stop(context.Background())
+		return err
 	}
 
 	// Hook interceptor for os signals.
@@ -98,48 +161,135 @@ func runStartCmd(ctx client.Context, cmd *cobra.Command, args []string) error {
 	}
 
 	fpServer := service.NewFinalityProviderServer(cfg, logger, fpApp, dbBackend, shutdownInterceptor)
+	fpServer.UseMetricsServer(metricsServer)
+	fpServer.EnableConfigReload(func() (*fpcfg.Config, error) {
+		return fpcmd.LoadConfig(cmd, homePath)
+	}, atomicLogLevel)
 	return fpServer.RunUntilShutdown()
 }
 
-// loadApp initialize an finality provider app based on config and flags set.
-func loadApp(
+// startSubsystems opens the database, connects to the consumer chain and the
+// EOTS manager, and starts the app within the startup timeout of the config.
+// The readiness of each subsystem is served by the metrics server at
+// startupReportPath and, if the startup fails or times out, the subsystems
+// which are ready are released and the returned error includes the readiness
+// report
+func startSubsystems(
 	logger *zap.Logger,
 	cfg *fpcfg.Config,
-	dbBackend walletdb.DB,
-) (*service.FinalityProviderApp, error) {
-	fpApp, err := service.NewFinalityProviderAppFromConfig(cfg, dbBackend, logger)
+	metricsServer *metrics.Server,
+	fpPkStr, passphrase string,
+) (*service.FinalityProviderApp, walletdb.DB, error) {
+	subsystems := []string{startupDatabase, startupConsumerChain, startupEOTSManager, startupApp}
+	if fpPkStr != "" {
+		subsystems = append(subsystems, startupFinalityProvider)
+	}
+	tracker := service.NewStartupTracker(logger, subsystems...)
+	metricsServer.Handle(startupReportPath, tracker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.StartupTimeout)
+	defer cancel()
+
+	var (
+		dbBackend walletdb.DB
+		cc        clientcontroller.ClientController
+		em        eotsmanager.EOTSManager
+		fpApp     *service.FinalityProviderApp
+		// releases releases the subsystems which are ready, in the reverse
+		// order, if a later one fails. A subsystem which is not ready may
+		// still be starting in the background, so it is not touched
+		releases []func() error
+	)
+	err := tracker.Run(ctx, startupDatabase, func() error {
+		var err error
+		dbBackend, err = cfg.DatabaseConfig.GetDbBackend()
+		if err != nil {
+			return fmt.Errorf("failed to create db backend: %w", err)
+		}
+		dbBackend = metrics.InstrumentDb(dbBackend, metrics.FpDbName)
+		return nil
+	})
+	if err == nil {
+		releases = append(releases, dbBackend.Close)
+		err = tracker.Run(ctx, startupConsumerChain, func() error {
+			var err error
+			cc, err = clientcontroller.NewClientController(cfg.ChainName, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainName, err)
+			}
+			if _, err := cc.QueryBestBlock(); err != nil {
+				return fmt.Errorf("failed to query the consumer chain %s: %w", cfg.ChainName, err)
+			}
+			return clientcontroller.VerifyNode(cc, cfg, logger)
+		})
+	}
+	if err == nil {
+		releases = append(releases, cc.Close)
+		err = tracker.Run(ctx, startupEOTSManager, func() error {
+			var err error
+			em, err = service.NewEOTSManager(cfg, logger)
+			if err != nil {
+				return err
+			}
+			// a round-trip ensures the EOTS manager serves requests, while
+			// there is nothing to reach in read-only mode
+			if pinger, ok := em.(interface{ Ping() error }); ok {
+				if err := pinger.Ping(); err != nil {
+					_ = em.Close()
+					return fmt.Errorf("the EOTS manager is not responding: %w", err)
+				}
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		releases = append(releases, em.Close)
+		err = tracker.Run(ctx, startupApp, func() error {
+			var err error
+			fpApp, err = service.NewFinalityProviderApp(cfg, cc, em, dbBackend, logger)
+			if err != nil {
+				return fmt.Errorf("failed to create finality-provider app: %w", err)
+			}
+			if err := fpApp.Start(); err != nil {
+				return fmt.Errorf("failed to start the finality provider app: %w", err)
+			}
+			return nil
+		})
+	}
+	if err == nil && fpPkStr != "" {
+		// the EOTS manager is closed when the app is stopped
+		releases[len(releases)-1] = fpApp.Stop
+		err = tracker.Run(ctx, startupFinalityProvider, func() error {
+			return startFinalityProvider(fpApp, fpPkStr, passphrase)
+		})
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create finality-provider app: %v", err)
+		for i := len(releases) - 1; i >= 0; i-- {
+			if err := releases[i](); err != nil {
+				logger.Warn("failed to release a subsystem after the startup failure", zap.Error(err))
+			}
+		}
+		return nil, nil, fmt.Errorf("%w\nreadiness report:\n%s", err, tracker)
 	}
 
-	return fpApp, nil
+	return fpApp, dbBackend, nil
 }
 
-// startApp starts the app and the handle of finality providers if needed based on flags.
-func startApp(
+// startFinalityProvider starts handling the finality provider with the given
+// public key
+func startFinalityProvider(
 	fpApp *service.FinalityProviderApp,
 	fpPkStr, passphrase string,
 ) error {
-	// only start the app without starting any finality provider instance
-	// this is needed for new finality provider registration or unjailing
-	// finality providers
-	if err := fpApp.Start(); err != nil {
-		return fmt.Errorf("failed to start the finality provider app: %w", err)
-	}
-
-	// no fp instance will be started if public key is not specified
-	if fpPkStr == "" {
-		return nil
-	}
-
-	// start the finality-provider instance with the given public key
 	fpPk, err := types.NewBIP340PubKeyFromHex(fpPkStr)
 	if err != nil {
 		return fmt.Errorf("invalid finality provider public key %s: %w", fpPkStr, err)
 	}
 
 	if err := fpApp.StartHandlingFinalityProvider(fpPk, passphrase); err != nil {
-		if errors.Is(err, service.ErrFinalityProviderJailed) {
+		if errors.Is(err, service.ErrFinalityProviderJailed) || errors.Is(err, service.ErrFinalityProviderSlashed) ||
+			errors.Is(err, service.ErrFinalityProviderPaused) || errors.Is(err, service.ErrEmergencyStopped) ||
+			errors.Is(err, service.ErrReadOnly) {
 			fpApp.Logger().Error("failed to start finality provider", zap.Error(err))
 			// do not return error as we still want the service to start
 			return nil
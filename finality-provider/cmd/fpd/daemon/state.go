@@ -0,0 +1,211 @@
+package daemon
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// dbLockTimeout is the time to wait for the database to be unlocked by a
+// running fpd before giving up
+const dbLockTimeout = 10 * time.Second
+
+// CommandExportState returns the export-state command of fpd daemon.
+func CommandExportState() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "export-state [archive-file]",
+		Short: "Export the state of the finality provider database into an archive.",
+		Long: `Export the finality provider records, the proofs of the committed public randomness,
+and the last voted heights serving as slashing protection into a gzipped JSON archive which
+can be imported with import-state for migration or disaster recovery. Note that fpd should be
+stopped beforehand as it locks the database.`,
+		Example: `fpd export-state fpd-state.json.gz --home /home/user/.fpd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandExportState),
+	}
+	return cmd
+}
+
+func runCommandExportState(ctx client.Context, cmd *cobra.Command, args []string) error {
+	cfg, db, err := loadConfigAndDb(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	snapshot, err := store.ExportState(db)
+	if err != nil {
+		return fmt.Errorf("failed to export the state: %w", err)
+	}
+
+	if err := writeStateArchive(args[0], snapshot); err != nil {
+		return err
+	}
+
+	cmd.Printf("exported %d finality provider(s) and %d public randomness proof(s) of chain %s to %s\n",
+		len(snapshot.FinalityProviders), len(snapshot.PubRandProofs), cfg.BabylonConfig.ChainID, args[0])
+	return nil
+}
+
+// CommandImportState returns the import-state command of fpd daemon.
+func CommandImportState() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "import-state [archive-file]",
+		Short: "Import the state of the finality provider database from an archive.",
+		Long: `Import an archive written by export-state into the finality provider database.
+The import is refused if the archive belongs to another chain, if the consumer chain is behind
+the heights in the archive, which means that the node is not synced or belongs to another network,
+or if a stored finality provider has voted above the archive, as it could then vote again at
+the heights in between. Note that fpd should be stopped beforehand as it locks the database.`,
+		Example: `fpd import-state fpd-state.json.gz --home /home/user/.fpd`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandImportState),
+	}
+	cmd.Flags().Bool(forceFlag, false, "Skip the checks against the consumer chain, e.g., if it is unreachable")
+	return cmd
+}
+
+func runCommandImportState(ctx client.Context, cmd *cobra.Command, args []string) error {
+	force, err := cmd.Flags().GetBool(forceFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", forceFlag, err)
+	}
+
+	snapshot, err := readStateArchive(args[0])
+	if err != nil {
+		return err
+	}
+	fps, err := snapshot.DecodeFinalityProviders()
+	if err != nil {
+		return fmt.Errorf("invalid archive %s: %w", args[0], err)
+	}
+
+	cfg, db, err := loadConfigAndDb(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	for _, fp := range fps {
+		if fp.ChainID != cfg.BabylonConfig.ChainID {
+			return fmt.Errorf("finality provider %s in the archive belongs to chain %s instead of %s",
+				fp.GetBIP340BTCPK().MarshalHex(), fp.ChainID, cfg.BabylonConfig.ChainID)
+		}
+	}
+
+	if !force {
+		if err := checkChainNotBehind(cfg, fps); err != nil {
+			return err
+		}
+	}
+
+	if err := store.ImportState(db, snapshot); err != nil {
+		return fmt.Errorf("failed to import the state: %w", err)
+	}
+
+	cmd.Printf("imported %d finality provider(s) and %d public randomness proof(s) from %s\n",
+		len(snapshot.FinalityProviders), len(snapshot.PubRandProofs), args[0])
+	return nil
+}
+
+// checkChainNotBehind returns an error if the tip of the consumer chain is
+// below the heights processed by any of the given finality providers
+func checkChainNotBehind(cfg *fpcfg.Config, fps []*store.StoredFinalityProvider) error {
+	cc, err := clientcontroller.NewClientController(cfg.ChainName, cfg.BabylonConfig, &cfg.BTCNetParams, zap.NewNop())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the consumer chain, use --%s to skip the check: %w", forceFlag, err)
+	}
+	defer cc.Close()
+
+	tip, err := cc.QueryBestBlock()
+	if err != nil {
+		return fmt.Errorf("failed to query the consumer chain, use --%s to skip the check: %w", forceFlag, err)
+	}
+
+	for _, fp := range fps {
+		if fp.LastProcessedHeight > tip.Height {
+			return fmt.Errorf("the consumer chain at height %d is behind finality provider %s at height %d in the archive, "+
+				"check that the node is synced and belongs to the network of the archive",
+				tip.Height, fp.GetBIP340BTCPK().MarshalHex(), fp.LastProcessedHeight)
+		}
+	}
+
+	return nil
+}
+
+func loadConfigAndDb(ctx client.Context, cmd *cobra.Command) (*fpcfg.Config, kvdb.Backend, error) {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config at %s: %w", homePath, err)
+	}
+
+	// do not wait for the default timeout if the database is locked
+	dbCfg := *cfg.DatabaseConfig
+	dbCfg.DBTimeout = dbLockTimeout
+	db, err := dbCfg.GetDbBackend()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open the database, check that fpd is stopped: %w", err)
+	}
+
+	return cfg, db, nil
+}
+
+func writeStateArchive(path string, snapshot *store.StateSnapshot) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create the archive: %w", err)
+	}
+
+	zw := gzip.NewWriter(f)
+	if err := json.NewEncoder(zw).Encode(snapshot); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write the archive: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write the archive: %w", err)
+	}
+
+	return f.Close()
+}
+
+func readStateArchive(path string) (*store.StateSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the archive: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var snapshot store.StateSnapshot
+	if err := json.NewDecoder(zr).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("invalid archive %s: %w", path, err)
+	}
+
+	return &snapshot, nil
+}
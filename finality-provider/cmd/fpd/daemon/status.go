@@ -0,0 +1,112 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// CommandStatus returns the status command by connecting to the fpd daemon.
+func CommandStatus() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the finality providers and their progress on the consumer chain.",
+		Long: `Show, for each finality provider stored in the database, its status, the last height
+		covered by its committed public randomness, its last voted and processed heights, its
+		voting power at the tip of the consumer chain and the number of blocks pending to be processed.
+		The registration and the public randomness commitments whose epoch is not finalized on
+		Bitcoin yet are listed below the table.`,
+		Example: fmt.Sprintf(`fpd status --daemon-address %s --output json`, defaultFpdDaemonAddress),
+		Args:    cobra.NoArgs,
+		RunE:    runCommandStatus,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(outputFlag, outputText, fmt.Sprintf("The output format, either %s or %s", outputText, outputJSON))
+	return cmd
+}
+
+func runCommandStatus(cmd *cobra.Command, args []string) error {
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid output format %s, it should be either %s or %s", output, outputText, outputJSON)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.QueryFinalityProviderStatus(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		printRespJSON(resp)
+		return nil
+	}
+
+	return printStatusTable(cmd.OutOrStdout(), resp.FinalityProviders)
+}
+
+func printStatusTable(out io.Writer, fps []*proto.FinalityProviderStatusInfo) error {
+	if len(fps) == 0 {
+		_, err := fmt.Fprintln(out, "no finality provider is stored")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BTC PK\tADDRESS\tSTATUS\tRUNNING\tLAST COMMITTED\tLAST VOTED\tLAST PROCESSED\tVOTING POWER\tTIP\tPENDING")
+	for _, fp := range fps {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%d\t%d\t%d\t%d\t%d\t%d\n",
+			fp.BtcPkHex, fp.FpAddr, fp.Status, fp.IsRunning, fp.LastCommittedHeight,
+			fp.LastVotedHeight, fp.LastProcessedHeight, fp.VotingPower, fp.TipHeight, fp.PendingBlocks)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// the chain errors are printed below the table to keep it readable
+	for _, fp := range fps {
+		if fp.ChainError != "" {
+			fmt.Fprintf(out, "%s: %s\n", fp.BtcPkHex, fp.ChainError)
+		}
+	}
+
+	// so are the txs whose epoch is not finalized yet
+	for _, fp := range fps {
+		for _, msg := range fp.PendingEpochMsgs {
+			epoch := "an epoch not resolved yet"
+			if msg.Epoch != 0 {
+				epoch = fmt.Sprintf("epoch %d", msg.Epoch)
+			}
+			fmt.Fprintf(out, "%s: the %s %s waits for %s to be finalized\n", fp.BtcPkHex, msg.Type, msg.TxHash, epoch)
+		}
+	}
+
+	return nil
+}
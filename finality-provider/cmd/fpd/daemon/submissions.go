@@ -0,0 +1,107 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/spf13/cobra"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+)
+
+const typeFlag = "type"
+
+// CommandSubmissions returns the submissions command by connecting to the fpd daemon.
+func CommandSubmissions() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "submissions [fp-eots-pk-hex]",
+		Short: "List the txs submitted on behalf of the finality provider.",
+		Long: `List the txs the daemon submitted to the consumer chain on behalf of the finality
+		provider from the latest, with their type, heights, hash, status, and gas used. The
+		status is one of success, failed, or skipped if the tx was rejected with an expected
+		error, e.g., as the finality signature was already submitted.`,
+		Example: fmt.Sprintf(`fpd submissions [fp-eots-pk-hex] --type finality_sig --limit 10 --daemon-address %s`,
+			defaultFpdDaemonAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: runCommandSubmissions,
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().String(typeFlag, "", "The type of the txs to list, one of finality_sig, pub_rand_commit, registration, or unjail; all if empty")
+	cmd.Flags().Uint64(limitFlag, 100, "The maximum number of txs to list, 0 for no limit")
+	cmd.Flags().String(outputFlag, outputText, fmt.Sprintf("The output format, either %s or %s", outputText, outputJSON))
+	return cmd
+}
+
+func runCommandSubmissions(cmd *cobra.Command, args []string) error {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(args[0])
+	if err != nil {
+		return err
+	}
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	txType, err := cmd.Flags().GetString(typeFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", typeFlag, err)
+	}
+	limit, err := cmd.Flags().GetUint64(limitFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", limitFlag, err)
+	}
+	output, err := cmd.Flags().GetString(outputFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", outputFlag, err)
+	}
+	if output != outputText && output != outputJSON {
+		return fmt.Errorf("invalid output format %s, it should be either %s or %s", output, outputText, outputJSON)
+	}
+
+	client, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	resp, err := client.QuerySubmissions(context.Background(), fpPk, txType, limit)
+	if err != nil {
+		return err
+	}
+
+	if output == outputJSON {
+		printRespJSON(resp)
+		return nil
+	}
+
+	return printSubmissionsTable(cmd.OutOrStdout(), resp)
+}
+
+func printSubmissionsTable(out io.Writer, resp *proto.QuerySubmissionsResponse) error {
+	if len(resp.Submissions) == 0 {
+		_, err := fmt.Fprintln(out, "no submission")
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tTYPE\tHEIGHTS\tSTATUS\tGAS USED\tSIGNER\tTX HASH\tERROR")
+	for _, sub := range resp.Submissions {
+		heights := "-"
+		if sub.Height > 0 {
+			heights = fmt.Sprintf("%d-%d", sub.Height, sub.EndHeight)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			time.Unix(sub.Timestamp, 0).UTC().Format(time.RFC3339), sub.Type, heights, sub.Status, sub.GasUsed, sub.Signer, sub.TxHash, sub.Error)
+	}
+
+	return w.Flush()
+}
@@ -115,7 +115,13 @@ func rootCmd(outputBuff *bytes.Buffer) *cobra.Command {
 		daemon.CommandInit(), daemon.CommandStart(), daemon.CommandKeys(),
 		daemon.CommandGetDaemonInfo(), daemon.CommandCreateFP(), daemon.CommandLsFP(),
 		daemon.CommandInfoFP(), daemon.CommandRegisterFP(), daemon.CommandAddFinalitySig(),
-		daemon.CommandExportFP(), daemon.CommandTxs(),
+		daemon.CommandExportFP(), daemon.CommandTxs(), daemon.CommandDoctor(), daemon.CommandStatus(),
+		daemon.CommandExportState(), daemon.CommandImportState(), daemon.CommandRestore(), daemon.CommandDb(), daemon.CommandConfig(),
+		daemon.CommandExportAuditLog(), daemon.CommandMissedBlocks(), daemon.CommandFinalized(),
+		daemon.CommandWithdrawRewards(), daemon.CommandSetRewardAddress(), daemon.CommandDelegations(),
+		daemon.CommandSubmissions(), daemon.CommandProveVote(), daemon.CommandMonitorVotes(),
+		daemon.CommandEmergencyStop(), daemon.CommandResume(),
+		daemon.CommandEnterMaintenance(), daemon.CommandExitMaintenance(),
 	)
 
 	return cmd
@@ -0,0 +1,179 @@
+package daemon
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	fpcmd "github.com/babylonlabs-io/finality-provider/finality-provider/cmd"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	dc "github.com/babylonlabs-io/finality-provider/finality-provider/service/client"
+	"github.com/babylonlabs-io/finality-provider/log"
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+const (
+	intervalFlag               = "interval"
+	defaultVoteMonitorInterval = 30 * time.Second
+)
+
+// CommandMonitorVotes returns the monitor-votes command, which runs against
+// the consumer chain and the fpd daemon.
+func CommandMonitorVotes() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "monitor-votes [fp-eots-pk-hex]...",
+		Short: "Monitor the consumer chain for the votes that fpd did not sign.",
+		Long: `Scan the votes on the consumer chain for the votes of the given finality providers,
+		or all the ones of the daemon, and alert until interrupted as soon as one appears that
+		the daemon did not sign, which reveals a compromised EOTS key. The consumer chain and the
+		alerting destinations are read from the config of the home directory, and the votes
+		signed by the daemon are queried from it, so the monitor can run on another host than
+		the daemon to survive its compromise. The daemon runs the same monitor itself if
+		votemonitorinterval is set.`,
+		Example: fmt.Sprintf(`fpd monitor-votes --home /home/user/.fpd --daemon-address %s`, defaultFpdDaemonAddress),
+		RunE:    fpcmd.RunEWithClientCtx(runCommandMonitorVotes),
+	}
+	cmd.Flags().String(fpdDaemonAddressFlag, defaultFpdDaemonAddress, "The RPC server address of fpd")
+	cmd.Flags().Duration(intervalFlag, defaultVoteMonitorInterval, "The interval between each scan of the votes")
+	cmd.Flags().Uint64(startHeightFlag, 0, "The height of the first block to scan; the tip of the consumer chain if 0")
+	return cmd
+}
+
+func runCommandMonitorVotes(ctx client.Context, cmd *cobra.Command, args []string) error {
+	homePath, err := filepath.Abs(ctx.HomeDir)
+	if err != nil {
+		return err
+	}
+	homePath = util.CleanAndExpandPath(homePath)
+
+	daemonAddress, err := cmd.Flags().GetString(fpdDaemonAddressFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", fpdDaemonAddressFlag, err)
+	}
+	interval, err := cmd.Flags().GetDuration(intervalFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", intervalFlag, err)
+	}
+	startHeight, err := cmd.Flags().GetUint64(startHeightFlag)
+	if err != nil {
+		return fmt.Errorf("failed to read flag %s: %w", startHeightFlag, err)
+	}
+	pks := make([]*bbntypes.BIP340PubKey, 0, len(args))
+	for _, arg := range args {
+		pk, err := bbntypes.NewBIP340PubKeyFromHex(arg)
+		if err != nil {
+			return fmt.Errorf("invalid finality provider %s: %w", arg, err)
+		}
+		pks = append(pks, pk)
+	}
+
+	if interval <= 0 {
+		return fmt.Errorf("--%s must be positive, e.g., %v", intervalFlag, defaultVoteMonitorInterval)
+	}
+
+	cfg, err := fpcmd.LoadConfig(cmd, homePath)
+	if err != nil {
+		return err
+	}
+
+	logger, err := log.NewRootLogger("console", cfg.LogLevel, cmd.OutOrStdout())
+	if err != nil {
+		return fmt.Errorf("failed to initialize the logger: %w", err)
+	}
+	cc, err := clientcontroller.NewClientController(cfg.ChainName, cfg.BabylonConfig, &cfg.BTCNetParams, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create rpc client for the consumer chain %s: %w", cfg.ChainName, err)
+	}
+	defer func() {
+		_ = cc.Close()
+	}()
+	if err := clientcontroller.VerifyChain(cc, cfg.BabylonConfig.ChainID, cfg.BabylonConfig.GenesisHash); err != nil {
+		return err
+	}
+
+	fpdClient, cleanUp, err := dc.NewFinalityProviderServiceGRpcClient(daemonAddress)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := cleanUp(); err != nil {
+			fmt.Printf("Failed to clean up grpc client: %v\n", err)
+		}
+	}()
+
+	sigCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	records := &daemonVoteRecords{ctx: sigCtx, client: fpdClient}
+	fpPks := func() ([]*bbntypes.BIP340PubKey, error) {
+		if len(pks) > 0 {
+			return pks, nil
+		}
+		return records.fpPks()
+	}
+	alerter := service.NewAlerter(cfg.AlertingConfig, logger)
+	defer alerter.Flush()
+	monitor := service.NewVoteMonitor(cc, records, fpPks, startHeight, alerter, logger)
+
+	logger.Info("monitoring the votes on the consumer chain", zap.Float64("interval seconds", interval.Seconds()))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := monitor.Scan(); err != nil {
+			logger.Warn("failed to scan the votes on the consumer chain", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-sigCtx.Done():
+			return nil
+		}
+	}
+}
+
+// daemonVoteRecords queries the votes signed by the fpd daemon
+type daemonVoteRecords struct {
+	ctx    context.Context
+	client *dc.FinalityProviderServiceGRpcClient
+}
+
+func (r *daemonVoteRecords) GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error) {
+	res, err := r.client.QueryVotedBlock(r.ctx, bbntypes.NewBIP340PubKeyFromBTCPK(btcPk), height)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Voted {
+		return nil, nil
+	}
+
+	return hex.DecodeString(res.BlockHash)
+}
+
+func (r *daemonVoteRecords) fpPks() ([]*bbntypes.BIP340PubKey, error) {
+	res, err := r.client.QueryFinalityProviderList(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	pks := make([]*bbntypes.BIP340PubKey, 0, len(res.FinalityProviders))
+	for _, fp := range res.FinalityProviders {
+		pk, err := bbntypes.NewBIP340PubKeyFromHex(fp.BtcPkHex)
+		if err != nil {
+			return nil, err
+		}
+		pks = append(pks, pk)
+	}
+
+	return pks, nil
+}
@@ -23,6 +23,7 @@ func NewRootCmd() *cobra.Command {
 		PersistentPreRunE: fpcmd.PersistClientCtx(client.Context{}),
 	}
 	rootCmd.PersistentFlags().String(flags.FlagHome, fpcfg.DefaultFpdDir, "The application home directory")
+	rootCmd.PersistentFlags().String(fpcmd.FlagConfig, "", "The config file (INI, or TOML if it has the .toml extension), defaults to fpd.conf under the home directory")
 
 	return rootCmd
 }
@@ -34,7 +35,16 @@ func main() {
 		daemon.CommandGetDaemonInfo(), daemon.CommandCreateFP(), daemon.CommandLsFP(),
 		daemon.CommandInfoFP(), daemon.CommandRegisterFP(), daemon.CommandAddFinalitySig(),
 		daemon.CommandExportFP(), daemon.CommandTxs(), daemon.CommandUnjailFP(),
-		daemon.CommandEditFinalityDescription(),
+		daemon.CommandPauseFP(), daemon.CommandResumeFP(),
+		daemon.CommandEditFinalityDescription(), daemon.CommandDumpDefaultConfig(), daemon.CommandConfig(),
+		daemon.CommandDoctor(), daemon.CommandStatus(),
+		daemon.CommandExportState(), daemon.CommandImportState(), daemon.CommandRestore(), daemon.CommandDb(),
+		daemon.CommandExportAuditLog(), daemon.CommandMissedBlocks(), daemon.CommandFinalized(),
+		daemon.CommandWithdrawRewards(), daemon.CommandSetRewardAddress(), daemon.CommandDelegations(),
+		daemon.CommandSubmissions(), daemon.CommandRemoteSignerKey(), daemon.CommandCommitPubRand(),
+		daemon.CommandEvents(), daemon.CommandEventJournal(), daemon.CommandProveVote(), daemon.CommandMonitorVotes(),
+		daemon.CommandEmergencyStop(), daemon.CommandResume(),
+		daemon.CommandEnterMaintenance(), daemon.CommandExitMaintenance(),
 	)
 
 	if err := cmd.Execute(); err != nil {
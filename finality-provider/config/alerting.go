@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+var (
+	defaultAlertTimeout            = 10 * time.Second
+	defaultAlertCooldown           = 30 * time.Minute
+	defaultAlertSubmissionFailures = uint32(5)
+	defaultAlertMinRandHeadroom    = uint64(1000)
+)
+
+// AlertingConfig defines where and when the alerts on critical conditions
+// are sent. The alerting is disabled if no destination is set
+type AlertingConfig struct {
+	WebhookURL          string        `long:"webhookurl" description:"The URL of a webhook to which each alert is posted as JSON; Empty if disabled"`
+	SlackWebhookURL     string        `long:"slackwebhookurl" description:"The URL of a Slack incoming webhook to which each alert is posted; Empty if disabled"`
+	PagerDutyRoutingKey string        `long:"pagerdutyroutingkey" description:"The routing key of a PagerDuty Events API v2 integration which each alert triggers; Empty if disabled"`
+	StatusWebhookURL    string        `long:"statuswebhookurl" description:"The URL of a webhook to which each status change of a finality provider is posted as JSON, without cooldown; Empty if disabled"`
+	Timeout             time.Duration `long:"timeout" description:"The maximum time to wait for each destination to accept an alert"`
+	Cooldown            time.Duration `long:"cooldown" description:"The minimum time between two alerts of the same kind for the same finality provider"`
+	SubmissionFailures  uint32        `long:"submissionfailures" description:"The number of consecutive failures to submit a finality signature or public randomness after which an alert is sent"`
+	MinRandHeadroom     uint64        `long:"minrandheadroom" description:"The number of blocks above the tip covered by the committed public randomness below which an alert is sent"`
+}
+
+func DefaultAlertingConfig() AlertingConfig {
+	return AlertingConfig{
+		Timeout:            defaultAlertTimeout,
+		Cooldown:           defaultAlertCooldown,
+		SubmissionFailures: defaultAlertSubmissionFailures,
+		MinRandHeadroom:    defaultAlertMinRandHeadroom,
+	}
+}
+
+// Enabled returns whether any destination of the alerts or of the status
+// changes is set
+func (cfg *AlertingConfig) Enabled() bool {
+	return cfg.WebhookURL != "" || cfg.SlackWebhookURL != "" || cfg.PagerDutyRoutingKey != "" ||
+		cfg.StatusWebhookURL != ""
+}
+
+func (cfg *AlertingConfig) Validate() error {
+	for name, rawURL := range map[string]string{
+		"alerting.webhookurl":       cfg.WebhookURL,
+		"alerting.slackwebhookurl":  cfg.SlackWebhookURL,
+		"alerting.statuswebhookurl": cfg.StatusWebhookURL,
+	} {
+		if rawURL == "" {
+			continue
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s must be an http or https URL, e.g., https://alerts.example.com/fpd", name)
+		}
+	}
+	if cfg.Timeout <= 0 {
+		return fmt.Errorf("alerting.timeout must be positive, e.g., %v", defaultAlertTimeout)
+	}
+	if cfg.Cooldown < 0 {
+		return fmt.Errorf("alerting.cooldown can't be negative: set it to 0 to send every alert")
+	}
+	if cfg.SubmissionFailures == 0 {
+		return fmt.Errorf("alerting.submissionfailures must be positive, e.g., %d", defaultAlertSubmissionFailures)
+	}
+
+	return nil
+}
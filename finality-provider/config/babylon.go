@@ -6,6 +6,20 @@ import (
 	bbncfg "github.com/babylonlabs-io/babylon/client/config"
 )
 
+const (
+	defaultFinalitySigGasAdjustment = 1.2
+	defaultFinalitySigStaticGas     = 300000
+	defaultPubRandGasAdjustment     = 1.2
+	defaultPubRandStaticGas         = 500000
+
+	defaultCircuitBreakerThreshold     = 5
+	defaultCircuitBreakerProbeInterval = 30 * time.Second
+	defaultQueryTimeout                = 1 * time.Minute
+	defaultBroadcastTimeout            = 5 * time.Minute
+	defaultGRPCPoolSize                = uint32(4)
+	defaultLightClientTrustingPeriod   = 168 * time.Hour
+)
+
 type BBNConfig struct {
 	Key            string        `long:"key" description:"name of the key to sign transactions with"`
 	ChainID        string        `long:"chain-id" description:"chain id of the chain to connect to"`
@@ -21,6 +35,68 @@ type BBNConfig struct {
 	BlockTimeout   time.Duration `long:"block-timeout" description:"block timeout when waiting for block events"`
 	OutputFormat   string        `long:"output-format" description:"default output when printint responses"`
 	SignModeStr    string        `long:"sign-mode" description:"sign mode to use"`
+
+	// The gas limit of each transaction is estimated by simulation and multiplied
+	// by the adjustment of its message type, capped by the max gas of the type, or
+	// set to the static gas of the type if the simulation cannot be done
+	FinalitySigGasAdjustment float64 `long:"finality-sig-gas-adjustment" description:"adjustment factor of the simulated gas of finality signature transactions; gas-adjustment is used if 0"`
+	FinalitySigMaxGas        uint64  `long:"finality-sig-max-gas" description:"maximum gas limit of each finality signature; no limit if 0"`
+	FinalitySigStaticGas     uint64  `long:"finality-sig-static-gas" description:"gas limit of each finality signature used if the gas simulation cannot be done; no fallback if 0"`
+	PubRandGasAdjustment     float64 `long:"pub-rand-gas-adjustment" description:"adjustment factor of the simulated gas of public randomness commit transactions; gas-adjustment is used if 0"`
+	PubRandMaxGas            uint64  `long:"pub-rand-max-gas" description:"maximum gas limit of each public randomness commit; no limit if 0"`
+	PubRandStaticGas         uint64  `long:"pub-rand-static-gas" description:"gas limit of each public randomness commit used if the gas simulation cannot be done; no fallback if 0"`
+	MaxGas                   uint64  `long:"max-gas" description:"maximum gas limit of each message of the other types; no limit if 0"`
+	StaticGas                uint64  `long:"static-gas" description:"gas limit of each message of the other types used if the gas simulation cannot be done; no fallback if 0"`
+
+	// The submissions are paused after a number of consecutive failures and
+	// resumed once the node responds to the periodic probes
+	CircuitBreakerThreshold     uint32        `long:"circuit-breaker-threshold" description:"number of consecutive failed submissions after which the submissions are paused until the node is healthy; disabled if 0"`
+	CircuitBreakerProbeInterval time.Duration `long:"circuit-breaker-probe-interval" description:"interval of probing the node while the submissions are paused"`
+
+	// Each call to the consumer chain, which may make several requests to the
+	// node, fails once it exceeds the timeout of its kind, so that a hung node
+	// cannot block the finality provider indefinitely
+	QueryTimeout     time.Duration `long:"query-timeout" description:"deadline of each query to the consumer chain, including its retries; no deadline if 0"`
+	BroadcastTimeout time.Duration `long:"broadcast-timeout" description:"deadline of each transaction submission to the consumer chain, including its retries and waiting for its inclusion; no deadline if 0"`
+
+	// The btcstaking, finality, epoching, and checkpointing modules can be
+	// queried over the gRPC server of the node, which is faster than CometBFT
+	// RPC
+	GRPCQueries  bool   `long:"grpc-queries" description:"query the btcstaking, finality, epoching, and checkpointing modules over the grpc-address, falling back to the rpc-address if the gRPC server is unavailable"`
+	GRPCTLS      bool   `long:"grpc-tls" description:"connect to the grpc-address over TLS"`
+	GRPCPoolSize uint32 `long:"grpc-pool-size" description:"number of connections to the grpc-address used in turn by the queries"`
+
+	// The queried blocks can be verified by a CometBFT light client, which
+	// cross-checks the headers of the node with independent witnesses, so
+	// that a compromised node cannot make the finality provider vote for
+	// fake blocks
+	LightClientWitnesses      []string      `long:"light-client-witness" description:"RPC address of a node independent of rpc-address to cross-check the headers with; verifies the queried blocks with a light client if set; can be repeated"`
+	LightClientTrustedHeight  uint64        `long:"light-client-trusted-height" description:"height of the header trusted by the light client, which must be within the trusting period"`
+	LightClientTrustedHash    string        `long:"light-client-trusted-hash" description:"hex-encoded hash of the header trusted by the light client"`
+	LightClientTrustingPeriod time.Duration `long:"light-client-trusting-period" description:"period during which the validators of a verified header are trusted, which should be shorter than the unbonding period of the chain"`
+
+	// The finality signatures and public randomness commits can be signed by
+	// other funded accounts than the one of the finality provider, so they
+	// are spread over the submitter keys to be sent in parallel
+	SubmitterKeys []string `long:"submitter-key" description:"name of an additional funded key to sign finality signature and public randomness commit transactions with, in parallel with the key; can be repeated"`
+	// The key registers the finality provider and pays the fees of the other
+	// administrative transactions, so it can be kept cold while the routine
+	// submissions are signed by the submitter keys only
+	ColdKey bool `long:"cold-key" description:"sign the finality signature and public randomness commit transactions with the submitter keys only, using the key only for the registration, unjailing, editing, and reward withdrawal transactions; requires a submitter-key"`
+
+	// The chain id, and the genesis hash if set, of the node are checked at
+	// startup before any submission, so that a misconfigured rpc-address
+	// can't make the finality provider sign for another network
+	GenesisHash string `long:"genesis-hash" description:"hex-encoded hash of the block at height 1 of the chain, checked against the node at startup in addition to the chain id; not checked if empty"`
+
+	// The version of Babylon run by the node and the params of its finality
+	// module are also checked at startup, so that an incompatible node fails
+	// the startup instead of rejecting the transactions later
+	AllowIncompatibleNode bool `long:"allow-incompatible-node" description:"only log a warning instead of refusing to start if the node runs a release of Babylon incompatible with the one the finality provider is built against, or its finality params reject the configured randomness commits"`
+
+	// In dry-run mode, the transactions are built, simulated, and signed but
+	// not broadcast, and the results are logged
+	DryRun bool `long:"dry-run" description:"simulate the transactions instead of broadcasting them, to validate the configuration, keys, and randomness generation before going live"`
 }
 
 func DefaultBBNConfig() BBNConfig {
@@ -42,6 +118,22 @@ func DefaultBBNConfig() BBNConfig {
 		BlockTimeout: 1 * time.Minute,
 		OutputFormat: dc.OutputFormat,
 		SignModeStr:  dc.SignModeStr,
+		// finality signatures and public randomness commits have a stable gas
+		// usage, so a tighter adjustment than the default one is enough
+		FinalitySigGasAdjustment: defaultFinalitySigGasAdjustment,
+		FinalitySigStaticGas:     defaultFinalitySigStaticGas,
+		PubRandGasAdjustment:     defaultPubRandGasAdjustment,
+		PubRandStaticGas:         defaultPubRandStaticGas,
+
+		CircuitBreakerThreshold:     defaultCircuitBreakerThreshold,
+		CircuitBreakerProbeInterval: defaultCircuitBreakerProbeInterval,
+
+		QueryTimeout:     defaultQueryTimeout,
+		BroadcastTimeout: defaultBroadcastTimeout,
+
+		GRPCPoolSize: defaultGRPCPoolSize,
+
+		LightClientTrustingPeriod: defaultLightClientTrustingPeriod,
 	}
 }
 
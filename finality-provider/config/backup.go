@@ -0,0 +1,44 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+const (
+	defaultBackupDirname   = "backups"
+	defaultBackupInterval  = time.Hour
+	defaultBackupRetention = uint32(24)
+)
+
+// BackupConfig defines how often the finality provider database is snapshotted
+// while the daemon is running and how many snapshots are kept
+type BackupConfig struct {
+	Interval  time.Duration `long:"interval" description:"The interval between each hot snapshot of the finality provider database; 0 to disable the automatic backups"`
+	Dir       string        `long:"dir" description:"The directory in which the snapshots are written"`
+	Retention uint32        `long:"retention" description:"The number of the latest snapshots to keep, the older ones being deleted; 0 to keep all of them"`
+}
+
+func DefaultBackupConfigWithHomePath(homePath string) *BackupConfig {
+	return &BackupConfig{
+		Interval:  defaultBackupInterval,
+		Dir:       BackupDir(homePath),
+		Retention: defaultBackupRetention,
+	}
+}
+
+func BackupDir(homePath string) string {
+	return filepath.Join(homePath, defaultBackupDirname)
+}
+
+func (cfg *BackupConfig) Validate() error {
+	if cfg.Interval < 0 {
+		return fmt.Errorf("backup.interval can't be negative: set it to 0 to disable the automatic backups")
+	}
+	if cfg.Interval > 0 && cfg.Dir == "" {
+		return fmt.Errorf("backup.dir must be set to the directory of the snapshots, or set backup.interval to 0 to disable the automatic backups")
+	}
+
+	return nil
+}
@@ -1,18 +1,25 @@
 package config
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net"
+	"net/url"
+	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/jessevdk/go-flags"
 	"go.uber.org/zap/zapcore"
 
 	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/util"
 )
@@ -24,6 +31,7 @@ const (
 	defaultLogFilename             = "fpd.log"
 	defaultFinalityProviderKeyName = "finality-provider"
 	DefaultRPCPort                 = 12581
+	DefaultRESTPort                = 12583
 	defaultConfigFileName          = "fpd.conf"
 	defaultNumPubRand              = 70000 // support running of 1 week with block production time as 10s
 	defaultNumPubRandMax           = 100000
@@ -36,8 +44,17 @@ const (
 	defaultFastSyncLimit           = 10
 	defaultFastSyncGap             = 3
 	defaultMaxSubmissionRetries    = 20
+	defaultStartupTimeout          = 3 * time.Minute
+	defaultLoopStuckTimeout        = 5 * time.Minute
+	defaultTipCacheTTL             = 2 * time.Second
+	defaultEpochTrackingInterval   = time.Minute
 	defaultBitcoinNetwork          = "signet"
 	defaultDataDirname             = "data"
+	defaultAuditLogFilename        = "audit.log"
+	defaultAdminTokenFilename      = "admin_token"
+	defaultWebhookTimeout          = time.Minute
+	defaultRemoteSignerKeyFilename = "remote_signer_key.json"
+	defaultRemoteSignerTimeout     = 30 * time.Second
 )
 
 var (
@@ -49,6 +66,7 @@ var (
 	defaultBTCNetParams       = chaincfg.SigNetParams
 	defaultEOTSManagerAddress = "127.0.0.1:" + strconv.Itoa(eotscfg.DefaultRPCPort)
 	DefaultRpcListener        = "127.0.0.1:" + strconv.Itoa(DefaultRPCPort)
+	DefaultRestListener       = "127.0.0.1:" + strconv.Itoa(DefaultRESTPort)
 	DefaultDataDir            = DataDir(DefaultFpdDir)
 )
 
@@ -56,12 +74,16 @@ var (
 type Config struct {
 	LogLevel string `long:"loglevel" description:"Logging level for all subsystems" choice:"trace" choice:"debug" choice:"info" choice:"warn" choice:"error" choice:"fatal"`
 	// ChainName and ChainID (if any) of the chain config identify a consumer chain
-	ChainName                string        `long:"chainname" description:"the name of the consumer chain" choice:"babylon"`
+	ChainName                string        `long:"chainname" description:"the name of the consumer chain" choice:"babylon" choice:"mock"`
 	NumPubRand               uint32        `long:"numPubRand" description:"The number of Schnorr public randomness for each commitment"`
 	NumPubRandMax            uint32        `long:"numpubrandmax" description:"The upper bound of the number of Schnorr public randomness for each commitment"`
 	MinRandHeightGap         uint32        `long:"minrandheightgap" description:"The minimum gap between the last committed rand height and the current Babylon block height"`
+	MaxRandLookahead         uint32        `long:"maxrandlookahead" description:"The maximum number of heights above the current Babylon block height that the committed randomness may cover, which must be greater than minrandheightgap; 0 for no limit"`
+	RandOverrides            []string      `long:"randoverride" description:"Overrides numPubRand, minrandheightgap, and maxrandlookahead for a finality provider, in the form of <fp-btc-pk-hex>:numpubrand=<n>,minrandheightgap=<n>,maxrandlookahead=<n>; can be specified multiple times"`
 	StatusUpdateInterval     time.Duration `long:"statusupdateinterval" description:"The interval between each update of finality-provider status"`
 	RandomnessCommitInterval time.Duration `long:"randomnesscommitinterval" description:"The interval between each attempt to commit public randomness"`
+	RandCommitDuration       time.Duration `long:"randcommitduration" description:"The duration of blocks each commitment of public randomness should cover, with the number of randomness estimated from the observed block rate and bounded by minrandheightgap and numpubrandmax; 0 to always commit numPubRand"`
+	RandPoolSize             uint32        `long:"randpoolsize" description:"The number of public randomness commitments generated ahead of time in the background, which are also committed upon a new block once the gap to the last committed height falls below minrandheightgap; 0 to disable the pool"`
 	SubmissionRetryInterval  time.Duration `long:"submissionretryinterval" description:"The interval between each attempt to submit finality signature or public randomness after a failure"`
 	MaxSubmissionRetries     uint32        `long:"maxsubmissionretries" description:"The maximum number of retries to submit finality signature or public randomness"`
 	FastSyncInterval         time.Duration `long:"fastsyncinterval" description:"The interval between each try of fast sync, which is disabled if the value is 0"`
@@ -69,6 +91,26 @@ type Config struct {
 	FastSyncGap              uint64        `long:"fastsyncgap" description:"The block gap that will trigger the fast sync"`
 	EOTSManagerAddress       string        `long:"eotsmanageraddress" description:"The address of the remote EOTS manager; Empty if the EOTS manager is running locally"`
 	SyncFpStatusInterval     time.Duration `long:"syncfpstatusinterval" description:"The duration of time that it should sync FP status with the client blockchain"`
+	VoteSyncDepth            uint64        `long:"votesyncdepth" description:"The number of blocks below the tip of the consumer chain whose votes are read from the chain at startup to raise the last voted height, e.g., after a backup instance voted with the same key; 0 to only count the votes of this daemon"`
+	LoopStuckTimeout         time.Duration `long:"loopstucktimeout" description:"The duration beyond its interval after which a loop without progress is reported as stuck by the health endpoints"`
+	StartupTimeout           time.Duration `long:"startuptimeout" description:"The maximum time to wait for all the subsystems (database, consumer chain, EOTS manager, and finality provider) to become ready at startup"`
+	TipCacheTTL              time.Duration `long:"tipcachettl" description:"The duration for which the tip of the consumer chain is shared across the loops instead of being queried again, 0 to disable caching"`
+	AuditLogFile             string        `long:"auditlogfile" description:"The path of the append-only and hash-chained log of the signing operations; Empty if the audit log is disabled"`
+	AdminTokenFile           string        `long:"admintokenfile" description:"The path of the token authenticating the administrative RPCs, i.e., the emergency stop and resume, which is generated if it does not exist; Empty to disable these RPCs"`
+	VoteMonitorInterval      time.Duration `long:"votemonitorinterval" description:"The interval between each scan of the votes on the consumer chain for votes of the managed finality providers that the daemon did not sign, which reveal a compromised key, e.g., 30s; 0 to disable the monitor"`
+	EpochTrackingInterval    time.Duration `long:"epochtrackinginterval" description:"The interval between each check of the Babylon epochs of the registration and the public randomness commitments, which are only final once their epoch is finalized on Bitcoin; 0 to disable the tracking"`
+	ReadOnly                 bool          `long:"readonly" description:"Serve the queries of the state only, with all the signing and the submissions disabled and without connecting to the EOTS manager, e.g., for an inspection replica running against a copy of the database"`
+	RewardWithdrawInterval   time.Duration `long:"rewardwithdrawinterval" description:"The interval between each automatic withdrawal of the finality provider rewards on Babylon; 0 to disable the automatic withdrawal"`
+	RewardRecipient          string        `long:"rewardrecipient" description:"The Babylon address the withdrawn rewards are sent to; Empty if the rewards are kept in the address of the Babylon key"`
+
+	RegistrationWebhookURL     string        `long:"registrationwebhookurl" description:"The URL of the webhook which must approve each registration of a finality provider before it is submitted; Empty if the registrations do not need approval"`
+	RegistrationWebhookToken   string        `long:"registrationwebhooktoken" description:"The bearer token sent to the registration webhook to authenticate the daemon"`
+	RegistrationWebhookTimeout time.Duration `long:"registrationwebhooktimeout" description:"The maximum time to wait for the registration webhook to respond"`
+
+	RemoteSignerListener string        `long:"remotesignerlistener" description:"The listener for the remote signer to dial in over the remote signer protocol, e.g., 0.0.0.0:12584; if set, the randomness and the signatures are served by the remote signer instead of the EOTS manager at eotsmanageraddress"`
+	RemoteSignerPubKey   string        `long:"remotesignerpubkey" description:"The hex-encoded ed25519 public key the remote signer must authenticate with, as printed by eotsd remote-signer-key"`
+	RemoteSignerKeyFile  string        `long:"remotesignerkeyfile" description:"The path of the ed25519 key to authenticate with to the remote signer, which is generated if it does not exist"`
+	RemoteSignerTimeout  time.Duration `long:"remotesignertimeout" description:"The maximum time to wait for the remote signer to connect and to answer each request"`
 
 	BitcoinNetwork string `long:"bitcoinnetwork" description:"Bitcoin network to run on" choise:"mainnet" choice:"regtest" choice:"testnet" choice:"simnet" choice:"signet"`
 
@@ -80,8 +122,18 @@ type Config struct {
 
 	BabylonConfig *BBNConfig `group:"babylon" namespace:"babylon"`
 
+	AlertingConfig *AlertingConfig `group:"alerting" namespace:"alerting"`
+
+	SubmissionConfig *SubmissionConfig `group:"submission" namespace:"submission"`
+
+	BackupConfig *BackupConfig `group:"backup" namespace:"backup"`
+
+	PruneConfig *PruneConfig `group:"prune" namespace:"prune"`
+
 	RpcListener string `long:"rpclistener" description:"the listener for RPC connections, e.g., 127.0.0.1:1234"`
 
+	RestListener string `long:"restlistener" description:"the listener for the REST/JSON gateway of the RPC service, e.g., 127.0.0.1:1234; Empty if the gateway is disabled"`
+
 	Metrics *metrics.Config `group:"metrics" namespace:"metrics"`
 }
 
@@ -90,12 +142,18 @@ func DefaultConfigWithHome(homePath string) Config {
 	bbnCfg.Key = defaultFinalityProviderKeyName
 	bbnCfg.KeyDirectory = homePath
 	pollerCfg := DefaultChainPollerConfig()
+	alertingCfg := DefaultAlertingConfig()
+	submissionCfg := DefaultSubmissionConfig()
 	cfg := Config{
 		ChainName:                defaultChainName,
 		LogLevel:                 defaultLogLevel.String(),
 		DatabaseConfig:           DefaultDBConfigWithHomePath(homePath),
 		BabylonConfig:            &bbnCfg,
 		PollerConfig:             &pollerCfg,
+		AlertingConfig:           &alertingCfg,
+		SubmissionConfig:         &submissionCfg,
+		BackupConfig:             DefaultBackupConfigWithHomePath(homePath),
+		PruneConfig:              DefaultPruneConfig(),
 		NumPubRand:               defaultNumPubRand,
 		NumPubRandMax:            defaultNumPubRandMax,
 		MinRandHeightGap:         defaultMinRandHeightGap,
@@ -110,8 +168,20 @@ func DefaultConfigWithHome(homePath string) Config {
 		BTCNetParams:             defaultBTCNetParams,
 		EOTSManagerAddress:       defaultEOTSManagerAddress,
 		RpcListener:              DefaultRpcListener,
+		RestListener:             DefaultRestListener,
 		Metrics:                  metrics.DefaultFpConfig(),
 		SyncFpStatusInterval:     defaultSyncFpStatusInterval,
+		StartupTimeout:           defaultStartupTimeout,
+		LoopStuckTimeout:         defaultLoopStuckTimeout,
+		TipCacheTTL:              defaultTipCacheTTL,
+		EpochTrackingInterval:    defaultEpochTrackingInterval,
+		AuditLogFile:             AuditLogFile(homePath),
+		AdminTokenFile:           AdminTokenFile(homePath),
+
+		RegistrationWebhookTimeout: defaultWebhookTimeout,
+
+		RemoteSignerKeyFile: RemoteSignerKeyFile(homePath),
+		RemoteSignerTimeout: defaultRemoteSignerTimeout,
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -141,28 +211,39 @@ func DataDir(homePath string) string {
 	return filepath.Join(homePath, defaultDataDirname)
 }
 
-// LoadConfig initializes and parses the config using a config file and command
-// line options.
+func RemoteSignerKeyFile(homePath string) string {
+	return filepath.Join(homePath, defaultRemoteSignerKeyFilename)
+}
+
+func AuditLogFile(homePath string) string {
+	return filepath.Join(DataDir(homePath), defaultAuditLogFilename)
+}
+
+func AdminTokenFile(homePath string) string {
+	return filepath.Join(homePath, defaultAdminTokenFilename)
+}
+
+// LoadConfig initializes and parses the config using the config file under the
+// home directory and the environment.
+func LoadConfig(homePath string) (*Config, error) {
+	return LoadConfigFromFile(homePath, ConfigFile(homePath))
+}
+
+// LoadConfigFromFile initializes and parses the config using the given config
+// file and the environment.
 //
 // The configuration proceeds as follows:
 //  1. Start with a default config with sane settings
-//  2. Pre-parse the command line to check for an alternative config file
-//  3. Load configuration file overwriting defaults with any specified options
-//  4. Parse CLI options and overwrite/add any specified options
-func LoadConfig(homePath string) (*Config, error) {
-	// The home directory is required to have a configuration file with a specific name
-	// under it.
-	cfgFile := ConfigFile(homePath)
-	if !util.FileExists(cfgFile) {
-		return nil, fmt.Errorf("specified config file does "+
-			"not exist in %s", cfgFile)
+//  2. Load configuration file overwriting defaults with any specified options.
+//     The file is parsed as TOML if it has the .toml extension, or as INI otherwise
+//  3. Overwrite the options with any FPD_-prefixed environment variables
+func LoadConfigFromFile(homePath, cfgFile string) (*Config, error) {
+	cfg, err := ParseConfigFile(homePath, cfgFile)
+	if err != nil {
+		return nil, err
 	}
 
-	// Next, load any additional configuration options from the file.
-	var cfg Config
-	fileParser := flags.NewParser(&cfg, flags.Default)
-	err := flags.NewIniParser(fileParser).ParseFile(cfgFile)
-	if err != nil {
+	if err := applyEnvOverrides(cfg); err != nil {
 		return nil, err
 	}
 
@@ -171,6 +252,37 @@ func LoadConfig(homePath string) (*Config, error) {
 		return nil, err
 	}
 
+	return cfg, nil
+}
+
+// ParseConfigFile parses the given config file on top of the default config
+// without applying the environment overrides and validation. It is meant for
+// commands that update the config file in place.
+func ParseConfigFile(homePath, cfgFile string) (*Config, error) {
+	if !util.FileExists(cfgFile) {
+		return nil, fmt.Errorf("specified config file does "+
+			"not exist in %s", cfgFile)
+	}
+
+	cfg := DefaultConfigWithHome(homePath)
+	fileParser := flags.NewParser(&cfg, flags.Default)
+
+	if strings.EqualFold(filepath.Ext(cfgFile), tomlConfigFileExt) {
+		f, err := os.Open(cfgFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		if err := parseTOML(fileParser, f); err != nil {
+			return nil, fmt.Errorf("failed to parse TOML config file %s: %w", cfgFile, err)
+		}
+	} else {
+		if err := flags.NewIniParser(fileParser).ParseFile(cfgFile); err != nil {
+			return nil, err
+		}
+	}
+
 	return &cfg, nil
 }
 
@@ -178,15 +290,205 @@ func LoadConfig(homePath string) (*Config, error) {
 // illegal values or a combination of values are set. All file system paths are
 // normalized. The cleaned up config is returned on success.
 func (cfg *Config) Validate() error {
-	if cfg.EOTSManagerAddress == "" {
-		return fmt.Errorf("EOTS manager address not specified")
+	if _, err := log.ParseLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf("invalid loglevel %q: use one of debug, info, warn, error, fatal, or panic", cfg.LogLevel)
+	}
+
+	if cfg.RemoteSignerListener != "" {
+		if _, err := net.ResolveTCPAddr("tcp", cfg.RemoteSignerListener); err != nil {
+			return fmt.Errorf("invalid remotesignerlistener %s: %w", cfg.RemoteSignerListener, err)
+		}
+		if _, err := remotesigner.ParsePubKey(cfg.RemoteSignerPubKey); err != nil {
+			return fmt.Errorf("invalid remotesignerpubkey: set it to the key printed by eotsd remote-signer-key on the remote signer: %w", err)
+		}
+		if cfg.RemoteSignerKeyFile == "" {
+			return fmt.Errorf("remotesignerkeyfile must be set to serve the signatures by a remote signer")
+		}
+		if cfg.RemoteSignerTimeout <= 0 {
+			return fmt.Errorf("remotesignertimeout must be positive, e.g., %v", defaultRemoteSignerTimeout)
+		}
+	} else if cfg.EOTSManagerAddress == "" {
+		return fmt.Errorf("EOTS manager address not specified: set eotsmanageraddress to the RPC address of eotsd, e.g., %s", defaultEOTSManagerAddress)
+	}
+
+	if err := cfg.validateRandConfig(cfg.RandConfigFor(""), ""); err != nil {
+		return err
+	}
+	for _, entry := range cfg.RandOverrides {
+		pkHex, _, err := parseRandOverride(entry)
+		if err != nil {
+			return fmt.Errorf("invalid randoverride %q: %w", entry, err)
+		}
+		if err := cfg.validateRandConfig(cfg.RandConfigFor(pkHex), pkHex); err != nil {
+			return err
+		}
+	}
+
+	if cfg.RandomnessCommitInterval <= 0 {
+		return fmt.Errorf("randomnesscommitinterval must be positive, e.g., %v", defaultRandomInterval)
+	}
+	if cfg.RandCommitDuration < 0 {
+		return fmt.Errorf("randcommitduration can't be negative: set it to 0 to always commit numPubRand")
+	}
+	if cfg.SubmissionRetryInterval <= 0 {
+		return fmt.Errorf("submissionretryinterval must be positive, e.g., %v", defaultSubmitRetryInterval)
+	}
+	if cfg.SyncFpStatusInterval <= 0 {
+		return fmt.Errorf("syncfpstatusinterval must be positive, e.g., %v", defaultSyncFpStatusInterval)
+	}
+	if cfg.StartupTimeout <= 0 {
+		return fmt.Errorf("startuptimeout must be positive, e.g., %v", defaultStartupTimeout)
+	}
+	if cfg.LoopStuckTimeout <= 0 {
+		return fmt.Errorf("loopstucktimeout must be positive, e.g., %v", defaultLoopStuckTimeout)
+	}
+	if cfg.TipCacheTTL < 0 {
+		return fmt.Errorf("tipcachettl must not be negative, e.g., %v", defaultTipCacheTTL)
+	}
+	if cfg.VoteMonitorInterval < 0 {
+		return fmt.Errorf("votemonitorinterval can't be negative: set it to 0 to disable the monitor")
 	}
+	if cfg.EpochTrackingInterval < 0 {
+		return fmt.Errorf("epochtrackinginterval can't be negative: set it to 0 to disable the tracking")
+	}
+	if cfg.RewardWithdrawInterval < 0 {
+		return fmt.Errorf("rewardwithdrawinterval can't be negative: set it to 0 to disable the automatic withdrawal")
+	}
+	if cfg.RegistrationWebhookURL != "" {
+		u, err := url.Parse(cfg.RegistrationWebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("registrationwebhookurl must be an http or https URL, e.g., https://approvals.example.com/fpd")
+		}
+		if cfg.RegistrationWebhookTimeout <= 0 {
+			return fmt.Errorf("registrationwebhooktimeout must be positive, e.g., %v", defaultWebhookTimeout)
+		}
+	}
+	if cfg.StatusUpdateInterval < 0 {
+		return fmt.Errorf("statusupdateinterval can't be negative: set it to 0 to disable the status update")
+	}
+	if cfg.FastSyncInterval < 0 {
+		return fmt.Errorf("fastsyncinterval can't be negative: set it to 0 to disable the fast sync")
+	}
+	if cfg.FastSyncInterval > 0 && cfg.FastSyncLimit == 0 {
+		return fmt.Errorf("fastsynclimit must be positive when the fast sync is enabled: set it, e.g., to %d, or set fastsyncinterval to 0", defaultFastSyncLimit)
+	}
+
+	if cfg.PollerConfig == nil {
+		return fmt.Errorf("empty chain poller config")
+	}
+	if cfg.PollerConfig.BufferSize == 0 {
+		return fmt.Errorf("chainpollerconfig.buffersize must be positive, e.g., %d", defaultBufferSize)
+	}
+	if cfg.PollerConfig.PollInterval <= 0 {
+		return fmt.Errorf("chainpollerconfig.pollinterval must be positive, e.g., %v", defaultPollingInterval)
+	}
+	switch cfg.PollerConfig.StartHeightMode {
+	case StartHeightAuto, StartHeightActivation, StartHeightLastVoted, StartHeightStatic, StartHeightTip:
+	default:
+		return fmt.Errorf("chainpollerconfig.startheightmode must be one of %s, %s, %s, %s, or %s, got %q",
+			StartHeightAuto, StartHeightActivation, StartHeightLastVoted, StartHeightStatic, StartHeightTip,
+			cfg.PollerConfig.StartHeightMode)
+	}
+	switch cfg.PollerConfig.OverflowPolicy {
+	case PollerOverflowBlock, PollerOverflowDropOldest:
+	default:
+		return fmt.Errorf("chainpollerconfig.overflowpolicy must be either %s or %s, got %q",
+			PollerOverflowBlock, PollerOverflowDropOldest, cfg.PollerConfig.OverflowPolicy)
+	}
+
+	if cfg.DatabaseConfig == nil {
+		return fmt.Errorf("empty database config")
+	}
+	if cfg.DatabaseConfig.DBPath == "" || cfg.DatabaseConfig.DBFileName == "" {
+		return fmt.Errorf("dbconfig.dbpath and dbconfig.dbfilename must be set to the location of the database file")
+	}
+
+	if cfg.BabylonConfig == nil {
+		return fmt.Errorf("empty babylon config")
+	}
+	bbnCfg := BBNConfigToBabylonConfig(cfg.BabylonConfig)
+	if err := bbnCfg.Validate(); err != nil {
+		return fmt.Errorf("invalid babylon config: %w", err)
+	}
+	if cfg.BabylonConfig.FinalitySigGasAdjustment < 0 || cfg.BabylonConfig.PubRandGasAdjustment < 0 {
+		return fmt.Errorf("babylon.finality-sig-gas-adjustment and babylon.pub-rand-gas-adjustment can't be negative: set them to 0 to use babylon.gas-adjustment")
+	}
+	if cfg.BabylonConfig.QueryTimeout < 0 || cfg.BabylonConfig.BroadcastTimeout < 0 {
+		return fmt.Errorf("babylon.query-timeout and babylon.broadcast-timeout can't be negative: set them to 0 to disable the deadlines")
+	}
+	if cfg.BabylonConfig.GRPCQueries && (cfg.BabylonConfig.GRPCAddr == "" || cfg.BabylonConfig.GRPCPoolSize == 0) {
+		return fmt.Errorf("babylon.grpc-address and babylon.grpc-pool-size must be set to query over gRPC, e.g., %d connections, or set babylon.grpc-queries to false", defaultGRPCPoolSize)
+	}
+	if cfg.BabylonConfig.GenesisHash != "" {
+		if hash, err := hex.DecodeString(cfg.BabylonConfig.GenesisHash); err != nil || len(hash) != 32 {
+			return fmt.Errorf("babylon.genesis-hash must be the hex-encoded 32-byte hash of the block at height 1, or empty")
+		}
+	}
+	if len(cfg.BabylonConfig.LightClientWitnesses) > 0 {
+		if cfg.BabylonConfig.LightClientTrustedHeight == 0 {
+			return fmt.Errorf("babylon.light-client-trusted-height must be set to verify the blocks with a light client")
+		}
+		if hash, err := hex.DecodeString(cfg.BabylonConfig.LightClientTrustedHash); err != nil || len(hash) != 32 {
+			return fmt.Errorf("babylon.light-client-trusted-hash must be the hex-encoded 32-byte hash of the header at babylon.light-client-trusted-height")
+		}
+		if cfg.BabylonConfig.LightClientTrustingPeriod <= 0 {
+			return fmt.Errorf("babylon.light-client-trusting-period must be positive, e.g., %v", defaultLightClientTrustingPeriod)
+		}
+	}
+	submitterKeys := map[string]bool{cfg.BabylonConfig.Key: true}
+	for _, key := range cfg.BabylonConfig.SubmitterKeys {
+		if submitterKeys[key] {
+			return fmt.Errorf("babylon.submitter-key %s is repeated: each submitter key must differ from babylon.key and the other submitter keys", key)
+		}
+		submitterKeys[key] = true
+	}
+	if cfg.BabylonConfig.ColdKey && len(cfg.BabylonConfig.SubmitterKeys) == 0 {
+		return fmt.Errorf("babylon.cold-key requires a babylon.submitter-key to sign the finality signatures and public randomness commits")
+	}
+	if cfg.BabylonConfig.CircuitBreakerThreshold > 0 && cfg.BabylonConfig.CircuitBreakerProbeInterval <= 0 {
+		return fmt.Errorf("babylon.circuit-breaker-probe-interval must be positive, e.g., %v, or set babylon.circuit-breaker-threshold to 0 to disable the circuit breaker", defaultCircuitBreakerProbeInterval)
+	}
+	if cfg.RewardRecipient != "" {
+		hrp, _, err := bech32.DecodeAndConvert(cfg.RewardRecipient)
+		if err != nil || hrp != cfg.BabylonConfig.AccountPrefix {
+			return fmt.Errorf("rewardrecipient must be a Babylon address with the prefix %s", cfg.BabylonConfig.AccountPrefix)
+		}
+	}
+
+	if cfg.AlertingConfig == nil {
+		return fmt.Errorf("empty alerting config")
+	}
+	if err := cfg.AlertingConfig.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.SubmissionConfig == nil {
+		return fmt.Errorf("empty submission config")
+	}
+	if err := cfg.SubmissionConfig.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.BackupConfig == nil {
+		return fmt.Errorf("empty backup config")
+	}
+	if err := cfg.BackupConfig.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.PruneConfig == nil {
+		return fmt.Errorf("empty prune config")
+	}
+	if err := cfg.PruneConfig.Validate(); err != nil {
+		return err
+	}
+
 	// Multiple networks can't be selected simultaneously.  Count number of
 	// network flags passed; assign active network params
 	// while we're at it.
 	btcNetConfig, err := NetParamsBTC(cfg.BitcoinNetwork)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: set bitcoinnetwork to one of mainnet, testnet, regtest, simnet, or signet", err)
 	}
 	cfg.BTCNetParams = btcNetConfig
 
@@ -195,12 +497,19 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid RPC listener address %s, %w", cfg.RpcListener, err)
 	}
 
+	if cfg.RestListener != "" {
+		_, err = net.ResolveTCPAddr("tcp", cfg.RestListener)
+		if err != nil {
+			return fmt.Errorf("invalid REST listener address %s, %w", cfg.RestListener, err)
+		}
+	}
+
 	if cfg.Metrics == nil {
 		return fmt.Errorf("empty metrics config")
 	}
 
 	if err := cfg.Metrics.Validate(); err != nil {
-		return fmt.Errorf("invalid metrics config")
+		return fmt.Errorf("invalid metrics config: %w", err)
 	}
 
 	// All good, return the sanitized result.
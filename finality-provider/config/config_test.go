@@ -0,0 +1,166 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+// testFpPkHex is the x-only public key of the secp256k1 generator
+const testFpPkHex = "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"
+
+func TestValidateConfig(t *testing.T) {
+	homePath := t.TempDir()
+
+	testCases := []struct {
+		name   string
+		modify func(cfg *config.Config)
+		errMsg string
+	}{
+		{"valid default config", func(cfg *config.Config) {}, ""},
+		{"unsupported log level", func(cfg *config.Config) { cfg.LogLevel = "trace" }, "loglevel"},
+		{"zero randomness", func(cfg *config.Config) { cfg.NumPubRand = 0 }, "numPubRand"},
+		{"too much randomness", func(cfg *config.Config) { cfg.NumPubRand = cfg.NumPubRandMax + 1 }, "numpubrandmax"},
+		{"zero commit interval", func(cfg *config.Config) { cfg.RandomnessCommitInterval = 0 }, "randomnesscommitinterval"},
+		{"lookahead within gap", func(cfg *config.Config) { cfg.MaxRandLookahead = cfg.MinRandHeightGap }, "maxrandlookahead"},
+		{"valid rand override", func(cfg *config.Config) {
+			cfg.RandOverrides = []string{testFpPkHex + ":numpubrand=100,minrandheightgap=50"}
+		}, ""},
+		{"malformed rand override", func(cfg *config.Config) { cfg.RandOverrides = []string{testFpPkHex + ":numpubrand"} }, "randoverride"},
+		{"unknown rand override", func(cfg *config.Config) { cfg.RandOverrides = []string{testFpPkHex + ":foo=1"} }, "unknown option foo"},
+		{"too much overridden randomness", func(cfg *config.Config) {
+			cfg.RandOverrides = []string{testFpPkHex + ":numpubrand=" + strings.Repeat("9", 7)}
+		}, "numpubrandmax"},
+		{"negative commit duration", func(cfg *config.Config) { cfg.RandCommitDuration = -time.Hour }, "randcommitduration"},
+		{"negative reward withdraw interval", func(cfg *config.Config) { cfg.RewardWithdrawInterval = -time.Hour }, "rewardwithdrawinterval"},
+		{"reward recipient of another chain", func(cfg *config.Config) {
+			cfg.RewardRecipient = "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"
+		}, "rewardrecipient"},
+		{"reward recipient", func(cfg *config.Config) { cfg.RewardRecipient = "bbn1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5gua0e9" }, ""},
+		{"zero fast sync limit", func(cfg *config.Config) { cfg.FastSyncLimit = 0 }, "fastsynclimit"},
+		{"disabled fast sync", func(cfg *config.Config) { cfg.FastSyncInterval, cfg.FastSyncLimit = 0, 0 }, ""},
+		{"zero poll interval", func(cfg *config.Config) { cfg.PollerConfig.PollInterval = 0 }, "pollinterval"},
+		{"unknown poller overflow policy", func(cfg *config.Config) { cfg.PollerConfig.OverflowPolicy = "drop-newest" }, "overflowpolicy"},
+		{"unknown poller start height mode", func(cfg *config.Config) { cfg.PollerConfig.StartHeightMode = "genesis" }, "startheightmode"},
+		{"zero babylon timeout", func(cfg *config.Config) { cfg.BabylonConfig.Timeout = 0 }, "babylon"},
+		{"negative query timeout", func(cfg *config.Config) { cfg.BabylonConfig.QueryTimeout = -time.Second }, "query-timeout"},
+		{"disabled broadcast timeout", func(cfg *config.Config) { cfg.BabylonConfig.BroadcastTimeout = 0 }, ""},
+		{"grpc queries without connections", func(cfg *config.Config) {
+			cfg.BabylonConfig.GRPCQueries, cfg.BabylonConfig.GRPCPoolSize = true, 0
+		}, "grpc-pool-size"},
+		{"light client without trusted header", func(cfg *config.Config) {
+			cfg.BabylonConfig.LightClientWitnesses = []string{"http://127.0.0.1:36657"}
+		}, "light-client-trusted-height"},
+		{"repeated submitter key", func(cfg *config.Config) { cfg.BabylonConfig.SubmitterKeys = []string{cfg.BabylonConfig.Key} }, "submitter-key"},
+		{"distinct submitter keys", func(cfg *config.Config) { cfg.BabylonConfig.SubmitterKeys = []string{"submitter-1", "submitter-2"} }, ""},
+		{"cold key without submitter keys", func(cfg *config.Config) { cfg.BabylonConfig.ColdKey = true }, "cold-key"},
+		{"cold key with submitter keys", func(cfg *config.Config) {
+			cfg.BabylonConfig.ColdKey = true
+			cfg.BabylonConfig.SubmitterKeys = []string{"submitter-1"}
+		}, ""},
+		{"remote signer without public key", func(cfg *config.Config) { cfg.RemoteSignerListener = "0.0.0.0:12584" }, "remotesignerpubkey"},
+		{"remote signer without eots manager", func(cfg *config.Config) {
+			cfg.RemoteSignerListener = "0.0.0.0:12584"
+			cfg.RemoteSignerPubKey = strings.Repeat("ab", 32)
+			cfg.EOTSManagerAddress = ""
+		}, ""},
+		{"unknown bitcoin network", func(cfg *config.Config) { cfg.BitcoinNetwork = "foo" }, "bitcoinnetwork"},
+		{"invalid registration webhook", func(cfg *config.Config) { cfg.RegistrationWebhookURL = "localhost:8080" }, "registrationwebhookurl"},
+		{"valid registration webhook", func(cfg *config.Config) { cfg.RegistrationWebhookURL = "http://localhost:8080/approve" }, ""},
+		{"invalid slack webhook", func(cfg *config.Config) { cfg.AlertingConfig.SlackWebhookURL = "hooks.slack.com" }, "alerting.slackwebhookurl"},
+		{"invalid status webhook", func(cfg *config.Config) { cfg.AlertingConfig.StatusWebhookURL = "ftp://status.example.com" }, "alerting.statuswebhookurl"},
+		{"zero alert timeout", func(cfg *config.Config) { cfg.AlertingConfig.Timeout = 0 }, "alerting.timeout"},
+		{"shrinking retry backoff", func(cfg *config.Config) { cfg.SubmissionConfig.BackoffMultiplier = 0.5 }, "submission.backoffmultiplier"},
+		{"negative finality sig retry duration", func(cfg *config.Config) { cfg.SubmissionConfig.FinalitySigMaxDuration = -time.Minute }, "submission.finalitysigmaxduration"},
+		{"backups without directory", func(cfg *config.Config) { cfg.BackupConfig.Dir = "" }, "backup.dir"},
+		{"disabled backups without directory", func(cfg *config.Config) {
+			cfg.BackupConfig.Interval = 0
+			cfg.BackupConfig.Dir = ""
+		}, ""},
+		{"pruning without depth", func(cfg *config.Config) { cfg.PruneConfig.Depth = 0 }, "prune.depth"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.DefaultConfigWithHome(homePath)
+			tc.modify(&cfg)
+			err := cfg.Validate()
+			if tc.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
+
+func TestApplyReloadable(t *testing.T) {
+	homePath := t.TempDir()
+	cfg := config.DefaultConfigWithHome(homePath)
+
+	newCfg := config.DefaultConfigWithHome(homePath)
+	require.Empty(t, cfg.ApplyReloadable(&newCfg))
+
+	newCfg.LogLevel = "debug"
+	newCfg.RandomnessCommitInterval = time.Minute
+	newCfg.PollerConfig.PollInterval = time.Second
+	newCfg.SubmissionConfig.BackoffMultiplier = 2
+	// structural options are not reloaded
+	newCfg.RpcListener = "127.0.0.1:1234"
+	newCfg.DatabaseConfig.DBFileName = "other.db"
+
+	updated := cfg.ApplyReloadable(&newCfg)
+	require.ElementsMatch(t, []string{"loglevel", "randomnesscommitinterval", "chainpollerconfig.pollinterval", "submission"}, updated)
+	require.Equal(t, "debug", cfg.GetLogLevel())
+	require.Equal(t, time.Minute, cfg.GetRandomnessCommitInterval())
+	require.Equal(t, time.Second, cfg.PollerConfig.GetPollInterval())
+	require.Equal(t, float64(2), cfg.FinalitySigRetryPolicy().Multiplier)
+	require.Equal(t, config.DefaultRpcListener, cfg.RpcListener)
+	require.NotEqual(t, "other.db", cfg.DatabaseConfig.DBFileName)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	cfg := config.DefaultConfigWithHome(t.TempDir())
+	cfg.SubmissionRetryInterval = time.Second
+	cfg.MaxSubmissionRetries = 10
+	cfg.SubmissionConfig.BackoffMultiplier = 2
+	cfg.SubmissionConfig.MaxRetryInterval = 5 * time.Second
+	cfg.SubmissionConfig.MaxDuration = time.Minute
+	cfg.SubmissionConfig.PubRandMaxRetries = 3
+
+	// the interval grows up to the max interval
+	policy := cfg.FinalitySigRetryPolicy()
+	require.Equal(t, time.Second, policy.Delay(1))
+	require.Equal(t, 2*time.Second, policy.Delay(2))
+	require.Equal(t, 4*time.Second, policy.Delay(3))
+	require.Equal(t, 5*time.Second, policy.Delay(4))
+	require.Equal(t, 5*time.Second, policy.Delay(100))
+
+	// the general limits apply unless overridden for the message type
+	require.NoError(t, policy.Exhausted(10, 59*time.Second))
+	require.ErrorContains(t, policy.Exhausted(11, 0), "max failed cycles")
+	require.ErrorContains(t, policy.Exhausted(1, time.Minute), "max retry duration")
+	policy = cfg.PubRandRetryPolicy()
+	require.NoError(t, policy.Exhausted(3, 0))
+	require.ErrorContains(t, policy.Exhausted(4, 0), "max failed cycles 3")
+}
+
+func TestRandConfigFor(t *testing.T) {
+	cfg := config.DefaultConfigWithHome(t.TempDir())
+	cfg.MaxRandLookahead = 100000
+	cfg.RandOverrides = []string{strings.ToUpper(testFpPkHex) + ":numpubrand=1000, maxrandlookahead=2000"}
+
+	randCfg := cfg.RandConfigFor(testFpPkHex)
+	require.Equal(t, uint32(1000), randCfg.NumPubRand)
+	require.Equal(t, cfg.MinRandHeightGap, randCfg.MinRandHeightGap)
+	require.Equal(t, uint32(2000), randCfg.MaxRandLookahead)
+
+	// the other finality providers use the global config
+	randCfg = cfg.RandConfigFor(strings.Repeat("0", 64))
+	require.Equal(t, cfg.NumPubRand, randCfg.NumPubRand)
+	require.Equal(t, cfg.MaxRandLookahead, randCfg.MaxRandLookahead)
+}
@@ -0,0 +1,67 @@
+package config_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestCompactDB(t *testing.T) {
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+
+	// fill the database and delete the records to leave free pages behind
+	db, err := cfg.GetDbBackend()
+	require.NoError(t, err)
+	bucketName := []byte("bucket")
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(bucketName)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < 1000; i++ {
+			if err := bucket.Put([]byte{byte(i >> 8), byte(i)}, make([]byte, 1024)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, func() {})
+	require.NoError(t, err)
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		return tx.DeleteTopLevelBucket(bucketName)
+	}, func() {})
+	require.NoError(t, err)
+
+	// the compaction fails while the database is in use
+	cfg.DBTimeout = 100 * time.Millisecond
+	_, _, err = cfg.Compact()
+	require.Error(t, err)
+	require.NoError(t, db.Close())
+
+	// the automatic compaction is skipped below the minimum size
+	info, err := os.Stat(cfg.DBFilePath())
+	require.NoError(t, err)
+	cfg.AutoCompact = true
+	cfg.AutoCompactMinAge = 0
+	cfg.AutoCompactMinSize = uint64(info.Size()) + 1
+	db, err = cfg.GetDbBackend()
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+	sizeAfterOpen, err := os.Stat(cfg.DBFilePath())
+	require.NoError(t, err)
+	require.Equal(t, info.Size(), sizeAfterOpen.Size())
+
+	before, after, err := cfg.Compact()
+	require.NoError(t, err)
+	require.Equal(t, uint64(info.Size()), before)
+	require.Less(t, after, before)
+
+	// the compacted database is still usable
+	db, err = cfg.GetDbBackend()
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
@@ -0,0 +1,218 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/babylonlabs-io/finality-provider/util"
+)
+
+// appOptionsSection is the INI section of the top-level options
+const appOptionsSection = "Application Options"
+
+// optionMigration moves or removes an option of an earlier schema of the
+// config file
+type optionMigration struct {
+	// section and key identify the option in the earlier schema, matched
+	// case-insensitively
+	section, key string
+	// newSection and newKey identify the option in the current schema, or
+	// are empty if the option was removed
+	newSection, newKey string
+	// reason explains why the option was removed
+	reason string
+}
+
+// optionMigrations are the options of the earlier releases which are renamed
+// or removed in the current schema. The options of the sections added since
+// are filled in with their defaults
+var optionMigrations = []optionMigration{
+	{section: "databaseconfig", key: "path", newSection: "dbconfig", newKey: "DBPath"},
+	{section: "databaseconfig", key: "name", newSection: "dbconfig", newKey: "DBFileName"},
+	{section: "databaseconfig", key: "backend", reason: "bbolt is the only database backend"},
+	{section: appOptionsSection, key: "maxnumfinalityproviders", reason: "the daemon runs a single finality provider"},
+	{section: appOptionsSection, key: "batchsubmissionsize", reason: "it is no longer used"},
+}
+
+// iniOption is an option of an INI config file with its raw value
+type iniOption struct {
+	section, key, value string
+}
+
+// MigrateConfigFile parses the given config file written for an earlier
+// release on top of the default config. The renamed options are moved to
+// their current names, and the removed and unknown ones are dropped instead
+// of failing the parsing. It returns the migrated config with a note for each
+// option changed. Like ParseConfigFile, the file is parsed as TOML if it has
+// the .toml extension, or as INI otherwise
+func MigrateConfigFile(homePath, cfgFile string) (*Config, []string, error) {
+	if !util.FileExists(cfgFile) {
+		return nil, nil, fmt.Errorf("specified config file does "+
+			"not exist in %s", cfgFile)
+	}
+
+	cfg := DefaultConfigWithHome(homePath)
+	parser := flags.NewParser(&cfg, flags.None)
+
+	f, err := os.Open(cfgFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.EqualFold(filepath.Ext(cfgFile), tomlConfigFileExt) {
+		buf, err := tomlToIni(parser, f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse TOML config file %s: %w", cfgFile, err)
+		}
+		r = buf
+	}
+
+	opts, err := readIniOptions(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file %s: %w", cfgFile, err)
+	}
+
+	opts, notes := migrateOptions(parser, opts)
+
+	var buf bytes.Buffer
+	for _, section := range sectionsOf(opts) {
+		fmt.Fprintf(&buf, "[%s]\n", section)
+		for _, opt := range opts {
+			if opt.section == section {
+				fmt.Fprintf(&buf, "%s = %s\n", opt.key, opt.value)
+			}
+		}
+	}
+	if err := flags.NewIniParser(parser).Parse(&buf); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the migrated config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("the migrated config is invalid: %w", err)
+	}
+
+	return &cfg, notes, nil
+}
+
+// migrateOptions moves the renamed options and drops the removed and unknown
+// ones
+func migrateOptions(parser *flags.Parser, opts []*iniOption) ([]*iniOption, []string) {
+	// the sections of the known options by their keys, to write the
+	// sections as the parser expects them
+	known := make(map[string]string)
+	eachOption(parser, func(g *flags.Group, opt *flags.Option) {
+		known[optionKey(g.ShortDescription, opt.Field().Name)] = g.ShortDescription
+		known[optionKey(g.ShortDescription, opt.LongName)] = g.ShortDescription
+	})
+
+	var (
+		migrated []*iniOption
+		notes    []string
+	)
+	for _, opt := range opts {
+		if section, ok := known[optionKey(opt.section, opt.key)]; ok {
+			migrated = append(migrated, &iniOption{section: section, key: opt.key, value: opt.value})
+			continue
+		}
+
+		m := findOptionMigration(opt)
+		switch {
+		case m == nil:
+			notes = append(notes, fmt.Sprintf("dropped the unknown option %s", optionName(opt.section, opt.key)))
+		case m.newKey == "":
+			notes = append(notes, fmt.Sprintf("dropped the option %s as %s", optionName(opt.section, opt.key), m.reason))
+		default:
+			notes = append(notes, fmt.Sprintf("renamed the option %s to %s",
+				optionName(opt.section, opt.key), optionName(m.newSection, m.newKey)))
+			migrated = append(migrated, &iniOption{section: m.newSection, key: m.newKey, value: opt.value})
+		}
+	}
+
+	return migrated, notes
+}
+
+func findOptionMigration(opt *iniOption) *optionMigration {
+	for i, m := range optionMigrations {
+		if optionKey(m.section, m.key) == optionKey(opt.section, opt.key) {
+			return &optionMigrations[i]
+		}
+	}
+
+	return nil
+}
+
+func optionKey(section, key string) string {
+	return strings.ToLower(section) + "." + strings.ToLower(key)
+}
+
+// optionName returns the name of the option as written in the config file,
+// e.g., dbconfig.DBPath or NumPubRand
+func optionName(section, key string) string {
+	if section == appOptionsSection {
+		return key
+	}
+	return section + "." + key
+}
+
+// readIniOptions reads the options of the INI document in order. The options
+// before any section header belong to the application options
+func readIniOptions(r io.Reader) ([]*iniOption, error) {
+	var (
+		opts    []*iniOption
+		section = appOptionsSection
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("malformed section header on line %d", lineNum)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed key=value on line %d", lineNum)
+		}
+		opts = append(opts, &iniOption{
+			section: section,
+			key:     strings.TrimSpace(key),
+			value:   strings.TrimSpace(value),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// sectionsOf returns the sections of the options in the order they appear
+func sectionsOf(opts []*iniOption) []string {
+	var sections []string
+	seen := make(map[string]bool)
+	for _, opt := range opts {
+		if !seen[opt.section] {
+			seen[opt.section] = true
+			sections = append(sections, opt.section)
+		}
+	}
+
+	return sections
+}
@@ -0,0 +1,59 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestMigrateConfigFile(t *testing.T) {
+	homePath := t.TempDir()
+	dbPath := filepath.Join(homePath, "olddata")
+
+	for name, content := range map[string]string{
+		"fpd.conf": `[Application Options]
+NumPubRand = 150
+MaxNumFinalityProviders = 3
+UnknownOption = 1
+
+[databaseconfig]
+Backend = bbolt
+Path = ` + dbPath + `
+Name = old.db
+`,
+		"fpd.toml": `NumPubRand = 150
+MaxNumFinalityProviders = 3
+UnknownOption = 1
+
+[databaseconfig]
+Backend = "bbolt"
+Path = "` + dbPath + `"
+Name = "old.db"
+`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfgFile := filepath.Join(homePath, name)
+			err := os.WriteFile(cfgFile, []byte(content), 0600)
+			require.NoError(t, err)
+
+			// the earlier schema fails to parse
+			_, err = config.LoadConfigFromFile(homePath, cfgFile)
+			require.Error(t, err)
+
+			cfg, notes, err := config.MigrateConfigFile(homePath, cfgFile)
+			require.NoError(t, err)
+			require.Equal(t, uint32(150), cfg.NumPubRand)
+			require.Equal(t, dbPath, cfg.DatabaseConfig.DBPath)
+			require.Equal(t, "old.db", cfg.DatabaseConfig.DBFileName)
+			// the sections missing from the file have their defaults
+			require.Equal(t, config.DefaultConfigWithHome(homePath).BackupConfig, cfg.BackupConfig)
+			require.Len(t, notes, 5)
+			require.Contains(t, notes, "renamed the option databaseconfig.Path to dbconfig.DBPath")
+			require.Contains(t, notes, "dropped the unknown option UnknownOption")
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The networks of Babylon with a built-in preset
+const (
+	NetworkMainnet = "mainnet"
+	NetworkTestnet = "testnet"
+	NetworkDevnet  = "devnet"
+)
+
+// NetworkPreset is the set of options which differ between the networks of
+// Babylon. The other options keep their defaults
+type NetworkPreset struct {
+	ChainID        string
+	BitcoinNetwork string
+	// RPCAddr and GRPCAddr are the endpoints of the node, which is expected
+	// to be run next to the finality provider
+	RPCAddr   string
+	GRPCAddr  string
+	GasPrices string
+	// the randomness is sized to the block time of the network, i.e., about
+	// a week of blocks is committed at once on the public networks
+	NumPubRand       uint32
+	NumPubRandMax    uint32
+	MinRandHeightGap uint32
+}
+
+var networkPresets = map[string]*NetworkPreset{
+	NetworkMainnet: {
+		ChainID:          "bbn-1",
+		BitcoinNetwork:   "mainnet",
+		RPCAddr:          "http://localhost:26657",
+		GRPCAddr:         "https://localhost:9090",
+		GasPrices:        "0.002ubbn",
+		NumPubRand:       defaultNumPubRand,
+		NumPubRandMax:    defaultNumPubRandMax,
+		MinRandHeightGap: defaultMinRandHeightGap,
+	},
+	NetworkTestnet: {
+		ChainID:          "bbn-test-5",
+		BitcoinNetwork:   "signet",
+		RPCAddr:          "http://localhost:26657",
+		GRPCAddr:         "https://localhost:9090",
+		GasPrices:        "0.002ubbn",
+		NumPubRand:       defaultNumPubRand,
+		NumPubRandMax:    defaultNumPubRandMax,
+		MinRandHeightGap: defaultMinRandHeightGap,
+	},
+	// the devnets are short-lived local networks with fast blocks, so less
+	// randomness is committed at once
+	NetworkDevnet: {
+		ChainID:          "chain-test",
+		BitcoinNetwork:   "regtest",
+		RPCAddr:          "http://localhost:26657",
+		GRPCAddr:         "https://localhost:9090",
+		GasPrices:        "0.002ubbn",
+		NumPubRand:       1000,
+		NumPubRandMax:    10000,
+		MinRandHeightGap: 500,
+	},
+}
+
+// Networks returns the names of the networks with a built-in preset
+func Networks() []string {
+	names := make([]string, 0, len(networkPresets))
+	for name := range networkPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// GetNetworkPreset returns the preset of the given network
+func GetNetworkPreset(network string) (*NetworkPreset, error) {
+	preset, ok := networkPresets[strings.ToLower(network)]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q: use one of %s", network, strings.Join(Networks(), ", "))
+	}
+
+	return preset, nil
+}
+
+// DefaultConfigForNetwork returns the default config with the options of the
+// preset of the given network, or the default config if the network is empty.
+// Any option can still be overridden in the config file or the environment
+func DefaultConfigForNetwork(homePath, network string) (Config, error) {
+	cfg := DefaultConfigWithHome(homePath)
+	if network == "" {
+		return cfg, nil
+	}
+
+	preset, err := GetNetworkPreset(network)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cfg.BabylonConfig.ChainID = preset.ChainID
+	cfg.BabylonConfig.RPCAddr = preset.RPCAddr
+	cfg.BabylonConfig.GRPCAddr = preset.GRPCAddr
+	cfg.BabylonConfig.GasPrices = preset.GasPrices
+	cfg.BitcoinNetwork = preset.BitcoinNetwork
+	cfg.NumPubRand = preset.NumPubRand
+	cfg.NumPubRandMax = preset.NumPubRandMax
+	cfg.MinRandHeightGap = preset.MinRandHeightGap
+
+	// the Bitcoin network params are derived from the Bitcoin network
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid preset of network %s: %w", network, err)
+	}
+
+	return cfg, nil
+}
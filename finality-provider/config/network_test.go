@@ -0,0 +1,36 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestDefaultConfigForNetwork(t *testing.T) {
+	homePath := t.TempDir()
+
+	cfg, err := config.DefaultConfigForNetwork(homePath, "")
+	require.NoError(t, err)
+	require.Equal(t, config.DefaultConfigWithHome(homePath), cfg)
+
+	for _, network := range config.Networks() {
+		cfg, err := config.DefaultConfigForNetwork(homePath, network)
+		require.NoError(t, err)
+		preset, err := config.GetNetworkPreset(network)
+		require.NoError(t, err)
+		require.Equal(t, preset.ChainID, cfg.BabylonConfig.ChainID)
+		require.Equal(t, preset.NumPubRand, cfg.NumPubRand)
+		// the options out of the preset keep their defaults
+		require.Equal(t, config.DefaultConfigWithHome(homePath).PollerConfig, cfg.PollerConfig)
+	}
+
+	cfg, err = config.DefaultConfigForNetwork(homePath, config.NetworkMainnet)
+	require.NoError(t, err)
+	require.Equal(t, chaincfg.MainNetParams.Name, cfg.BTCNetParams.Name)
+
+	_, err = config.DefaultConfigForNetwork(homePath, "unknown")
+	require.ErrorContains(t, err, "unknown network")
+}
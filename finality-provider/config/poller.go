@@ -2,6 +2,31 @@ package config
 
 import "time"
 
+// The behaviors of the poller when the buffer of blocks is full
+const (
+	// PollerOverflowBlock stops polling until the buffer has room
+	PollerOverflowBlock = "block"
+	// PollerOverflowDropOldest drops the oldest buffered block and tracks it
+	// as a gap, which is delivered once the consumer catches up
+	PollerOverflowDropOldest = "drop-oldest"
+)
+
+// The modes to determine the height from which the poller starts
+const (
+	// StartHeightAuto starts from the last processed height or the latest
+	// finalized height, whichever is higher
+	StartHeightAuto = "auto"
+	// StartHeightActivation starts from the height that BTC staking is activated
+	StartHeightActivation = "activation"
+	// StartHeightLastVoted starts from the last voted height
+	StartHeightLastVoted = "lastvoted"
+	// StartHeightStatic starts from StaticChainScanningStartHeight
+	StartHeightStatic = "static"
+	// StartHeightTip starts from the chain tip without backfilling the
+	// blocks the finality provider missed
+	StartHeightTip = "tip"
+)
+
 var (
 	defaultBufferSize        = uint32(1000)
 	defaultPollingInterval   = 20 * time.Second
@@ -12,7 +37,10 @@ type ChainPollerConfig struct {
 	BufferSize                     uint32        `long:"buffersize" description:"The maximum number of Babylon blocks that can be stored in the buffer"`
 	PollInterval                   time.Duration `long:"pollinterval" description:"The interval between each polling of Babylon blocks"`
 	StaticChainScanningStartHeight uint64        `long:"staticchainscanningstartheight" description:"The static height from which we start polling the chain"`
-	AutoChainScanningMode          bool          `long:"autochainscanningmode" description:"Automatically discover the height from which to start polling the chain"`
+	AutoChainScanningMode          bool          `long:"autochainscanningmode" description:"Automatically discover the height from which to start polling the chain according to startheightmode; if false, the static height is used"`
+	StartHeightMode                string        `long:"startheightmode" description:"How to discover the height from which to start polling the chain: auto for the last processed or finalized height, activation for the BTC staking activation height, lastvoted for the last voted height, static for the static height, or tip to skip to the chain tip without backfilling" choice:"auto" choice:"activation" choice:"lastvoted" choice:"static" choice:"tip"`
+	OverflowPolicy                 string        `long:"overflowpolicy" description:"The behavior when the buffer is full: block to stop polling until there is room, or drop-oldest to drop the oldest buffered block and process it later" choice:"block" choice:"drop-oldest"`
+	ReorgCheckDepth                uint64        `long:"reorgcheckdepth" description:"The number of the latest polled blocks re-checked for a chain reorganization in each poll; 0 disables the check"`
 }
 
 func DefaultChainPollerConfig() ChainPollerConfig {
@@ -21,5 +49,7 @@ func DefaultChainPollerConfig() ChainPollerConfig {
 		PollInterval:                   defaultPollingInterval,
 		StaticChainScanningStartHeight: defaultStaticStartHeight,
 		AutoChainScanningMode:          true,
+		StartHeightMode:                StartHeightAuto,
+		OverflowPolicy:                 PollerOverflowBlock,
 	}
 }
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultPruneInterval = time.Hour
+	defaultPruneDepth    = uint64(1000000)
+)
+
+// PruneConfig defines how often the history of the finality providers is
+// deleted from the database and how far it is kept behind the finalized
+// height, so that the database stays bounded
+type PruneConfig struct {
+	Interval time.Duration `long:"interval" description:"The interval between each deletion of the submissions, missed blocks, vote proofs, and events below depth blocks behind the latest finalized height of the consumer chain; 0 to keep all of them"`
+	Depth    uint64        `long:"depth" description:"The number of blocks behind the latest finalized height of the consumer chain whose submissions, missed blocks, vote proofs, and events are kept"`
+}
+
+func DefaultPruneConfig() *PruneConfig {
+	return &PruneConfig{
+		Interval: defaultPruneInterval,
+		Depth:    defaultPruneDepth,
+	}
+}
+
+func (cfg *PruneConfig) Validate() error {
+	if cfg.Interval < 0 {
+		return fmt.Errorf("prune.interval can't be negative: set it to 0 to keep all the history")
+	}
+	if cfg.Interval > 0 && cfg.Depth == 0 {
+		return fmt.Errorf("prune.depth must be positive, or set prune.interval to 0 to keep all the history")
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+)
+
+// RandConfig is the config of the public randomness commitments of a finality
+// provider
+type RandConfig struct {
+	// NumPubRand is the number of public randomness of each commitment
+	NumPubRand uint32
+	// MinRandHeightGap is the minimum gap between the last committed height
+	// and the tip, below which more randomness is committed
+	MinRandHeightGap uint32
+	// MaxRandLookahead is the maximum number of heights above the tip that the
+	// committed randomness may cover, 0 for no limit
+	MaxRandLookahead uint32
+}
+
+// RandConfigFor returns the randomness config of the given finality provider,
+// i.e., the global options overridden by its randoverride entry, if any. The
+// invalid entries are ignored as they are rejected by Validate
+func (cfg *Config) RandConfigFor(fpPkHex string) RandConfig {
+	randCfg := RandConfig{
+		NumPubRand:       cfg.NumPubRand,
+		MinRandHeightGap: cfg.MinRandHeightGap,
+		MaxRandLookahead: cfg.MaxRandLookahead,
+	}
+	for _, entry := range cfg.RandOverrides {
+		pkHex, fields, err := parseRandOverride(entry)
+		if err != nil || !strings.EqualFold(pkHex, fpPkHex) {
+			continue
+		}
+		for name, value := range fields {
+			switch name {
+			case "numpubrand":
+				randCfg.NumPubRand = value
+			case "minrandheightgap":
+				randCfg.MinRandHeightGap = value
+			case "maxrandlookahead":
+				randCfg.MaxRandLookahead = value
+			}
+		}
+	}
+
+	return randCfg
+}
+
+// parseRandOverride parses an entry in the form of
+// <fp-btc-pk-hex>:<option>=<value>,<option>=<value>,...
+func parseRandOverride(entry string) (string, map[string]uint32, error) {
+	pkHex, options, found := strings.Cut(entry, ":")
+	if !found || options == "" {
+		return "", nil, fmt.Errorf("expected <fp-btc-pk-hex>:<option>=<value>,...")
+	}
+	if _, err := bbntypes.NewBIP340PubKeyFromHex(pkHex); err != nil {
+		return "", nil, fmt.Errorf("invalid finality provider public key %s: %w", pkHex, err)
+	}
+
+	fields := make(map[string]uint32)
+	for _, option := range strings.Split(options, ",") {
+		name, rawValue, found := strings.Cut(option, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !found {
+			return "", nil, fmt.Errorf("missing value of %s", name)
+		}
+		switch name {
+		case "numpubrand", "minrandheightgap", "maxrandlookahead":
+		default:
+			return "", nil, fmt.Errorf("unknown option %s, expected numpubrand, minrandheightgap, or maxrandlookahead", name)
+		}
+		value, err := strconv.ParseUint(strings.TrimSpace(rawValue), 10, 32)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid value of %s: %w", name, err)
+		}
+		fields[name] = uint32(value)
+	}
+
+	return pkHex, fields, nil
+}
+
+// validateRandConfig checks the randomness config of a finality provider, or
+// the global one if fpPkHex is empty
+func (cfg *Config) validateRandConfig(randCfg RandConfig, fpPkHex string) error {
+	prefix := ""
+	if fpPkHex != "" {
+		prefix = fmt.Sprintf("randoverride of %s: ", fpPkHex)
+	}
+
+	if randCfg.NumPubRand == 0 {
+		return fmt.Errorf("%snumPubRand must be positive: set it to the number of randomness to commit each time, e.g., %d", prefix, defaultNumPubRand)
+	}
+	if randCfg.NumPubRand > cfg.NumPubRandMax {
+		return fmt.Errorf("%snumPubRand (%d) exceeds numpubrandmax (%d): decrease numPubRand or increase numpubrandmax", prefix, randCfg.NumPubRand, cfg.NumPubRandMax)
+	}
+	if randCfg.MaxRandLookahead > 0 && randCfg.MaxRandLookahead <= randCfg.MinRandHeightGap {
+		return fmt.Errorf("%smaxrandlookahead (%d) must be greater than minrandheightgap (%d), or 0 for no limit", prefix, randCfg.MaxRandLookahead, randCfg.MinRandHeightGap)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// reloadMtx guards the options that can be reloaded while the daemon is
+// running, as they are read by long-running loops concurrently with a reload
+var reloadMtx sync.RWMutex
+
+// ApplyReloadable copies the options that are safe to change at runtime from
+// newCfg into cfg and returns the names of the options that were changed.
+// Structural options, e.g., the database, listeners, or the consumer chain,
+// require a restart and are left untouched
+func (cfg *Config) ApplyReloadable(newCfg *Config) []string {
+	reloadMtx.Lock()
+	defer reloadMtx.Unlock()
+
+	var updated []string
+	if cfg.LogLevel != newCfg.LogLevel {
+		cfg.LogLevel = newCfg.LogLevel
+		updated = append(updated, "loglevel")
+	}
+	if cfg.RandomnessCommitInterval != newCfg.RandomnessCommitInterval {
+		cfg.RandomnessCommitInterval = newCfg.RandomnessCommitInterval
+		updated = append(updated, "randomnesscommitinterval")
+	}
+	if cfg.SubmissionRetryInterval != newCfg.SubmissionRetryInterval {
+		cfg.SubmissionRetryInterval = newCfg.SubmissionRetryInterval
+		updated = append(updated, "submissionretryinterval")
+	}
+	if cfg.MaxSubmissionRetries != newCfg.MaxSubmissionRetries {
+		cfg.MaxSubmissionRetries = newCfg.MaxSubmissionRetries
+		updated = append(updated, "maxsubmissionretries")
+	}
+	if *cfg.SubmissionConfig != *newCfg.SubmissionConfig {
+		*cfg.SubmissionConfig = *newCfg.SubmissionConfig
+		updated = append(updated, "submission")
+	}
+	if cfg.SyncFpStatusInterval != newCfg.SyncFpStatusInterval {
+		cfg.SyncFpStatusInterval = newCfg.SyncFpStatusInterval
+		updated = append(updated, "syncfpstatusinterval")
+	}
+	if cfg.PollerConfig.PollInterval != newCfg.PollerConfig.PollInterval {
+		cfg.PollerConfig.PollInterval = newCfg.PollerConfig.PollInterval
+		updated = append(updated, "chainpollerconfig.pollinterval")
+	}
+
+	return updated
+}
+
+func (cfg *Config) GetLogLevel() string {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.LogLevel
+}
+
+func (cfg *Config) GetRandomnessCommitInterval() time.Duration {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.RandomnessCommitInterval
+}
+
+func (cfg *Config) GetSyncFpStatusInterval() time.Duration {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.SyncFpStatusInterval
+}
+
+func (cfg *ChainPollerConfig) GetPollInterval() time.Duration {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.PollInterval
+}
+
+func (cfg *SubmissionConfig) GetSkipFinalized() bool {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.SkipFinalized
+}
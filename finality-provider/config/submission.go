@@ -0,0 +1,123 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	defaultBackoffMultiplier = float64(1)
+	defaultMaxRetryInterval  = 1 * time.Minute
+)
+
+// SubmissionConfig defines how the failed submissions of finality signatures
+// and public randomness are retried, on top of submissionretryinterval and
+// maxsubmissionretries. The options of a message type override the general
+// ones if set
+type SubmissionConfig struct {
+	BackoffMultiplier float64       `long:"backoffmultiplier" description:"The factor by which the interval between the retries of a submission grows after each failure, starting from submissionretryinterval; 1 for a constant interval"`
+	MaxRetryInterval  time.Duration `long:"maxretryinterval" description:"The maximum interval between the retries of a submission; 0 for no limit"`
+	MaxDuration       time.Duration `long:"maxduration" description:"The maximum time to retry a submission since its first attempt, regardless of the number of retries; 0 for no limit"`
+
+	FinalitySigMaxRetries  uint32        `long:"finalitysigmaxretries" description:"The maximum number of retries to submit a finality signature; maxsubmissionretries is used if 0"`
+	FinalitySigMaxDuration time.Duration `long:"finalitysigmaxduration" description:"The maximum time to retry submitting a finality signature; maxduration is used if 0"`
+	PubRandMaxRetries      uint32        `long:"pubrandmaxretries" description:"The maximum number of retries to commit public randomness; maxsubmissionretries is used if 0"`
+	PubRandMaxDuration     time.Duration `long:"pubrandmaxduration" description:"The maximum time to retry committing public randomness; maxduration is used if 0"`
+
+	SkipFinalized bool `long:"skipfinalized" description:"Whether to skip the finality signatures over the blocks already finalized on the consumer chain, e.g., to save fees while catching up; the skipped blocks are not counted as missed"`
+}
+
+func DefaultSubmissionConfig() SubmissionConfig {
+	return SubmissionConfig{
+		BackoffMultiplier: defaultBackoffMultiplier,
+		MaxRetryInterval:  defaultMaxRetryInterval,
+	}
+}
+
+func (cfg *SubmissionConfig) Validate() error {
+	if cfg.BackoffMultiplier < 1 {
+		return fmt.Errorf("submission.backoffmultiplier must be at least 1, e.g., %v for a constant interval", defaultBackoffMultiplier)
+	}
+	if cfg.MaxRetryInterval < 0 {
+		return fmt.Errorf("submission.maxretryinterval can't be negative: set it to 0 for no limit")
+	}
+	if cfg.MaxDuration < 0 || cfg.FinalitySigMaxDuration < 0 || cfg.PubRandMaxDuration < 0 {
+		return fmt.Errorf("submission.maxduration, submission.finalitysigmaxduration, and submission.pubrandmaxduration can't be negative: set them to 0 for the default")
+	}
+
+	return nil
+}
+
+// RetryPolicy is the resolved retry policy of the submissions of a message type
+type RetryPolicy struct {
+	Interval    time.Duration
+	Multiplier  float64
+	MaxInterval time.Duration
+	MaxRetries  uint32
+	MaxDuration time.Duration
+}
+
+// Delay returns the interval before the next retry after the given number of
+// consecutive failures
+func (p RetryPolicy) Delay(failures uint32) time.Duration {
+	if p.Multiplier <= 1 {
+		return p.Interval
+	}
+
+	delay := float64(p.Interval)
+	for i := uint32(1); i < failures; i++ {
+		delay *= p.Multiplier
+		if p.MaxInterval > 0 && delay >= float64(p.MaxInterval) {
+			return p.MaxInterval
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// Exhausted returns an error if a submission that failed the given number of
+// times and has been retried for the given duration must not be retried again
+func (p RetryPolicy) Exhausted(failures uint32, elapsed time.Duration) error {
+	if failures > p.MaxRetries {
+		return fmt.Errorf("reached max failed cycles %d", p.MaxRetries)
+	}
+	if p.MaxDuration > 0 && elapsed >= p.MaxDuration {
+		return fmt.Errorf("reached max retry duration %v after %d failed cycles", p.MaxDuration, failures)
+	}
+
+	return nil
+}
+
+// FinalitySigRetryPolicy returns the retry policy of the finality signatures
+func (cfg *Config) FinalitySigRetryPolicy() RetryPolicy {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.retryPolicy(cfg.SubmissionConfig.FinalitySigMaxRetries, cfg.SubmissionConfig.FinalitySigMaxDuration)
+}
+
+// PubRandRetryPolicy returns the retry policy of the public randomness commits
+func (cfg *Config) PubRandRetryPolicy() RetryPolicy {
+	reloadMtx.RLock()
+	defer reloadMtx.RUnlock()
+
+	return cfg.retryPolicy(cfg.SubmissionConfig.PubRandMaxRetries, cfg.SubmissionConfig.PubRandMaxDuration)
+}
+
+func (cfg *Config) retryPolicy(maxRetries uint32, maxDuration time.Duration) RetryPolicy {
+	policy := RetryPolicy{
+		Interval:    cfg.SubmissionRetryInterval,
+		Multiplier:  cfg.SubmissionConfig.BackoffMultiplier,
+		MaxInterval: cfg.SubmissionConfig.MaxRetryInterval,
+		MaxRetries:  cfg.MaxSubmissionRetries,
+		MaxDuration: cfg.SubmissionConfig.MaxDuration,
+	}
+	if maxRetries > 0 {
+		policy.MaxRetries = maxRetries
+	}
+	if maxDuration > 0 {
+		policy.MaxDuration = maxDuration
+	}
+
+	return policy
+}
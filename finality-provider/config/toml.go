@@ -0,0 +1,220 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	tomlConfigFileExt = ".toml"
+
+	// EnvPrefix is the prefix of the environment variables overriding
+	// config options, e.g., FPD_NUMPUBRAND or FPD_BABYLON_CHAIN_ID
+	EnvPrefix = "FPD_"
+)
+
+// eachOption calls fn for every option of the config together with the
+// group it belongs to. The top-level options belong to the root group,
+// whose namespace is empty
+func eachOption(parser *flags.Parser, fn func(g *flags.Group, opt *flags.Option)) {
+	var walk func(g *flags.Group)
+	walk = func(g *flags.Group) {
+		for _, opt := range g.Options() {
+			fn(g, opt)
+		}
+		for _, sub := range g.Groups() {
+			walk(sub)
+		}
+	}
+
+	for _, g := range parser.Groups() {
+		walk(g)
+	}
+}
+
+// EnvKey returns the name of the environment variable that overrides
+// the given option
+func EnvKey(opt *flags.Option) string {
+	key := strings.NewReplacer(".", "_", "-", "_").Replace(opt.LongNameWithNamespace())
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// applyEnvOverrides overrides the options of the config with the values of
+// the matching FPD_-prefixed environment variables, if any
+func applyEnvOverrides(cfg *Config) error {
+	parser := flags.NewParser(cfg, flags.None)
+
+	var (
+		sections []string
+		values   = make(map[string][]string)
+	)
+	eachOption(parser, func(g *flags.Group, opt *flags.Option) {
+		v, ok := os.LookupEnv(EnvKey(opt))
+		if !ok {
+			return
+		}
+		section := g.ShortDescription
+		if _, ok := values[section]; !ok {
+			sections = append(sections, section)
+		}
+		values[section] = append(values[section], fmt.Sprintf("%s = %s", opt.Field().Name, strconv.Quote(v)))
+	})
+
+	if len(sections) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, section := range sections {
+		fmt.Fprintf(&buf, "[%s]\n", section)
+		for _, line := range values[section] {
+			fmt.Fprintln(&buf, line)
+		}
+	}
+
+	if err := flags.NewIniParser(parser).Parse(&buf); err != nil {
+		return fmt.Errorf("invalid config override from the environment: %w", err)
+	}
+
+	return nil
+}
+
+// parseTOML parses the TOML document into the config. The top-level keys are
+// the application options and each table is one of the config sections, using
+// the same names as the INI config file
+func parseTOML(parser *flags.Parser, r io.Reader) error {
+	buf, err := tomlToIni(parser, r)
+	if err != nil {
+		return err
+	}
+
+	return flags.NewIniParser(parser).Parse(buf)
+}
+
+// tomlToIni converts the TOML document into the equivalent INI document
+func tomlToIni(parser *flags.Parser, r io.Reader) (*bytes.Buffer, error) {
+	var doc map[string]interface{}
+	if err := toml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	keys := sortedKeys(doc)
+	fmt.Fprintf(&buf, "[%s]\n", parser.Groups()[0].ShortDescription)
+	for _, k := range keys {
+		if _, ok := doc[k].(map[string]interface{}); ok {
+			continue
+		}
+		if err := writeIniValue(&buf, k, doc[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, k := range keys {
+		table, ok := doc[k].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(&buf, "[%s]\n", k)
+		for _, tk := range sortedKeys(table) {
+			if _, ok := table[tk].(map[string]interface{}); ok {
+				return nil, fmt.Errorf("nested table %s.%s is not supported", k, tk)
+			}
+			if err := writeIniValue(&buf, tk, table[tk]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &buf, nil
+}
+
+func writeIniValue(w io.Writer, key string, v interface{}) error {
+	if list, ok := v.([]interface{}); ok {
+		for _, item := range list {
+			if err := writeIniValue(w, key, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch val := v.(type) {
+	case string:
+		_, err := fmt.Fprintf(w, "%s = %s\n", key, strconv.Quote(val))
+		return err
+	case int64, float64, bool:
+		_, err := fmt.Fprintf(w, "%s = %v\n", key, val)
+		return err
+	default:
+		return fmt.Errorf("unsupported value type %T of %s", v, key)
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WriteTOML writes the config as a TOML document with the description of each
+// option as a comment
+func WriteTOML(w io.Writer, cfg *Config) error {
+	parser := flags.NewParser(cfg, flags.None)
+
+	var buf bytes.Buffer
+	var lastGroup *flags.Group
+	eachOption(parser, func(g *flags.Group, opt *flags.Option) {
+		if g != lastGroup {
+			if g.Namespace != "" {
+				fmt.Fprintf(&buf, "\n[%s]\n", g.Namespace)
+			}
+			lastGroup = g
+		}
+		if opt.Description != "" {
+			fmt.Fprintf(&buf, "\n# %s\n", opt.Description)
+		}
+		fmt.Fprintf(&buf, "%s = %s\n", opt.Field().Name, tomlValue(opt.Value()))
+	})
+
+	_, err := w.Write(bytes.TrimLeft(buf.Bytes(), "\n"))
+	return err
+}
+
+func tomlValue(v interface{}) string {
+	if d, ok := v.(time.Duration); ok {
+		return strconv.Quote(d.String())
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(v)
+	case reflect.Slice:
+		items := make([]string, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			items[i] = tomlValue(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
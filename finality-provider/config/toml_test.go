@@ -0,0 +1,78 @@
+package config_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+)
+
+func TestLoadTOMLConfig(t *testing.T) {
+	homePath := t.TempDir()
+	defaultCfg := config.DefaultConfigWithHome(homePath)
+
+	// the dumped default config is loaded back as is
+	var buf bytes.Buffer
+	err := config.WriteTOML(&buf, &defaultCfg)
+	require.NoError(t, err)
+	cfgFile := filepath.Join(homePath, "fpd.toml")
+	err = os.WriteFile(cfgFile, buf.Bytes(), 0600)
+	require.NoError(t, err)
+
+	cfg, err := config.LoadConfigFromFile(homePath, cfgFile)
+	require.NoError(t, err)
+	require.Equal(t, defaultCfg, *cfg)
+
+	// missing options and sections fall back to the defaults
+	partialCfg := `
+NumPubRand = 100
+RandomnessCommitInterval = "1m"
+
+[babylon]
+Key = "my-key"
+GasAdjustment = 2
+`
+	err = os.WriteFile(cfgFile, []byte(partialCfg), 0600)
+	require.NoError(t, err)
+
+	cfg, err = config.LoadConfigFromFile(homePath, cfgFile)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), cfg.NumPubRand)
+	require.Equal(t, time.Minute, cfg.RandomnessCommitInterval)
+	require.Equal(t, "my-key", cfg.BabylonConfig.Key)
+	require.Equal(t, float64(2), cfg.BabylonConfig.GasAdjustment)
+	require.Equal(t, defaultCfg.BabylonConfig.ChainID, cfg.BabylonConfig.ChainID)
+	require.Equal(t, defaultCfg.PollerConfig, cfg.PollerConfig)
+	require.Equal(t, defaultCfg.DatabaseConfig, cfg.DatabaseConfig)
+
+	// environment variables override the config file
+	t.Setenv("FPD_NUMPUBRAND", "200")
+	t.Setenv("FPD_BABYLON_KEY", "env-key")
+	t.Setenv("FPD_CHAINPOLLERCONFIG_POLLINTERVAL", "3s")
+
+	cfg, err = config.LoadConfigFromFile(homePath, cfgFile)
+	require.NoError(t, err)
+	require.Equal(t, uint32(200), cfg.NumPubRand)
+	require.Equal(t, "env-key", cfg.BabylonConfig.Key)
+	require.Equal(t, 3*time.Second, cfg.PollerConfig.PollInterval)
+
+	// invalid values are rejected
+	t.Setenv("FPD_NUMPUBRAND", "not-a-number")
+	_, err = config.LoadConfigFromFile(homePath, cfgFile)
+	require.Error(t, err)
+}
+
+func TestLoadTOMLConfigUnknownOption(t *testing.T) {
+	homePath := t.TempDir()
+	cfgFile := filepath.Join(homePath, "fpd.toml")
+	err := os.WriteFile(cfgFile, []byte("[babylon]\nUnknownOption = 1\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = config.LoadConfigFromFile(homePath, cfgFile)
+	require.Error(t, err)
+}
@@ -19,15 +19,33 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FinalityProviders_GetInfo_FullMethodName                   = "/proto.FinalityProviders/GetInfo"
-	FinalityProviders_CreateFinalityProvider_FullMethodName    = "/proto.FinalityProviders/CreateFinalityProvider"
-	FinalityProviders_RegisterFinalityProvider_FullMethodName  = "/proto.FinalityProviders/RegisterFinalityProvider"
-	FinalityProviders_AddFinalitySignature_FullMethodName      = "/proto.FinalityProviders/AddFinalitySignature"
-	FinalityProviders_UnjailFinalityProvider_FullMethodName    = "/proto.FinalityProviders/UnjailFinalityProvider"
-	FinalityProviders_QueryFinalityProvider_FullMethodName     = "/proto.FinalityProviders/QueryFinalityProvider"
-	FinalityProviders_QueryFinalityProviderList_FullMethodName = "/proto.FinalityProviders/QueryFinalityProviderList"
-	FinalityProviders_SignMessageFromChainKey_FullMethodName   = "/proto.FinalityProviders/SignMessageFromChainKey"
-	FinalityProviders_EditFinalityProvider_FullMethodName      = "/proto.FinalityProviders/EditFinalityProvider"
+	FinalityProviders_GetInfo_FullMethodName                     = "/proto.FinalityProviders/GetInfo"
+	FinalityProviders_CreateFinalityProvider_FullMethodName      = "/proto.FinalityProviders/CreateFinalityProvider"
+	FinalityProviders_RegisterFinalityProvider_FullMethodName    = "/proto.FinalityProviders/RegisterFinalityProvider"
+	FinalityProviders_AddFinalitySignature_FullMethodName        = "/proto.FinalityProviders/AddFinalitySignature"
+	FinalityProviders_UnjailFinalityProvider_FullMethodName      = "/proto.FinalityProviders/UnjailFinalityProvider"
+	FinalityProviders_PauseFinalityProvider_FullMethodName       = "/proto.FinalityProviders/PauseFinalityProvider"
+	FinalityProviders_ResumeFinalityProvider_FullMethodName      = "/proto.FinalityProviders/ResumeFinalityProvider"
+	FinalityProviders_EmergencyStop_FullMethodName               = "/proto.FinalityProviders/EmergencyStop"
+	FinalityProviders_Resume_FullMethodName                      = "/proto.FinalityProviders/Resume"
+	FinalityProviders_EnterMaintenance_FullMethodName            = "/proto.FinalityProviders/EnterMaintenance"
+	FinalityProviders_ExitMaintenance_FullMethodName             = "/proto.FinalityProviders/ExitMaintenance"
+	FinalityProviders_SetRewardAddress_FullMethodName            = "/proto.FinalityProviders/SetRewardAddress"
+	FinalityProviders_WithdrawRewards_FullMethodName             = "/proto.FinalityProviders/WithdrawRewards"
+	FinalityProviders_QueryFinalityProvider_FullMethodName       = "/proto.FinalityProviders/QueryFinalityProvider"
+	FinalityProviders_QueryFinalityProviderList_FullMethodName   = "/proto.FinalityProviders/QueryFinalityProviderList"
+	FinalityProviders_QueryFinalityProviderStatus_FullMethodName = "/proto.FinalityProviders/QueryFinalityProviderStatus"
+	FinalityProviders_QueryMissedBlocks_FullMethodName           = "/proto.FinalityProviders/QueryMissedBlocks"
+	FinalityProviders_QuerySubmissions_FullMethodName            = "/proto.FinalityProviders/QuerySubmissions"
+	FinalityProviders_ListDelegations_FullMethodName             = "/proto.FinalityProviders/ListDelegations"
+	FinalityProviders_QueryBlockFinalization_FullMethodName      = "/proto.FinalityProviders/QueryBlockFinalization"
+	FinalityProviders_ProveVote_FullMethodName                   = "/proto.FinalityProviders/ProveVote"
+	FinalityProviders_QueryVotedBlock_FullMethodName             = "/proto.FinalityProviders/QueryVotedBlock"
+	FinalityProviders_QueryLastVotedHeight_FullMethodName        = "/proto.FinalityProviders/QueryLastVotedHeight"
+	FinalityProviders_SignMessageFromChainKey_FullMethodName     = "/proto.FinalityProviders/SignMessageFromChainKey"
+	FinalityProviders_EditFinalityProvider_FullMethodName        = "/proto.FinalityProviders/EditFinalityProvider"
+	FinalityProviders_StreamEvents_FullMethodName                = "/proto.FinalityProviders/StreamEvents"
+	FinalityProviders_QueryEvents_FullMethodName                 = "/proto.FinalityProviders/QueryEvents"
 )
 
 // FinalityProvidersClient is the client API for FinalityProviders service.
@@ -47,14 +65,71 @@ type FinalityProvidersClient interface {
 	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
 	// finality provider
 	UnjailFinalityProvider(ctx context.Context, in *UnjailFinalityProviderRequest, opts ...grpc.CallOption) (*UnjailFinalityProviderResponse, error)
+	// PauseFinalityProvider stops the submissions of a finality provider
+	// until it is resumed, even across restarts of the daemon
+	PauseFinalityProvider(ctx context.Context, in *PauseFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// ResumeFinalityProvider restarts the submissions of a paused finality
+	// provider
+	ResumeFinalityProvider(ctx context.Context, in *ResumeFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// EmergencyStop halts all the signing and the submissions of the daemon,
+	// even across restarts, until it is resumed with Resume. It must be
+	// authenticated with the admin token
+	EmergencyStop(ctx context.Context, in *EmergencyStopRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// Resume lifts the emergency stop of the daemon. It must be authenticated
+	// with the admin token
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// EnterMaintenance stops generating new work, i.e., polling new blocks
+	// and committing randomness or withdrawing rewards on schedule, and
+	// returns once the in-flight submissions are finished and confirmed,
+	// e.g., before upgrading the node
+	EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// ExitMaintenance resumes the work stopped by EnterMaintenance
+	ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// SetRewardAddress sets the address the rewards of a finality provider
+	// are sent to upon withdrawal
+	SetRewardAddress(ctx context.Context, in *SetRewardAddressRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// WithdrawRewards sends a transaction to Babylon to withdraw the accumulated
+	// finality provider rewards to a recipient address
+	WithdrawRewards(ctx context.Context, in *WithdrawRewardsRequest, opts ...grpc.CallOption) (*WithdrawRewardsResponse, error)
 	// QueryFinalityProvider queries the finality provider
 	QueryFinalityProvider(ctx context.Context, in *QueryFinalityProviderRequest, opts ...grpc.CallOption) (*QueryFinalityProviderResponse, error)
 	// QueryFinalityProviderList queries a list of finality providers
 	QueryFinalityProviderList(ctx context.Context, in *QueryFinalityProviderListRequest, opts ...grpc.CallOption) (*QueryFinalityProviderListResponse, error)
+	// QueryFinalityProviderStatus queries the status of the stored finality providers
+	// together with their progress on the consumer chain
+	QueryFinalityProviderStatus(ctx context.Context, in *QueryFinalityProviderStatusRequest, opts ...grpc.CallOption) (*QueryFinalityProviderStatusResponse, error)
+	// QueryMissedBlocks queries the blocks that a finality provider failed to
+	// vote for, together with the reasons
+	QueryMissedBlocks(ctx context.Context, in *QueryMissedBlocksRequest, opts ...grpc.CallOption) (*QueryMissedBlocksResponse, error)
+	// QuerySubmissions queries the txs submitted to the consumer chain on
+	// behalf of a finality provider
+	QuerySubmissions(ctx context.Context, in *QuerySubmissionsRequest, opts ...grpc.CallOption) (*QuerySubmissionsResponse, error)
+	// ListDelegations lists the BTC delegations to a finality provider
+	ListDelegations(ctx context.Context, in *ListDelegationsRequest, opts ...grpc.CallOption) (*ListDelegationsResponse, error)
+	// QueryBlockFinalization queries whether the block at a height is finalized
+	// on the consumer chain and which finality providers voted for it
+	QueryBlockFinalization(ctx context.Context, in *QueryBlockFinalizationRequest, opts ...grpc.CallOption) (*QueryBlockFinalizationResponse, error)
+	// ProveVote returns the proof of the vote of a finality provider for the
+	// block at a height, which can be verified against the commitment of its
+	// public randomness on the consumer chain, e.g., for reward disputes
+	ProveVote(ctx context.Context, in *ProveVoteRequest, opts ...grpc.CallOption) (*ProveVoteResponse, error)
+	// QueryVotedBlock queries the block the daemon signed the vote of a
+	// finality provider for at a height, if any
+	QueryVotedBlock(ctx context.Context, in *QueryVotedBlockRequest, opts ...grpc.CallOption) (*QueryVotedBlockResponse, error)
+	// QueryLastVotedHeight queries the height of the last block a finality
+	// provider voted for
+	QueryLastVotedHeight(ctx context.Context, in *QueryLastVotedHeightRequest, opts ...grpc.CallOption) (*QueryLastVotedHeightResponse, error)
 	// SignMessageFromChainKey signs a message from the chain keyring.
 	SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error)
 	// EditFinalityProvider edits finality provider
 	EditFinalityProvider(ctx context.Context, in *EditFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// StreamEvents streams the events of the daemon, e.g., the submitted
+	// finality signatures and the status changes of the finality providers,
+	// as they happen
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (FinalityProviders_StreamEventsClient, error)
+	// QueryEvents queries the events recorded in the event journal of the
+	// daemon in the order they happened
+	QueryEvents(ctx context.Context, in *QueryEventsRequest, opts ...grpc.CallOption) (*QueryEventsResponse, error)
 }
 
 type finalityProvidersClient struct {
@@ -110,6 +185,78 @@ func (c *finalityProvidersClient) UnjailFinalityProvider(ctx context.Context, in
 	return out, nil
 }
 
+func (c *finalityProvidersClient) PauseFinalityProvider(ctx context.Context, in *PauseFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_PauseFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) ResumeFinalityProvider(ctx context.Context, in *ResumeFinalityProviderRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ResumeFinalityProvider_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) EmergencyStop(ctx context.Context, in *EmergencyStopRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_EmergencyStop_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_Resume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) EnterMaintenance(ctx context.Context, in *EnterMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_EnterMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) ExitMaintenance(ctx context.Context, in *ExitMaintenanceRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ExitMaintenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) SetRewardAddress(ctx context.Context, in *SetRewardAddressRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_SetRewardAddress_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) WithdrawRewards(ctx context.Context, in *WithdrawRewardsRequest, opts ...grpc.CallOption) (*WithdrawRewardsResponse, error) {
+	out := new(WithdrawRewardsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_WithdrawRewards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *finalityProvidersClient) QueryFinalityProvider(ctx context.Context, in *QueryFinalityProviderRequest, opts ...grpc.CallOption) (*QueryFinalityProviderResponse, error) {
 	out := new(QueryFinalityProviderResponse)
 	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProvider_FullMethodName, in, out, opts...)
@@ -128,6 +275,78 @@ func (c *finalityProvidersClient) QueryFinalityProviderList(ctx context.Context,
 	return out, nil
 }
 
+func (c *finalityProvidersClient) QueryFinalityProviderStatus(ctx context.Context, in *QueryFinalityProviderStatusRequest, opts ...grpc.CallOption) (*QueryFinalityProviderStatusResponse, error) {
+	out := new(QueryFinalityProviderStatusResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryFinalityProviderStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QueryMissedBlocks(ctx context.Context, in *QueryMissedBlocksRequest, opts ...grpc.CallOption) (*QueryMissedBlocksResponse, error) {
+	out := new(QueryMissedBlocksResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryMissedBlocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QuerySubmissions(ctx context.Context, in *QuerySubmissionsRequest, opts ...grpc.CallOption) (*QuerySubmissionsResponse, error) {
+	out := new(QuerySubmissionsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QuerySubmissions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) ListDelegations(ctx context.Context, in *ListDelegationsRequest, opts ...grpc.CallOption) (*ListDelegationsResponse, error) {
+	out := new(ListDelegationsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ListDelegations_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QueryBlockFinalization(ctx context.Context, in *QueryBlockFinalizationRequest, opts ...grpc.CallOption) (*QueryBlockFinalizationResponse, error) {
+	out := new(QueryBlockFinalizationResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryBlockFinalization_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) ProveVote(ctx context.Context, in *ProveVoteRequest, opts ...grpc.CallOption) (*ProveVoteResponse, error) {
+	out := new(ProveVoteResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_ProveVote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QueryVotedBlock(ctx context.Context, in *QueryVotedBlockRequest, opts ...grpc.CallOption) (*QueryVotedBlockResponse, error) {
+	out := new(QueryVotedBlockResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryVotedBlock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *finalityProvidersClient) QueryLastVotedHeight(ctx context.Context, in *QueryLastVotedHeightRequest, opts ...grpc.CallOption) (*QueryLastVotedHeightResponse, error) {
+	out := new(QueryLastVotedHeightResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryLastVotedHeight_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *finalityProvidersClient) SignMessageFromChainKey(ctx context.Context, in *SignMessageFromChainKeyRequest, opts ...grpc.CallOption) (*SignMessageFromChainKeyResponse, error) {
 	out := new(SignMessageFromChainKeyResponse)
 	err := c.cc.Invoke(ctx, FinalityProviders_SignMessageFromChainKey_FullMethodName, in, out, opts...)
@@ -146,6 +365,47 @@ func (c *finalityProvidersClient) EditFinalityProvider(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *finalityProvidersClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (FinalityProviders_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FinalityProviders_ServiceDesc.Streams[0], FinalityProviders_StreamEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &finalityProvidersStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FinalityProviders_StreamEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type finalityProvidersStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *finalityProvidersStreamEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *finalityProvidersClient) QueryEvents(ctx context.Context, in *QueryEventsRequest, opts ...grpc.CallOption) (*QueryEventsResponse, error) {
+	out := new(QueryEventsResponse)
+	err := c.cc.Invoke(ctx, FinalityProviders_QueryEvents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FinalityProvidersServer is the server API for FinalityProviders service.
 // All implementations must embed UnimplementedFinalityProvidersServer
 // for forward compatibility
@@ -163,14 +423,71 @@ type FinalityProvidersServer interface {
 	// UnjailFinalityProvider sends a transactions to the consumer chain to unjail a given
 	// finality provider
 	UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error)
+	// PauseFinalityProvider stops the submissions of a finality provider
+	// until it is resumed, even across restarts of the daemon
+	PauseFinalityProvider(context.Context, *PauseFinalityProviderRequest) (*EmptyResponse, error)
+	// ResumeFinalityProvider restarts the submissions of a paused finality
+	// provider
+	ResumeFinalityProvider(context.Context, *ResumeFinalityProviderRequest) (*EmptyResponse, error)
+	// EmergencyStop halts all the signing and the submissions of the daemon,
+	// even across restarts, until it is resumed with Resume. It must be
+	// authenticated with the admin token
+	EmergencyStop(context.Context, *EmergencyStopRequest) (*EmptyResponse, error)
+	// Resume lifts the emergency stop of the daemon. It must be authenticated
+	// with the admin token
+	Resume(context.Context, *ResumeRequest) (*EmptyResponse, error)
+	// EnterMaintenance stops generating new work, i.e., polling new blocks
+	// and committing randomness or withdrawing rewards on schedule, and
+	// returns once the in-flight submissions are finished and confirmed,
+	// e.g., before upgrading the node
+	EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EmptyResponse, error)
+	// ExitMaintenance resumes the work stopped by EnterMaintenance
+	ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*EmptyResponse, error)
+	// SetRewardAddress sets the address the rewards of a finality provider
+	// are sent to upon withdrawal
+	SetRewardAddress(context.Context, *SetRewardAddressRequest) (*EmptyResponse, error)
+	// WithdrawRewards sends a transaction to Babylon to withdraw the accumulated
+	// finality provider rewards to a recipient address
+	WithdrawRewards(context.Context, *WithdrawRewardsRequest) (*WithdrawRewardsResponse, error)
 	// QueryFinalityProvider queries the finality provider
 	QueryFinalityProvider(context.Context, *QueryFinalityProviderRequest) (*QueryFinalityProviderResponse, error)
 	// QueryFinalityProviderList queries a list of finality providers
 	QueryFinalityProviderList(context.Context, *QueryFinalityProviderListRequest) (*QueryFinalityProviderListResponse, error)
+	// QueryFinalityProviderStatus queries the status of the stored finality providers
+	// together with their progress on the consumer chain
+	QueryFinalityProviderStatus(context.Context, *QueryFinalityProviderStatusRequest) (*QueryFinalityProviderStatusResponse, error)
+	// QueryMissedBlocks queries the blocks that a finality provider failed to
+	// vote for, together with the reasons
+	QueryMissedBlocks(context.Context, *QueryMissedBlocksRequest) (*QueryMissedBlocksResponse, error)
+	// QuerySubmissions queries the txs submitted to the consumer chain on
+	// behalf of a finality provider
+	QuerySubmissions(context.Context, *QuerySubmissionsRequest) (*QuerySubmissionsResponse, error)
+	// ListDelegations lists the BTC delegations to a finality provider
+	ListDelegations(context.Context, *ListDelegationsRequest) (*ListDelegationsResponse, error)
+	// QueryBlockFinalization queries whether the block at a height is finalized
+	// on the consumer chain and which finality providers voted for it
+	QueryBlockFinalization(context.Context, *QueryBlockFinalizationRequest) (*QueryBlockFinalizationResponse, error)
+	// ProveVote returns the proof of the vote of a finality provider for the
+	// block at a height, which can be verified against the commitment of its
+	// public randomness on the consumer chain, e.g., for reward disputes
+	ProveVote(context.Context, *ProveVoteRequest) (*ProveVoteResponse, error)
+	// QueryVotedBlock queries the block the daemon signed the vote of a
+	// finality provider for at a height, if any
+	QueryVotedBlock(context.Context, *QueryVotedBlockRequest) (*QueryVotedBlockResponse, error)
+	// QueryLastVotedHeight queries the height of the last block a finality
+	// provider voted for
+	QueryLastVotedHeight(context.Context, *QueryLastVotedHeightRequest) (*QueryLastVotedHeightResponse, error)
 	// SignMessageFromChainKey signs a message from the chain keyring.
 	SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error)
 	// EditFinalityProvider edits finality provider
 	EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error)
+	// StreamEvents streams the events of the daemon, e.g., the submitted
+	// finality signatures and the status changes of the finality providers,
+	// as they happen
+	StreamEvents(*StreamEventsRequest, FinalityProviders_StreamEventsServer) error
+	// QueryEvents queries the events recorded in the event journal of the
+	// daemon in the order they happened
+	QueryEvents(context.Context, *QueryEventsRequest) (*QueryEventsResponse, error)
 	mustEmbedUnimplementedFinalityProvidersServer()
 }
 
@@ -193,18 +510,72 @@ func (UnimplementedFinalityProvidersServer) AddFinalitySignature(context.Context
 func (UnimplementedFinalityProvidersServer) UnjailFinalityProvider(context.Context, *UnjailFinalityProviderRequest) (*UnjailFinalityProviderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnjailFinalityProvider not implemented")
 }
+func (UnimplementedFinalityProvidersServer) PauseFinalityProvider(context.Context, *PauseFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ResumeFinalityProvider(context.Context, *ResumeFinalityProviderRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeFinalityProvider not implemented")
+}
+func (UnimplementedFinalityProvidersServer) EmergencyStop(context.Context, *EmergencyStopRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmergencyStop not implemented")
+}
+func (UnimplementedFinalityProvidersServer) Resume(context.Context, *ResumeRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedFinalityProvidersServer) EnterMaintenance(context.Context, *EnterMaintenanceRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterMaintenance not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ExitMaintenance(context.Context, *ExitMaintenanceRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExitMaintenance not implemented")
+}
+func (UnimplementedFinalityProvidersServer) SetRewardAddress(context.Context, *SetRewardAddressRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRewardAddress not implemented")
+}
+func (UnimplementedFinalityProvidersServer) WithdrawRewards(context.Context, *WithdrawRewardsRequest) (*WithdrawRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WithdrawRewards not implemented")
+}
 func (UnimplementedFinalityProvidersServer) QueryFinalityProvider(context.Context, *QueryFinalityProviderRequest) (*QueryFinalityProviderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryFinalityProvider not implemented")
 }
 func (UnimplementedFinalityProvidersServer) QueryFinalityProviderList(context.Context, *QueryFinalityProviderListRequest) (*QueryFinalityProviderListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryFinalityProviderList not implemented")
 }
+func (UnimplementedFinalityProvidersServer) QueryFinalityProviderStatus(context.Context, *QueryFinalityProviderStatusRequest) (*QueryFinalityProviderStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryFinalityProviderStatus not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryMissedBlocks(context.Context, *QueryMissedBlocksRequest) (*QueryMissedBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryMissedBlocks not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QuerySubmissions(context.Context, *QuerySubmissionsRequest) (*QuerySubmissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuerySubmissions not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ListDelegations(context.Context, *ListDelegationsRequest) (*ListDelegationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDelegations not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryBlockFinalization(context.Context, *QueryBlockFinalizationRequest) (*QueryBlockFinalizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryBlockFinalization not implemented")
+}
+func (UnimplementedFinalityProvidersServer) ProveVote(context.Context, *ProveVoteRequest) (*ProveVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProveVote not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryVotedBlock(context.Context, *QueryVotedBlockRequest) (*QueryVotedBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryVotedBlock not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryLastVotedHeight(context.Context, *QueryLastVotedHeightRequest) (*QueryLastVotedHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryLastVotedHeight not implemented")
+}
 func (UnimplementedFinalityProvidersServer) SignMessageFromChainKey(context.Context, *SignMessageFromChainKeyRequest) (*SignMessageFromChainKeyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignMessageFromChainKey not implemented")
 }
 func (UnimplementedFinalityProvidersServer) EditFinalityProvider(context.Context, *EditFinalityProviderRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditFinalityProvider not implemented")
 }
+func (UnimplementedFinalityProvidersServer) StreamEvents(*StreamEventsRequest, FinalityProviders_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+func (UnimplementedFinalityProvidersServer) QueryEvents(context.Context, *QueryEventsRequest) (*QueryEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryEvents not implemented")
+}
 func (UnimplementedFinalityProvidersServer) mustEmbedUnimplementedFinalityProvidersServer() {}
 
 // UnsafeFinalityProvidersServer may be embedded to opt out of forward compatibility for this service.
@@ -308,6 +679,150 @@ func _FinalityProviders_UnjailFinalityProvider_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinalityProviders_PauseFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).PauseFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_PauseFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).PauseFinalityProvider(ctx, req.(*PauseFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_ResumeFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeFinalityProviderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ResumeFinalityProvider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ResumeFinalityProvider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ResumeFinalityProvider(ctx, req.(*ResumeFinalityProviderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_EmergencyStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyStopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).EmergencyStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_EmergencyStop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).EmergencyStop(ctx, req.(*EmergencyStopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_EnterMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnterMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).EnterMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_EnterMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).EnterMaintenance(ctx, req.(*EnterMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_ExitMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExitMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ExitMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ExitMaintenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ExitMaintenance(ctx, req.(*ExitMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_SetRewardAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRewardAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).SetRewardAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_SetRewardAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).SetRewardAddress(ctx, req.(*SetRewardAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_WithdrawRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WithdrawRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).WithdrawRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_WithdrawRewards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).WithdrawRewards(ctx, req.(*WithdrawRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FinalityProviders_QueryFinalityProvider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryFinalityProviderRequest)
 	if err := dec(in); err != nil {
@@ -344,6 +859,150 @@ func _FinalityProviders_QueryFinalityProviderList_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinalityProviders_QueryFinalityProviderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryFinalityProviderStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryFinalityProviderStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryFinalityProviderStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryFinalityProviderStatus(ctx, req.(*QueryFinalityProviderStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QueryMissedBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryMissedBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryMissedBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryMissedBlocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryMissedBlocks(ctx, req.(*QueryMissedBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QuerySubmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuerySubmissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QuerySubmissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QuerySubmissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QuerySubmissions(ctx, req.(*QuerySubmissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_ListDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ListDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ListDelegations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ListDelegations(ctx, req.(*ListDelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QueryBlockFinalization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryBlockFinalizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryBlockFinalization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryBlockFinalization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryBlockFinalization(ctx, req.(*QueryBlockFinalizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_ProveVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProveVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).ProveVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_ProveVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).ProveVote(ctx, req.(*ProveVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QueryVotedBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryVotedBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryVotedBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryVotedBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryVotedBlock(ctx, req.(*QueryVotedBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FinalityProviders_QueryLastVotedHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLastVotedHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryLastVotedHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryLastVotedHeight_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryLastVotedHeight(ctx, req.(*QueryLastVotedHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FinalityProviders_SignMessageFromChainKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignMessageFromChainKeyRequest)
 	if err := dec(in); err != nil {
@@ -380,6 +1039,45 @@ func _FinalityProviders_EditFinalityProvider_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FinalityProviders_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FinalityProvidersServer).StreamEvents(m, &finalityProvidersStreamEventsServer{stream})
+}
+
+type FinalityProviders_StreamEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type finalityProvidersStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *finalityProvidersStreamEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FinalityProviders_QueryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FinalityProvidersServer).QueryEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FinalityProviders_QueryEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FinalityProvidersServer).QueryEvents(ctx, req.(*QueryEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FinalityProviders_ServiceDesc is the grpc.ServiceDesc for FinalityProviders service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -407,6 +1105,38 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnjailFinalityProvider",
 			Handler:    _FinalityProviders_UnjailFinalityProvider_Handler,
 		},
+		{
+			MethodName: "PauseFinalityProvider",
+			Handler:    _FinalityProviders_PauseFinalityProvider_Handler,
+		},
+		{
+			MethodName: "ResumeFinalityProvider",
+			Handler:    _FinalityProviders_ResumeFinalityProvider_Handler,
+		},
+		{
+			MethodName: "EmergencyStop",
+			Handler:    _FinalityProviders_EmergencyStop_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _FinalityProviders_Resume_Handler,
+		},
+		{
+			MethodName: "EnterMaintenance",
+			Handler:    _FinalityProviders_EnterMaintenance_Handler,
+		},
+		{
+			MethodName: "ExitMaintenance",
+			Handler:    _FinalityProviders_ExitMaintenance_Handler,
+		},
+		{
+			MethodName: "SetRewardAddress",
+			Handler:    _FinalityProviders_SetRewardAddress_Handler,
+		},
+		{
+			MethodName: "WithdrawRewards",
+			Handler:    _FinalityProviders_WithdrawRewards_Handler,
+		},
 		{
 			MethodName: "QueryFinalityProvider",
 			Handler:    _FinalityProviders_QueryFinalityProvider_Handler,
@@ -415,6 +1145,38 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryFinalityProviderList",
 			Handler:    _FinalityProviders_QueryFinalityProviderList_Handler,
 		},
+		{
+			MethodName: "QueryFinalityProviderStatus",
+			Handler:    _FinalityProviders_QueryFinalityProviderStatus_Handler,
+		},
+		{
+			MethodName: "QueryMissedBlocks",
+			Handler:    _FinalityProviders_QueryMissedBlocks_Handler,
+		},
+		{
+			MethodName: "QuerySubmissions",
+			Handler:    _FinalityProviders_QuerySubmissions_Handler,
+		},
+		{
+			MethodName: "ListDelegations",
+			Handler:    _FinalityProviders_ListDelegations_Handler,
+		},
+		{
+			MethodName: "QueryBlockFinalization",
+			Handler:    _FinalityProviders_QueryBlockFinalization_Handler,
+		},
+		{
+			MethodName: "ProveVote",
+			Handler:    _FinalityProviders_ProveVote_Handler,
+		},
+		{
+			MethodName: "QueryVotedBlock",
+			Handler:    _FinalityProviders_QueryVotedBlock_Handler,
+		},
+		{
+			MethodName: "QueryLastVotedHeight",
+			Handler:    _FinalityProviders_QueryLastVotedHeight_Handler,
+		},
 		{
 			MethodName: "SignMessageFromChainKey",
 			Handler:    _FinalityProviders_SignMessageFromChainKey_Handler,
@@ -423,7 +1185,17 @@ var FinalityProviders_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EditFinalityProvider",
 			Handler:    _FinalityProviders_EditFinalityProvider_Handler,
 		},
+		{
+			MethodName: "QueryEvents",
+			Handler:    _FinalityProviders_QueryEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _FinalityProviders_StreamEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "finality_providers.proto",
 }
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// The kinds of the alerts fired on critical conditions
+const (
+	AlertSubmissionFailures = "submission_failures"
+	AlertRandomnessHorizon  = "randomness_horizon"
+	AlertJailed             = "jailed"
+	AlertSlashed            = "slashed"
+	AlertDBWrite            = "db_write_error"
+	AlertConflictingVote    = "conflicting_vote"
+	AlertInvalidFinalitySig = "invalid_finality_sig"
+	AlertUnknownVote        = "unknown_vote"
+	AlertReorg              = "chain_reorg"
+	AlertFatal              = "fatal"
+)
+
+// The severities of the alerts, which match the ones of PagerDuty
+const (
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Alert is a notification on a critical condition of the daemon. It is the
+// body posted to the generic alerting webhook
+type Alert struct {
+	Kind     string `json:"kind"`
+	Severity string `json:"severity"`
+	// FpBtcPkHex is the hex BTC public key of the concerned finality
+	// provider, which is empty if the alert concerns the daemon
+	FpBtcPkHex string    `json:"fp_btc_pk_hex,omitempty"`
+	Message    string    `json:"message"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+func (a *Alert) summary() string {
+	s := fmt.Sprintf("[%s] %s: %s", a.Severity, a.Kind, a.Message)
+	if a.FpBtcPkHex != "" {
+		s += fmt.Sprintf(" (finality provider %s)", a.FpBtcPkHex)
+	}
+	return s
+}
+
+// Alerter sends the alerts to the configured destinations in the background.
+// Alerts of the same kind for the same finality provider are sent at most once
+// per cooldown
+type Alerter struct {
+	cfg    *fpcfg.AlertingConfig
+	client *http.Client
+	logger *zap.Logger
+
+	mu       sync.Mutex
+	lastSent map[string]time.Time
+
+	wg sync.WaitGroup
+}
+
+// NewAlerter returns an alerter with the given config. It returns nil if no
+// destination is configured, on which firing alerts is a no-op
+func NewAlerter(cfg *fpcfg.AlertingConfig, logger *zap.Logger) *Alerter {
+	if cfg == nil || !cfg.Enabled() {
+		return nil
+	}
+
+	return &Alerter{
+		cfg:      cfg,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		logger:   logger,
+		lastSent: make(map[string]time.Time),
+	}
+}
+
+// Fire sends an alert unless one of the same kind for the same finality
+// provider was sent within the cooldown. It does not wait for the alert to be
+// delivered
+func (a *Alerter) Fire(kind, severity, fpBtcPkHex, msg string) {
+	if a == nil {
+		return
+	}
+
+	now := time.Now()
+	key := kind + "/" + fpBtcPkHex
+	a.mu.Lock()
+	if last, ok := a.lastSent[key]; ok && now.Sub(last) < a.cfg.Cooldown {
+		a.mu.Unlock()
+		return
+	}
+	a.lastSent[key] = now
+	a.mu.Unlock()
+
+	alert := &Alert{
+		Kind:       kind,
+		Severity:   severity,
+		FpBtcPkHex: fpBtcPkHex,
+		Message:    msg,
+		Timestamp:  now.UTC(),
+	}
+
+	if a.cfg.WebhookURL != "" {
+		a.send("webhook", a.cfg.WebhookURL, alert)
+	}
+	if a.cfg.SlackWebhookURL != "" {
+		a.send("slack", a.cfg.SlackWebhookURL, map[string]string{"text": alert.summary()})
+	}
+	if a.cfg.PagerDutyRoutingKey != "" {
+		a.send("pagerduty", pagerDutyEventsURL, map[string]interface{}{
+			"routing_key":  a.cfg.PagerDutyRoutingKey,
+			"event_action": "trigger",
+			"dedup_key":    key,
+			"payload": map[string]string{
+				"summary":  alert.summary(),
+				"source":   "fpd",
+				"severity": alert.Severity,
+			},
+		})
+	}
+}
+
+// NotifyStatusChange posts the status change of a finality provider to the
+// status webhook, if any, without cooldown. It does not wait for the status
+// change to be delivered
+func (a *Alerter) NotifyStatusChange(ev *proto.Event) {
+	if a == nil || a.cfg.StatusWebhookURL == "" {
+		return
+	}
+
+	a.send("status webhook", a.cfg.StatusWebhookURL, ev)
+}
+
+// Flush waits for the alerts being sent. It is meant to be called before the
+// daemon exits on a fatal error
+func (a *Alerter) Flush() {
+	if a == nil {
+		return
+	}
+
+	a.wg.Wait()
+}
+
+// submissionFailed fires an alert once the consecutive failures to submit to
+// the consumer chain reach the configured number
+func (a *Alerter) submissionFailed(fpBtcPkHex, what string, failures uint32, err error) {
+	if a == nil || failures != a.cfg.SubmissionFailures {
+		return
+	}
+
+	a.Fire(AlertSubmissionFailures, AlertSeverityWarning, fpBtcPkHex,
+		fmt.Sprintf("failed to submit %s %d times in a row: %v", what, failures, err))
+}
+
+// checkRandHeadroom fires an alert if the committed public randomness covers
+// too few blocks above the tip
+func (a *Alerter) checkRandHeadroom(fpBtcPkHex string, lastCommittedHeight, tipHeight uint64) {
+	if a == nil || lastCommittedHeight == 0 || lastCommittedHeight >= tipHeight+a.cfg.MinRandHeadroom {
+		return
+	}
+
+	msg := fmt.Sprintf("the committed public randomness is exhausted at height %d, while the tip is at height %d",
+		lastCommittedHeight, tipHeight)
+	if lastCommittedHeight > tipHeight {
+		msg = fmt.Sprintf("the committed public randomness only covers %d blocks above the tip at height %d",
+			lastCommittedHeight-tipHeight, tipHeight)
+	}
+	a.Fire(AlertRandomnessHorizon, AlertSeverityWarning, fpBtcPkHex, msg)
+}
+
+func (a *Alerter) send(destination, url string, body interface{}) {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		if err := a.post(url, body); err != nil {
+			a.logger.Warn("failed to send the alert",
+				zap.String("destination", destination), zap.Error(err))
+		}
+	}()
+}
+
+func (a *Alerter) post(url string, body interface{}) error {
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("the destination responded %s", resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+func TestAlerter(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		alerts      []*service.Alert
+		slackAlerts []string
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert service.Alert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&alert))
+		mu.Lock()
+		alerts = append(alerts, &alert)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+	slack := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg struct {
+			Text string `json:"text"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		mu.Lock()
+		slackAlerts = append(slackAlerts, msg.Text)
+		mu.Unlock()
+	}))
+	defer slack.Close()
+
+	// the alerting is disabled without destinations
+	cfg := config.DefaultAlertingConfig()
+	alerter := service.NewAlerter(&cfg, zap.NewNop())
+	require.Nil(t, alerter)
+	alerter.Fire(service.AlertFatal, service.AlertSeverityCritical, "", "ignored")
+	alerter.Flush()
+
+	cfg.WebhookURL = webhook.URL
+	cfg.SlackWebhookURL = slack.URL
+	cfg.Cooldown = time.Hour
+	alerter = service.NewAlerter(&cfg, zap.NewNop())
+	require.NotNil(t, alerter)
+
+	alerter.Fire(service.AlertJailed, service.AlertSeverityCritical, "fp1", "jailed")
+	// the same alert is not sent again within the cooldown
+	alerter.Fire(service.AlertJailed, service.AlertSeverityCritical, "fp1", "jailed again")
+	// while the alerts of other kinds or finality providers are
+	alerter.Fire(service.AlertJailed, service.AlertSeverityCritical, "fp2", "jailed")
+	alerter.Fire(service.AlertDBWrite, service.AlertSeverityCritical, "fp1", "disk full")
+	alerter.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, alerts, 3)
+	require.Len(t, slackAlerts, 3)
+	kinds := make(map[string]int)
+	for _, a := range alerts {
+		kinds[a.Kind+"/"+a.FpBtcPkHex]++
+		require.Equal(t, service.AlertSeverityCritical, a.Severity)
+		require.False(t, a.Timestamp.IsZero())
+	}
+	require.Equal(t, map[string]int{"jailed/fp1": 1, "jailed/fp2": 1, "db_write_error/fp1": 1}, kinds)
+	require.Contains(t, slackAlerts, "[critical] db_write_error: disk full (finality provider fp1)")
+}
+
+// TestStatusWebhook tests that every status change is posted to the status
+// webhook regardless of the cooldown of the alerts
+func TestStatusWebhook(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []*proto.Event
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev proto.Event
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ev))
+		mu.Lock()
+		events = append(events, &ev)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	cfg := config.DefaultAlertingConfig()
+	cfg.StatusWebhookURL = webhook.URL
+	alerter := service.NewAlerter(&cfg, zap.NewNop())
+	require.NotNil(t, alerter)
+
+	for _, status := range []proto.FinalityProviderStatus{
+		proto.FinalityProviderStatus_ACTIVE,
+		proto.FinalityProviderStatus_JAILED,
+	} {
+		alerter.NotifyStatusChange(&proto.Event{
+			Type:       service.EventStatusChange,
+			BtcPk:      "fp1",
+			Status:     status.String(),
+			PrevStatus: proto.FinalityProviderStatus_REGISTERED.String(),
+		})
+	}
+	// the alerts are not sent to the status webhook
+	alerter.Fire(service.AlertJailed, service.AlertSeverityCritical, "fp1", "jailed")
+	alerter.Flush()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 2)
+	statuses := make(map[string]bool)
+	for _, ev := range events {
+		require.Equal(t, service.EventStatusChange, ev.Type)
+		require.Equal(t, "fp1", ev.BtcPk)
+		statuses[ev.Status] = true
+	}
+	require.Equal(t, map[string]bool{"ACTIVE": true, "JAILED": true}, statuses)
+}
@@ -16,16 +16,19 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"go.uber.org/zap"
+	protobuf "google.golang.org/protobuf/proto"
 
 	"github.com/babylonlabs-io/finality-provider/clientcontroller"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager"
 	"github.com/babylonlabs-io/finality-provider/eotsmanager/client"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/remotesigner"
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	fpkr "github.com/babylonlabs-io/finality-provider/keyring"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/types"
+	"github.com/babylonlabs-io/finality-provider/version"
 )
 
 type FinalityProviderApp struct {
@@ -35,10 +38,23 @@ type FinalityProviderApp struct {
 	wg   sync.WaitGroup
 	quit chan struct{}
 
+	// stopping is closed at the beginning of the shutdown to stop accepting
+	// requests, while the accepted ones are still handled until quit is closed
+	stopping       chan struct{}
+	registrationWg sync.WaitGroup
+	// isDaemon is whether the app is started as the daemon rather than to
+	// serve a one-shot command
+	isDaemon bool
+
 	cc           clientcontroller.ClientController
+	db           kvdb.Backend
 	kr           keyring.Keyring
-	fps          *store.FinalityProviderStore
+	fps          store.FpStore
 	pubRandStore *store.PubRandProofStore
+	missedBlocks *store.MissedBlockStore
+	submissions  *store.SubmissionStore
+	voteProofs   *store.VoteProofStore
+	epochMsgs    *store.EpochMsgStore
 	config       *fpcfg.Config
 	logger       *zap.Logger
 	input        *strings.Reader
@@ -46,7 +62,24 @@ type FinalityProviderApp struct {
 	fpManager   *FinalityProviderManager
 	eotsManager eotsmanager.EOTSManager
 
-	metrics *metrics.FpMetrics
+	metrics    *metrics.FpMetrics
+	heartbeats *Heartbeats
+	tipCache   *TipCache
+	auditLog   *AuditLog
+	alerter    *Alerter
+	events     *EventBus
+
+	// eventJournal records the events published on the event bus
+	eventJournal *store.EventStore
+
+	// emergency is the emergency stop checked by the guards of the signing
+	// and the submissions, which is persisted in emergencyStops
+	emergency      *emergencyState
+	emergencyStops *store.EmergencyStopStore
+	adminToken     string
+	// maintenance holds the new work of the loops while the daemon is in
+	// maintenance
+	maintenance *maintenanceState
 
 	createFinalityProviderRequestChan   chan *createFinalityProviderRequest
 	registerFinalityProviderRequestChan chan *registerFinalityProviderRequest
@@ -62,16 +95,54 @@ func NewFinalityProviderAppFromConfig(
 	if err != nil {
 		return nil, fmt.Errorf("failed to create rpc client for the consumer chain %s: %v", cfg.ChainName, err)
 	}
+	if err := clientcontroller.VerifyNode(cc, cfg, logger); err != nil {
+		_ = cc.Close()
+		return nil, err
+	}
+
+	em, err := NewEOTSManager(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFinalityProviderApp(cfg, cc, em, db, logger)
+}
+
+// NewEOTSManager connects to the EOTS manager at eotsmanageraddress with a
+// gRPC client or, if remotesignerlistener is set, waits for the remote signer
+// to connect over the remote signer protocol
+func NewEOTSManager(cfg *fpcfg.Config, logger *zap.Logger) (eotsmanager.EOTSManager, error) {
+	if cfg.ReadOnly {
+		logger.Info("running in read-only mode: no EOTS manager is connected")
+		return &readOnlyEOTSManager{}, nil
+	}
+
+	if cfg.RemoteSignerListener != "" {
+		key, err := remotesigner.LoadOrGenKey(cfg.RemoteSignerKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		signerPk, err := remotesigner.ParsePubKey(cfg.RemoteSignerPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid remotesignerpubkey: %w", err)
+		}
+
+		em, err := remotesigner.NewClient(cfg.RemoteSignerListener, key, signerPk, cfg.RemoteSignerTimeout, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the remote signer: %w", err)
+		}
+
+		logger.Info("successfully connected to the remote signer", zap.String("address", cfg.RemoteSignerListener))
+		return em, nil
+	}
 
-	// if the EOTSManagerAddress is empty, run a local EOTS manager;
-	// otherwise connect a remote one with a gRPC client
 	em, err := client.NewEOTSManagerGRpcClient(cfg.EOTSManagerAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create EOTS manager client: %w", err)
 	}
 
 	logger.Info("successfully connected to a remote EOTS manager", zap.String("address", cfg.EOTSManagerAddress))
-	return NewFinalityProviderApp(cfg, cc, em, db, logger)
+	return em, nil
 }
 
 func NewFinalityProviderApp(
@@ -85,11 +156,51 @@ func NewFinalityProviderApp(
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate finality provider store: %w", err)
 	}
+
+	return NewFinalityProviderAppWithFpStore(config, cc, em, db, fpStore, logger)
+}
+
+// NewFinalityProviderAppWithFpStore returns the app keeping the finality
+// providers in the given store instead of the database, e.g., an in-memory
+// one for tests. The other records are still kept in the database
+func NewFinalityProviderAppWithFpStore(
+	config *fpcfg.Config,
+	cc clientcontroller.ClientController,
+	em eotsmanager.EOTSManager,
+	db kvdb.Backend,
+	fpStore store.FpStore,
+	logger *zap.Logger,
+) (*FinalityProviderApp, error) {
 	pubRandStore, err := store.NewPubRandProofStore(db)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate public randomness store: %w", err)
 	}
 
+	// the emergency stop is restored before anything can be signed or
+	// submitted
+	emergencyStops, err := store.NewEmergencyStopStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate emergency stop store: %w", err)
+	}
+	stop, err := emergencyStops.GetEmergencyStop()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the emergency stop: %w", err)
+	}
+	emergency := &emergencyState{readOnly: config.ReadOnly}
+	if stop != nil {
+		emergency.stopped.Store(true)
+		logger.Warn("the daemon is stopped in emergency, nothing is signed or submitted until it is resumed",
+			zap.String("reason", stop.Reason), zap.Time("since", time.Unix(stop.Timestamp, 0)))
+	}
+	cc = &emergencyGuardController{ClientController: cc, emergency: emergency}
+	em = &emergencyGuardEOTSManager{EOTSManager: em, emergency: emergency}
+	var adminToken string
+	if config.AdminTokenFile != "" {
+		if adminToken, err = LoadOrGenAdminToken(config.AdminTokenFile); err != nil {
+			return nil, err
+		}
+	}
+
 	input := strings.NewReader("")
 	kr, err := fpkr.CreateKeyring(
 		config.BabylonConfig.KeyDirectory,
@@ -108,10 +219,56 @@ func NewFinalityProviderApp(
 		return nil, fmt.Errorf("failed to create finality-provider manager: %w", err)
 	}
 
+	heartbeats := NewHeartbeats(config.LoopStuckTimeout)
+	fpm.heartbeats = heartbeats
+	tipCache := NewTipCache(config.TipCacheTTL)
+	fpm.tipCache = tipCache
+	auditLog, err := OpenAuditLog(config.AuditLogFile)
+	if err != nil {
+		return nil, err
+	}
+	fpm.auditLog = auditLog
+	alerter := NewAlerter(config.AlertingConfig, logger)
+	fpm.alerter = alerter
+	missedBlocks, err := store.NewMissedBlockStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate missed block store: %w", err)
+	}
+	fpm.missedBlocks = missedBlocks
+	submissions, err := store.NewSubmissionStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate submission store: %w", err)
+	}
+	fpm.submissions = submissions
+	voteProofs, err := store.NewVoteProofStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate vote proof store: %w", err)
+	}
+	fpm.voteProofs = voteProofs
+	epochMsgs, err := store.NewEpochMsgStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate epoch message store: %w", err)
+	}
+	fpm.epochMsgs = epochMsgs
+	eventJournal, err := store.NewEventStore(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate event store: %w", err)
+	}
+	events := NewEventBus(eventJournal, logger)
+	fpm.events = events
+	fpm.emergency = emergency
+	maintenance := newMaintenanceState()
+	fpm.maintenance = maintenance
+
 	return &FinalityProviderApp{
 		cc:                                  cc,
+		db:                                  db,
 		fps:                                 fpStore,
 		pubRandStore:                        pubRandStore,
+		missedBlocks:                        missedBlocks,
+		submissions:                         submissions,
+		voteProofs:                          voteProofs,
+		epochMsgs:                           epochMsgs,
 		kr:                                  kr,
 		config:                              config,
 		logger:                              logger,
@@ -119,7 +276,18 @@ func NewFinalityProviderApp(
 		fpManager:                           fpm,
 		eotsManager:                         em,
 		metrics:                             fpMetrics,
+		heartbeats:                          heartbeats,
+		tipCache:                            tipCache,
+		auditLog:                            auditLog,
+		alerter:                             alerter,
+		events:                              events,
+		eventJournal:                        eventJournal,
+		emergency:                           emergency,
+		emergencyStops:                      emergencyStops,
+		adminToken:                          adminToken,
+		maintenance:                         maintenance,
 		quit:                                make(chan struct{}),
+		stopping:                            make(chan struct{}),
 		createFinalityProviderRequestChan:   make(chan *createFinalityProviderRequest),
 		registerFinalityProviderRequestChan: make(chan *registerFinalityProviderRequest),
 		finalityProviderRegisteredEventChan: make(chan *finalityProviderRegisteredEvent),
@@ -130,7 +298,7 @@ func (app *FinalityProviderApp) GetConfig() *fpcfg.Config {
 	return app.config
 }
 
-func (app *FinalityProviderApp) GetFinalityProviderStore() *store.FinalityProviderStore {
+func (app *FinalityProviderApp) GetFinalityProviderStore() store.FpStore {
 	return app.fps
 }
 
@@ -138,6 +306,10 @@ func (app *FinalityProviderApp) GetPubRandProofStore() *store.PubRandProofStore
 	return app.pubRandStore
 }
 
+func (app *FinalityProviderApp) GetEOTSManager() eotsmanager.EOTSManager {
+	return app.eotsManager
+}
+
 func (app *FinalityProviderApp) GetKeyring() keyring.Keyring {
 	return app.kr
 }
@@ -159,11 +331,105 @@ func (app *FinalityProviderApp) GetFinalityProviderInfo(fpPk *bbntypes.BIP340Pub
 	return app.fpManager.FinalityProviderInfo(fpPk)
 }
 
+// FinalityProvidersStatus returns the status of the stored finality providers
+// together with their progress on the consumer chain. The failures of the chain
+// queries are reported per finality provider instead of failing the whole query
+func (app *FinalityProviderApp) FinalityProvidersStatus() ([]*proto.FinalityProviderStatusInfo, error) {
+	storedFps, err := app.fps.GetAllStoredFinalityProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	tip, tipErr := app.tipCache.Query(app.cc)
+
+	statusList := make([]*proto.FinalityProviderStatusInfo, 0, len(storedFps))
+	for _, fp := range storedFps {
+		pendingEpochMsgs, err := app.queryEpochMsgs(fp.GetBIP340BTCPK())
+		if err != nil {
+			return nil, err
+		}
+		status := &proto.FinalityProviderStatusInfo{
+			BtcPkHex:            fp.GetBIP340BTCPK().MarshalHex(),
+			FpAddr:              fp.FPAddr,
+			Status:              fp.Status.String(),
+			IsRunning:           app.fpManager.IsFinalityProviderRunning(fp.GetBIP340BTCPK()),
+			LastVotedHeight:     fp.LastVotedHeight,
+			LastProcessedHeight: fp.LastProcessedHeight,
+			PendingEpochMsgs:    pendingEpochMsgs,
+		}
+		statusList = append(statusList, status)
+
+		if tipErr != nil {
+			status.ChainError = fmt.Sprintf("failed to query the tip: %v", tipErr)
+			continue
+		}
+		status.TipHeight = tip.Height
+		if tip.Height > fp.LastProcessedHeight {
+			status.PendingBlocks = tip.Height - fp.LastProcessedHeight
+		}
+
+		// the finality provider is not known by the consumer chain until registered
+		if fp.Status == proto.FinalityProviderStatus_CREATED {
+			continue
+		}
+
+		lastCommittedHeight, err := app.queryLastCommittedHeight(fp.BtcPk)
+		if err != nil {
+			status.ChainError = err.Error()
+			continue
+		}
+		status.LastCommittedHeight = lastCommittedHeight
+
+		power, err := app.cc.QueryFinalityProviderVotingPower(fp.BtcPk, tip.Height)
+		if err != nil {
+			status.ChainError = err.Error()
+			continue
+		}
+		status.VotingPower = power
+	}
+
+	return statusList, nil
+}
+
+// queryLastCommittedHeight returns the last height covered by the public
+// randomness committed by the given finality provider, or 0 if there is none
+func (app *FinalityProviderApp) queryLastCommittedHeight(fpPk *btcec.PublicKey) (uint64, error) {
+	pubRandCommitMap, err := app.cc.QueryLastCommittedPublicRand(fpPk, 1)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query the last committed public randomness: %w", err)
+	}
+
+	var lastCommittedHeight uint64
+	for startHeight, resp := range pubRandCommitMap {
+		lastCommittedHeight = startHeight + resp.NumPubRand - 1
+	}
+
+	return lastCommittedHeight, nil
+}
+
 // GetFinalityProviderInstance returns the finality-provider instance with the given Babylon public key
 func (app *FinalityProviderApp) GetFinalityProviderInstance() (*FinalityProviderInstance, error) {
 	return app.fpManager.GetFinalityProviderInstance()
 }
 
+// SubmitFinalitySignaturesForRange submits the finality signatures of the
+// running finality provider over the blocks from startHeight to endHeight in
+// batched transactions
+func (app *FinalityProviderApp) SubmitFinalitySignaturesForRange(
+	fpPk *bbntypes.BIP340PubKey,
+	startHeight, endHeight uint64,
+) ([]*types.TxResponse, error) {
+	fpi, err := app.fpManager.GetFinalityProviderInstance()
+	if err != nil {
+		return nil, err
+	}
+	if fpi.GetBtcPkHex() != fpPk.MarshalHex() {
+		return nil, fmt.Errorf("the finality provider %s is not running", fpPk.MarshalHex())
+	}
+
+	return fpi.SubmitFinalitySignaturesForRange(startHeight, endHeight)
+}
+
 func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*RegisterFinalityProviderResponse, error) {
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(fpPkStr)
 	if err != nil {
@@ -179,6 +445,10 @@ func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*Regis
 		return nil, fmt.Errorf("finality-provider is already registered")
 	}
 
+	if err := app.approveRegistration(fp); err != nil {
+		return nil, err
+	}
+
 	btcSig, err := bbntypes.NewBIP340Signature(fp.Pop.BtcSig)
 	if err != nil {
 		return nil, err
@@ -204,15 +474,18 @@ func (app *FinalityProviderApp) RegisterFinalityProvider(fpPkStr string) (*Regis
 		successResponse: make(chan *RegisterFinalityProviderResponse, 1),
 	}
 
-	app.registerFinalityProviderRequestChan <- request
+	select {
+	case app.registerFinalityProviderRequestChan <- request:
+	case <-app.stopping:
+		return nil, ErrFinalityProviderAppShutDown
+	}
 
+	// an accepted request is always responded, even if the app is stopping
 	select {
 	case err := <-request.errResponse:
 		return nil, err
 	case successResponse := <-request.successResponse:
 		return successResponse, nil
-	case <-app.quit:
-		return nil, fmt.Errorf("finality-provider app is shutting down")
 	}
 }
 
@@ -234,7 +507,7 @@ func (app *FinalityProviderApp) getFpPrivKey(fpPk []byte) (*btcec.PrivateKey, er
 
 // SyncFinalityProviderStatus syncs the status of the finality-providers with the chain.
 func (app *FinalityProviderApp) SyncFinalityProviderStatus() (fpInstanceRunning bool, err error) {
-	latestBlock, err := app.cc.QueryBestBlock()
+	latestBlock, err := app.tipCache.Query(app.cc)
 	if err != nil {
 		return false, err
 	}
@@ -278,10 +551,11 @@ func (app *FinalityProviderApp) SyncFinalityProviderStatus() (fpInstanceRunning
 				zap.String("old_status", oldStatus.String()),
 				zap.String("new_status", newStatus.String()),
 			)
+			app.publishStatusChange(bip340PubKey.MarshalHex(), oldStatus, newStatus)
 			fp.Status = newStatus
 		}
 
-		if !fp.ShouldStart() {
+		if !fp.ShouldStart() || app.emergency.check() != nil {
 			continue
 		}
 
@@ -300,21 +574,108 @@ func (app *FinalityProviderApp) Start() error {
 	app.startOnce.Do(func() {
 		app.logger.Info("Starting FinalityProviderApp")
 
-		app.wg.Add(4)
+		app.wg.Add(3)
 		go app.syncChainFpStatusLoop()
 		go app.eventLoop()
-		go app.registrationLoop()
 		go app.metricsUpdateLoop()
+
+		if app.config.RewardWithdrawInterval > 0 && !app.config.ReadOnly {
+			app.wg.Add(1)
+			go app.rewardWithdrawalLoop()
+		}
+
+		if app.config.BackupConfig.Interval > 0 {
+			app.wg.Add(1)
+			go app.backupLoop()
+		}
+
+		if app.config.Metrics.DbStatsInterval > 0 {
+			app.wg.Add(1)
+			go app.dbStatsLoop()
+		}
+
+		// the records of an inspection replica are kept as they were copied
+		if app.config.PruneConfig.Interval > 0 && !app.config.ReadOnly {
+			app.wg.Add(1)
+			go app.pruneLoop()
+		}
+
+		if app.config.VoteMonitorInterval > 0 {
+			app.wg.Add(1)
+			go app.voteMonitorLoop()
+		}
+
+		// the queued txs of an inspection replica are kept as they were
+		// copied
+		if app.config.EpochTrackingInterval > 0 && !app.config.ReadOnly {
+			app.wg.Add(1)
+			go app.epochTrackingLoop()
+		}
+
+		app.registrationWg.Add(1)
+		go app.registrationLoop()
+
+		app.isDaemon = true
+		app.events.Publish(&proto.Event{
+			Type:    EventDaemonStarted,
+			Message: fmt.Sprintf("fpd %s started", version.Version()),
+		})
 	})
 
 	return startErr
 }
 
+// StartOneShot starts only the loops handling the creation and registration
+// requests, so that the app can serve the requests of a one-shot command
+// without voting, committing randomness, or polling the consumer chain in the
+// background. The app is stopped with Stop as usual
+func (app *FinalityProviderApp) StartOneShot() error {
+	app.startOnce.Do(func() {
+		app.logger.Info("Starting FinalityProviderApp in one-shot mode")
+
+		app.wg.Add(1)
+		go app.eventLoop()
+
+		app.registrationWg.Add(1)
+		go app.registrationLoop()
+	})
+
+	return nil
+}
+
+// CommitPubRand commits public randomness for the given registered finality
+// provider once if it is running out of randomness at the current tip, without
+// starting its instance. It returns a nil response if nothing is committed
+func (app *FinalityProviderApp) CommitPubRand(fpPk *bbntypes.BIP340PubKey, passphrase string) (*types.TxResponse, error) {
+	if app.fpManager.IsFinalityProviderRunning(fpPk) {
+		return nil, fmt.Errorf("the finality provider %s is running and commits randomness by itself", fpPk.MarshalHex())
+	}
+
+	fpi, err := app.fpManager.newFinalityProviderInstance(fpPk, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := fpi.getLatestBlockWithRetry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest block: %w", err)
+	}
+
+	return fpi.CommitPubRand(tip.Height)
+}
+
 func (app *FinalityProviderApp) Stop() error {
 	var stopErr error
 	app.stopOnce.Do(func() {
 		app.logger.Info("Stopping FinalityProviderApp")
 
+		// Stop accepting requests and wait for the in-flight registration to
+		// finish, so that its result is stored by the event loop and
+		// returned to the caller before the loops are stopped
+		app.logger.Debug("Draining in-flight requests")
+		close(app.stopping)
+		app.registrationWg.Wait()
+
 		// Always stop the submission loop first to not generate additional events and actions
 		app.logger.Debug("Stopping submission loop")
 		close(app.quit)
@@ -332,18 +693,40 @@ func (app *FinalityProviderApp) Stop() error {
 			return
 		}
 
+		if err := app.auditLog.Close(); err != nil {
+			stopErr = err
+			return
+		}
+
+		if app.isDaemon {
+			app.events.Publish(&proto.Event{Type: EventDaemonStopped})
+		}
+
+		app.alerter.Flush()
+
 		app.logger.Debug("FinalityProviderApp successfully stopped")
 
 	})
 	return stopErr
 }
 
+// CreateFinalityProvider creates a finality provider and its keys if they do
+// not exist. If the proof of possession of the EOTS key is given, the EOTS key
+// is not loaded, so it can be kept offline. The commission can never be raised
+// above the max commission unless it is nil
 func (app *FinalityProviderApp) CreateFinalityProvider(
 	keyName, chainID, passPhrase, hdPath string,
 	eotsPk *bbntypes.BIP340PubKey,
+	pop *bstypes.ProofOfPossessionBTC,
 	description *stakingtypes.Description,
-	commission *sdkmath.LegacyDec,
+	commission, maxCommission *sdkmath.LegacyDec,
 ) (*CreateFinalityProviderResult, error) {
+	if pop != nil && eotsPk == nil {
+		return nil, fmt.Errorf("the EOTS public key of the proof of possession is required")
+	}
+	if err := validateCommission(commission, maxCommission); err != nil {
+		return nil, err
+	}
 
 	req := &createFinalityProviderRequest{
 		keyName:         keyName,
@@ -351,14 +734,21 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 		passPhrase:      passPhrase,
 		hdPath:          hdPath,
 		eotsPk:          eotsPk,
+		pop:             pop,
 		description:     description,
 		commission:      commission,
+		maxCommission:   maxCommission,
 		errResponse:     make(chan error, 1),
 		successResponse: make(chan *createFinalityProviderResponse, 1),
 	}
 
-	app.createFinalityProviderRequestChan <- req
+	select {
+	case app.createFinalityProviderRequestChan <- req:
+	case <-app.stopping:
+		return nil, ErrFinalityProviderAppShutDown
+	}
 
+	// an accepted request is always responded, even if the app is stopping
 	select {
 	case err := <-req.errResponse:
 		return nil, err
@@ -366,20 +756,80 @@ func (app *FinalityProviderApp) CreateFinalityProvider(
 		return &CreateFinalityProviderResult{
 			FpInfo: successResponse.FpInfo,
 		}, nil
-	case <-app.quit:
-		return nil, fmt.Errorf("finality-provider app is shutting down")
 	}
 }
 
+// EditFinalityProvider updates the description and, if not nil, the commission
+// of the finality provider on Babylon, and syncs them into the local store.
+// The empty fields of the description keep their values on Babylon
+func (app *FinalityProviderApp) EditFinalityProvider(
+	fpPk *bbntypes.BIP340PubKey,
+	description *proto.Description,
+	commission *sdkmath.LegacyDec,
+) error {
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return fmt.Errorf("failed to get finality provider from db: %w", err)
+	}
+
+	if commission != nil {
+		if err := validateCommission(commission, fp.MaxCommission); err != nil {
+			return err
+		}
+	}
+
+	descBytes, err := protobuf.Marshal(description)
+	if err != nil {
+		return err
+	}
+
+	msg, err := app.cc.EditFinalityProvider(fpPk.MustToBTCPK(), commission, descBytes)
+	if err != nil {
+		return fmt.Errorf("failed to send edit transaction: %w", err)
+	}
+
+	if err := app.fps.SetFpDescription(fpPk.MustToBTCPK(), msg.Description, msg.Commission); err != nil {
+		return fmt.Errorf("failed to update finality-provider description after editing: %w", err)
+	}
+
+	app.logger.Info("successfully edited finality-provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+		zap.String("moniker", msg.Description.Moniker),
+		zap.String("commission", msg.Commission.String()),
+	)
+
+	return nil
+}
+
+// validateCommission checks that the commission is a valid rate not above the
+// max commission, if any
+func validateCommission(commission, maxCommission *sdkmath.LegacyDec) error {
+	if commission.IsNegative() || commission.GT(sdkmath.LegacyOneDec()) {
+		return fmt.Errorf("commission rate %s must be between 0 and 1", commission)
+	}
+	if maxCommission == nil {
+		return nil
+	}
+	if maxCommission.GT(sdkmath.LegacyOneDec()) {
+		return fmt.Errorf("max commission rate %s can't be greater than 1", maxCommission)
+	}
+	if commission.GT(*maxCommission) {
+		return fmt.Errorf("commission rate %s can't be greater than the max commission rate %s", commission, maxCommission)
+	}
+
+	return nil
+}
+
 // UnjailFinalityProvider sends a transaction to unjail a finality-provider
 func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubKey) (string, error) {
-	_, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
 	if err != nil {
 		return "", fmt.Errorf("failed to get finality provider from db: %w", err)
 	}
 
 	// Send unjail transaction
 	res, err := app.cc.UnjailFinalityProvider(fpPk.MustToBTCPK())
+	app.recordSubmission(fpPk.MustToBTCPK(), store.SubmissionTypeUnjail, res, err)
 	if err != nil {
 		return "", fmt.Errorf("failed to send unjail transaction: %w", err)
 	}
@@ -393,6 +843,9 @@ func (app *FinalityProviderApp) UnjailFinalityProvider(fpPk *bbntypes.BIP340PubK
 	}
 
 	app.fpManager.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_INACTIVE)
+	if fp.Status != proto.FinalityProviderStatus_INACTIVE {
+		app.publishStatusChange(fpPk.MarshalHex(), fp.Status, proto.FinalityProviderStatus_INACTIVE)
+	}
 
 	app.logger.Info("successfully unjailed finality-provider",
 		zap.String("btc_pk", fpPk.MarshalHex()),
@@ -432,18 +885,25 @@ func (app *FinalityProviderApp) handleCreateFinalityProviderRequest(req *createF
 		}
 	}
 
-	fpRecord, err := app.eotsManager.KeyRecord(fpPk.MustMarshal(), req.passPhrase)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get finality-provider record: %w", err)
-	}
+	// 3. create proof-of-possession, unless it is given
+	pop := req.pop
+	if pop != nil {
+		if err := pop.VerifyBIP340(fpAddr, fpPk); err != nil {
+			return nil, fmt.Errorf("invalid proof-of-possession of the finality-provider: %w", err)
+		}
+	} else {
+		fpRecord, err := app.eotsManager.KeyRecord(fpPk.MustMarshal(), req.passPhrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get finality-provider record: %w", err)
+		}
 
-	// 3. create proof-of-possession
-	pop, err := kr.CreatePop(fpAddr, fpRecord.PrivKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create proof-of-possession of the finality-provider: %w", err)
+		pop, err = kr.CreatePop(fpAddr, fpRecord.PrivKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create proof-of-possession of the finality-provider: %w", err)
+		}
 	}
 
-	if err := app.fps.CreateFinalityProvider(fpAddr, fpPk.MustToBTCPK(), req.description, req.commission, req.keyName, req.chainID, pop.BtcSig); err != nil {
+	if err := app.fps.CreateFinalityProvider(fpAddr, fpPk.MustToBTCPK(), req.description, req.commission, req.maxCommission, req.keyName, req.chainID, pop.BtcSig); err != nil {
 		return nil, fmt.Errorf("failed to save finality-provider: %w", err)
 	}
 	app.fpManager.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_CREATED)
@@ -541,7 +1001,7 @@ func (app *FinalityProviderApp) StoreFinalityProvider(
 		return nil, fmt.Errorf("failed to create proof-of-possession of the finality provider: %w", err)
 	}
 
-	if err := app.fps.CreateFinalityProvider(fpAddr, fpPk.MustToBTCPK(), description, commission, keyName, chainID, pop.BtcSig); err != nil {
+	if err := app.fps.CreateFinalityProvider(fpAddr, fpPk.MustToBTCPK(), description, commission, nil, keyName, chainID, pop.BtcSig); err != nil {
 		return nil, fmt.Errorf("failed to save finality-provider: %w", err)
 	}
 	app.fpManager.metrics.RecordFpStatus(fpPk.MarshalHex(), proto.FinalityProviderStatus_CREATED)
@@ -599,12 +1059,17 @@ func (app *FinalityProviderApp) eventLoop() {
 			// change the status of the finality-provider to registered
 			err := app.fps.SetFpStatus(ev.btcPubKey.MustToBTCPK(), proto.FinalityProviderStatus_REGISTERED)
 			if err != nil {
+				app.alerter.Fire(AlertDBWrite, AlertSeverityCritical, ev.btcPubKey.MarshalHex(),
+					fmt.Sprintf("failed to set the finality provider status to REGISTERED: %v", err))
+				app.alerter.Flush()
 				app.logger.Fatal("failed to set finality-provider status to REGISTERED",
 					zap.String("pk", ev.btcPubKey.MarshalHex()),
 					zap.Error(err),
 				)
 			}
 			app.fpManager.metrics.RecordFpStatus(ev.btcPubKey.MarshalHex(), proto.FinalityProviderStatus_REGISTERED)
+			app.publishStatusChange(ev.btcPubKey.MarshalHex(),
+				proto.FinalityProviderStatus_CREATED, proto.FinalityProviderStatus_REGISTERED)
 
 			// return to the caller
 			ev.successResponse <- &RegisterFinalityProviderResponse{
@@ -621,7 +1086,7 @@ func (app *FinalityProviderApp) eventLoop() {
 }
 
 func (app *FinalityProviderApp) registrationLoop() {
-	defer app.wg.Done()
+	defer app.registrationWg.Done()
 	for {
 		select {
 		case req := <-app.registerFinalityProviderRequestChan:
@@ -645,6 +1110,7 @@ func (app *FinalityProviderApp) registrationLoop() {
 				req.commission,
 				desBytes,
 			)
+			app.recordSubmission(req.btcPubKey.MustToBTCPK(), store.SubmissionTypeRegistration, res, err)
 
 			if err != nil {
 				app.logger.Error(
@@ -663,6 +1129,18 @@ func (app *FinalityProviderApp) registrationLoop() {
 				zap.String("txHash", res.TxHash),
 			)
 
+			// the finality provider is not registered in dry-run mode, so its
+			// status is left unchanged
+			if app.config.BabylonConfig.DryRun {
+				req.successResponse <- &RegisterFinalityProviderResponse{
+					bbnAddress: req.fpAddr,
+					btcPubKey:  req.btcPubKey,
+					TxHash:     res.TxHash,
+				}
+				continue
+			}
+
+			app.queueEpochMsg(req.btcPubKey.MustToBTCPK(), store.SubmissionTypeRegistration, res)
 			app.finalityProviderRegisteredEventChan <- &finalityProviderRegisteredEvent{
 				btcPubKey:  req.btcPubKey,
 				bbnAddress: req.fpAddr,
@@ -671,7 +1149,7 @@ func (app *FinalityProviderApp) registrationLoop() {
 				// the registration
 				successResponse: req.successResponse,
 			}
-		case <-app.quit:
+		case <-app.stopping:
 			app.logger.Debug("exiting registration loop")
 			return
 		}
@@ -685,8 +1163,11 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 	app.logger.Info("starting metrics update loop",
 		zap.Float64("interval seconds", interval.Seconds()))
 	updateTicker := time.NewTicker(interval)
+	defer app.heartbeats.remove(metricsUpdateLoopName)
 
 	for {
+		app.heartbeats.beat(metricsUpdateLoopName, interval)
+
 		select {
 		case <-updateTicker.C:
 			fps, err := app.fps.GetAllStoredFinalityProviders()
@@ -695,6 +1176,9 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 				continue
 			}
 			app.metrics.UpdateFpMetrics(fps)
+			if cb, ok := app.cc.(clientcontroller.CircuitBreaker); ok {
+				app.metrics.RecordSubmissionsPaused(cb.IsCircuitOpen())
+			}
 		case <-app.quit:
 			updateTicker.Stop()
 			app.logger.Info("exiting metrics update loop")
@@ -703,6 +1187,35 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 	}
 }
 
+// dbStatsLoop periodically records the size of the database and the number of
+// keys of its buckets
+func (app *FinalityProviderApp) dbStatsLoop() {
+	defer app.wg.Done()
+
+	interval := app.config.Metrics.DbStatsInterval
+	app.logger.Info("starting db stats loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+	statsTicker := time.NewTicker(interval)
+	defer statsTicker.Stop()
+	defer app.heartbeats.remove(dbStatsLoopName)
+
+	dbMetrics := metrics.NewDbMetrics()
+	path := app.config.DatabaseConfig.DBFilePath()
+	for {
+		app.heartbeats.beat(dbStatsLoopName, interval)
+
+		select {
+		case <-statsTicker.C:
+			if err := dbMetrics.UpdateDbStats(metrics.FpDbName, app.db, path); err != nil {
+				app.logger.Error("failed to update the db metrics", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting db stats loop")
+			return
+		}
+	}
+}
+
 // syncChainFpStatusLoop keeps querying the chain for the finality
 // provider voting power and update the FP status accordingly.
 // If there is some voting power it sets to active, for zero voting power
@@ -712,7 +1225,7 @@ func (app *FinalityProviderApp) metricsUpdateLoop() {
 func (app *FinalityProviderApp) syncChainFpStatusLoop() {
 	defer app.wg.Done()
 
-	interval := app.config.SyncFpStatusInterval
+	interval := app.config.GetSyncFpStatusInterval()
 	app.logger.Info(
 		"starting sync FP status loop",
 		zap.Float64("interval seconds", interval.Seconds()),
@@ -723,6 +1236,7 @@ func (app *FinalityProviderApp) syncChainFpStatusLoop() {
 	for {
 		select {
 		case <-syncFpStatusTicker.C:
+			syncFpStatusTicker.Reset(app.config.GetSyncFpStatusInterval())
 			fpInstanceStarted, err := app.SyncFinalityProviderStatus()
 			if err != nil {
 				app.Logger().Error("failed to sync finality-provider status", zap.Error(err))
@@ -1,18 +1,25 @@
 package service_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	sdkmath "cosmossdk.io/math"
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
 	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -81,6 +88,11 @@ func FuzzRegisterFinalityProvider(f *testing.F) {
 			require.NoError(t, err)
 		}()
 
+		// the commission can't be above the max commission
+		commission, maxCommission := sdkmath.LegacyNewDecWithPrec(2, 1), sdkmath.LegacyNewDecWithPrec(1, 1)
+		_, err = app.CreateFinalityProvider("key", "chain-id", passphrase, hdPath, nil, nil, testutil.RandomDescription(r), &commission, &maxCommission)
+		require.ErrorContains(t, err, "can't be greater than the max commission rate")
+
 		var eotsPk *bbntypes.BIP340PubKey
 		eotsPk = nil
 		generateEotsKeyBefore := r.Int31n(10) > 5
@@ -289,3 +301,393 @@ func FuzzUnjailFinalityProvider(f *testing.F) {
 		require.Equal(t, proto.FinalityProviderStatus_INACTIVE.String(), fpInfo.GetStatus())
 	})
 }
+
+func TestStopDrainsInFlightRegistration(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 2)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+
+	// the registration is still in flight when the app is stopped
+	registering := make(chan struct{})
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		RegisterFinalityProvider(fp.BtcPk, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, _, _ interface{}) (*types.TxResponse, error) {
+			close(registering)
+			time.Sleep(200 * time.Millisecond)
+			return &types.TxResponse{TxHash: txHash}, nil
+		}).Times(1)
+
+	type result struct {
+		res *service.RegisterFinalityProviderResponse
+		err error
+	}
+	resChan := make(chan result, 1)
+	go func() {
+		res, err := app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+		resChan <- result{res, err}
+	}()
+
+	<-registering
+	err = app.Stop()
+	require.NoError(t, err)
+
+	// the caller receives the result and the status is stored
+	res := <-resChan
+	require.NoError(t, res.err)
+	require.Equal(t, txHash, res.res.TxHash)
+	fpInfo, err := app.GetFinalityProviderInfo(fp.GetBIP340BTCPK())
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_REGISTERED.String(), fpInfo.Status)
+
+	// new requests are rejected rather than blocking forever
+	_, err = app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+	require.Error(t, err)
+	_, err = app.CreateFinalityProvider("key", "chain-id", passphrase, hdPath, nil, nil, testutil.RandomDescription(r), testutil.ZeroCommissionRate(), nil)
+	require.ErrorIs(t, err, service.ErrFinalityProviderAppShutDown)
+}
+
+func TestOneShotRegisterAndCommitPubRand(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	currentHeight := uint64(100)
+	mockClientController := testutil.PrepareMockedClientController(t, r, currentHeight, currentHeight)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.StartOneShot()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, app.Stop())
+	}()
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+
+	// the randomness cannot be committed before the registration
+	_, err = app.CommitPubRand(fp.GetBIP340BTCPK(), passphrase)
+	require.Error(t, err)
+
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		RegisterFinalityProvider(fp.BtcPk, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).Times(1)
+	res, err := app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+	require.NoError(t, err)
+	require.Equal(t, txHash, res.TxHash)
+
+	// the registered finality provider is not started
+	fpInfo, err := app.GetFinalityProviderInfo(fp.GetBIP340BTCPK())
+	require.NoError(t, err)
+	require.Equal(t, proto.FinalityProviderStatus_REGISTERED.String(), fpInfo.Status)
+	require.False(t, fpInfo.IsRunning)
+
+	// the randomness is committed from the height after the tip once
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().
+		CommitPubRandList(fp.BtcPk, currentHeight+1, uint64(testutil.TestPubRandNum), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).Times(1)
+	commitRes, err := app.CommitPubRand(fp.GetBIP340BTCPK(), passphrase)
+	require.NoError(t, err)
+	require.Equal(t, txHash, commitRes.TxHash)
+}
+
+func TestEditFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 2)
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	defer func() {
+		err = app.Stop()
+		require.NoError(t, err)
+	}()
+
+	// create a finality provider whose commission can't exceed 0.1
+	keyName := testutil.GenRandomHexStr(r, 4)
+	_, err = service.CreateChainKey(fpCfg.BabylonConfig.KeyDirectory, fpCfg.BabylonConfig.ChainID, keyName, keyring.BackendTest, passphrase, hdPath, "")
+	require.NoError(t, err)
+	maxCommission := sdkmath.LegacyNewDecWithPrec(1, 1)
+	res, err := app.CreateFinalityProvider(keyName, "chain-id", passphrase, hdPath, nil, nil, testutil.RandomDescription(r), testutil.ZeroCommissionRate(), &maxCommission)
+	require.NoError(t, err)
+	require.Equal(t, maxCommission.String(), res.FpInfo.MaxCommission)
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(res.FpInfo.BtcPkHex)
+	require.NoError(t, err)
+
+	// an edit above the max commission is rejected before it is sent
+	aboveMax := sdkmath.LegacyNewDecWithPrec(2, 1)
+	err = app.EditFinalityProvider(fpPk, &proto.Description{}, &aboveMax)
+	require.ErrorContains(t, err, "can't be greater than the max commission rate")
+
+	// the edited fields are synced into the local store
+	rate := sdkmath.LegacyNewDecWithPrec(5, 2)
+	desc := stakingtypes.Description{Moniker: "new-moniker"}
+	mockClientController.EXPECT().EditFinalityProvider(fpPk.MustToBTCPK(), &rate, gomock.Any()).
+		Return(&bstypes.MsgEditFinalityProvider{Description: &desc, Commission: &rate}, nil).Times(1)
+	err = app.EditFinalityProvider(fpPk, &proto.Description{Moniker: desc.Moniker}, &rate)
+	require.NoError(t, err)
+	fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.Equal(t, desc.Moniker, fpInfo.Description.Moniker)
+	require.Equal(t, rate.String(), fpInfo.Commission)
+	require.Equal(t, maxCommission.String(), fpInfo.MaxCommission)
+}
+
+func TestCreateFinalityProviderWithPoP(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 2)
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	defer func() {
+		err = app.Stop()
+		require.NoError(t, err)
+	}()
+
+	keyName := testutil.GenRandomHexStr(r, 4)
+	keyInfo, err := service.CreateChainKey(fpCfg.BabylonConfig.KeyDirectory, fpCfg.BabylonConfig.ChainID, keyName, keyring.BackendTest, passphrase, hdPath, "")
+	require.NoError(t, err)
+
+	// the EOTS key is kept offline, so the EOTS manager does not have it
+	eotsSk, eotsPk, err := datagen.GenRandomBTCKeyPair(r)
+	require.NoError(t, err)
+	fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(eotsPk)
+
+	// a PoP over another address is rejected
+	otherPop, err := bstypes.NewPoPBTC(datagen.GenRandomAccount().GetAddress(), eotsSk)
+	require.NoError(t, err)
+	_, err = app.CreateFinalityProvider(keyName, "chain-id", passphrase, hdPath, fpPk, otherPop, testutil.RandomDescription(r), testutil.ZeroCommissionRate(), nil)
+	require.ErrorContains(t, err, "invalid proof-of-possession")
+
+	// the finality provider is created with the given PoP
+	pop, err := bstypes.NewPoPBTC(keyInfo.AccAddress, eotsSk)
+	require.NoError(t, err)
+	res, err := app.CreateFinalityProvider(keyName, "chain-id", passphrase, hdPath, fpPk, pop, testutil.RandomDescription(r), testutil.ZeroCommissionRate(), nil)
+	require.NoError(t, err)
+	require.Equal(t, fpPk.MarshalHex(), res.FpInfo.BtcPkHex)
+	fp, err := app.GetFinalityProviderStore().GetFinalityProvider(eotsPk)
+	require.NoError(t, err)
+	require.Equal(t, pop.BtcSig, fp.Pop.BtcSig)
+}
+
+func TestFinalityProvidersStatus(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	currentHeight := uint64(10)
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, currentHeight)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+
+	// the created finality provider is not queried on the consumer chain
+	createdFp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+	activeFp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+
+	// the status is read from the store and the consumer chain only
+	err = app.Stop()
+	require.NoError(t, err)
+
+	err = app.GetFinalityProviderStore().SetFpStatus(activeFp.BtcPk, proto.FinalityProviderStatus_ACTIVE)
+	require.NoError(t, err)
+	err = app.GetFinalityProviderStore().SetFpLastProcessedHeight(activeFp.BtcPk, 6)
+	require.NoError(t, err)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(activeFp.BtcPk, uint64(1)).
+		Return(map[uint64]*ftypes.PubRandCommitResponse{5: {NumPubRand: 100}}, nil).Times(1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(activeFp.BtcPk, currentHeight).
+		Return(uint64(7), nil).Times(1)
+
+	statusList, err := app.FinalityProvidersStatus()
+	require.NoError(t, err)
+	require.Len(t, statusList, 2)
+	statuses := make(map[string]*proto.FinalityProviderStatusInfo)
+	for _, s := range statusList {
+		statuses[s.BtcPkHex] = s
+	}
+
+	created := statuses[createdFp.GetBIP340BTCPK().MarshalHex()]
+	require.Equal(t, proto.FinalityProviderStatus_CREATED.String(), created.Status)
+	require.Equal(t, currentHeight, created.TipHeight)
+	require.Equal(t, currentHeight, created.PendingBlocks)
+	require.Zero(t, created.LastCommittedHeight)
+	require.Zero(t, created.VotingPower)
+	require.Empty(t, created.ChainError)
+
+	active := statuses[activeFp.GetBIP340BTCPK().MarshalHex()]
+	require.Equal(t, proto.FinalityProviderStatus_ACTIVE.String(), active.Status)
+	require.Equal(t, activeFp.FPAddr, active.FpAddr)
+	require.Equal(t, uint64(104), active.LastCommittedHeight)
+	require.Equal(t, uint64(6), active.LastProcessedHeight)
+	require.Equal(t, uint64(4), active.PendingBlocks)
+	require.Equal(t, uint64(7), active.VotingPower)
+	require.Empty(t, active.ChainError)
+
+	// the failed chain queries are reported without failing the whole query
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(activeFp.BtcPk, uint64(1)).
+		Return(nil, errors.New("connection refused")).Times(1)
+	statusList, err = app.FinalityProvidersStatus()
+	require.NoError(t, err)
+	require.Len(t, statusList, 2)
+	for _, s := range statusList {
+		if s.BtcPkHex == active.BtcPkHex {
+			require.Contains(t, s.ChainError, "connection refused")
+			require.Equal(t, uint64(4), s.PendingBlocks)
+		} else {
+			require.Empty(t, s.ChainError)
+		}
+	}
+}
+
+func TestRegistrationWebhook(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// the webhook rejects the first registration and approves the second one
+	var (
+		calls    int
+		received service.RegistrationApprovalRequest
+	)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&received))
+		if calls == 1 {
+			http.Error(w, "pending review", http.StatusForbidden)
+		}
+	}))
+	defer webhook.Close()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 2)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).Return(uint64(0), nil).AnyTimes()
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.RegistrationWebhookURL = webhook.URL
+	fpCfg.RegistrationWebhookToken = "secret"
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.Start()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, app.Stop())
+	}()
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+
+	// the rejected registration is not submitted
+	_, err = app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+	require.ErrorIs(t, err, service.ErrRegistrationNotApproved)
+	require.ErrorContains(t, err, "pending review")
+	require.Equal(t, fp.GetBIP340BTCPK().MarshalHex(), received.BtcPkHex)
+	require.Equal(t, fp.FPAddr, received.FpAddr)
+	require.Equal(t, fp.Description.Moniker, received.Description.Moniker)
+
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		RegisterFinalityProvider(fp.BtcPk, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).Times(1)
+	res, err := app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+	require.NoError(t, err)
+	require.Equal(t, txHash, res.TxHash)
+	require.Equal(t, 2, calls)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// AuditOpFinalitySig is the audited operation of signing a block with EOTS
+	AuditOpFinalitySig = "finality_signature"
+	// AuditOpPubRandCommit is the audited operation of signing a commitment of
+	// public randomness with Schnorr
+	AuditOpPubRandCommit = "public_randomness_commit"
+	// AuditOpEmergencyStop is the audited operation of halting all the
+	// signing and the submissions of the daemon
+	AuditOpEmergencyStop = "emergency_stop"
+	// AuditOpResume is the audited operation of lifting the emergency stop
+	AuditOpResume = "resume"
+)
+
+// ErrAuditLogTampered is returned if the entries of the audit log do not
+// form a hash chain, i.e., an entry was modified, removed, or reordered
+var ErrAuditLogTampered = errors.New("the audit log was tampered with")
+
+// AuditEntry is an entry of the audit log recording a signing or an
+// administrative operation. Each entry commits to the previous one through
+// PrevHash so that the log cannot be altered without breaking the chain
+type AuditEntry struct {
+	Seq       uint64    `json:"seq"`
+	Timestamp time.Time `json:"timestamp"`
+	// Signer is the hex BTC public key of the finality provider, which is
+	// empty for the administrative operations
+	Signer    string `json:"signer"`
+	Operation string `json:"operation"`
+	// Height is the height of the signed block, or the start height of the
+	// committed public randomness
+	Height uint64 `json:"height"`
+	// NumPubRand is the number of the committed public randomness
+	NumPubRand uint64 `json:"num_pub_rand,omitempty"`
+	// Hash is the hex hash of the signed block, or the hex commitment of the
+	// public randomness
+	Hash string `json:"hash"`
+	// TxHash is the hash of the transaction carrying the signature, which is
+	// empty if the submission failed
+	TxHash string `json:"tx_hash"`
+	// Reason is why the operator stopped or resumed the daemon, which is
+	// empty for the signing operations
+	Reason   string `json:"reason,omitempty"`
+	PrevHash string `json:"prev_hash"`
+	// EntryHash is the hex SHA-256 hash of the entry without this field
+	EntryHash string `json:"entry_hash"`
+}
+
+func (e *AuditEntry) computeHash() (string, error) {
+	c := *e
+	c.EntryHash = ""
+	bz, err := json.Marshal(&c)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(bz)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// AuditLog is an append-only and hash-chained log of the signing operations
+type AuditLog struct {
+	mu       sync.Mutex
+	f        *os.File
+	seq      uint64
+	lastHash string
+}
+
+// OpenAuditLog opens the audit log at the given path, creating it if it does
+// not exist, after verifying its entries. It returns a nil log if the path is
+// empty, on which appending is a no-op
+func OpenAuditLog(path string) (*AuditLog, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log: %w", err)
+	}
+
+	entries, validSize, err := readAuditEntries(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("invalid audit log %s: %w", path, err)
+	}
+
+	// drop the last entry if it was partially written before a crash
+	if err := f.Truncate(validSize); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	if _, err := f.Seek(validSize, io.SeekStart); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	l := &AuditLog{f: f}
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		l.seq = last.Seq
+		l.lastHash = last.EntryHash
+	}
+
+	return l, nil
+}
+
+// Append records the given signing operation. It is a no-op on a nil receiver
+func (l *AuditLog) Append(e AuditEntry) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e.Seq = l.seq + 1
+	e.Timestamp = time.Now().UTC()
+	e.PrevHash = l.lastHash
+	entryHash, err := e.computeHash()
+	if err != nil {
+		return err
+	}
+	e.EntryHash = entryHash
+
+	bz, err := json.Marshal(&e)
+	if err != nil {
+		return err
+	}
+	if _, err := l.f.Write(append(bz, '\n')); err != nil {
+		return fmt.Errorf("failed to write the audit log: %w", err)
+	}
+	if err := l.f.Sync(); err != nil {
+		return fmt.Errorf("failed to sync the audit log: %w", err)
+	}
+
+	l.seq = e.Seq
+	l.lastHash = e.EntryHash
+
+	return nil
+}
+
+// Close closes the audit log. It is a no-op on a nil receiver
+func (l *AuditLog) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.f.Close()
+}
+
+// ReadAuditLog reads the entries of the audit log at the given path and
+// verifies that they form a hash chain
+func ReadAuditLog(path string) ([]*AuditEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the audit log: %w", err)
+	}
+	defer f.Close()
+
+	entries, _, err := readAuditEntries(f)
+	return entries, err
+}
+
+// readAuditEntries reads and verifies the complete entries, and returns the
+// size of the data they span, which excludes a trailing partial entry
+func readAuditEntries(r io.Reader) ([]*AuditEntry, int64, error) {
+	var (
+		entries  []*AuditEntry
+		size     int64
+		lastHash string
+	)
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// an entry without the trailing newline was not completely written
+			return entries, size, nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var e AuditEntry
+		if err := json.Unmarshal(bytes.TrimSpace(line), &e); err != nil {
+			return nil, 0, fmt.Errorf("%w: entry %d cannot be decoded: %v", ErrAuditLogTampered, len(entries)+1, err)
+		}
+		if e.Seq != uint64(len(entries))+1 || e.PrevHash != lastHash {
+			return nil, 0, fmt.Errorf("%w: entry %d does not follow entry %d", ErrAuditLogTampered, e.Seq, len(entries))
+		}
+		entryHash, err := e.computeHash()
+		if err != nil {
+			return nil, 0, err
+		}
+		if entryHash != e.EntryHash {
+			return nil, 0, fmt.Errorf("%w: the hash of entry %d does not match its content", ErrAuditLogTampered, e.Seq)
+		}
+
+		entries = append(entries, &e)
+		size += int64(len(line))
+		lastHash = e.EntryHash
+	}
+}
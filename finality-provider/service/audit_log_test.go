@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+func TestAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "audit.log")
+
+	auditLog, err := service.OpenAuditLog(path)
+	require.NoError(t, err)
+	err = auditLog.Append(service.AuditEntry{Signer: "fp", Operation: service.AuditOpPubRandCommit, Height: 1, NumPubRand: 100, Hash: "aa", TxHash: "tx1"})
+	require.NoError(t, err)
+	err = auditLog.Append(service.AuditEntry{Signer: "fp", Operation: service.AuditOpFinalitySig, Height: 1, Hash: "bb", TxHash: "tx2"})
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Close())
+
+	// the chain continues after reopening the log, and a partially written
+	// entry is dropped
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":3,"sig`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	auditLog, err = service.OpenAuditLog(path)
+	require.NoError(t, err)
+	err = auditLog.Append(service.AuditEntry{Signer: "fp", Operation: service.AuditOpFinalitySig, Height: 2, Hash: "cc"})
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Close())
+
+	entries, err := service.ReadAuditLog(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		require.Equal(t, uint64(i+1), e.Seq)
+		if i > 0 {
+			require.Equal(t, entries[i-1].EntryHash, e.PrevHash)
+		}
+	}
+	require.Equal(t, "tx2", entries[1].TxHash)
+	require.Equal(t, uint64(2), entries[2].Height)
+	require.Empty(t, entries[2].TxHash)
+
+	// modifying an entry breaks the chain
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := bytes.Replace(data, []byte(`"tx_hash":"tx2"`), []byte(`"tx_hash":"tx3"`), 1)
+	require.NoError(t, os.WriteFile(path, tampered, 0600))
+	_, err = service.ReadAuditLog(path)
+	require.ErrorIs(t, err, service.ErrAuditLogTampered)
+	_, err = service.OpenAuditLog(path)
+	require.ErrorIs(t, err, service.ErrAuditLogTampered)
+
+	// removing an entry breaks the chain
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	removed := append(append([]byte{}, lines[0]...), lines[2]...)
+	require.NoError(t, os.WriteFile(path, removed, 0600))
+	_, err = service.ReadAuditLog(path)
+	require.ErrorIs(t, err, service.ErrAuditLogTampered)
+
+	// the audit log is disabled with an empty path
+	auditLog, err = service.OpenAuditLog("")
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Append(service.AuditEntry{}))
+	require.NoError(t, auditLog.Close())
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// backupLoop periodically writes a hot snapshot of the database into the
+// backup directory and deletes the snapshots beyond the retention
+func (app *FinalityProviderApp) backupLoop() {
+	defer app.wg.Done()
+
+	cfg := app.config.BackupConfig
+	app.logger.Info("starting backup loop",
+		zap.Float64("interval seconds", cfg.Interval.Seconds()),
+		zap.String("dir", cfg.Dir))
+	backupTicker := time.NewTicker(cfg.Interval)
+	defer backupTicker.Stop()
+	defer app.heartbeats.remove(backupLoopName)
+
+	for {
+		app.heartbeats.beat(backupLoopName, cfg.Interval)
+
+		select {
+		case <-backupTicker.C:
+			app.backup()
+		case <-app.quit:
+			app.logger.Info("exiting backup loop")
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) backup() {
+	cfg := app.config.BackupConfig
+	path, err := store.WriteBackup(app.db, cfg.Dir, time.Now())
+	if err != nil {
+		app.logger.Error("failed to back up the database", zap.Error(err))
+		return
+	}
+	app.logger.Info("successfully backed up the database", zap.String("path", path))
+
+	pruned, err := store.PruneBackups(cfg.Dir, cfg.Retention)
+	if err != nil {
+		app.logger.Error("failed to delete the old backups", zap.Error(err))
+		return
+	}
+	for _, p := range pruned {
+		app.logger.Debug("deleted the old backup", zap.String("path", p))
+	}
+}
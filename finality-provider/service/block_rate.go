@@ -0,0 +1,77 @@
+package service
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// blockRateWindow is the number of tips the block rate is estimated over
+const blockRateWindow = 10
+
+type blockSample struct {
+	height uint64
+	time   time.Time
+}
+
+// blockRateEstimator estimates the block rate of the consumer chain from the
+// tips observed over time
+type blockRateEstimator struct {
+	mu      sync.Mutex
+	samples []blockSample
+}
+
+// observe records the tip at the given time. A tip lower than the previous
+// one restarts the estimation
+func (e *blockRateEstimator) observe(height uint64, t time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if n := len(e.samples); n > 0 && height < e.samples[n-1].height {
+		e.samples = nil
+	}
+	e.samples = append(e.samples, blockSample{height: height, time: t})
+	if len(e.samples) > blockRateWindow {
+		e.samples = e.samples[len(e.samples)-blockRateWindow:]
+	}
+}
+
+// blocksPerSecond returns the estimated block rate, or 0 if there are not
+// enough tips observed yet
+func (e *blockRateEstimator) blocksPerSecond() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.samples) < 2 {
+		return 0
+	}
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	elapsed := last.time.Sub(first.time).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(last.height-first.height) / elapsed
+}
+
+// numPubRandToCommit returns the number of public randomness of the next
+// commitment. With RandCommitDuration set, it covers the estimated number of
+// blocks produced within the duration, bounded below by MinRandHeightGap so
+// that a commitment is not needed at every tick, and above by NumPubRandMax.
+// Otherwise, or until the block rate is known, it is NumPubRand
+func (fp *FinalityProviderInstance) numPubRandToCommit() uint32 {
+	randCfg := fp.randCfg()
+	if fp.cfg.RandCommitDuration == 0 {
+		return randCfg.NumPubRand
+	}
+	rate := fp.blockRate.blocksPerSecond()
+	if rate == 0 {
+		return randCfg.NumPubRand
+	}
+
+	num := math.Ceil(rate * fp.cfg.RandCommitDuration.Seconds())
+	num = math.Max(num, float64(randCfg.MinRandHeightGap))
+	num = math.Min(num, float64(fp.cfg.NumPubRandMax))
+
+	return uint32(math.Max(num, 1))
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 	"time"
@@ -37,6 +38,22 @@ type skipHeightResponse struct {
 	err error
 }
 
+// ReorgEvent notifies that the consumer chain replaced blocks that the
+// poller has already delivered
+type ReorgEvent struct {
+	// ForkHeight is the lowest height whose block has changed
+	ForkHeight uint64
+	// Blocks are the blocks of the new chain from ForkHeight up to the
+	// last polled height, in ascending order
+	Blocks []*types.BlockInfo
+}
+
+// heightRange is the range of heights [start, end]
+type heightRange struct {
+	start uint64
+	end   uint64
+}
+
 type ChainPoller struct {
 	isStarted *atomic.Bool
 	wg        sync.WaitGroup
@@ -44,11 +61,21 @@ type ChainPoller struct {
 
 	cc             clientcontroller.ClientController
 	cfg            *cfg.ChainPollerConfig
+	heartbeats     *Heartbeats
+	tipCache       *TipCache
+	alerter        *Alerter
+	maintenance    *maintenanceState
 	metrics        *metrics.FpMetrics
 	blockInfoChan  chan *types.BlockInfo
 	skipHeightChan chan *skipHeightRequest
+	reorgChan      chan *ReorgEvent
+	recentBlocks   []*types.BlockInfo
 	nextHeight     uint64
 	logger         *zap.Logger
+
+	// gaps are the ranges of the blocks dropped from the full buffer
+	gaps   []*heightRange
+	gapsMu sync.Mutex
 }
 
 func NewChainPoller(
@@ -65,6 +92,7 @@ func NewChainPoller(
 		metrics:        metrics,
 		blockInfoChan:  make(chan *types.BlockInfo, cfg.BufferSize),
 		skipHeightChan: make(chan *skipHeightRequest),
+		reorgChan:      make(chan *ReorgEvent, 1),
 		quit:           make(chan struct{}),
 	}
 }
@@ -122,6 +150,11 @@ func (cp *ChainPoller) GetBlockInfoChan() <-chan *types.BlockInfo {
 	return cp.blockInfoChan
 }
 
+// GetReorgChan returns the read only channel of detected chain reorgs
+func (cp *ChainPoller) GetReorgChan() <-chan *ReorgEvent {
+	return cp.reorgChan
+}
+
 func (cp *ChainPoller) latestBlockWithRetry() (*types.BlockInfo, error) {
 	var (
 		latestBlock *types.BlockInfo
@@ -144,6 +177,8 @@ func (cp *ChainPoller) latestBlockWithRetry() (*types.BlockInfo, error) {
 	})); err != nil {
 		return nil, err
 	}
+	cp.tipCache.update(latestBlock)
+
 	return latestBlock, nil
 }
 
@@ -205,6 +240,8 @@ func (cp *ChainPoller) validateStartHeight(startHeight uint64) error {
 func (cp *ChainPoller) waitForActivation() {
 	// ensure that the startHeight is no lower than the activated height
 	for {
+		cp.heartbeats.beat(chainPollerLoopName, cp.cfg.GetPollInterval())
+
 		activatedHeight, err := cp.cc.QueryActivatedHeight()
 		if err != nil {
 			cp.logger.Debug("failed to query the consumer chain for the activated height", zap.Error(err))
@@ -216,7 +253,7 @@ func (cp *ChainPoller) waitForActivation() {
 		}
 
 		select {
-		case <-time.After(cp.cfg.PollInterval):
+		case <-time.After(cp.cfg.GetPollInterval()):
 
 		case <-cp.quit:
 			return
@@ -228,45 +265,60 @@ func (cp *ChainPoller) waitForActivation() {
 func (cp *ChainPoller) pollChain() {
 	defer cp.wg.Done()
 
+	defer cp.heartbeats.remove(chainPollerLoopName)
+
 	cp.waitForActivation()
 
 	var failedCycles uint32
 
 	for {
-		// TODO: Handlig of request cancellation, as otherwise shutdown will be blocked
-		// until request is finished
-		blockToRetrieve := cp.nextHeight
-		block, err := cp.blockWithRetry(blockToRetrieve)
-		if err != nil {
-			failedCycles++
-			cp.logger.Debug(
-				"failed to query the consumer chain for the block",
-				zap.Uint32("current_failures", failedCycles),
-				zap.Uint64("block_to_retrieve", blockToRetrieve),
-				zap.Error(err),
-			)
-		} else {
-			// no error and we got the header we wanted to get, bump the state and push
-			// notification about data
-			cp.nextHeight = blockToRetrieve + 1
-			failedCycles = 0
-			cp.metrics.RecordLastPolledHeight(block.Height)
-
-			cp.logger.Info("the poller retrieved the block from the consumer chain",
-				zap.Uint64("height", block.Height))
-
-			// push the data to the channel
-			// Note: if the consumer is too slow -- the buffer is full
-			// the channel will block, and we will stop retrieving data from the node
-			cp.blockInfoChan <- block
-		}
+		cp.heartbeats.beat(chainPollerLoopName, cp.cfg.GetPollInterval())
+
+		// no new blocks are polled in maintenance, while the requests to skip
+		// heights are still served
+		if !cp.maintenance.active() {
+			// TODO: Handlig of request cancellation, as otherwise shutdown will be blocked
+			// until request is finished
+			blockToRetrieve := cp.nextHeight
+			block, err := cp.blockWithRetry(blockToRetrieve)
+			if err == nil {
+				// the reorg is checked after the block is retrieved, so an
+				// unchanged last block means the new one extends the same chain
+				err = cp.checkReorg()
+			}
+			if err != nil {
+				failedCycles++
+				cp.logger.Debug(
+					"failed to query the consumer chain for the block",
+					zap.Uint32("current_failures", failedCycles),
+					zap.Uint64("block_to_retrieve", blockToRetrieve),
+					zap.Error(err),
+				)
+			} else {
+				// no error and we got the header we wanted to get, bump the state and push
+				// notification about data
+				cp.nextHeight = blockToRetrieve + 1
+				failedCycles = 0
+				cp.metrics.RecordLastPolledHeight(block.Height)
+				cp.tipCache.observe(block)
+				cp.rememberBlock(block)
+
+				cp.logger.Info("the poller retrieved the block from the consumer chain",
+					zap.Uint64("height", block.Height))
+
+				cp.pushBlock(block)
+			}
 
-		if failedCycles > maxFailedCycles {
-			cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
+			if failedCycles > maxFailedCycles {
+				cp.alerter.Fire(AlertFatal, AlertSeverityCritical, "",
+					"the poller has reached the max failed cycles to retrieve blocks from the consumer chain, exiting")
+				cp.alerter.Flush()
+				cp.logger.Fatal("the poller has reached the max failed cycles, exiting")
+			}
 		}
 
 		select {
-		case <-time.After(cp.cfg.PollInterval):
+		case <-time.After(cp.cfg.GetPollInterval()):
 
 		case req := <-cp.skipHeightChan:
 			// no need to skip heights if the target height is not higher
@@ -283,6 +335,7 @@ func (cp *ChainPoller) pollChain() {
 
 			// drain blocks that can be skipped from blockInfoChan
 			cp.clearChanBufferUpToHeight(targetHeight)
+			cp.clearGapsUpToHeight(targetHeight)
 
 			// set the next height to the skip height
 			cp.nextHeight = targetHeight
@@ -298,6 +351,141 @@ func (cp *ChainPoller) pollChain() {
 	}
 }
 
+// pushBlock pushes the block to the channel. If the consumer is too slow and
+// the buffer is full, either the channel blocks, and we will stop retrieving
+// data from the node, or the oldest buffered block is dropped as a gap
+func (cp *ChainPoller) pushBlock(block *types.BlockInfo) {
+	if cp.cfg.OverflowPolicy != cfg.PollerOverflowDropOldest {
+		cp.blockInfoChan <- block
+		cp.metrics.RecordPollerQueueDepth(len(cp.blockInfoChan))
+		return
+	}
+
+	for {
+		select {
+		case cp.blockInfoChan <- block:
+			cp.metrics.RecordPollerQueueDepth(len(cp.blockInfoChan))
+			return
+		default:
+		}
+		cp.dropOldestBlock()
+	}
+}
+
+// dropOldestBlock drops the oldest buffered block and records it as a gap.
+// The gap is recorded under the lock, so a consumer receiving a later block
+// always sees the gaps below it
+func (cp *ChainPoller) dropOldestBlock() {
+	cp.gapsMu.Lock()
+	defer cp.gapsMu.Unlock()
+
+	select {
+	case dropped := <-cp.blockInfoChan:
+		n := len(cp.gaps)
+		if n > 0 && cp.gaps[n-1].end+1 == dropped.Height {
+			cp.gaps[n-1].end = dropped.Height
+		} else {
+			cp.gaps = append(cp.gaps, &heightRange{start: dropped.Height, end: dropped.Height})
+		}
+		cp.metrics.IncrementPollerDroppedBlocks()
+		cp.logger.Warn("the poller buffer is full, dropped the oldest block to process it later",
+			zap.Uint64("height", dropped.Height))
+	default:
+	}
+}
+
+// takeGaps returns the ranges of the dropped blocks in ascending order and
+// clears them
+func (cp *ChainPoller) takeGaps() []*heightRange {
+	cp.gapsMu.Lock()
+	defer cp.gapsMu.Unlock()
+
+	gaps := cp.gaps
+	cp.gaps = nil
+
+	return gaps
+}
+
+// clearGapsUpToHeight drops the gaps below the given height
+func (cp *ChainPoller) clearGapsUpToHeight(upToHeight uint64) {
+	cp.gapsMu.Lock()
+	defer cp.gapsMu.Unlock()
+
+	var gaps []*heightRange
+	for _, g := range cp.gaps {
+		if g.end < upToHeight {
+			continue
+		}
+		if g.start < upToHeight {
+			g.start = upToHeight
+		}
+		gaps = append(gaps, g)
+	}
+	cp.gaps = gaps
+}
+
+// checkReorg re-checks the recently polled blocks and notifies the reorg
+// if any of them has been replaced
+func (cp *ChainPoller) checkReorg() error {
+	reorg, err := cp.detectReorg()
+	if err != nil {
+		return fmt.Errorf("failed to check for a chain reorg: %w", err)
+	}
+	if reorg == nil {
+		return nil
+	}
+
+	cp.logger.Warn("detected a chain reorg below the polled height",
+		zap.Uint64("fork_height", reorg.ForkHeight),
+		zap.Int("reorged_blocks", len(reorg.Blocks)))
+	select {
+	case cp.reorgChan <- reorg:
+	case <-cp.quit:
+	}
+
+	return nil
+}
+
+// detectReorg compares the recently polled blocks with the ones currently
+// on the consumer chain, from the highest down to the first unchanged one.
+// A reorg deeper than the window cannot be fully recovered
+func (cp *ChainPoller) detectReorg() (*ReorgEvent, error) {
+	var changed []*types.BlockInfo
+	for i := len(cp.recentBlocks) - 1; i >= 0; i-- {
+		polled := cp.recentBlocks[i]
+		current, err := cp.blockWithRetry(polled.Height)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(current.Hash, polled.Hash) {
+			break
+		}
+		changed = append([]*types.BlockInfo{current}, changed...)
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	copy(cp.recentBlocks[len(cp.recentBlocks)-len(changed):], changed)
+	if len(changed) == len(cp.recentBlocks) {
+		cp.logger.Warn("the chain reorg may be deeper than the checked blocks",
+			zap.Uint64("reorg_check_depth", cp.cfg.ReorgCheckDepth))
+	}
+
+	return &ReorgEvent{ForkHeight: changed[0].Height, Blocks: changed}, nil
+}
+
+// rememberBlock keeps the polled block for the reorg check
+func (cp *ChainPoller) rememberBlock(block *types.BlockInfo) {
+	if cp.cfg.ReorgCheckDepth == 0 {
+		return
+	}
+	cp.recentBlocks = append(cp.recentBlocks, block)
+	if uint64(len(cp.recentBlocks)) > cp.cfg.ReorgCheckDepth {
+		cp.recentBlocks = cp.recentBlocks[1:]
+	}
+}
+
 func (cp *ChainPoller) SkipToHeight(height uint64) error {
 	if !cp.IsRunning() {
 		return fmt.Errorf("the chain poller is stopped")
@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
@@ -152,3 +153,169 @@ func FuzzChainPoller_SkipHeight(f *testing.F) {
 		require.Equal(t, skipHeight+1, poller.NextHeight())
 	})
 }
+
+// FuzzChainPoller_Reorg tests that the poller notifies the blocks replaced
+// by a chain reorg below the polled height
+func FuzzChainPoller_Reorg(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		currentHeight := uint64(r.Int63n(100) + 1)
+		startHeight := currentHeight + 1
+		endHeight := startHeight + uint64(r.Int63n(10)+1)
+		forkHeight := startHeight + uint64(r.Int63n(int64(endHeight-startHeight+1)))
+
+		var mu sync.Mutex
+		hashes := make(map[uint64][]byte)
+		for i := startHeight; i <= endHeight; i++ {
+			hashes[i] = testutil.GenRandomByteArray(r, 32)
+		}
+
+		// the next block is held until the reorg and the ones after it until
+		// the poller is stopped to avoid spinning on retries
+		reorged := make(chan struct{})
+		closed := make(chan struct{})
+		ctl := gomock.NewController(t)
+		mockClientController := mocks.NewMockClientController(ctl)
+		mockClientController.EXPECT().Close().DoAndReturn(func() error {
+			close(closed)
+			return nil
+		}).Times(1)
+		mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+		currentBlockRes := &types.BlockInfo{
+			Height: currentHeight,
+		}
+		mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBlock(gomock.Any()).DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+			switch {
+			case height == endHeight+1:
+				select {
+				case <-reorged:
+				case <-closed:
+				}
+			case height > endHeight+1:
+				<-closed
+				return &types.BlockInfo{Height: height}, nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			hash, ok := hashes[height]
+			if !ok {
+				return nil, fmt.Errorf("block %d not found", height)
+			}
+			return &types.BlockInfo{Height: height, Hash: hash}, nil
+		}).AnyTimes()
+
+		m := metrics.NewFpMetrics()
+		pollerCfg := fpcfg.DefaultChainPollerConfig()
+		pollerCfg.PollInterval = 10 * time.Millisecond
+		pollerCfg.ReorgCheckDepth = 20
+		poller := service.NewChainPoller(zap.NewNop(), &pollerCfg, mockClientController, m)
+		err := poller.Start(startHeight)
+		require.NoError(t, err)
+		defer func() {
+			err := poller.Stop()
+			require.NoError(t, err)
+		}()
+
+		for i := startHeight; i <= endHeight; i++ {
+			select {
+			case info := <-poller.GetBlockInfoChan():
+				require.Equal(t, i, info.Height)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("Failed to get block info")
+			}
+		}
+
+		// replace the blocks from the fork height and extend the new chain
+		mu.Lock()
+		for i := forkHeight; i <= endHeight+1; i++ {
+			hashes[i] = testutil.GenRandomByteArray(r, 32)
+		}
+		mu.Unlock()
+		close(reorged)
+
+		select {
+		case reorg := <-poller.GetReorgChan():
+			require.Equal(t, forkHeight, reorg.ForkHeight)
+			require.Len(t, reorg.Blocks, int(endHeight-forkHeight+1))
+			mu.Lock()
+			for _, b := range reorg.Blocks {
+				require.Equal(t, hashes[b.Height], b.Hash)
+			}
+			mu.Unlock()
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Failed to get the reorg event")
+		}
+	})
+}
+
+// FuzzChainPoller_DropOldest tests that the poller keeps polling and drops
+// the oldest buffered blocks when the buffer is full
+func FuzzChainPoller_DropOldest(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		currentHeight := uint64(r.Int63n(100) + 1)
+		startHeight := currentHeight + 1
+		bufferSize := uint32(r.Int63n(5) + 1)
+		endHeight := startHeight + uint64(bufferSize) + uint64(r.Int63n(10)+1)
+
+		// the blocks above the end height are held until the poller is
+		// stopped to avoid spinning on retries, and querying them means
+		// that all the blocks up to the end height are pushed
+		closed := make(chan struct{})
+		polledAll := make(chan struct{})
+		var polledAllOnce sync.Once
+		ctl := gomock.NewController(t)
+		mockClientController := mocks.NewMockClientController(ctl)
+		mockClientController.EXPECT().Close().DoAndReturn(func() error {
+			close(closed)
+			return nil
+		}).Times(1)
+		mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+		currentBlockRes := &types.BlockInfo{
+			Height: currentHeight,
+		}
+		mockClientController.EXPECT().QueryBestBlock().Return(currentBlockRes, nil).AnyTimes()
+		mockClientController.EXPECT().QueryBlock(gomock.Any()).DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+			if height > endHeight {
+				polledAllOnce.Do(func() { close(polledAll) })
+				<-closed
+			}
+			return &types.BlockInfo{Height: height}, nil
+		}).AnyTimes()
+
+		m := metrics.NewFpMetrics()
+		pollerCfg := fpcfg.DefaultChainPollerConfig()
+		pollerCfg.PollInterval = 10 * time.Millisecond
+		pollerCfg.BufferSize = bufferSize
+		pollerCfg.OverflowPolicy = fpcfg.PollerOverflowDropOldest
+		poller := service.NewChainPoller(zap.NewNop(), &pollerCfg, mockClientController, m)
+		err := poller.Start(startHeight)
+		require.NoError(t, err)
+		defer func() {
+			err := poller.Stop()
+			require.NoError(t, err)
+		}()
+
+		// the poller is not blocked by the full buffer
+		select {
+		case <-polledAll:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Failed to poll all the blocks")
+		}
+
+		// only the latest blocks are left in the buffer
+		for i := endHeight - uint64(bufferSize) + 1; i <= endHeight; i++ {
+			select {
+			case info := <-poller.GetBlockInfoChan():
+				require.Equal(t, i, info.Height)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("Failed to get block info")
+			}
+		}
+	})
+}
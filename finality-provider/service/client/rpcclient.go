@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/staking/types"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 )
@@ -60,9 +61,9 @@ func (c *FinalityProviderServiceGRpcClient) RegisterFinalityProvider(
 
 func (c *FinalityProviderServiceGRpcClient) CreateFinalityProvider(
 	ctx context.Context,
-	keyName, chainID, eotsPkHex, passphrase, hdPath string,
+	keyName, chainID, eotsPkHex, popHex, passphrase, hdPath string,
 	description types.Description,
-	commission *sdkmath.LegacyDec,
+	commission, maxCommission *sdkmath.LegacyDec,
 ) (*proto.CreateFinalityProviderResponse, error) {
 
 	descBytes, err := description.Marshal()
@@ -70,14 +71,21 @@ func (c *FinalityProviderServiceGRpcClient) CreateFinalityProvider(
 		return nil, err
 	}
 
+	var maxCommissionStr string
+	if maxCommission != nil {
+		maxCommissionStr = maxCommission.String()
+	}
+
 	req := &proto.CreateFinalityProviderRequest{
-		KeyName:     keyName,
-		ChainId:     chainID,
-		Passphrase:  passphrase,
-		HdPath:      hdPath,
-		Description: descBytes,
-		Commission:  commission.String(),
-		EotsPkHex:   eotsPkHex,
+		KeyName:       keyName,
+		ChainId:       chainID,
+		Passphrase:    passphrase,
+		HdPath:        hdPath,
+		Description:   descBytes,
+		Commission:    commission.String(),
+		EotsPkHex:     eotsPkHex,
+		MaxCommission: maxCommissionStr,
+		PopHex:        popHex,
 	}
 
 	res, err := c.client.CreateFinalityProvider(ctx, req)
@@ -116,6 +124,96 @@ func (c *FinalityProviderServiceGRpcClient) UnjailFinalityProvider(ctx context.C
 	return res, nil
 }
 
+func (c *FinalityProviderServiceGRpcClient) PauseFinalityProvider(ctx context.Context, fpPk string) error {
+	req := &proto.PauseFinalityProviderRequest{
+		BtcPk: fpPk,
+	}
+
+	_, err := c.client.PauseFinalityProvider(ctx, req)
+
+	return err
+}
+
+func (c *FinalityProviderServiceGRpcClient) ResumeFinalityProvider(ctx context.Context, fpPk, passphrase string) error {
+	req := &proto.ResumeFinalityProviderRequest{
+		BtcPk:      fpPk,
+		Passphrase: passphrase,
+	}
+
+	_, err := c.client.ResumeFinalityProvider(ctx, req)
+
+	return err
+}
+
+// EmergencyStop - halts all the signing and the submissions of the daemon,
+// authenticated with the admin token
+func (c *FinalityProviderServiceGRpcClient) EmergencyStop(ctx context.Context, adminToken, reason string) error {
+	req := &proto.EmergencyStopRequest{
+		Reason: reason,
+	}
+
+	_, err := c.client.EmergencyStop(withAdminToken(ctx, adminToken), req)
+
+	return err
+}
+
+// Resume - lifts the emergency stop of the daemon, authenticated with the
+// admin token
+func (c *FinalityProviderServiceGRpcClient) Resume(ctx context.Context, adminToken, reason, passphrase string) error {
+	req := &proto.ResumeRequest{
+		Reason:     reason,
+		Passphrase: passphrase,
+	}
+
+	_, err := c.client.Resume(withAdminToken(ctx, adminToken), req)
+
+	return err
+}
+
+// EnterMaintenance - stops generating new work in the daemon and returns once
+// the in-flight submissions are finished, or fails if ctx is done first
+func (c *FinalityProviderServiceGRpcClient) EnterMaintenance(ctx context.Context) error {
+	_, err := c.client.EnterMaintenance(ctx, &proto.EnterMaintenanceRequest{})
+
+	return err
+}
+
+// ExitMaintenance - resumes the work stopped by EnterMaintenance
+func (c *FinalityProviderServiceGRpcClient) ExitMaintenance(ctx context.Context) error {
+	_, err := c.client.ExitMaintenance(ctx, &proto.ExitMaintenanceRequest{})
+
+	return err
+}
+
+// withAdminToken attaches the admin token to the request as a bearer token
+func withAdminToken(ctx context.Context, adminToken string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+adminToken)
+}
+
+func (c *FinalityProviderServiceGRpcClient) SetRewardAddress(ctx context.Context, fpPk, rewardAddress string) error {
+	req := &proto.SetRewardAddressRequest{
+		BtcPk:         fpPk,
+		RewardAddress: rewardAddress,
+	}
+
+	_, err := c.client.SetRewardAddress(ctx, req)
+
+	return err
+}
+
+func (c *FinalityProviderServiceGRpcClient) WithdrawRewards(ctx context.Context, recipient string) (*proto.WithdrawRewardsResponse, error) {
+	req := &proto.WithdrawRewardsRequest{
+		Recipient: recipient,
+	}
+
+	res, err := c.client.WithdrawRewards(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderList(ctx context.Context) (*proto.QueryFinalityProviderListResponse, error) {
 	req := &proto.QueryFinalityProviderListRequest{}
 	res, err := c.client.QueryFinalityProviderList(ctx, req)
@@ -126,6 +224,150 @@ func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderList(ctx contex
 	return res, nil
 }
 
+// QueryFinalityProviderStatus - gets the status of the stored finality providers
+// together with their progress on the consumer chain
+func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderStatus(ctx context.Context) (*proto.QueryFinalityProviderStatusResponse, error) {
+	req := &proto.QueryFinalityProviderStatusRequest{}
+	res, err := c.client.QueryFinalityProviderStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// QueryMissedBlocks - gets the blocks that the finality provider failed to vote for,
+// or skipped on purpose as already finalized if skipped is set
+func (c *FinalityProviderServiceGRpcClient) QueryMissedBlocks(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	startHeight, endHeight, limit uint64,
+	skipped bool,
+) (*proto.QueryMissedBlocksResponse, error) {
+	req := &proto.QueryMissedBlocksRequest{
+		BtcPk:       fpPk.MarshalHex(),
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Limit:       limit,
+		Skipped:     skipped,
+	}
+	res, err := c.client.QueryMissedBlocks(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// QuerySubmissions - gets the txs submitted on behalf of the finality provider
+func (c *FinalityProviderServiceGRpcClient) QuerySubmissions(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	txType string,
+	limit uint64,
+) (*proto.QuerySubmissionsResponse, error) {
+	req := &proto.QuerySubmissionsRequest{
+		BtcPk: fpPk.MarshalHex(),
+		Type:  txType,
+		Limit: limit,
+	}
+	res, err := c.client.QuerySubmissions(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ProveVote - gets the proof of the vote of the finality provider at the height
+func (c *FinalityProviderServiceGRpcClient) ProveVote(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	height uint64,
+) (*proto.ProveVoteResponse, error) {
+	req := &proto.ProveVoteRequest{
+		BtcPk:  fpPk.MarshalHex(),
+		Height: height,
+	}
+	res, err := c.client.ProveVote(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// QueryVotedBlock - gets the block the daemon signed the vote of the finality
+// provider for at the height
+func (c *FinalityProviderServiceGRpcClient) QueryVotedBlock(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	height uint64,
+) (*proto.QueryVotedBlockResponse, error) {
+	req := &proto.QueryVotedBlockRequest{
+		BtcPk:  fpPk.MarshalHex(),
+		Height: height,
+	}
+	res, err := c.client.QueryVotedBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// QueryLastVotedHeight - gets the height of the last block the finality
+// provider voted for
+func (c *FinalityProviderServiceGRpcClient) QueryLastVotedHeight(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+) (*proto.QueryLastVotedHeightResponse, error) {
+	req := &proto.QueryLastVotedHeightRequest{BtcPk: fpPk.MarshalHex()}
+	res, err := c.client.QueryLastVotedHeight(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// ListDelegations - lists the BTC delegations to the finality provider
+func (c *FinalityProviderServiceGRpcClient) ListDelegations(
+	ctx context.Context,
+	fpPk *bbntypes.BIP340PubKey,
+	status string,
+	pageKey []byte,
+	limit uint64,
+) (*proto.ListDelegationsResponse, error) {
+	req := &proto.ListDelegationsRequest{
+		BtcPk:   fpPk.MarshalHex(),
+		Status:  status,
+		PageKey: pageKey,
+		Limit:   limit,
+	}
+	res, err := c.client.ListDelegations(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// QueryBlockFinalization queries whether the block at the given height is
+// finalized and which finality providers voted for it
+func (c *FinalityProviderServiceGRpcClient) QueryBlockFinalization(
+	ctx context.Context,
+	height uint64,
+) (*proto.QueryBlockFinalizationResponse, error) {
+	req := &proto.QueryBlockFinalizationRequest{Height: height}
+	res, err := c.client.QueryBlockFinalization(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // QueryFinalityProviderInfo - gets the finality provider data from local store
 func (c *FinalityProviderServiceGRpcClient) QueryFinalityProviderInfo(ctx context.Context, fpPk *bbntypes.BIP340PubKey) (*proto.QueryFinalityProviderResponse, error) {
 	req := &proto.QueryFinalityProviderRequest{BtcPk: fpPk.MarshalHex()}
@@ -162,3 +404,31 @@ func (c *FinalityProviderServiceGRpcClient) SignMessageFromChainKey(
 	}
 	return c.client.SignMessageFromChainKey(ctx, req)
 }
+
+// StreamEvents streams the events of the daemon, optionally of the given
+// finality provider and types only, until the context is canceled. The events
+// of the journal from fromSeq on are replayed first, unless it is 0
+func (c *FinalityProviderServiceGRpcClient) StreamEvents(
+	ctx context.Context, fpPk string, types []string, fromSeq uint64,
+) (proto.FinalityProviders_StreamEventsClient, error) {
+	req := &proto.StreamEventsRequest{
+		BtcPk:   fpPk,
+		Types:   types,
+		FromSeq: fromSeq,
+	}
+
+	return c.client.StreamEvents(ctx, req)
+}
+
+func (c *FinalityProviderServiceGRpcClient) QueryEvents(
+	ctx context.Context, fromSeq uint64, fpPk string, types []string, limit uint64,
+) (*proto.QueryEventsResponse, error) {
+	req := &proto.QueryEventsRequest{
+		FromSeq: fromSeq,
+		BtcPk:   fpPk,
+		Types:   types,
+		Limit:   limit,
+	}
+
+	return c.client.QueryEvents(ctx, req)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+const (
+	DelegationStatusActive    = "active"
+	DelegationStatusPending   = "pending"
+	DelegationStatusUnbonding = "unbonding"
+
+	// defaultDelegationsLimit is the number of delegations listed if no
+	// limit is given
+	defaultDelegationsLimit = 100
+)
+
+// delegationStatus maps the status of a delegation on Babylon to the one
+// shown to the operator. The verified delegations are still waiting for the
+// inclusion of their staking tx, so they are pending as well
+func delegationStatus(chainStatus string) string {
+	switch chainStatus {
+	case btcstakingtypes.BTCDelegationStatus_ACTIVE.String():
+		return DelegationStatusActive
+	case btcstakingtypes.BTCDelegationStatus_PENDING.String(), btcstakingtypes.BTCDelegationStatus_VERIFIED.String():
+		return DelegationStatusPending
+	case btcstakingtypes.BTCDelegationStatus_UNBONDED.String():
+		return DelegationStatusUnbonding
+	default:
+		return chainStatus
+	}
+}
+
+// ListDelegations returns up to limit BTC delegations to the given finality
+// provider with the given status, or with any status if empty, starting from
+// the page key. As the delegations are filtered by status after being
+// queried, the pages of the consumer chain are queried until limit
+// delegations are found or there are no more pages. It also returns the key
+// of the next page, which is nil if there are no more delegations
+func (app *FinalityProviderApp) ListDelegations(
+	fpPk *bbntypes.BIP340PubKey,
+	status string,
+	pageKey []byte,
+	limit uint64,
+) ([]*proto.Delegation, []byte, error) {
+	switch status {
+	case "", DelegationStatusActive, DelegationStatusPending, DelegationStatusUnbonding:
+	default:
+		return nil, nil, fmt.Errorf("invalid status %s, it should be one of %s, %s, or %s",
+			status, DelegationStatusActive, DelegationStatusPending, DelegationStatusUnbonding)
+	}
+	if limit == 0 {
+		limit = defaultDelegationsLimit
+	}
+
+	res := make([]*proto.Delegation, 0)
+	for {
+		dels, nextKey, err := app.cc.QueryFinalityProviderDelegations(fpPk.MustToBTCPK(), pageKey, limit-uint64(len(res)))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, d := range dels {
+			delStatus := delegationStatus(d.Status)
+			if status != "" && delStatus != status {
+				continue
+			}
+			res = append(res, &proto.Delegation{
+				StakerAddr:    d.StakerAddr,
+				BtcPkHex:      d.BtcPkHex,
+				StakingTxHash: d.StakingTxHash,
+				TotalSat:      d.TotalSat,
+				StartHeight:   d.StartHeight,
+				EndHeight:     d.EndHeight,
+				Status:        delStatus,
+			})
+		}
+
+		pageKey = nextKey
+		if len(pageKey) == 0 || uint64(len(res)) >= limit {
+			return res, pageKey, nil
+		}
+	}
+}
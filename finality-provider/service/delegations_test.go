@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestListDelegations tests that the delegations are filtered by status across
+// the pages of the consumer chain
+func TestListDelegations(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+
+	firstPage := []*types.Delegation{
+		{StakingTxHash: "a", TotalSat: 1, Status: "ACTIVE"},
+		{StakingTxHash: "b", TotalSat: 2, Status: "VERIFIED"},
+	}
+	secondPage := []*types.Delegation{
+		{StakingTxHash: "c", TotalSat: 3, Status: "UNBONDED"},
+		{StakingTxHash: "d", TotalSat: 4, Status: "ACTIVE"},
+	}
+	mockClientController.EXPECT().QueryFinalityProviderDelegations(gomock.Any(), gomock.Nil(), uint64(2)).
+		Return(firstPage, []byte("second"), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderDelegations(gomock.Any(), []byte("second"), uint64(1)).
+		Return(secondPage[:1], []byte("third"), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderDelegations(gomock.Any(), []byte("third"), uint64(1)).
+		Return(secondPage[1:], nil, nil).AnyTimes()
+
+	dels, nextKey, err := app.ListDelegations(fpIns.GetBtcPkBIP340(), "", nil, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), nextKey)
+	require.Len(t, dels, 2)
+	require.Equal(t, service.DelegationStatusActive, dels[0].Status)
+	require.Equal(t, service.DelegationStatusPending, dels[1].Status)
+
+	dels, nextKey, err = app.ListDelegations(fpIns.GetBtcPkBIP340(), service.DelegationStatusActive, nil, 2)
+	require.NoError(t, err)
+	require.Nil(t, nextKey)
+	require.Len(t, dels, 2)
+	require.Equal(t, "a", dels[0].StakingTxHash)
+	require.Equal(t, "d", dels[1].StakingTxHash)
+
+	_, _, err = app.ListDelegations(fpIns.GetBtcPkBIP340(), "slashed", nil, 2)
+	require.ErrorContains(t, err, "invalid status")
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"cosmossdk.io/math"
+	btcstakingtypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+const (
+	// AdminAuthorizationKey is the metadata key of the administrative
+	// requests carrying the admin token as a bearer token
+	AdminAuthorizationKey = "authorization"
+
+	// adminTokenBytes is the number of the random bytes of a generated admin
+	// token
+	adminTokenBytes = 32
+)
+
+// ErrUnauthenticated is returned if an administrative request does not carry
+// the admin token
+var ErrUnauthenticated = errors.New("invalid or missing admin token")
+
+// LoadOrGenAdminToken returns the admin token in the given file, which is
+// generated if the file does not exist
+func LoadOrGenAdminToken(path string) (string, error) {
+	bz, err := os.ReadFile(path)
+	if err == nil {
+		token := strings.TrimSpace(string(bz))
+		if token == "" {
+			return "", fmt.Errorf("the admin token file %s is empty", path)
+		}
+		return token, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read the admin token: %w", err)
+	}
+
+	b := make([]byte, adminTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("failed to write the admin token: %w", err)
+	}
+
+	return token, nil
+}
+
+// AuthenticateAdmin checks that the given token is the admin token of the
+// daemon. It always fails if no admin token file is configured
+func (app *FinalityProviderApp) AuthenticateAdmin(token string) error {
+	if app.adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(app.adminToken)) != 1 {
+		return ErrUnauthenticated
+	}
+
+	return nil
+}
+
+// EmergencyStop halts all the signing and the submissions of the daemon at
+// once, e.g., if the operator suspects that a key is compromised, and stops
+// the running finality provider. The stop is persisted, so it holds across
+// restarts of the daemon until it is lifted with Resume
+func (app *FinalityProviderApp) EmergencyStop(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("the reason of the emergency stop must be given")
+	}
+
+	// the guards refuse the signing and the submissions from now on, even
+	// the ones in flight, before the stop is persisted
+	app.emergency.stopped.Store(true)
+	if err := app.emergencyStops.SetEmergencyStop(&store.EmergencyStop{
+		Reason:    reason,
+		Timestamp: time.Now().Unix(),
+	}); err != nil {
+		return fmt.Errorf("failed to persist the emergency stop, which only holds until the daemon restarts: %w", err)
+	}
+	app.auditAdmin(AuditOpEmergencyStop, reason)
+
+	if fpIns, err := app.fpManager.GetFinalityProviderInstance(); err == nil && fpIns.IsRunning() {
+		// the instance might have been stopped in the meantime
+		if err := fpIns.Stop(); err != nil && !errors.Is(err, ErrFinalityProviderStopped) {
+			return fmt.Errorf("failed to stop the finality provider instance %s: %w", fpIns.GetBtcPkHex(), err)
+		}
+	}
+
+	app.events.Publish(&proto.Event{Type: EventEmergencyStop, Message: reason})
+	app.logger.Warn("the daemon is stopped in emergency, nothing is signed or submitted until it is resumed",
+		zap.String("reason", reason))
+
+	return nil
+}
+
+// Resume lifts the emergency stop and starts the finality provider kept by the
+// daemon again if its status allows it. The passphrase is used to unlock the
+// EOTS key if the finality provider was not started since the daemon started,
+// in which case it is started by the status sync instead
+func (app *FinalityProviderApp) Resume(reason, passphrase string) error {
+	if strings.TrimSpace(reason) == "" {
+		return fmt.Errorf("the reason of the resumption must be given")
+	}
+	if !app.IsEmergencyStopped() {
+		return fmt.Errorf("the daemon is not stopped in emergency")
+	}
+
+	if err := app.emergencyStops.SetEmergencyStop(nil); err != nil {
+		return fmt.Errorf("failed to lift the emergency stop: %w", err)
+	}
+	app.emergency.stopped.Store(false)
+	app.auditAdmin(AuditOpResume, reason)
+	app.events.Publish(&proto.Event{Type: EventEmergencyResume, Message: reason})
+	app.logger.Info("the emergency stop of the daemon is lifted", zap.String("reason", reason))
+
+	fpIns, err := app.fpManager.GetFinalityProviderInstance()
+	if err != nil || fpIns.IsRunning() {
+		return nil
+	}
+	fp, err := app.fps.GetFinalityProvider(fpIns.GetBtcPk())
+	if err != nil {
+		return err
+	}
+	if !fp.ShouldStart() {
+		return nil
+	}
+	if err := app.fpManager.StartFinalityProvider(fpIns.GetBtcPkBIP340(), passphrase); err != nil {
+		return fmt.Errorf("failed to start the finality provider instance after resuming: %w", err)
+	}
+
+	return nil
+}
+
+// IsEmergencyStopped returns whether the daemon is stopped in emergency
+func (app *FinalityProviderApp) IsEmergencyStopped() bool {
+	return app.emergency.stopped.Load()
+}
+
+// auditAdmin records the given administrative operation in the audit log. The
+// failure to record it is logged rather than failing the operation
+func (app *FinalityProviderApp) auditAdmin(op, reason string) {
+	if err := app.auditLog.Append(AuditEntry{Operation: op, Reason: reason}); err != nil {
+		app.logger.Error("failed to record the administrative operation in the audit log",
+			zap.String("operation", op), zap.Error(err))
+	}
+}
+
+// emergencyState is the emergency stop and the read-only mode of the daemon
+// checked by the guards of the signing and the submissions
+type emergencyState struct {
+	stopped  atomic.Bool
+	readOnly bool
+}
+
+func (s *emergencyState) check() error {
+	if s == nil {
+		return nil
+	}
+	if s.readOnly {
+		return ErrReadOnly
+	}
+	if s.stopped.Load() {
+		return ErrEmergencyStopped
+	}
+
+	return nil
+}
+
+// emergencyGuardController refuses the txs to the consumer chain while the
+// daemon is stopped in emergency or in read-only mode
+type emergencyGuardController struct {
+	clientcontroller.ClientController
+	emergency *emergencyState
+}
+
+func (c *emergencyGuardController) RegisterFinalityProvider(
+	fpPk *btcec.PublicKey,
+	pop []byte,
+	commission *math.LegacyDec,
+	description []byte,
+) (*types.TxResponse, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.RegisterFinalityProvider(fpPk, pop, commission, description)
+}
+
+func (c *emergencyGuardController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight uint64, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types.TxResponse, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.CommitPubRandList(fpPk, startHeight, numPubRand, commitment, sig)
+}
+
+func (c *emergencyGuardController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types.TxResponse, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.SubmitFinalitySig(fpPk, block, pubRand, proof, sig)
+}
+
+func (c *emergencyGuardController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types.TxResponse, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.SubmitBatchFinalitySigs(fpPk, blocks, pubRandList, proofList, sigs)
+}
+
+func (c *emergencyGuardController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types.TxResponse, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.UnjailFinalityProvider(fpPk)
+}
+
+func (c *emergencyGuardController) WithdrawFinalityProviderRewards(recipient string) (*types.TxResponse, sdk.Coins, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, nil, err
+	}
+
+	return c.ClientController.WithdrawFinalityProviderRewards(recipient)
+}
+
+func (c *emergencyGuardController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*btcstakingtypes.MsgEditFinalityProvider, error) {
+	if err := c.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return c.ClientController.EditFinalityProvider(fpPk, commission, description)
+}
+
+// closedChan is returned by CircuitClosed if the wrapped client controller has
+// no circuit breaker
+var closedChan = func() chan struct{} {
+	c := make(chan struct{})
+	close(c)
+	return c
+}()
+
+// IsCircuitOpen forwards to the circuit breaker of the wrapped client
+// controller, if any
+func (c *emergencyGuardController) IsCircuitOpen() bool {
+	cb, ok := c.ClientController.(clientcontroller.CircuitBreaker)
+	return ok && cb.IsCircuitOpen()
+}
+
+// CircuitClosed forwards to the circuit breaker of the wrapped client
+// controller, if any
+func (c *emergencyGuardController) CircuitClosed() <-chan struct{} {
+	if cb, ok := c.ClientController.(clientcontroller.CircuitBreaker); ok {
+		return cb.CircuitClosed()
+	}
+
+	return closedChan
+}
+
+// emergencyGuardEOTSManager refuses the signing while the daemon is stopped
+// in emergency or in read-only mode
+type emergencyGuardEOTSManager struct {
+	eotsmanager.EOTSManager
+	emergency *emergencyState
+}
+
+func (em *emergencyGuardEOTSManager) SignEOTS(uid []byte, chainID []byte, msg []byte, height uint64, passphrase string) (*btcec.ModNScalar, error) {
+	if err := em.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return em.EOTSManager.SignEOTS(uid, chainID, msg, height, passphrase)
+}
+
+func (em *emergencyGuardEOTSManager) SignSchnorrSig(uid []byte, msg []byte, passphrase string) (*schnorr.Signature, error) {
+	if err := em.emergency.check(); err != nil {
+		return nil, err
+	}
+
+	return em.EOTSManager.SignSchnorrSig(uid, msg, passphrase)
+}
+
+// Ping forwards to the wrapped EOTS manager if it is remote, as only a remote
+// one can be unavailable
+func (em *emergencyGuardEOTSManager) Ping() error {
+	if pinger, ok := em.EOTSManager.(interface{ Ping() error }); ok {
+		return pinger.Ping()
+	}
+
+	return nil
+}
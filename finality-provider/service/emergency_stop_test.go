@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestEmergencyStopResume tests that nothing is signed and the finality
+// provider can't be started while the daemon is stopped in emergency
+func TestEmergencyStopResume(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+	fpPk := fpIns.GetBtcPkBIP340()
+	msg := testutil.GenRandomByteArray(r, 32)
+
+	require.NoError(t, app.StartHandlingFinalityProvider(fpPk, passphrase))
+	require.Error(t, app.EmergencyStop(""))
+
+	require.NoError(t, app.EmergencyStop("suspected key leak"))
+	require.True(t, app.IsEmergencyStopped())
+	fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.False(t, fpInfo.IsRunning)
+
+	_, err = app.GetEOTSManager().SignSchnorrSig(fpPk.MustMarshal(), msg, passphrase)
+	require.ErrorIs(t, err, service.ErrEmergencyStopped)
+	err = app.StartHandlingFinalityProvider(fpPk, passphrase)
+	require.ErrorIs(t, err, service.ErrEmergencyStopped)
+
+	require.Error(t, app.Resume("", passphrase))
+	require.NoError(t, app.Resume("the key was rotated", passphrase))
+	require.False(t, app.IsEmergencyStopped())
+	require.Error(t, app.Resume("the key was rotated", passphrase))
+	fpInfo, err = app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.True(t, fpInfo.IsRunning)
+
+	_, err = app.GetEOTSManager().SignSchnorrSig(fpPk.MustMarshal(), msg, passphrase)
+	require.NoError(t, err)
+}
+
+// TestLoadOrGenAdminToken tests that the admin token is generated once and
+// then loaded from its file
+func TestLoadOrGenAdminToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "admin_token")
+
+	token, err := service.LoadOrGenAdminToken(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	loaded, err := service.LoadOrGenAdminToken(path)
+	require.NoError(t, err)
+	require.Equal(t, token, loaded)
+}
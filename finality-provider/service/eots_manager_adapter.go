@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 
 	bbntypes "github.com/babylonlabs-io/babylon/types"
@@ -9,6 +10,8 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 )
 
 func (fp *FinalityProviderInstance) getPubRandList(startHeight uint64, numPubRand uint32) ([]*btcec.FieldVal, error) {
@@ -57,6 +60,21 @@ func getMsgToSignForVote(blockHeight uint64, blockHash []byte) []byte {
 }
 
 func (fp *FinalityProviderInstance) signFinalitySig(b *types.BlockInfo) (*bbntypes.SchnorrEOTSSig, error) {
+	// signing two different blocks at the same height leaks the EOTS key, so
+	// the block is recorded before signing and conflicting ones are refused
+	if err := fp.fpState.guardVote(b.Height, b.Hash); err != nil {
+		if errors.Is(err, store.ErrConflictingVote) {
+			fp.alerter.Fire(AlertConflictingVote, AlertSeverityCritical, fp.GetBtcPkHex(), err.Error())
+		}
+		return nil, fmt.Errorf("failed to guard the vote: %w", err)
+	}
+
+	return fp.signEOTS(b)
+}
+
+// signEOTS signs the block without guarding the vote, which is only meant for
+// the deliberate double signing of TestSubmitFinalitySignatureAndExtractPrivKey
+func (fp *FinalityProviderInstance) signEOTS(b *types.BlockInfo) (*bbntypes.SchnorrEOTSSig, error) {
 	// build proper finality signature request
 	msgToSign := getMsgToSignForVote(b.Height, b.Hash)
 	sig, err := fp.em.SignEOTS(fp.btcPk.MustMarshal(), fp.GetChainID(), msgToSign, b.Height, fp.passphrase)
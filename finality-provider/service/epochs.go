@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// newEpochMsg returns the record of a tx included on Babylon which is only
+// final once its epoch is finalized. Its epoch is resolved later by the
+// epoch tracking loop not to query Babylon upon each submission
+func newEpochMsg(txType string, height, endHeight uint64, res *types.TxResponse) *store.EpochMsg {
+	return &store.EpochMsg{
+		Type:      txType,
+		Height:    height,
+		EndHeight: endHeight,
+		TxHash:    res.TxHash,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// queueEpochMsg queues a tx submitted on behalf of the finality provider until
+// its epoch is finalized. Nothing is queued for the txs which are not sent.
+// Failing to queue it is not critical, so the error is only logged
+func (fp *FinalityProviderInstance) queueEpochMsg(txType string, height, endHeight uint64, res *types.TxResponse) {
+	if fp.epochMsgs == nil || fp.cfg.BabylonConfig.DryRun || res == nil {
+		return
+	}
+	if err := fp.epochMsgs.AddEpochMsg(fp.GetBtcPk(), newEpochMsg(txType, height, endHeight, res)); err != nil {
+		fp.logger.Error("failed to queue the tx until its epoch is finalized",
+			zap.String("pk", fp.GetBtcPkHex()), zap.String("type", txType), zap.Uint64("height", height), zap.Error(err))
+	}
+}
+
+func (app *FinalityProviderApp) queueEpochMsg(fpPk *btcec.PublicKey, txType string, res *types.TxResponse) {
+	if app.config.BabylonConfig.DryRun || res == nil {
+		return
+	}
+	if err := app.epochMsgs.AddEpochMsg(fpPk, newEpochMsg(txType, 0, 0, res)); err != nil {
+		app.logger.Error("failed to queue the tx until its epoch is finalized", zap.String("type", txType), zap.Error(err))
+	}
+}
+
+// epochTrackingLoop periodically resolves the epochs of the queued txs and
+// reports the ones whose epoch is finalized on Bitcoin, as the votes with the
+// committed randomness are only accepted from then on
+func (app *FinalityProviderApp) epochTrackingLoop() {
+	defer app.wg.Done()
+
+	interval := app.config.EpochTrackingInterval
+	app.logger.Info("starting epoch tracking loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+	trackingTicker := time.NewTicker(interval)
+	defer trackingTicker.Stop()
+	defer app.heartbeats.remove(epochTrackingLoopName)
+
+	for {
+		app.heartbeats.beat(epochTrackingLoopName, interval)
+
+		select {
+		case <-trackingTicker.C:
+			app.trackEpochs()
+		case <-app.quit:
+			app.logger.Info("exiting epoch tracking loop")
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) trackEpochs() {
+	storedFps, err := app.fps.GetAllStoredFinalityProviders()
+	if err != nil {
+		app.logger.Error("failed to get the finality providers to track the epochs", zap.Error(err))
+		return
+	}
+
+	// the epochs are only queried if there is a queued tx
+	var currentEpoch, lastFinalizedEpoch uint64
+	queried := false
+	for _, fp := range storedFps {
+		fpPkHex := fp.GetBIP340BTCPK().MarshalHex()
+		msgs, err := app.epochMsgs.GetEpochMsgs(fp.BtcPk)
+		if err != nil {
+			app.logger.Error("failed to get the queued txs", zap.String("pk", fpPkHex), zap.Error(err))
+			continue
+		}
+		if len(msgs) == 0 {
+			continue
+		}
+
+		if !queried {
+			currentEpoch, err = app.cc.QueryCurrentEpoch()
+			if err != nil {
+				app.logger.Error("failed to query the current epoch", zap.Error(err))
+				return
+			}
+			lastFinalizedEpoch, err = app.cc.QueryLastFinalizedEpoch()
+			if err != nil {
+				app.logger.Error("failed to query the last finalized epoch", zap.Error(err))
+				return
+			}
+			queried = true
+		}
+
+		finalized, err := app.epochMsgs.ResolveEpochMsgs(fp.BtcPk, currentEpoch, lastFinalizedEpoch)
+		if err != nil {
+			app.logger.Error("failed to resolve the epochs of the queued txs", zap.String("pk", fpPkHex), zap.Error(err))
+			continue
+		}
+		for _, msg := range finalized {
+			app.logger.Info("the epoch of the tx is finalized",
+				zap.String("pk", fpPkHex),
+				zap.String("type", msg.Type),
+				zap.Uint64("epoch", msg.Epoch),
+				zap.String("tx_hash", msg.TxHash))
+			app.events.Publish(&proto.Event{
+				Type:      EventEpochFinalized,
+				BtcPk:     fpPkHex,
+				Height:    msg.Height,
+				EndHeight: msg.EndHeight,
+				TxHash:    msg.TxHash,
+				Message: fmt.Sprintf("the %s included in epoch %d is final as the epoch is finalized on Bitcoin",
+					msg.Type, msg.Epoch),
+			})
+		}
+	}
+}
+
+// queryEpochMsgs returns the txs of the given finality provider whose epoch is
+// not finalized yet
+func (app *FinalityProviderApp) queryEpochMsgs(fpPk *bbntypes.BIP340PubKey) ([]*proto.EpochMsg, error) {
+	msgs, err := app.epochMsgs.GetEpochMsgs(fpPk.MustToBTCPK())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*proto.EpochMsg, 0, len(msgs))
+	for _, msg := range msgs {
+		res = append(res, &proto.EpochMsg{
+			Type:      msg.Type,
+			Height:    msg.Height,
+			EndHeight: msg.EndHeight,
+			TxHash:    msg.TxHash,
+			Epoch:     msg.Epoch,
+			Timestamp: msg.Timestamp,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestEpochTracking tests that the registration and the randomness commitment
+// are reported as pending in the status until their epoch is finalized, and
+// that they are then reported as epoch_finalized events
+func TestEpochTracking(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// create an EOTS manager
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	dbBackend, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer dbBackend.Close()
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, dbBackend, logger)
+	require.NoError(t, err)
+
+	currentHeight := uint64(100)
+	mockClientController := testutil.PrepareMockedClientController(t, r, currentHeight, currentHeight)
+
+	fpHomeDir := filepath.Join(t.TempDir(), "fp-home")
+	fpCfg := config.DefaultConfigWithHome(fpHomeDir)
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	// the epochs are tracked by the test
+	fpCfg.EpochTrackingInterval = 0
+	fpdb, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	defer fpdb.Close()
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, fpdb, logger)
+	require.NoError(t, err)
+	err = app.StartOneShot()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, app.Stop())
+	}()
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+
+	// nothing is queried while there is no queued tx
+	app.TrackEpochs()
+
+	registrationTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		RegisterFinalityProvider(fp.BtcPk, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: registrationTxHash}, nil).Times(1)
+	_, err = app.RegisterFinalityProvider(fp.GetBIP340BTCPK().MarshalHex())
+	require.NoError(t, err)
+
+	commitTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().
+		CommitPubRandList(fp.BtcPk, currentHeight+1, uint64(testutil.TestPubRandNum), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: commitTxHash}, nil).Times(1)
+	_, err = app.CommitPubRand(fp.GetBIP340BTCPK(), passphrase)
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(fp.BtcPk, uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(fp.BtcPk, currentHeight).Return(uint64(0), nil).AnyTimes()
+	statusList, err := app.FinalityProvidersStatus()
+	require.NoError(t, err)
+	require.Len(t, statusList, 1)
+	pending := statusList[0].PendingEpochMsgs
+	require.Len(t, pending, 2)
+	require.Equal(t, store.SubmissionTypeRegistration, pending[0].Type)
+	require.Equal(t, registrationTxHash, pending[0].TxHash)
+	require.Equal(t, store.SubmissionTypePubRandCommit, pending[1].Type)
+	require.Equal(t, commitTxHash, pending[1].TxHash)
+	require.Equal(t, currentHeight+1, pending[1].Height)
+	require.Equal(t, currentHeight+uint64(testutil.TestPubRandNum), pending[1].EndHeight)
+	for _, msg := range pending {
+		require.Zero(t, msg.Epoch)
+	}
+
+	// the txs are included in the current epoch, which is not finalized yet
+	epoch := uint64(r.Int63n(1000) + 2)
+	mockClientController.EXPECT().QueryCurrentEpoch().Return(epoch, nil).Times(1)
+	mockClientController.EXPECT().QueryLastFinalizedEpoch().Return(epoch-1, nil).Times(1)
+	app.TrackEpochs()
+	statusList, err = app.FinalityProvidersStatus()
+	require.NoError(t, err)
+	pending = statusList[0].PendingEpochMsgs
+	require.Len(t, pending, 2)
+	for _, msg := range pending {
+		require.Equal(t, epoch, msg.Epoch)
+	}
+	events, _, err := app.QueryEvents(0, fp.GetBIP340BTCPK(), []string{service.EventEpochFinalized}, 0)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	// the txs take effect once the epoch is finalized
+	mockClientController.EXPECT().QueryCurrentEpoch().Return(epoch+1, nil).Times(1)
+	mockClientController.EXPECT().QueryLastFinalizedEpoch().Return(epoch, nil).Times(1)
+	app.TrackEpochs()
+	statusList, err = app.FinalityProvidersStatus()
+	require.NoError(t, err)
+	require.Empty(t, statusList[0].PendingEpochMsgs)
+	events, _, err = app.QueryEvents(0, fp.GetBIP340BTCPK(), []string{service.EventEpochFinalized}, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, registrationTxHash, events[0].TxHash)
+	require.Equal(t, commitTxHash, events[1].TxHash)
+	require.Equal(t, currentHeight+1, events[1].Height)
+	require.Contains(t, events[1].Message, "epoch")
+}
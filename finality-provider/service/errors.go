@@ -3,7 +3,14 @@ package service
 import "errors"
 
 var (
-	ErrFinalityProviderShutDown = errors.New("the finality provider instance is shutting down")
-	ErrFinalityProviderJailed   = errors.New("the finality provider instance is jailed")
-	ErrFinalityProviderSlashed  = errors.New("the finality provider instance is slashed")
+	ErrFinalityProviderShutDown    = errors.New("the finality provider instance is shutting down")
+	ErrFinalityProviderAppShutDown = errors.New("the finality provider app is shutting down")
+	ErrFinalityProviderJailed      = errors.New("the finality provider instance is jailed")
+	ErrFinalityProviderSlashed     = errors.New("the finality provider instance is slashed")
+	ErrFinalityProviderStopped     = errors.New("the finality provider instance is not running")
+	ErrFinalityProviderPaused      = errors.New("the finality provider is paused")
+	ErrConflictingPubRandCommit    = errors.New("the public randomness conflicts with an existing commitment")
+	ErrInvalidFinalitySig          = errors.New("the finality signature does not verify")
+	ErrEmergencyStopped            = errors.New("the daemon is stopped in emergency")
+	ErrReadOnly                    = errors.New("the daemon is in read-only mode")
 )
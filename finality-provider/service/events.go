@@ -0,0 +1,212 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// The types of the events emitted by the daemon besides the ones of the
+// submitted txs, which are typed after the submissions, e.g., finality_sig
+const (
+	EventStatusChange     = "status_change"
+	EventError            = "error"
+	EventPaused           = "paused"
+	EventResumed          = "resumed"
+	EventDaemonStarted    = "daemon_started"
+	EventDaemonStopped    = "daemon_stopped"
+	EventEmergencyStop    = "emergency_stop"
+	EventEmergencyResume  = "emergency_resume"
+	EventMaintenanceEnter = "maintenance_enter"
+	EventMaintenanceExit  = "maintenance_exit"
+	EventEpochFinalized   = "epoch_finalized"
+)
+
+// journalPageSize is the number of the events read from the journal at once
+// when replaying it
+const journalPageSize = 1000
+
+// eventBufferSize is the number of the events buffered for a subscriber
+const eventBufferSize = 256
+
+// EventBus records the events of the daemon in the event journal and
+// broadcasts them to its subscribers, e.g., the clients streaming the events.
+// Publishing never blocks on the subscribers, so a subscriber whose buffer is
+// full is dropped and its channel is closed to let it know that it missed
+// events, which it can replay from the journal
+type EventBus struct {
+	mu      sync.Mutex
+	subs    map[chan *proto.Event]struct{}
+	journal *store.EventStore
+	logger  *zap.Logger
+}
+
+// NewEventBus returns an event bus recording the events in the given
+// journal, unless it is nil
+func NewEventBus(journal *store.EventStore, logger *zap.Logger) *EventBus {
+	return &EventBus{
+		subs:    make(map[chan *proto.Event]struct{}),
+		journal: journal,
+		logger:  logger,
+	}
+}
+
+// Subscribe returns a channel receiving the events published from now on and
+// a function to cancel the subscription
+func (b *EventBus) Subscribe() (<-chan *proto.Event, func()) {
+	ch := make(chan *proto.Event, eventBufferSize)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[ch]; ok {
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish records the event in the journal, which sets its sequence number,
+// and sends it to the subscribers. Failing to record it is not critical, so
+// the error is only logged. It is a no-op on a nil bus
+func (b *EventBus) Publish(ev *proto.Event) {
+	if b == nil {
+		return
+	}
+	if ev.Timestamp == 0 {
+		ev.Timestamp = time.Now().Unix()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.journal != nil {
+		rec := eventToRecord(ev)
+		if err := b.journal.AppendEvent(rec); err != nil {
+			b.logger.Error("failed to record the event in the journal",
+				zap.String("type", ev.Type), zap.String("pk", ev.BtcPk), zap.Error(err))
+		} else {
+			ev.Seq = rec.Seq
+		}
+	}
+	for ch := range b.subs {
+		select {
+		case ch <- ev:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+func eventToRecord(ev *proto.Event) *store.Event {
+	return &store.Event{
+		Seq:        ev.Seq,
+		Type:       ev.Type,
+		BtcPkHex:   ev.BtcPk,
+		Height:     ev.Height,
+		EndHeight:  ev.EndHeight,
+		TxHash:     ev.TxHash,
+		Status:     ev.Status,
+		PrevStatus: ev.PrevStatus,
+		Message:    ev.Message,
+		Timestamp:  ev.Timestamp,
+	}
+}
+
+func eventFromRecord(rec *store.Event) *proto.Event {
+	return &proto.Event{
+		Seq:        rec.Seq,
+		Type:       rec.Type,
+		BtcPk:      rec.BtcPkHex,
+		Height:     rec.Height,
+		EndHeight:  rec.EndHeight,
+		TxHash:     rec.TxHash,
+		Status:     rec.Status,
+		PrevStatus: rec.PrevStatus,
+		Message:    rec.Message,
+		Timestamp:  rec.Timestamp,
+	}
+}
+
+func submissionEvent(fpPkHex string, sub *store.Submission) *proto.Event {
+	return &proto.Event{
+		Type:      sub.Type,
+		BtcPk:     fpPkHex,
+		Height:    sub.Height,
+		EndHeight: sub.EndHeight,
+		TxHash:    sub.TxHash,
+		Status:    sub.Status,
+		Message:   sub.Error,
+		Timestamp: sub.Timestamp,
+	}
+}
+
+func statusChangeEvent(fpPkHex string, prev, curr proto.FinalityProviderStatus) *proto.Event {
+	return &proto.Event{
+		Type:       EventStatusChange,
+		BtcPk:      fpPkHex,
+		Status:     curr.String(),
+		PrevStatus: prev.String(),
+		Timestamp:  time.Now().Unix(),
+	}
+}
+
+// publishStatusChange emits the status change of the finality provider as an
+// event and notifies the status webhook of it
+func (fp *FinalityProviderInstance) publishStatusChange(prev, curr proto.FinalityProviderStatus) {
+	ev := statusChangeEvent(fp.GetBtcPkHex(), prev, curr)
+	fp.events.Publish(ev)
+	fp.alerter.NotifyStatusChange(ev)
+}
+
+func (app *FinalityProviderApp) publishStatusChange(fpPkHex string, prev, curr proto.FinalityProviderStatus) {
+	ev := statusChangeEvent(fpPkHex, prev, curr)
+	app.events.Publish(ev)
+	app.alerter.NotifyStatusChange(ev)
+}
+
+// SubscribeEvents returns a channel receiving the events of the daemon and a
+// function to cancel the subscription. The channel is closed if the events are
+// not received fast enough
+func (app *FinalityProviderApp) SubscribeEvents() (<-chan *proto.Event, func()) {
+	return app.events.Subscribe()
+}
+
+// QueryEvents returns the events recorded in the journal from the given
+// sequence number on, optionally of the given finality provider and types
+// only, up to limit events or all of them if limit is 0. It also returns the
+// sequence number to query the next events from
+func (app *FinalityProviderApp) QueryEvents(
+	fromSeq uint64,
+	fpPk *bbntypes.BIP340PubKey,
+	types []string,
+	limit uint64,
+) ([]*proto.Event, uint64, error) {
+	fpPkHex := ""
+	if fpPk != nil {
+		fpPkHex = fpPk.MarshalHex()
+	}
+
+	recs, nextSeq, err := app.eventJournal.GetEvents(fromSeq, fpPkHex, types, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*proto.Event, 0, len(recs))
+	for _, rec := range recs {
+		events = append(events, eventFromRecord(rec))
+	}
+
+	return events, nextSeq, nil
+}
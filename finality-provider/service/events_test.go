@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestEventBus tests that the events are broadcast to the subscribers and that
+// a subscriber not receiving them fast enough is dropped
+func TestEventBus(t *testing.T) {
+	bus := service.NewEventBus(nil, zap.NewNop())
+	fast, unsubscribeFast := bus.Subscribe()
+	defer unsubscribeFast()
+	slow, unsubscribeSlow := bus.Subscribe()
+	defer unsubscribeSlow()
+
+	for i := 0; i < 300; i++ {
+		bus.Publish(&proto.Event{Type: service.EventError, Height: uint64(i)})
+		ev := <-fast
+		require.Equal(t, uint64(i), ev.Height)
+		require.NotZero(t, ev.Timestamp)
+	}
+
+	// the slow subscriber receives the buffered events before being closed
+	received := 0
+	for range slow {
+		received++
+	}
+	require.Less(t, received, 300)
+
+	unsubscribeFast()
+	_, ok := <-fast
+	require.False(t, ok)
+	// canceling twice is a no-op
+	unsubscribeFast()
+}
+
+// TestSubmissionEvents tests that the submitted txs and the resulting status
+// changes are emitted as events and recorded in the journal
+func TestSubmissionEvents(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+
+	events, unsubscribe := app.SubscribeEvents()
+	defer unsubscribe()
+
+	txHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().UnjailFinalityProvider(fpIns.GetBtcPk()).
+		Return(&types.TxResponse{TxHash: txHash}, nil).Times(1)
+	_, err := app.UnjailFinalityProvider(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+
+	ev := <-events
+	require.Equal(t, store.SubmissionTypeUnjail, ev.Type)
+	require.Equal(t, fpIns.GetBtcPkHex(), ev.BtcPk)
+	require.Equal(t, txHash, ev.TxHash)
+	require.Equal(t, store.SubmissionStatusSuccess, ev.Status)
+
+	// the unjailed finality provider becomes inactive
+	ev = <-events
+	require.Equal(t, service.EventStatusChange, ev.Type)
+	require.Equal(t, fpIns.GetBtcPkHex(), ev.BtcPk)
+	require.Equal(t, proto.FinalityProviderStatus_REGISTERED.String(), ev.PrevStatus)
+	require.Equal(t, proto.FinalityProviderStatus_INACTIVE.String(), ev.Status)
+
+	// the events are recorded in the journal after the start of the daemon
+	recorded, nextSeq, err := app.QueryEvents(1, fpIns.GetBtcPkBIP340(), nil, 0)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	require.Equal(t, store.SubmissionTypeUnjail, recorded[0].Type)
+	require.Equal(t, uint64(2), recorded[0].Seq)
+	require.Equal(t, txHash, recorded[0].TxHash)
+	require.Equal(t, service.EventStatusChange, recorded[1].Type)
+	require.Equal(t, uint64(3), recorded[1].Seq)
+	require.Equal(t, uint64(4), nextSeq)
+	started, _, err := app.QueryEvents(1, nil, []string{service.EventDaemonStarted}, 0)
+	require.NoError(t, err)
+	require.Len(t, started, 1)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// UseMissedBlockStoreOf makes the instance, created without the manager of the
+// app, persist its missed blocks to the store of the app as if it was created
+// by the manager
+func (fp *FinalityProviderInstance) UseMissedBlockStoreOf(app *FinalityProviderApp) {
+	fp.missedBlocks = app.missedBlocks
+}
+
+// RetrySubmitFinalitySignatureWithHeartbeats retries the vote for the block as
+// the finality signature submission loop does, which is tracked by the given
+// heartbeats
+func (fp *FinalityProviderInstance) RetrySubmitFinalitySignatureWithHeartbeats(
+	heartbeats *Heartbeats,
+	b *types.BlockInfo,
+) (*types.TxResponse, error) {
+	fp.heartbeats = heartbeats
+	fp.heartbeats.beat(finalitySigLoopName, fp.cfg.PollerConfig.GetPollInterval())
+
+	return fp.retrySubmitFinalitySignatureUntilBlockFinalized(b)
+}
+
+// TrackEpochs resolves the epochs of the queued txs once as the epoch tracking
+// loop does
+func (app *FinalityProviderApp) TrackEpochs() {
+	app.trackEpochs()
+}
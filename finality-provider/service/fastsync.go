@@ -23,8 +23,45 @@ func (fp *FinalityProviderInstance) FastSync(startHeight, endHeight uint64) (*Fa
 	}
 	defer fp.inSync.Store(false)
 
+	responses, syncedHeight, err := fp.submitFinalitySigsForRange(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	// update the processed height
+	fp.MustSetLastProcessedHeight(syncedHeight)
+
+	return &FastSyncResult{
+		Responses:           responses,
+		SyncedHeight:        syncedHeight,
+		LastProcessedHeight: fp.GetLastProcessedHeight(),
+	}, nil
+}
+
+// SubmitFinalitySignaturesForRange fetches the blocks from startHeight to
+// endHeight and submits the finality signatures over the ones that are not
+// processed yet and have voting power in batched transactions, e.g., to catch
+// up after downtime. It cannot run together with fast sync
+func (fp *FinalityProviderInstance) SubmitFinalitySignaturesForRange(startHeight, endHeight uint64) ([]*types.TxResponse, error) {
+	if fp.inSync.Swap(true) {
+		return nil, fmt.Errorf("the finality-provider is in fast sync")
+	}
+	defer fp.inSync.Store(false)
+
+	responses, _, err := fp.submitFinalitySigsForRange(startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses, nil
+}
+
+// submitFinalitySigsForRange submits the finality signatures over the blocks
+// in the range in batches of at most FastSyncLimit blocks. It returns the
+// responses and the highest height voted for
+func (fp *FinalityProviderInstance) submitFinalitySigsForRange(startHeight, endHeight uint64) ([]*types.TxResponse, uint64, error) {
 	if startHeight > endHeight {
-		return nil, fmt.Errorf("the start height %v should not be higher than the end height %v",
+		return nil, 0, fmt.Errorf("the start height %v should not be higher than the end height %v",
 			startHeight, endHeight)
 	}
 
@@ -35,7 +72,7 @@ func (fp *FinalityProviderInstance) FastSync(startHeight, endHeight uint64) (*Fa
 	for startHeight <= endHeight {
 		blocks, err := fp.cc.QueryBlocks(startHeight, endHeight, fp.cfg.FastSyncLimit)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
 		if len(blocks) < 1 {
@@ -56,44 +93,54 @@ func (fp *FinalityProviderInstance) FastSync(startHeight, endHeight uint64) (*Fa
 			// check whether the finality provider has voting power
 			hasVp, err := fp.hasVotingPower(b)
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
 			if !hasVp {
 				fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
 				continue
 			}
+			if fp.skipFinalized(b) {
+				continue
+			}
 			// all good, add the block for catching up
 			catchUpBlocks = append(catchUpBlocks, b)
 		}
 
-		if len(catchUpBlocks) < 1 {
-			continue
-		}
+		// the public randomness of a batch is looked up from the height of
+		// its first block, so a batch only covers consecutive heights
+		for _, batch := range splitConsecutiveBlocks(catchUpBlocks) {
+			res, err := fp.SubmitBatchFinalitySignatures(batch)
+			if err != nil {
+				return nil, 0, err
+			}
+			fp.metrics.AddToFpTotalVotedBlocks(fp.GetBtcPkHex(), float64(len(batch)))
 
-		syncedHeight = catchUpBlocks[len(catchUpBlocks)-1].Height
+			responses = append(responses, res)
+			syncedHeight = batch[len(batch)-1].Height
 
-		res, err := fp.SubmitBatchFinalitySignatures(catchUpBlocks)
-		if err != nil {
-			return nil, err
+			fp.logger.Debug(
+				"the finality-provider is catching up by sending finality signatures in a batch",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("start_height", batch[0].Height),
+				zap.Uint64("synced_height", syncedHeight),
+			)
 		}
-		fp.metrics.AddToFpTotalVotedBlocks(fp.GetBtcPkHex(), float64(len(catchUpBlocks)))
+	}
 
-		responses = append(responses, res)
+	return responses, syncedHeight, nil
+}
 
-		fp.logger.Debug(
-			"the finality-provider is catching up by sending finality signatures in a batch",
-			zap.String("pk", fp.GetBtcPkHex()),
-			zap.Uint64("start_height", catchUpBlocks[0].Height),
-			zap.Uint64("synced_height", syncedHeight),
-		)
+// splitConsecutiveBlocks splits the blocks sorted by height into runs of
+// consecutive heights
+func splitConsecutiveBlocks(blocks []*types.BlockInfo) [][]*types.BlockInfo {
+	var runs [][]*types.BlockInfo
+	runStart := 0
+	for i := 1; i <= len(blocks); i++ {
+		if i == len(blocks) || blocks[i].Height != blocks[i-1].Height+1 {
+			runs = append(runs, blocks[runStart:i])
+			runStart = i
+		}
 	}
 
-	// update the processed height
-	fp.MustSetLastProcessedHeight(syncedHeight)
-
-	return &FastSyncResult{
-		Responses:           responses,
-		SyncedHeight:        syncedHeight,
-		LastProcessedHeight: fp.GetLastProcessedHeight(),
-	}, nil
+	return runs
 }
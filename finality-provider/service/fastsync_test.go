@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/testutil"
 	"github.com/babylonlabs-io/finality-provider/types"
 )
@@ -122,3 +123,111 @@ func FuzzFastSync_NoRandomness(f *testing.F) {
 		require.Equal(t, lastHeightWithPubRand, fpIns.GetLastProcessedHeight())
 	})
 }
+
+// FuzzSubmitFinalitySignaturesForRange tests submitting the finality signatures
+// over a range of blocks, which is split into batches of consecutive heights
+// around the block without voting power
+func FuzzSubmitFinalitySignaturesForRange(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		randomStartingHeight := uint64(r.Int63n(100) + 1)
+		startHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+		endHeight := startHeight + uint64(r.Int63n(8)+2)
+		noPowerHeight := startHeight + 1 + uint64(r.Int63n(int64(endHeight-startHeight-1)))
+		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, endHeight)
+		mockClientController.EXPECT().QueryLatestFinalizedBlocks(uint64(1)).Return(nil, nil).AnyTimes()
+		_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+		defer cleanUp()
+
+		// commit pub rand
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+		_, err := fpIns.CommitPubRand(randomStartingHeight)
+		require.NoError(t, err)
+
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(fpIns.GetBtcPk(), noPowerHeight).
+			Return(uint64(0), nil).AnyTimes()
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(fpIns.GetBtcPk(), gomock.Any()).
+			Return(uint64(1), nil).AnyTimes()
+
+		blocks := testutil.GenBlocks(r, startHeight, endHeight)
+		noPowerIdx := noPowerHeight - startHeight
+		mockClientController.EXPECT().QueryBlocks(startHeight, endHeight, uint32(10)).
+			Return(blocks, nil)
+		firstTxHash := testutil.GenRandomHexStr(r, 32)
+		secondTxHash := testutil.GenRandomHexStr(r, 32)
+		mockClientController.EXPECT().SubmitBatchFinalitySigs(fpIns.GetBtcPk(), blocks[:noPowerIdx], gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: firstTxHash}, nil).Times(1)
+		mockClientController.EXPECT().SubmitBatchFinalitySigs(fpIns.GetBtcPk(), blocks[noPowerIdx+1:], gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: secondTxHash}, nil).Times(1)
+
+		responses, err := fpIns.SubmitFinalitySignaturesForRange(startHeight, endHeight)
+		require.NoError(t, err)
+		require.Len(t, responses, 2)
+		require.Equal(t, firstTxHash, responses[0].TxHash)
+		require.Equal(t, secondTxHash, responses[1].TxHash)
+		require.Equal(t, endHeight, fpIns.GetLastVotedHeight())
+	})
+}
+
+// TestFastSync_SkipFinalized tests that the blocks already finalized are
+// skipped during fast-sync if enabled
+func TestFastSync_SkipFinalized(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	startingHeight := uint64(10)
+	currentHeight := startingHeight + 3
+	mockClientController := testutil.PrepareMockedClientController(t, r, startingHeight, startingHeight)
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startingHeight)
+	defer cleanUp()
+	app.GetConfig().SubmissionConfig.SkipFinalized = true
+	fpIns.UseMissedBlockStoreOf(app)
+
+	// commit pub rand
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	_, err := fpIns.CommitPubRand(startingHeight)
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(fpIns.GetBtcPk(), gomock.Any()).
+		Return(uint64(1), nil).AnyTimes()
+	lastCommittedPubRandMap := map[uint64]*ftypes.PubRandCommitResponse{
+		startingHeight + testutil.TestPubRandNum: {
+			NumPubRand: 1000,
+			Commitment: datagen.GenRandomByteArray(r, 32),
+		},
+	}
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(lastCommittedPubRandMap, nil).AnyTimes()
+
+	// the first block is finalized by the time the finality provider catches up
+	catchUpBlocks := testutil.GenBlocks(r, startingHeight+1, currentHeight)
+	mockClientController.EXPECT().QueryBlocks(startingHeight+1, currentHeight, uint32(10)).
+		Return(catchUpBlocks, nil)
+	finalizedBlock := *catchUpBlocks[0]
+	finalizedBlock.Finalized = true
+	mockClientController.EXPECT().QueryBlock(finalizedBlock.Height).Return(&finalizedBlock, nil).Times(1)
+	for _, b := range catchUpBlocks[1:] {
+		mockClientController.EXPECT().QueryBlock(b.Height).Return(b, nil).Times(1)
+	}
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().SubmitBatchFinalitySigs(fpIns.GetBtcPk(), catchUpBlocks[1:], gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+
+	result, err := fpIns.FastSync(startingHeight+1, currentHeight)
+	require.NoError(t, err)
+	require.Len(t, result.Responses, 1)
+	require.Equal(t, expectedTxHash, result.Responses[0].TxHash)
+	require.Equal(t, currentHeight, fpIns.GetLastVotedHeight())
+
+	// the skipped block is recorded, but not as missed
+	skippedBlocks, total, err := app.QueryMissedBlocks(fpIns.GetBtcPkBIP340(), 0, 0, 0, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), total)
+	require.Equal(t, finalizedBlock.Height, skippedBlocks[0].Height)
+	require.Equal(t, store.MissedReasonSkippedFinalized, skippedBlocks[0].Reason)
+	_, total, err = app.QueryMissedBlocks(fpIns.GetBtcPkBIP340(), 0, 0, 0, false)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
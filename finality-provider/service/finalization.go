@@ -0,0 +1,49 @@
+package service
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// QueryBlockFinalization returns whether the block at the given height is
+// finalized on the consumer chain, the finality providers that voted for it,
+// and whether each of the stored finality providers is among them, so that
+// the operator can check that their votes contributed to the finalization
+func (app *FinalityProviderApp) QueryBlockFinalization(height uint64) (*proto.QueryBlockFinalizationResponse, error) {
+	block, err := app.cc.QueryBlock(height)
+	if err != nil {
+		return nil, err
+	}
+	voters, err := app.cc.QueryVotesAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the votes at height %d: %w", height, err)
+	}
+	storedFps, err := app.fps.GetAllStoredFinalityProviders()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &proto.QueryBlockFinalizationResponse{
+		Height:    block.Height,
+		Hash:      hex.EncodeToString(block.Hash),
+		Finalized: block.Finalized,
+		Voters:    make([]string, 0, len(voters)),
+	}
+	voted := make(map[string]bool, len(voters))
+	for _, pk := range voters {
+		res.Voters = append(res.Voters, pk.MarshalHex())
+		voted[pk.MarshalHex()] = true
+	}
+	for _, fp := range storedFps {
+		pkHex := fp.GetBIP340BTCPK().MarshalHex()
+		res.ManagedVotes = append(res.ManagedVotes, &proto.ManagedVote{
+			BtcPkHex: pkHex,
+			Status:   fp.Status.String(),
+			Voted:    voted[pkHex],
+		})
+	}
+
+	return res, nil
+}
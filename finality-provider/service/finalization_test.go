@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"testing"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// FuzzQueryBlockFinalization tests that the finalization of a block reports
+// whether the managed finality providers voted for it
+func FuzzQueryBlockFinalization(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		randomStartingHeight := uint64(r.Int63n(100) + 1)
+		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight)
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+			Return(uint64(0), nil).AnyTimes()
+		app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+		defer cleanUp()
+
+		height := randomStartingHeight + uint64(r.Int63n(10))
+		block := &types.BlockInfo{Height: height, Hash: testutil.GenRandomByteArray(r, 32), Finalized: true}
+		otherVoter := bbntypes.NewBIP340PubKeyFromBTCPK(testutil.GenRandomFinalityProvider(r, t).BtcPk)
+		mockClientController.EXPECT().QueryBlock(height).Return(block, nil).AnyTimes()
+		voted := r.Intn(2) == 0
+		voters := []bbntypes.BIP340PubKey{*otherVoter}
+		if voted {
+			voters = append(voters, *fpIns.GetBtcPkBIP340())
+		}
+		mockClientController.EXPECT().QueryVotesAtHeight(height).Return(voters, nil).AnyTimes()
+
+		res, err := app.QueryBlockFinalization(height)
+		require.NoError(t, err)
+		require.Equal(t, height, res.Height)
+		require.Equal(t, hex.EncodeToString(block.Hash), res.Hash)
+		require.True(t, res.Finalized)
+		require.Len(t, res.Voters, len(voters))
+		require.Len(t, res.ManagedVotes, 1)
+		require.Equal(t, fpIns.GetBtcPkHex(), res.ManagedVotes[0].BtcPkHex)
+		require.Equal(t, voted, res.ManagedVotes[0].Voted)
+	})
+}
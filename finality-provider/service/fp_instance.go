@@ -1,6 +1,7 @@
 package service
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -38,12 +39,50 @@ type FinalityProviderInstance struct {
 	poller  *ChainPoller
 	metrics *metrics.FpMetrics
 
+	// heartbeats is set by the manager to detect stuck loops
+	heartbeats *Heartbeats
+	// tipCache is set by the manager to share the tip across the loops
+	tipCache *TipCache
+	// auditLog is set by the manager to record the signing operations
+	auditLog *AuditLog
+	// alerter is set by the manager to notify the critical conditions
+	alerter *Alerter
+	// missedBlocks is set by the manager to persist the missed blocks
+	missedBlocks *store.MissedBlockStore
+	// submissions is set by the manager to persist the submitted txs
+	submissions *store.SubmissionStore
+	// voteProofs is set by the manager to persist the proofs of the votes
+	voteProofs *store.VoteProofStore
+	// epochMsgs is set by the manager to queue the commitments until their
+	// epoch is finalized
+	epochMsgs *store.EpochMsgStore
+	// events is set by the manager to broadcast the events
+	events *EventBus
+	// maintenance is set by the manager to hold the new work in maintenance
+	maintenance *maintenanceState
+
 	// passphrase is used to unlock private keys
 	passphrase string
 
-	laggingTargetChan chan *types.BlockInfo
-	criticalErrChan   chan<- *CriticalError
-
+	// randPool keeps the public randomness generated ahead of time
+	randPool *randPool
+	// blockRate scales the size of the commitments to the block rate
+	blockRate *blockRateEstimator
+	// lastCommittedHeight is the last committed height of public randomness
+	// seen by the latest commitment, 0 if unknown
+	lastCommittedHeight *atomic.Uint64
+	// reorgedBlocks replace the stale blocks still buffered in the poller
+	// after a chain reorg, only accessed by the submission loop
+	reorgedBlocks map[uint64]*types.BlockInfo
+
+	laggingTargetChan     chan *types.BlockInfo
+	randPoolRefillChan    chan struct{}
+	randCommitTriggerChan chan *types.BlockInfo
+	criticalErrChan       chan<- *CriticalError
+
+	// startMu serializes starting and stopping the instance, so that it is
+	// not stopped halfway through its start
+	startMu   sync.Mutex
 	isStarted *atomic.Bool
 	inSync    *atomic.Bool
 	isLagging *atomic.Bool
@@ -57,7 +96,7 @@ type FinalityProviderInstance struct {
 func NewFinalityProviderInstance(
 	fpPk *bbntypes.BIP340PubKey,
 	cfg *fpcfg.Config,
-	s *store.FinalityProviderStore,
+	s store.FpStore,
 	prStore *store.PubRandProofStore,
 	cc clientcontroller.ClientController,
 	em eotsmanager.EOTSManager,
@@ -71,6 +110,10 @@ func NewFinalityProviderInstance(
 		return nil, fmt.Errorf("failed to retrive the finality provider %s from DB: %w", fpPk.MarshalHex(), err)
 	}
 
+	if sfp.Paused {
+		return nil, fmt.Errorf("%w: %s", ErrFinalityProviderPaused, fpPk.MarshalHex())
+	}
+
 	if !sfp.ShouldStart() {
 		return nil, fmt.Errorf("the finality provider instance cannot be initiated with status %s", sfp.Status.String())
 	}
@@ -89,13 +132,25 @@ func NewFinalityProviderInstance(
 		em:              em,
 		cc:              cc,
 		metrics:         metrics,
+		randPool:        &randPool{},
+		blockRate:       &blockRateEstimator{},
+
+		lastCommittedHeight: atomic.NewUint64(0),
 	}, nil
 }
 
-func (fp *FinalityProviderInstance) Start() error {
+func (fp *FinalityProviderInstance) Start() (err error) {
+	fp.startMu.Lock()
+	defer fp.startMu.Unlock()
+
 	if fp.isStarted.Swap(true) {
 		return fmt.Errorf("the finality-provider instance %s is already started", fp.GetBtcPkHex())
 	}
+	defer func() {
+		if err != nil {
+			fp.isStarted.Store(false)
+		}
+	}()
 
 	if fp.IsJailed() {
 		return fmt.Errorf("%w: %s", ErrFinalityProviderJailed, fp.GetBtcPkHex())
@@ -103,6 +158,10 @@ func (fp *FinalityProviderInstance) Start() error {
 
 	fp.logger.Info("Starting finality-provider instance", zap.String("pk", fp.GetBtcPkHex()))
 
+	if err := fp.Reconcile(); err != nil {
+		return fmt.Errorf("failed to reconcile the finality-provider %s: %w", fp.GetBtcPkHex(), err)
+	}
+
 	startHeight, err := fp.bootstrap()
 	if err != nil {
 		return fmt.Errorf("failed to bootstrap the finality-provider %s: %w", fp.GetBtcPkHex(), err)
@@ -112,6 +171,10 @@ func (fp *FinalityProviderInstance) Start() error {
 		zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", startHeight))
 
 	poller := NewChainPoller(fp.logger, fp.cfg.PollerConfig, fp.cc, fp.metrics)
+	poller.heartbeats = fp.heartbeats
+	poller.tipCache = fp.tipCache
+	poller.alerter = fp.alerter
+	poller.maintenance = fp.maintenance
 
 	if err := poller.Start(startHeight + 1); err != nil {
 		return fmt.Errorf("failed to start the poller: %w", err)
@@ -119,7 +182,10 @@ func (fp *FinalityProviderInstance) Start() error {
 
 	fp.poller = poller
 
+	fp.reorgedBlocks = make(map[uint64]*types.BlockInfo)
 	fp.laggingTargetChan = make(chan *types.BlockInfo, 1)
+	fp.randPoolRefillChan = make(chan struct{}, 1)
+	fp.randCommitTriggerChan = make(chan *types.BlockInfo, 1)
 
 	fp.quit = make(chan struct{})
 
@@ -129,6 +195,10 @@ func (fp *FinalityProviderInstance) Start() error {
 	go fp.randomnessCommitmentLoop()
 	fp.wg.Add(1)
 	go fp.checkLaggingLoop()
+	if fp.cfg.RandPoolSize > 0 {
+		fp.wg.Add(1)
+		go fp.randomnessPoolLoop()
+	}
 
 	return nil
 }
@@ -139,7 +209,10 @@ func (fp *FinalityProviderInstance) bootstrap() (uint64, error) {
 		return 0, err
 	}
 
-	if fp.checkLagging(latestBlock) {
+	// skipping to the tip means not backfilling the missed blocks
+	skipToTip := fp.cfg.PollerConfig.AutoChainScanningMode &&
+		fp.cfg.PollerConfig.StartHeightMode == fpcfg.StartHeightTip
+	if !skipToTip && fp.checkLagging(latestBlock) {
 		_, err := fp.tryFastSync(latestBlock)
 		if err != nil {
 			if errors.Is(err, ErrFinalityProviderJailed) {
@@ -151,7 +224,7 @@ func (fp *FinalityProviderInstance) bootstrap() (uint64, error) {
 		}
 	}
 
-	startHeight, err := fp.getPollerStartingHeight()
+	startHeight, err := fp.getPollerStartingHeight(latestBlock)
 	if err != nil {
 		return 0, err
 	}
@@ -160,8 +233,11 @@ func (fp *FinalityProviderInstance) bootstrap() (uint64, error) {
 }
 
 func (fp *FinalityProviderInstance) Stop() error {
+	fp.startMu.Lock()
+	defer fp.startMu.Unlock()
+
 	if !fp.isStarted.Swap(false) {
-		return fmt.Errorf("the finality-provider %s has already stopped", fp.GetBtcPkHex())
+		return fmt.Errorf("%w: %s", ErrFinalityProviderStopped, fp.GetBtcPkHex())
 	}
 
 	if err := fp.poller.Stop(); err != nil {
@@ -189,57 +265,43 @@ func (fp *FinalityProviderInstance) IsJailed() bool {
 func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 	defer fp.wg.Done()
 
+	// the loop beats while waiting for blocks and once each block is
+	// processed, so that the processing of a block which hangs is detected
+	idleTicker := time.NewTicker(fp.cfg.PollerConfig.GetPollInterval())
+	defer idleTicker.Stop()
+	defer fp.heartbeats.remove(finalitySigLoopName)
+
 	for {
+		fp.heartbeats.beat(finalitySigLoopName, fp.cfg.PollerConfig.GetPollInterval())
+
 		select {
-		case b := <-fp.poller.GetBlockInfoChan():
-			fp.logger.Debug(
-				"the finality-provider received a new block, start processing",
-				zap.String("pk", fp.GetBtcPkHex()),
-				zap.Uint64("height", b.Height),
-			)
+		case <-idleTicker.C:
+			// pick up the interval in case it has been reloaded
+			idleTicker.Reset(fp.cfg.PollerConfig.GetPollInterval())
 
-			// check whether the block has been processed before
-			if fp.hasProcessed(b) {
-				continue
-			}
-			// check whether the finality provider has voting power
-			hasVp, err := fp.hasVotingPower(b)
-			if err != nil {
-				fp.reportCriticalErr(err)
-				continue
-			}
-			if !hasVp {
-				// the finality provider does not have voting power
-				// and it will never will at this block
-				fp.MustSetLastProcessedHeight(b.Height)
-				fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
-				continue
-			}
-			// use the copy of the block to avoid the impact to other receivers
-			nextBlock := *b
-			res, err := fp.retrySubmitFinalitySignatureUntilBlockFinalized(&nextBlock)
-			if err != nil {
-				fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
-				if !errors.Is(err, ErrFinalityProviderShutDown) {
-					fp.reportCriticalErr(err)
-				}
+		case b := <-fp.poller.GetBlockInfoChan():
+			// the blocks polled before the maintenance are held until it
+			// is exited
+			if !fp.beginSubmission() {
 				continue
 			}
-			if res == nil {
-				// this can happen when a finality signature is not needed
-				// either if the block is already submitted or the signature
-				// is already submitted
+			fp.processGaps()
+			fp.processBlock(fp.replaceReorgedBlock(b))
+			fp.maintenance.end()
+
+		case reorg := <-fp.poller.GetReorgChan():
+			if !fp.beginSubmission() {
 				continue
 			}
-			fp.logger.Info(
-				"successfully submitted a finality signature to the consumer chain",
-				zap.String("pk", fp.GetBtcPkHex()),
-				zap.Uint64("height", b.Height),
-				zap.String("tx_hash", res.TxHash),
-			)
+			fp.handleReorg(reorg)
+			fp.maintenance.end()
 
 		case targetBlock := <-fp.laggingTargetChan:
+			if !fp.beginSubmission() {
+				continue
+			}
 			res, err := fp.tryFastSync(targetBlock)
+			fp.maintenance.end()
 			fp.isLagging.Store(false)
 			if err != nil {
 				if errors.Is(err, ErrFinalityProviderSlashed) {
@@ -279,34 +341,129 @@ func (fp *FinalityProviderInstance) finalitySigSubmissionLoop() {
 	}
 }
 
+// beginSubmission begins the work of the finality signature submission loop
+// once the maintenance, if any, is exited. The loop is not expected to beat
+// while it is held by the maintenance, so it is tracked again only once the
+// work is begun. It returns false if the instance is stopped first
+func (fp *FinalityProviderInstance) beginSubmission() bool {
+	if fp.maintenance.active() {
+		fp.heartbeats.remove(finalitySigLoopName)
+	}
+	begun := fp.maintenance.begin(fp.quit)
+	fp.heartbeats.beat(finalitySigLoopName, fp.cfg.PollerConfig.GetPollInterval())
+
+	return begun
+}
+
+// processBlock votes for the block received from the poller if the finality
+// provider has voting power and has not processed it before
+func (fp *FinalityProviderInstance) processBlock(b *types.BlockInfo) {
+	fp.logger.Debug(
+		"the finality-provider received a new block, start processing",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", b.Height),
+	)
+	fp.triggerRandCommitIfLow(b)
+
+	// check whether the block has been processed before
+	if fp.hasProcessed(b) {
+		return
+	}
+	// check whether the finality provider has voting power
+	hasVp, err := fp.hasVotingPower(b)
+	if err != nil {
+		fp.reportCriticalErr(err)
+		return
+	}
+	if !hasVp {
+		// the finality provider does not have voting power
+		// and it will never will at this block
+		fp.MustSetLastProcessedHeight(b.Height)
+		fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
+		return
+	}
+	if fp.skipFinalized(b) {
+		fp.MustSetLastProcessedHeight(b.Height)
+		return
+	}
+	// use the copy of the block to avoid the impact to other receivers
+	nextBlock := *b
+	res, err := fp.retrySubmitFinalitySignatureUntilBlockFinalized(&nextBlock)
+	if err != nil {
+		fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
+		if !errors.Is(err, ErrFinalityProviderShutDown) {
+			fp.recordMissedBlock(b.Height, missedBlockReason(err), err)
+			fp.reportCriticalErr(err)
+		}
+		return
+	}
+	if res == nil {
+		// this can happen when a finality signature is not needed
+		// either if the block is already submitted or the signature
+		// is already submitted
+		return
+	}
+	fp.logger.Info(
+		"successfully submitted a finality signature to the consumer chain",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", b.Height),
+		zap.String("tx_hash", res.TxHash),
+	)
+}
+
+// processGaps processes the blocks dropped by the poller from its full
+// buffer, which are all below the blocks still buffered
+func (fp *FinalityProviderInstance) processGaps() {
+	for _, g := range fp.poller.takeGaps() {
+		for height := g.start; height <= g.end; height++ {
+			if height <= fp.GetLastProcessedHeight() {
+				continue
+			}
+			b, err := fp.poller.blockWithRetry(height)
+			if err != nil {
+				fp.recordMissedBlock(height, missedBlockReason(err), err)
+				continue
+			}
+			fp.processBlock(b)
+			fp.heartbeats.beat(finalitySigLoopName, fp.cfg.PollerConfig.GetPollInterval())
+		}
+	}
+}
+
 func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 	defer fp.wg.Done()
 
-	commitRandTicker := time.NewTicker(fp.cfg.RandomnessCommitInterval)
+	commitRandTicker := time.NewTicker(fp.cfg.GetRandomnessCommitInterval())
 	defer commitRandTicker.Stop()
+	defer fp.heartbeats.remove(randomnessCommitmentLoopName)
 
 	for {
+		fp.heartbeats.beat(randomnessCommitmentLoopName, fp.cfg.GetRandomnessCommitInterval())
+
 		select {
 		case <-commitRandTicker.C:
-			tipBlock, err := fp.getLatestBlockWithRetry()
-			if err != nil {
-				fp.reportCriticalErr(err)
+			// pick up the interval in case it has been reloaded
+			commitRandTicker.Reset(fp.cfg.GetRandomnessCommitInterval())
+			// no randomness is committed in maintenance
+			if !fp.maintenance.tryBegin() {
 				continue
 			}
-			txRes, err := fp.retryCommitPubRandUntilBlockFinalized(tipBlock)
+			tipBlock, err := fp.getLatestBlockWithRetry()
 			if err != nil {
-				fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
+				fp.maintenance.end()
 				fp.reportCriticalErr(err)
 				continue
 			}
-			// txRes could be nil if no need to commit more randomness
-			if txRes != nil {
-				fp.logger.Info(
-					"successfully committed public randomness to the consumer chain",
-					zap.String("pk", fp.GetBtcPkHex()),
-					zap.String("tx_hash", txRes.TxHash),
-				)
+			fp.commitRandomness(tipBlock)
+			fp.maintenance.end()
+
+		case b := <-fp.randCommitTriggerChan:
+			// the committed randomness is running out before the next tick
+			if !fp.maintenance.tryBegin() {
+				continue
 			}
+			fp.commitRandomness(b)
+			fp.maintenance.end()
 
 		case <-fp.quit:
 			fp.logger.Info("the randomness commitment loop is closing")
@@ -315,6 +472,31 @@ func (fp *FinalityProviderInstance) randomnessCommitmentLoop() {
 	}
 }
 
+// randCfg returns the randomness config of the finality provider, which may
+// override the global one
+func (fp *FinalityProviderInstance) randCfg() fpcfg.RandConfig {
+	return fp.cfg.RandConfigFor(fp.GetBtcPkHex())
+}
+
+func (fp *FinalityProviderInstance) commitRandomness(tipBlock *types.BlockInfo) {
+	fp.blockRate.observe(tipBlock.Height, time.Now())
+
+	txRes, err := fp.retryCommitPubRandUntilBlockFinalized(tipBlock)
+	if err != nil {
+		fp.metrics.IncrementFpTotalFailedRandomness(fp.GetBtcPkHex())
+		fp.reportCriticalErr(err)
+		return
+	}
+	// txRes could be nil if no need to commit more randomness
+	if txRes != nil {
+		fp.logger.Info(
+			"successfully committed public randomness to the consumer chain",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.String("tx_hash", txRes.TxHash),
+		)
+	}
+}
+
 func (fp *FinalityProviderInstance) checkLaggingLoop() {
 	defer fp.wg.Done()
 
@@ -333,6 +515,10 @@ func (fp *FinalityProviderInstance) checkLaggingLoop() {
 				// we are in fast sync mode, skip do not do checks
 				continue
 			}
+			if fp.maintenance.active() {
+				// no fast sync is started in maintenance
+				continue
+			}
 
 			latestBlock, err := fp.getLatestBlockWithRetry()
 			if err != nil {
@@ -443,6 +629,8 @@ func (fp *FinalityProviderInstance) checkLagging(currentBlock *types.BlockInfo)
 // error will be returned if maximum retries have been reached or the query to the consumer chain fails
 func (fp *FinalityProviderInstance) retrySubmitFinalitySignatureUntilBlockFinalized(targetBlock *types.BlockInfo) (*types.TxResponse, error) {
 	var failedCycles uint32
+	policy := fp.cfg.FinalitySigRetryPolicy()
+	start := time.Now()
 
 	// we break the for loop if the block is finalized or the signature is successfully submitted
 	// error will be returned if maximum retries have been reached or the query to the consumer chain fails
@@ -459,7 +647,27 @@ func (fp *FinalityProviderInstance) retrySubmitFinalitySignatureUntilBlockFinali
 				zap.Error(err),
 			)
 
-			if clientcontroller.IsUnrecoverable(err) {
+			// the paused submissions are not counted as failures
+			if errors.Is(err, clientcontroller.ErrCircuitOpen) {
+				if !fp.waitForSubmissions(finalitySigLoopName, fp.cfg.PollerConfig.GetPollInterval()) {
+					return nil, ErrFinalityProviderShutDown
+				}
+				continue
+			}
+
+			// the randomness committed in an epoch not finalized on Bitcoin
+			// yet cannot be used for hours, so the vote is not retried
+			if clientcontroller.IsPubRandNotTimestamped(err) {
+				fp.recordMissedBlock(targetBlock.Height, store.MissedReasonNotTimestamped, err)
+				return nil, nil
+			}
+
+			// the missing randomness is committed right away and the vote is
+			// retried until the commitment is included on the consumer chain
+			if isPubRandMissing(err) {
+				fp.requestPubRandCommit(targetBlock)
+			} else if clientcontroller.IsUnrecoverable(err) || errors.Is(err, store.ErrConflictingVote) ||
+				errors.Is(err, ErrInvalidFinalitySig) {
 				return nil, err
 			}
 
@@ -468,15 +676,20 @@ func (fp *FinalityProviderInstance) retrySubmitFinalitySignatureUntilBlockFinali
 			}
 
 			failedCycles += 1
-			if failedCycles > fp.cfg.MaxSubmissionRetries {
-				return nil, fmt.Errorf("reached max failed cycles with err: %w", err)
+			fp.alerter.submissionFailed(fp.GetBtcPkHex(), "finality signature", failedCycles, err)
+			if exhaustedErr := policy.Exhausted(failedCycles, time.Since(start)); exhaustedErr != nil {
+				return nil, fmt.Errorf("%s with err: %w", exhaustedErr, err)
 			}
 		} else {
 			// the signature has been successfully submitted
 			return res, nil
 		}
+		submitErr := err
+		// the loop is not stuck while it backs off
+		delay := policy.Delay(failedCycles)
+		fp.heartbeats.beat(finalitySigLoopName, delay)
 		select {
-		case <-time.After(fp.cfg.SubmissionRetryInterval):
+		case <-time.After(delay):
 			// periodically query the index block to be later checked whether it is Finalized
 			finalized, err := fp.checkBlockFinalization(targetBlock.Height)
 			if err != nil {
@@ -488,6 +701,7 @@ func (fp *FinalityProviderInstance) retrySubmitFinalitySignatureUntilBlockFinali
 					zap.String("pk", fp.GetBtcPkHex()),
 					zap.Uint64("target_height", targetBlock.Height),
 				)
+				fp.recordMissedBlock(targetBlock.Height, store.MissedReasonLate, submitErr)
 				// TODO: returning nil here is to safely break the loop
 				//  the error still exists
 				return nil, nil
@@ -500,6 +714,68 @@ func (fp *FinalityProviderInstance) retrySubmitFinalitySignatureUntilBlockFinali
 	}
 }
 
+// waitForSubmissions blocks while the submissions to the consumer chain are
+// paused by the circuit breaker of the client controller, and returns false
+// if the instance is stopped in the meantime. The given loop keeps beating
+// every interval as the pause is not a sign of being stuck
+func (fp *FinalityProviderInstance) waitForSubmissions(loop string, interval time.Duration) bool {
+	cb, ok := fp.cc.(clientcontroller.CircuitBreaker)
+	if !ok {
+		return true
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fp.heartbeats.beat(loop, interval)
+
+		select {
+		case <-cb.CircuitClosed():
+			return true
+		case <-ticker.C:
+		case <-fp.quit:
+			return false
+		}
+	}
+}
+
+// skipFinalized returns whether the vote for the given block is skipped as
+// the block is already finalized, if enabled. The finalization is checked on
+// a best-effort basis, so the block is voted for if the check fails
+func (fp *FinalityProviderInstance) skipFinalized(b *types.BlockInfo) bool {
+	if !fp.cfg.SubmissionConfig.GetSkipFinalized() {
+		return false
+	}
+
+	finalized := b.Finalized
+	if !finalized {
+		var err error
+		finalized, err = fp.checkBlockFinalization(b.Height)
+		if err != nil {
+			fp.logger.Debug(
+				"failed to check whether the block is finalized, voting for it",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", b.Height),
+				zap.Error(err),
+			)
+			return false
+		}
+	}
+	if !finalized {
+		return false
+	}
+
+	fp.logger.Debug(
+		"the block is already finalized, skip voting for it",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", b.Height),
+	)
+	fp.recordSkippedBlock(b.Height)
+
+	return true
+}
+
 func (fp *FinalityProviderInstance) checkBlockFinalization(height uint64) (bool, error) {
 	b, err := fp.cc.QueryBlock(height)
 	if err != nil {
@@ -513,6 +789,8 @@ func (fp *FinalityProviderInstance) checkBlockFinalization(height uint64) (bool,
 // error will be returned if maximum retries have been reached or the query to the consumer chain fails
 func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(targetBlock *types.BlockInfo) (*types.TxResponse, error) {
 	var failedCycles uint32
+	policy := fp.cfg.PubRandRetryPolicy()
+	start := time.Now()
 
 	// we break the for loop if the block is finalized or the public rand is successfully committed
 	// error will be returned if maximum retries have been reached or the query to the consumer chain fails
@@ -526,7 +804,14 @@ func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(target
 		// is finalised or the pub rand is committed successfully
 		res, err := fp.CommitPubRand(targetBlock.Height)
 		if err != nil {
-			if clientcontroller.IsUnrecoverable(err) {
+			// the paused submissions are not counted as failures
+			if errors.Is(err, clientcontroller.ErrCircuitOpen) {
+				if !fp.waitForSubmissions(randomnessCommitmentLoopName, fp.cfg.GetRandomnessCommitInterval()) {
+					return nil, nil
+				}
+				continue
+			}
+			if clientcontroller.IsUnrecoverable(err) || errors.Is(err, ErrConflictingPubRandCommit) {
 				return nil, err
 			}
 			fp.logger.Debug(
@@ -538,15 +823,19 @@ func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(target
 			)
 
 			failedCycles += 1
-			if failedCycles > fp.cfg.MaxSubmissionRetries {
-				return nil, fmt.Errorf("reached max failed cycles with err: %w", err)
+			fp.alerter.submissionFailed(fp.GetBtcPkHex(), "public randomness", failedCycles, err)
+			if exhaustedErr := policy.Exhausted(failedCycles, time.Since(start)); exhaustedErr != nil {
+				return nil, fmt.Errorf("%s with err: %w", exhaustedErr, err)
 			}
 		} else {
 			// the public randomness has been successfully submitted
 			return res, nil
 		}
+		// the loop is not stuck while it backs off
+		delay := policy.Delay(failedCycles)
+		fp.heartbeats.beat(randomnessCommitmentLoopName, delay)
 		select {
-		case <-time.After(fp.cfg.SubmissionRetryInterval):
+		case <-time.After(delay):
 			// periodically query the index block to be later checked whether it is Finalized
 			finalized, err := fp.checkBlockFinalization(targetBlock.Height)
 			if err != nil {
@@ -574,16 +863,23 @@ func (fp *FinalityProviderInstance) retryCommitPubRandUntilBlockFinalized(target
 // commits the public randomness for the managed finality providers,
 // and save the randomness pair to DB
 func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxResponse, error) {
-	lastCommittedHeight, err := fp.GetLastCommittedHeight()
+	pubRandCommitMap, err := fp.lastCommittedPublicRandWithRetry(1)
+	if err != nil {
+		return nil, err
+	}
+	lastCommittedHeight, err := getLastCommittedHeight(pubRandCommitMap)
 	if err != nil {
 		return nil, err
 	}
+	fp.alerter.checkRandHeadroom(fp.GetBtcPkHex(), lastCommittedHeight, tipHeight)
+	fp.lastCommittedHeight.Store(lastCommittedHeight)
 
+	randCfg := fp.randCfg()
 	var startHeight uint64
 	if lastCommittedHeight == uint64(0) {
 		// the finality-provider has never submitted public rand before
 		startHeight = tipHeight + 1
-	} else if lastCommittedHeight < uint64(fp.cfg.MinRandHeightGap)+tipHeight {
+	} else if lastCommittedHeight < uint64(randCfg.MinRandHeightGap)+tipHeight {
 		// (should not use subtraction because they are in the type of uint64)
 		// we are running out of the randomness
 		startHeight = lastCommittedHeight + 1
@@ -597,22 +893,72 @@ func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxRe
 		return nil, nil
 	}
 
-	// generate a list of Schnorr randomness pairs
+	// generate a list of Schnorr randomness pairs with the commitment and
+	// proof for each public randomness, unless the pool has them already
 	// NOTE: currently, calling this will create and save a list of randomness
 	// in case of failure, randomness that has been created will be overwritten
 	// for safety reason as the same randomness must not be used twice
-	pubRandList, err := fp.getPubRandList(startHeight, fp.cfg.NumPubRand)
+	// a commitment recorded by a previous attempt which did not land is sent
+	// again as is, as the number to commit changes with the tip while a
+	// different commitment over the same heights is refused
+	pending, err := fp.pendingPubRandCommit(lastCommittedHeight)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate randomness: %w", err)
+		return nil, err
+	}
+	var numToCommit uint32
+	if pending != nil {
+		startHeight = pending.StartHeight
+		numToCommit = uint32(pending.NumPubRand)
+	} else {
+		numToCommit = fp.numPubRandToCommit()
+	}
+	if pending == nil && randCfg.MaxRandLookahead > 0 {
+		// do not commit beyond the lookahead
+		maxEndHeight := tipHeight + uint64(randCfg.MaxRandLookahead)
+		if startHeight > maxEndHeight {
+			fp.logger.Debug(
+				"the public randomness is committed up to the maximum lookahead, skip committing more",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("block_height", tipHeight),
+				zap.Uint64("last_committed_height", lastCommittedHeight),
+			)
+			return nil, nil
+		}
+		if maxNum := maxEndHeight - startHeight + 1; uint64(numToCommit) > maxNum {
+			numToCommit = uint32(maxNum)
+		}
 	}
+	batch := fp.randPool.take(startHeight, numToCommit)
+	if batch == nil {
+		batch, err = fp.generatePubRandBatch(startHeight, numToCommit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate randomness: %w", err)
+		}
+	}
+	pubRandList, commitment, proofList := batch.pubRandList, batch.commitment, batch.proofList
 	numPubRand := uint64(len(pubRandList))
 
-	// generate commitment and proof for each public randomness
-	commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+	// committing different randomness for the same height would leak the
+	// EOTS key once both are used, so refuse to overlap existing commitments
+	commit := &store.PubRandCommit{
+		StartHeight: startHeight,
+		NumPubRand:  numPubRand,
+		Commitment:  commitment,
+	}
+	if err := fp.checkPubRandCommitOverlap(commit, pubRandCommitMap); err != nil {
+		return nil, err
+	}
 
-	// store them to database
-	if err := fp.pubRandState.AddPubRandProofList(pubRandList, proofList); err != nil {
-		return nil, fmt.Errorf("failed to save public randomness to DB: %w", err)
+	// store them to database together with the commitment, so that a crash
+	// cannot leave one without the other. The commitment is not made in
+	// dry-run mode, so it must not prevent the commitments once the daemon
+	// goes live
+	recordedCommit := commit
+	if fp.cfg.BabylonConfig.DryRun {
+		recordedCommit = nil
+	}
+	if err := fp.pubRandState.AddPubRandProofListAndCommit(fp.GetBtcPk(), recordedCommit, pubRandList, proofList); err != nil {
+		return nil, fmt.Errorf("failed to save public randomness and its commitment to DB: %w", err)
 	}
 
 	// sign the commitment
@@ -622,6 +968,13 @@ func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxRe
 	}
 
 	res, err := fp.cc.CommitPubRandList(fp.GetBtcPk(), startHeight, numPubRand, commitment, schnorrSig)
+	fp.auditSigning(AuditEntry{
+		Operation:  AuditOpPubRandCommit,
+		Height:     startHeight,
+		NumPubRand: numPubRand,
+		Hash:       hex.EncodeToString(commitment),
+	}, res)
+	fp.recordSubmission(store.SubmissionTypePubRandCommit, startHeight, batch.endHeight(), res, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to commit public randomness to the consumer chain: %w", err)
 	}
@@ -631,6 +984,10 @@ func (fp *FinalityProviderInstance) CommitPubRand(tipHeight uint64) (*types.TxRe
 	fp.metrics.RecordFpLastCommittedRandomnessHeight(fp.GetBtcPkHex(), lastCommittedHeight)
 	fp.metrics.AddToFpTotalCommittedRandomness(fp.GetBtcPkHex(), float64(len(pubRandList)))
 
+	fp.queueEpochMsg(store.SubmissionTypePubRandCommit, startHeight, batch.endHeight(), res)
+	fp.lastCommittedHeight.Store(batch.endHeight())
+	fp.requestRandPoolRefill()
+
 	return res, nil
 }
 
@@ -660,8 +1017,16 @@ func (fp *FinalityProviderInstance) SubmitFinalitySignature(b *types.BlockInfo)
 		)
 	}
 
+	if err := fp.verifyFinalitySig(b, pubRand, proofBytes, sig.ToModNScalar()); err != nil {
+		fp.alerter.Fire(AlertInvalidFinalitySig, AlertSeverityCritical, fp.GetBtcPkHex(), err.Error())
+		return nil, err
+	}
+
 	// send finality signature to the consumer chain
 	res, err := fp.cc.SubmitFinalitySig(fp.GetBtcPk(), b, pubRand, proofBytes, sig.ToModNScalar())
+	fp.auditFinalitySigs([]*types.BlockInfo{b}, res)
+	fp.recordSubmission(store.SubmissionTypeFinalitySig, b.Height, b.Height, res, err)
+	fp.recordVoteProofs([]*types.BlockInfo{b}, prList, [][]byte{proofBytes}, []*btcec.ModNScalar{sig.ToModNScalar()}, res, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send finality signature to the consumer chain: %w", err)
 	}
@@ -702,16 +1067,23 @@ func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*type
 
 	// sign blocks
 	sigList := make([]*btcec.ModNScalar, 0, len(blocks))
-	for _, b := range blocks {
+	for i, b := range blocks {
 		eotsSig, err := fp.signFinalitySig(b)
 		if err != nil {
 			return nil, err
 		}
+		if err := fp.verifyFinalitySig(b, prList[i], proofBytesList[i], eotsSig.ToModNScalar()); err != nil {
+			fp.alerter.Fire(AlertInvalidFinalitySig, AlertSeverityCritical, fp.GetBtcPkHex(), err.Error())
+			return nil, err
+		}
 		sigList = append(sigList, eotsSig.ToModNScalar())
 	}
 
 	// send finality signature to the consumer chain
 	res, err := fp.cc.SubmitBatchFinalitySigs(fp.GetBtcPk(), blocks, prList, proofBytesList, sigList)
+	fp.auditFinalitySigs(blocks, res)
+	fp.recordSubmission(store.SubmissionTypeFinalitySig, blocks[0].Height, blocks[len(blocks)-1].Height, res, err)
+	fp.recordVoteProofs(blocks, prList, proofBytesList, sigList, res, err)
 	if err != nil {
 		if strings.Contains(err.Error(), "jailed") {
 			return nil, ErrFinalityProviderJailed
@@ -729,6 +1101,35 @@ func (fp *FinalityProviderInstance) SubmitBatchFinalitySignatures(blocks []*type
 	return res, nil
 }
 
+// auditFinalitySigs records the finality signatures over the given blocks in
+// the audit log, whether or not their submission succeeded
+func (fp *FinalityProviderInstance) auditFinalitySigs(blocks []*types.BlockInfo, res *types.TxResponse) {
+	for _, b := range blocks {
+		fp.auditSigning(AuditEntry{
+			Operation: AuditOpFinalitySig,
+			Height:    b.Height,
+			Hash:      hex.EncodeToString(b.Hash),
+		}, res)
+	}
+}
+
+// auditSigning records the given signing operation in the audit log. The
+// failure to record it is logged rather than blocking the submissions
+func (fp *FinalityProviderInstance) auditSigning(e AuditEntry, res *types.TxResponse) {
+	e.Signer = fp.GetBtcPkHex()
+	if res != nil {
+		e.TxHash = res.TxHash
+	}
+
+	if err := fp.auditLog.Append(e); err != nil {
+		fp.logger.Error("failed to record the signing operation in the audit log",
+			zap.String("operation", e.Operation),
+			zap.Uint64("height", e.Height),
+			zap.Error(err),
+		)
+	}
+}
+
 // TestSubmitFinalitySignatureAndExtractPrivKey is exposed for presentation/testing purpose to allow manual sending finality signature
 // this API is the same as SubmitFinalitySignature except that we don't constraint the voting height and update status
 // Note: this should not be used in the submission loop
@@ -746,14 +1147,17 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 		return nil, nil, fmt.Errorf("failed to get public randomness inclusion proof: %v", err)
 	}
 
-	// sign block
-	eotsSig, err := fp.signFinalitySig(b)
+	// sign block, bypassing the vote guard as a conflicting vote is the
+	// purpose of this test path
+	eotsSig, err := fp.signEOTS(b)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	// send finality signature to the consumer chain
 	res, err := fp.cc.SubmitFinalitySig(fp.GetBtcPk(), b, pubRand, proofBytes, eotsSig.ToModNScalar())
+	fp.auditFinalitySigs([]*types.BlockInfo{b}, res)
+	fp.recordSubmission(store.SubmissionTypeFinalitySig, b.Height, b.Height, res, err)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to send finality signature to the consumer chain: %w", err)
 	}
@@ -779,9 +1183,29 @@ func (fp *FinalityProviderInstance) TestSubmitFinalitySignatureAndExtractPrivKey
 	return res, privKey, nil
 }
 
-func (fp *FinalityProviderInstance) getPollerStartingHeight() (uint64, error) {
-	if !fp.cfg.PollerConfig.AutoChainScanningMode {
-		return fp.cfg.PollerConfig.StaticChainScanningStartHeight, nil
+// getPollerStartingHeight returns the height after which the poller starts
+// according to the configured start height mode
+func (fp *FinalityProviderInstance) getPollerStartingHeight(latestBlock *types.BlockInfo) (uint64, error) {
+	pollerCfg := fp.cfg.PollerConfig
+	if !pollerCfg.AutoChainScanningMode {
+		return pollerCfg.StaticChainScanningStartHeight, nil
+	}
+
+	switch pollerCfg.StartHeightMode {
+	case fpcfg.StartHeightStatic:
+		return pollerCfg.StaticChainScanningStartHeight, nil
+	case fpcfg.StartHeightActivation:
+		// the poller waits for the activation and starts from the
+		// activation height
+		return 0, nil
+	case fpcfg.StartHeightLastVoted:
+		return fp.GetLastVotedHeight(), nil
+	case fpcfg.StartHeightTip:
+		// start from the tip block itself
+		if latestBlock.Height == 0 {
+			return 0, nil
+		}
+		return latestBlock.Height - 1, nil
 	}
 
 	// Set initial block to the maximum of
@@ -811,12 +1235,68 @@ func (fp *FinalityProviderInstance) getPollerStartingHeight() (uint64, error) {
 	return initialBlockToGet, nil
 }
 
+// pendingPubRandCommit returns the commitment recorded locally which has not
+// landed on the consumer chain, i.e., the one starting right after the last
+// committed height, or the lowest one if nothing is committed yet. It returns
+// nil if there is none
+func (fp *FinalityProviderInstance) pendingPubRandCommit(lastCommittedHeight uint64) (*store.PubRandCommit, error) {
+	commits, err := fp.pubRandState.GetOverlappingPubRandCommits(fp.GetBtcPk(), lastCommittedHeight+1, math.MaxUint64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public randomness commitments from DB: %w", err)
+	}
+	for _, c := range commits {
+		if lastCommittedHeight == 0 || c.StartHeight == lastCommittedHeight+1 {
+			return c, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// checkPubRandCommitOverlap returns ErrConflictingPubRandCommit if any height
+// covered by the given commitment is already covered by a different one,
+// either recorded in the store or committed on the consumer chain
+func (fp *FinalityProviderInstance) checkPubRandCommitOverlap(
+	commit *store.PubRandCommit,
+	chainCommitMap map[uint64]*ftypes.PubRandCommitResponse,
+) error {
+	existing, err := fp.pubRandState.GetOverlappingPubRandCommits(fp.GetBtcPk(), commit.StartHeight, commit.EndHeight())
+	if err != nil {
+		return fmt.Errorf("failed to get public randomness commitments from DB: %w", err)
+	}
+	for startHeight, resp := range chainCommitMap {
+		chainCommit := &store.PubRandCommit{
+			StartHeight: startHeight,
+			NumPubRand:  resp.NumPubRand,
+			Commitment:  resp.Commitment,
+		}
+		if chainCommit.StartHeight <= commit.EndHeight() && chainCommit.EndHeight() >= commit.StartHeight {
+			existing = append(existing, chainCommit)
+		}
+	}
+
+	for _, c := range existing {
+		// re-submitting the very same commitment is harmless
+		if c.Equal(commit) {
+			continue
+		}
+		return fmt.Errorf("%w: heights [%d, %d] overlap with the commitment over heights [%d, %d]",
+			ErrConflictingPubRandCommit, commit.StartHeight, commit.EndHeight(), c.StartHeight, c.EndHeight())
+	}
+
+	return nil
+}
+
 func (fp *FinalityProviderInstance) GetLastCommittedHeight() (uint64, error) {
 	pubRandCommitMap, err := fp.lastCommittedPublicRandWithRetry(1)
 	if err != nil {
 		return 0, err
 	}
 
+	return getLastCommittedHeight(pubRandCommitMap)
+}
+
+func getLastCommittedHeight(pubRandCommitMap map[uint64]*ftypes.PubRandCommitResponse) (uint64, error) {
 	// no committed randomness yet
 	if len(pubRandCommitMap) == 0 {
 		return 0, nil
@@ -884,7 +1364,7 @@ func (fp *FinalityProviderInstance) getLatestBlockWithRetry() (*types.BlockInfo,
 	)
 
 	if err := retry.Do(func() error {
-		latestBlock, err = fp.cc.QueryBestBlock()
+		latestBlock, err = fp.tipCache.Query(fp.cc)
 		if err != nil {
 			return err
 		}
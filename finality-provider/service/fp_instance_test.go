@@ -1,11 +1,14 @@
 package service_test
 
 import (
+	"errors"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -19,6 +22,7 @@ import (
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 	"github.com/babylonlabs-io/finality-provider/testutil"
 	"github.com/babylonlabs-io/finality-provider/types"
@@ -49,6 +53,111 @@ func FuzzCommitPubRandList(f *testing.F) {
 	})
 }
 
+// FuzzCommitPubRandOverlap tests that the public randomness is not committed
+// over heights covered by a different commitment that is not on chain
+func FuzzCommitPubRandOverlap(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		randomStartingHeight := uint64(r.Int63n(100) + 1)
+		currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight)
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+			Return(uint64(0), nil).AnyTimes()
+		app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+		defer cleanUp()
+
+		// the commitment is not included on chain yet
+		var chainCommits map[uint64]*ftypes.PubRandCommitResponse
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).
+			DoAndReturn(func(_ *btcec.PublicKey, _ uint64) (map[uint64]*ftypes.PubRandCommitResponse, error) {
+				return chainCommits, nil
+			}).AnyTimes()
+		mockClientController.EXPECT().
+			CommitPubRandList(fpIns.GetBtcPk(), randomStartingHeight+1, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(2)
+		_, err := fpIns.CommitPubRand(randomStartingHeight)
+		require.NoError(t, err)
+
+		// re-submitting the same commitment is allowed
+		_, err = fpIns.CommitPubRand(randomStartingHeight)
+		require.NoError(t, err)
+
+		// a different commitment landed on chain over the first heights of
+		// the recorded one, so the next commitment overlaps the recorded one
+		shift := uint64(r.Int63n(int64(testutil.TestPubRandNum)-1) + 1)
+		chainCommits = map[uint64]*ftypes.PubRandCommitResponse{
+			randomStartingHeight + 1: {NumPubRand: shift, Commitment: datagen.GenRandomByteArray(r, 32)},
+		}
+		_, err = fpIns.CommitPubRand(randomStartingHeight + shift)
+		require.ErrorIs(t, err, service.ErrConflictingPubRandCommit)
+
+		// a commitment after the recorded one is allowed once it landed
+		commits, err := app.GetPubRandProofStore().GetOverlappingPubRandCommits(
+			fpIns.GetBtcPk(), randomStartingHeight+1, randomStartingHeight+1)
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		chainCommits = map[uint64]*ftypes.PubRandCommitResponse{
+			randomStartingHeight + 1: {NumPubRand: commits[0].NumPubRand, Commitment: commits[0].Commitment},
+		}
+		mockClientController.EXPECT().
+			CommitPubRandList(fpIns.GetBtcPk(), randomStartingHeight+testutil.TestPubRandNum+1, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(1)
+		_, err = fpIns.CommitPubRand(randomStartingHeight + testutil.TestPubRandNum)
+		require.NoError(t, err)
+	})
+}
+
+// FuzzCommitPubRandRetry tests that a commitment whose tx failed is sent again
+// as is after the tip moved, although the number to commit changed
+func FuzzCommitPubRandRetry(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		randomStartingHeight := uint64(r.Int63n(100) + 1)
+		currentHeight := randomStartingHeight + uint64(r.Int63n(10)+2)
+		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, currentHeight)
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+			Return(uint64(0), nil).AnyTimes()
+		app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+		defer cleanUp()
+
+		// the lookahead caps the commitment below the configured size, so
+		// the number to commit grows with the tip
+		lookahead := uint64(testutil.TestPubRandNum)
+		app.GetConfig().MaxRandLookahead = uint32(lookahead)
+		lastCommittedHeight := randomStartingHeight + lookahead
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).
+			Return(map[uint64]*ftypes.PubRandCommitResponse{
+				1: {NumPubRand: lastCommittedHeight, Commitment: datagen.GenRandomByteArray(r, 32)},
+			}, nil).AnyTimes()
+
+		var numPubRands []uint64
+		recordNum := func(_ *btcec.PublicKey, _ uint64, numPubRand uint64, _ []byte, _ *schnorr.Signature) {
+			numPubRands = append(numPubRands, numPubRand)
+		}
+		gomock.InOrder(
+			mockClientController.EXPECT().
+				CommitPubRandList(fpIns.GetBtcPk(), lastCommittedHeight+1, gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(recordNum).Return(nil, errors.New("transient failure")).Times(1),
+			mockClientController.EXPECT().
+				CommitPubRandList(fpIns.GetBtcPk(), lastCommittedHeight+1, gomock.Any(), gomock.Any(), gomock.Any()).
+				Do(recordNum).Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(1),
+		)
+		tipHeight := randomStartingHeight + 1
+		_, err := fpIns.CommitPubRand(tipHeight)
+		require.Error(t, err)
+
+		tipHeight += uint64(r.Int63n(int64(lookahead)-1) + 1)
+		_, err = fpIns.CommitPubRand(tipHeight)
+		require.NoError(t, err)
+		require.Len(t, numPubRands, 2)
+		require.Equal(t, numPubRands[0], numPubRands[1])
+	})
+}
+
 func FuzzSubmitFinalitySig(f *testing.F) {
 	testutil.AddRandomSeedsToFuzzer(f, 10)
 	f.Fuzz(func(t *testing.T, seed int64) {
@@ -96,6 +205,21 @@ func FuzzSubmitFinalitySig(f *testing.F) {
 		// check the last_voted_height
 		require.Equal(t, nextBlock.Height, fpIns.GetLastVotedHeight())
 		require.Equal(t, nextBlock.Height, fpIns.GetLastProcessedHeight())
+
+		// a conflicting block at the voted height is refused, except by the
+		// test path which double signs on purpose
+		conflictingBlock := &types.BlockInfo{
+			Height: nextBlock.Height,
+			Hash:   testutil.GenRandomByteArray(r, 32),
+		}
+		_, err = fpIns.SubmitFinalitySignature(conflictingBlock)
+		require.ErrorIs(t, err, store.ErrConflictingVote)
+		mockClientController.EXPECT().
+			SubmitFinalitySig(fpIns.GetBtcPk(), conflictingBlock, gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+		res, _, err := fpIns.TestSubmitFinalitySignatureAndExtractPrivKey(conflictingBlock)
+		require.NoError(t, err)
+		require.Equal(t, expectedTxHash, res.TxHash)
 	})
 }
 
@@ -148,3 +272,43 @@ func startFinalityProviderAppWithRegisteredFp(t *testing.T, r *rand.Rand, cc cli
 
 	return app, fpIns, cleanUp
 }
+
+// FuzzSubmitFinalitySigVerification tests that a finality signature which does
+// not verify against the recorded commitment is not sent to the consumer chain
+func FuzzSubmitFinalitySigVerification(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		randomStartingHeight := uint64(r.Int63n(100) + 1)
+		mockClientController := testutil.PrepareMockedClientController(t, r, randomStartingHeight, randomStartingHeight)
+		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+			Return(uint64(1), nil).AnyTimes()
+		app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, randomStartingHeight)
+		defer cleanUp()
+
+		mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+		mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil).Times(1)
+		_, err := fpIns.CommitPubRand(randomStartingHeight)
+		require.NoError(t, err)
+
+		// the recorded commitment no longer matches the proofs
+		commits, err := app.GetPubRandProofStore().GetOverlappingPubRandCommits(
+			fpIns.GetBtcPk(), randomStartingHeight+1, randomStartingHeight+1)
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		commits[0].Commitment = datagen.GenRandomByteArray(r, 32)
+		require.NoError(t, app.GetPubRandProofStore().AddPubRandCommit(fpIns.GetBtcPk(), commits[0]))
+
+		nextBlock := &types.BlockInfo{
+			Height: randomStartingHeight + 1,
+			Hash:   testutil.GenRandomByteArray(r, 32),
+		}
+		mockClientController.EXPECT().
+			SubmitFinalitySig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		_, err = fpIns.SubmitFinalitySignature(nextBlock)
+		require.ErrorIs(t, err, service.ErrInvalidFinalitySig)
+		require.Zero(t, fpIns.GetLastVotedHeight())
+	})
+}
@@ -41,14 +41,26 @@ type FinalityProviderManager struct {
 	fpIns *FinalityProviderInstance
 
 	// needed for initiating finality-provider instances
-	fps          *store.FinalityProviderStore
+	fps          store.FpStore
 	pubRandStore *store.PubRandProofStore
 	config       *fpcfg.Config
 	cc           clientcontroller.ClientController
 	em           eotsmanager.EOTSManager
 	logger       *zap.Logger
 
-	metrics *metrics.FpMetrics
+	metrics    *metrics.FpMetrics
+	heartbeats *Heartbeats
+	tipCache   *TipCache
+	auditLog   *AuditLog
+	alerter    *Alerter
+
+	missedBlocks *store.MissedBlockStore
+	submissions  *store.SubmissionStore
+	voteProofs   *store.VoteProofStore
+	epochMsgs    *store.EpochMsgStore
+	events       *EventBus
+	emergency    *emergencyState
+	maintenance  *maintenanceState
 
 	criticalErrChan chan *CriticalError
 
@@ -56,7 +68,7 @@ type FinalityProviderManager struct {
 }
 
 func NewFinalityProviderManager(
-	fps *store.FinalityProviderStore,
+	fps store.FpStore,
 	pubRandStore *store.PubRandProofStore,
 	config *fpcfg.Config,
 	cc clientcontroller.ClientController,
@@ -89,6 +101,11 @@ func (fpm *FinalityProviderManager) monitorCriticalErr() {
 	for {
 		select {
 		case criticalErr = <-fpm.criticalErrChan:
+			fpm.events.Publish(&proto.Event{
+				Type:    EventError,
+				BtcPk:   criticalErr.fpBtcPk.MarshalHex(),
+				Message: criticalErr.err.Error(),
+			})
 			fpi, err := fpm.GetFinalityProviderInstance()
 			if err != nil {
 				fpm.logger.Debug("the finality-provider instance is already shutdown",
@@ -110,6 +127,16 @@ func (fpm *FinalityProviderManager) monitorCriticalErr() {
 
 				continue
 			}
+			if errors.Is(criticalErr.err, ErrEmergencyStopped) {
+				// the instance is being stopped by the emergency stop
+				fpm.logger.Warn("the finality-provider failed as the daemon is stopped in emergency",
+					zap.String("pk", criticalErr.fpBtcPk.MarshalHex()), zap.Error(criticalErr.err))
+
+				continue
+			}
+			fpm.alerter.Fire(AlertFatal, AlertSeverityCritical, criticalErr.fpBtcPk.MarshalHex(),
+				fmt.Sprintf("%s: %v", instanceTerminatingMsg, criticalErr.err))
+			fpm.alerter.Flush()
 			fpm.logger.Fatal(instanceTerminatingMsg,
 				zap.String("pk", criticalErr.fpBtcPk.MarshalHex()), zap.Error(criticalErr.err))
 		case <-fpm.quit:
@@ -135,8 +162,11 @@ func (fpm *FinalityProviderManager) monitorStatusUpdate() {
 
 	statusUpdateTicker := time.NewTicker(fpm.config.StatusUpdateInterval)
 	defer statusUpdateTicker.Stop()
+	defer fpm.heartbeats.remove(statusUpdateLoopName)
 
 	for {
+		fpm.heartbeats.beat(statusUpdateLoopName, fpm.config.StatusUpdateInterval)
+
 		select {
 		case <-statusUpdateTicker.C:
 			fpi := fpm.fpIns
@@ -218,6 +248,8 @@ func (fpm *FinalityProviderManager) monitorStatusUpdate() {
 }
 
 func (fpm *FinalityProviderManager) setFinalityProviderSlashed(fpi *FinalityProviderInstance) {
+	fpm.alerter.Fire(AlertSlashed, AlertSeverityCritical, fpi.GetBtcPkHex(),
+		"the finality provider is slashed and its instance is terminated")
 	fpi.MustSetStatus(proto.FinalityProviderStatus_SLASHED)
 	if err := fpm.removeFinalityProviderInstance(); err != nil {
 		panic(fmt.Errorf("failed to terminate a slashed finality-provider %s: %w", fpi.GetBtcPkHex(), err))
@@ -225,6 +257,8 @@ func (fpm *FinalityProviderManager) setFinalityProviderSlashed(fpi *FinalityProv
 }
 
 func (fpm *FinalityProviderManager) setFinalityProviderJailed(fpi *FinalityProviderInstance) {
+	fpm.alerter.Fire(AlertJailed, AlertSeverityCritical, fpi.GetBtcPkHex(),
+		"the finality provider is jailed and its instance is terminated until it is unjailed")
 	fpi.MustSetStatus(proto.FinalityProviderStatus_JAILED)
 	if err := fpm.removeFinalityProviderInstance(); err != nil {
 		panic(fmt.Errorf("failed to terminate a jailed finality-provider %s: %w", fpi.GetBtcPkHex(), err))
@@ -338,7 +372,8 @@ func (fpm *FinalityProviderManager) removeFinalityProviderInstance() error {
 		return fmt.Errorf("the finality provider instance does not exist")
 	}
 	if fpi.IsRunning() {
-		if err := fpi.Stop(); err != nil {
+		// the instance might have been stopped in the meantime
+		if err := fpi.Stop(); err != nil && !errors.Is(err, ErrFinalityProviderStopped) {
 			return fmt.Errorf("failed to stop the finality provider instance %s", fpi.GetBtcPkHex())
 		}
 	}
@@ -353,22 +388,58 @@ func (fpm *FinalityProviderManager) startFinalityProviderInstance(
 	pk *bbntypes.BIP340PubKey,
 	passphrase string,
 ) error {
-	pkHex := pk.MarshalHex()
+	if err := fpm.emergency.check(); err != nil {
+		return fmt.Errorf("%w: resume it to start the finality provider %s", err, pk.MarshalHex())
+	}
+
 	if fpm.fpIns == nil {
-		fpIns, err := NewFinalityProviderInstance(
-			pk, fpm.config, fpm.fps, fpm.pubRandStore, fpm.cc, fpm.em,
-			fpm.metrics, passphrase, fpm.criticalErrChan, fpm.logger,
-		)
+		fpIns, err := fpm.newFinalityProviderInstance(pk, passphrase)
 		if err != nil {
-			return fmt.Errorf("failed to create finality provider instance %s: %w", pkHex, err)
+			return err
 		}
-
 		fpm.fpIns = fpIns
+	} else {
+		// the instance kept by the manager might have been paused since
+		sfp, err := fpm.fps.GetFinalityProvider(pk.MustToBTCPK())
+		if err != nil {
+			return err
+		}
+		if sfp.Paused {
+			return fmt.Errorf("%w: %s", ErrFinalityProviderPaused, pk.MarshalHex())
+		}
 	}
 
 	return fpm.fpIns.Start()
 }
 
+// newFinalityProviderInstance creates a finality-provider instance sharing the
+// stores and the monitoring of the finality-provider manager without starting it
+func (fpm *FinalityProviderManager) newFinalityProviderInstance(
+	pk *bbntypes.BIP340PubKey,
+	passphrase string,
+) (*FinalityProviderInstance, error) {
+	fpIns, err := NewFinalityProviderInstance(
+		pk, fpm.config, fpm.fps, fpm.pubRandStore, fpm.cc, fpm.em,
+		fpm.metrics, passphrase, fpm.criticalErrChan, fpm.logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create finality provider instance %s: %w", pk.MarshalHex(), err)
+	}
+
+	fpIns.heartbeats = fpm.heartbeats
+	fpIns.tipCache = fpm.tipCache
+	fpIns.auditLog = fpm.auditLog
+	fpIns.alerter = fpm.alerter
+	fpIns.missedBlocks = fpm.missedBlocks
+	fpIns.submissions = fpm.submissions
+	fpIns.voteProofs = fpm.voteProofs
+	fpIns.epochMsgs = fpm.epochMsgs
+	fpIns.events = fpm.events
+	fpIns.maintenance = fpm.maintenance
+
+	return fpIns, nil
+}
+
 func (fpm *FinalityProviderManager) getLatestBlockWithRetry() (*types.BlockInfo, error) {
 	var (
 		latestBlock *types.BlockInfo
@@ -376,7 +447,7 @@ func (fpm *FinalityProviderManager) getLatestBlockWithRetry() (*types.BlockInfo,
 	)
 
 	if err := retry.Do(func() error {
-		latestBlock, err = fpm.cc.QueryBestBlock()
+		latestBlock, err = fpm.tipCache.Query(fpm.cc)
 		if err != nil {
 			return err
 		}
@@ -61,6 +61,8 @@ func FuzzStatusUpdate(f *testing.F) {
 		votingPower := uint64(r.Intn(2))
 		mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), currentHeight).Return(votingPower, nil).AnyTimes()
 		mockClientController.EXPECT().SubmitFinalitySig(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&types.TxResponse{TxHash: ""}, nil).AnyTimes()
+		// the status is reconciled upon start, before it changes on chain
+		mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).Times(1)
 		var isSlashedOrJailed int
 		if votingPower == 0 {
 			// 0 means is slashed, 1 means is jailed, 2 means neither slashed nor jailed
@@ -160,6 +162,7 @@ func newFinalityProviderManagerWithRegisteredFp(t *testing.T, r *rand.Rand, cc c
 		btcPk.MustToBTCPK(),
 		testutil.RandomDescription(r),
 		testutil.ZeroCommissionRate(),
+		nil,
 		keyName,
 		chainID,
 		pop.BtcSig,
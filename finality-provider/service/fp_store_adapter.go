@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"sync"
 
 	sdkmath "cosmossdk.io/math"
@@ -25,8 +26,10 @@ type createFinalityProviderRequest struct {
 	hdPath          string
 	chainID         string
 	eotsPk          *bbntypes.BIP340PubKey
+	pop             *btcstakingtypes.ProofOfPossessionBTC
 	description     *stakingtypes.Description
 	commission      *sdkmath.LegacyDec
+	maxCommission   *sdkmath.LegacyDec
 	errResponse     chan error
 	successResponse chan *createFinalityProviderResponse
 }
@@ -61,12 +64,12 @@ type CreateFinalityProviderResult struct {
 type fpState struct {
 	mu sync.Mutex
 	fp *store.StoredFinalityProvider
-	s  *store.FinalityProviderStore
+	s  store.FpStore
 }
 
 func NewFpState(
 	fp *store.StoredFinalityProvider,
-	s *store.FinalityProviderStore,
+	s store.FpStore,
 ) *fpState {
 	return &fpState{
 		fp: fp,
@@ -89,19 +92,37 @@ func (fps *fpState) setStatus(s proto.FinalityProviderStatus) error {
 
 func (fps *fpState) setLastProcessedHeight(height uint64) error {
 	fps.mu.Lock()
-	fps.fp.LastProcessedHeight = height
+	if fps.fp.LastProcessedHeight < height {
+		fps.fp.LastProcessedHeight = height
+	}
 	fps.mu.Unlock()
 	return fps.s.SetFpLastProcessedHeight(fps.fp.BtcPk, height)
 }
 
 func (fps *fpState) setLastProcessedAndVotedHeight(height uint64) error {
 	fps.mu.Lock()
-	fps.fp.LastVotedHeight = height
-	fps.fp.LastProcessedHeight = height
+	if fps.fp.LastVotedHeight < height {
+		fps.fp.LastVotedHeight = height
+	}
+	if fps.fp.LastProcessedHeight < height {
+		fps.fp.LastProcessedHeight = height
+	}
 	fps.mu.Unlock()
 	return fps.s.SetFpLastVotedHeight(fps.fp.BtcPk, height)
 }
 
+func (fps *fpState) guardVote(height uint64, blockHash []byte) error {
+	return fps.s.GuardVote(fps.fp.BtcPk, height, blockHash)
+}
+
+func (fps *fpState) getVotedBlockHash(height uint64) ([]byte, error) {
+	return fps.s.GetVotedBlockHash(fps.fp.BtcPk, height)
+}
+
+func (fps *fpState) getPendingVotes() ([]*store.VotedBlock, error) {
+	return fps.s.GetPendingVotes(fps.fp.BtcPk)
+}
+
 func (fp *FinalityProviderInstance) GetStoreFinalityProvider() *store.StoredFinalityProvider {
 	return fp.fpState.getStoreFinalityProvider()
 }
@@ -135,11 +156,20 @@ func (fp *FinalityProviderInstance) GetChainID() []byte {
 }
 
 func (fp *FinalityProviderInstance) SetStatus(s proto.FinalityProviderStatus) error {
-	return fp.fpState.setStatus(s)
+	prev := fp.GetStatus()
+	if err := fp.fpState.setStatus(s); err != nil {
+		return err
+	}
+	if prev != s {
+		fp.publishStatusChange(prev, s)
+	}
+
+	return nil
 }
 
 func (fp *FinalityProviderInstance) MustSetStatus(s proto.FinalityProviderStatus) {
 	if err := fp.SetStatus(s); err != nil {
+		fp.fatalDBWrite(fmt.Sprintf("failed to set the finality provider status to %s: %v", s.String(), err))
 		fp.logger.Fatal("failed to set finality-provider status",
 			zap.String("pk", fp.GetBtcPkHex()), zap.String("status", s.String()))
 	}
@@ -151,18 +181,27 @@ func (fp *FinalityProviderInstance) SetLastProcessedHeight(height uint64) error
 
 func (fp *FinalityProviderInstance) MustSetLastProcessedHeight(height uint64) {
 	if err := fp.SetLastProcessedHeight(height); err != nil {
+		fp.fatalDBWrite(fmt.Sprintf("failed to set the last processed height to %d: %v", height, err))
 		fp.logger.Fatal("failed to set last processed height",
 			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("last_processed_height", height))
 	}
 	fp.metrics.RecordFpLastProcessedHeight(fp.GetBtcPkHex(), height)
 }
 
+// fatalDBWrite fires an alert on a failed write to the database and waits for
+// it to be sent before the daemon exits
+func (fp *FinalityProviderInstance) fatalDBWrite(msg string) {
+	fp.alerter.Fire(AlertDBWrite, AlertSeverityCritical, fp.GetBtcPkHex(), msg)
+	fp.alerter.Flush()
+}
+
 func (fp *FinalityProviderInstance) updateStateAfterFinalitySigSubmission(height uint64) error {
 	return fp.fpState.setLastProcessedAndVotedHeight(height)
 }
 
 func (fp *FinalityProviderInstance) MustUpdateStateAfterFinalitySigSubmission(height uint64) {
 	if err := fp.updateStateAfterFinalitySigSubmission(height); err != nil {
+		fp.fatalDBWrite(fmt.Sprintf("failed to update the state after submitting the finality signature at height %d: %v", height, err))
 		fp.logger.Fatal("failed to update state after finality signature submitted",
 			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", height))
 	}
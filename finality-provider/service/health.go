@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	// LivenessPath and ReadinessPath are the paths of the metrics server that
+	// serve the health of the daemon, e.g., for Kubernetes probes
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	// healthCheckTimeout is the timeout of each readiness check
+	healthCheckTimeout = 5 * time.Second
+
+	finalitySigLoopName          = "finality-sig-submission"
+	randomnessCommitmentLoopName = "randomness-commitment"
+	chainPollerLoopName          = "chain-poller"
+	statusUpdateLoopName         = "status-update"
+	metricsUpdateLoopName        = "metrics-update"
+	rewardWithdrawalLoopName     = "reward-withdrawal"
+	backupLoopName               = "backup"
+	dbStatsLoopName              = "db-stats"
+	pruneLoopName                = "prune"
+	voteMonitorLoopName          = "vote-monitor"
+	epochTrackingLoopName        = "epoch-tracking"
+)
+
+var healthCheckBucketName = []byte("healthcheck")
+
+// Heartbeats keeps the time of the last iteration of each running loop, so
+// that the loops that do not make progress can be detected
+type Heartbeats struct {
+	mu    sync.Mutex
+	loops map[string]*heartbeat
+
+	// stuckTimeout is the time beyond the loop interval after which a loop
+	// without heartbeats is considered stuck
+	stuckTimeout time.Duration
+}
+
+type heartbeat struct {
+	last     time.Time
+	interval time.Duration
+}
+
+func NewHeartbeats(stuckTimeout time.Duration) *Heartbeats {
+	return &Heartbeats{
+		loops:        make(map[string]*heartbeat),
+		stuckTimeout: stuckTimeout,
+	}
+}
+
+// beat records an iteration of the given loop, which is expected to iterate
+// every interval. It is a no-op on a nil receiver
+func (h *Heartbeats) beat(loop string, interval time.Duration) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.loops[loop] = &heartbeat{last: time.Now(), interval: interval}
+}
+
+// remove stops tracking the given loop, which should be called when the loop
+// exits. It is a no-op on a nil receiver
+func (h *Heartbeats) remove(loop string) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.loops, loop)
+}
+
+// StuckLoops returns the loops without heartbeats for longer than their
+// interval plus the stuck timeout, together with the time since their last
+// heartbeat
+func (h *Heartbeats) StuckLoops() map[string]time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stuck := make(map[string]time.Duration)
+	for loop, hb := range h.loops {
+		since := time.Since(hb.last)
+		if since > hb.interval+h.stuckTimeout {
+			stuck[loop] = since
+		}
+	}
+
+	return stuck
+}
+
+// HealthCheck is the result of a health check
+type HealthCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func newHealthCheck(name string, err error) HealthCheck {
+	if err != nil {
+		return HealthCheck{Name: name, OK: false, Error: err.Error()}
+	}
+	return HealthCheck{Name: name, OK: true}
+}
+
+// CheckLiveness checks that none of the loops is stuck
+func (app *FinalityProviderApp) CheckLiveness() []HealthCheck {
+	return []HealthCheck{app.checkLoops()}
+}
+
+// CheckReadiness checks the connectivity to the consumer chain and the EOTS
+// manager, that the database is writable, and that none of the loops is stuck
+func (app *FinalityProviderApp) CheckReadiness() []HealthCheck {
+	checks := []struct {
+		name  string
+		check func() error
+	}{
+		{"consumer-chain", app.checkConsumerChain},
+		{"eots-manager", app.checkEOTSManager},
+		{"database", app.checkDatabase},
+	}
+
+	results := make([]HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, name string, check func() error) {
+			defer wg.Done()
+			results[i] = newHealthCheck(name, runWithTimeout(check, healthCheckTimeout))
+		}(i, c.name, c.check)
+	}
+	wg.Wait()
+
+	return append(results, app.checkLoops())
+}
+
+func (app *FinalityProviderApp) checkLoops() HealthCheck {
+	stuck := app.heartbeats.StuckLoops()
+	if len(stuck) == 0 {
+		return newHealthCheck("event-loops", nil)
+	}
+
+	loops := make([]string, 0, len(stuck))
+	for loop, since := range stuck {
+		loops = append(loops, fmt.Sprintf("%s (last heartbeat %v ago)", loop, since.Round(time.Second)))
+	}
+	sort.Strings(loops)
+
+	return newHealthCheck("event-loops", fmt.Errorf("stuck loops: %s", strings.Join(loops, ", ")))
+}
+
+func (app *FinalityProviderApp) checkConsumerChain() error {
+	_, err := app.cc.QueryBestBlock()
+	return err
+}
+
+func (app *FinalityProviderApp) checkEOTSManager() error {
+	// only the remote EOTS manager can be unavailable
+	pinger, ok := app.eotsManager.(interface{ Ping() error })
+	if !ok {
+		return nil
+	}
+	return pinger.Ping()
+}
+
+func (app *FinalityProviderApp) checkDatabase() error {
+	return kvdb.Update(app.db, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(healthCheckBucketName)
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte("last_check"), []byte(time.Now().UTC().Format(time.RFC3339)))
+	}, func() {})
+}
+
+// runWithTimeout runs the check and returns an error if it does not complete
+// within the timeout, in which case the check keeps running in the background
+func runWithTimeout(check func() error, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("no response within %v", timeout)
+	}
+}
+
+// healthHandler serves the results of the health checks as JSON, with the
+// status code 200 if all the checks pass or 503 otherwise
+type healthHandler func() []HealthCheck
+
+func (h healthHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	checks := h()
+	healthy := true
+	for _, c := range checks {
+		if !c.OK {
+			healthy = false
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(struct {
+		Healthy bool          `json:"healthy"`
+		Checks  []HealthCheck `json:"checks"`
+	}{healthy, checks})
+}
+
+// LivenessHandler returns the handler of the liveness probe
+func (app *FinalityProviderApp) LivenessHandler() http.Handler {
+	return healthHandler(app.CheckLiveness)
+}
+
+// ReadinessHandler returns the handler of the readiness probe
+func (app *FinalityProviderApp) ReadinessHandler() http.Handler {
+	return healthHandler(app.CheckReadiness)
+}
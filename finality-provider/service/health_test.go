@@ -0,0 +1,248 @@
+package service_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+type healthResponse struct {
+	Healthy bool                  `json:"healthy"`
+	Checks  []service.HealthCheck `json:"checks"`
+}
+
+func TestHealthEndpoints(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	// the consumer chain becomes unavailable after the first query
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	gomock.InOrder(
+		mockClientController.EXPECT().QueryBestBlock().
+			Return(&types.BlockInfo{Height: 10, Hash: testutil.GenRandomByteArray(r, 32)}, nil).Times(1),
+		mockClientController.EXPECT().QueryBestBlock().
+			Return(nil, errors.New("connection refused")).AnyTimes(),
+	)
+
+	app, _, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+
+	res := serveHealth(t, app.ReadinessHandler())
+	require.Equal(t, http.StatusOK, res.code)
+	require.True(t, res.Healthy)
+	require.Len(t, res.Checks, 4)
+
+	res = serveHealth(t, app.ReadinessHandler())
+	require.Equal(t, http.StatusServiceUnavailable, res.code)
+	require.False(t, res.Healthy)
+	for _, c := range res.Checks {
+		if c.Name == "consumer-chain" {
+			require.False(t, c.OK)
+			require.Contains(t, c.Error, "connection refused")
+		} else {
+			require.True(t, c.OK, c.Name)
+		}
+	}
+
+	// the liveness does not depend on the consumer chain
+	res = serveHealth(t, app.LivenessHandler())
+	require.Equal(t, http.StatusOK, res.code)
+	require.True(t, res.Healthy)
+}
+
+type servedHealth struct {
+	healthResponse
+	code int
+}
+
+func serveHealth(t *testing.T, h http.Handler) servedHealth {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var res servedHealth
+	res.code = rec.Code
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&res.healthResponse))
+
+	return res
+}
+
+// TestLivenessStalledVoteLoop tests that the liveness check fails while the
+// processing of a block by the finality signature submission loop hangs
+func TestLivenessStalledVoteLoop(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	// the voting power query of the first polled block hangs until released
+	tipHeight := uint64(10)
+	stalled := make(chan struct{})
+	release := make(chan struct{})
+	mockClientController := mocks.NewMockClientController(gomock.NewController(t))
+	mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+	mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+	mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).Return(false, false, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBestBlock().
+		Return(&types.BlockInfo{Height: tipHeight, Hash: testutil.GenRandomByteArray(r, 32)}, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).
+		DoAndReturn(func(height uint64) (*types.BlockInfo, error) {
+			return &types.BlockInfo{Height: height, Hash: testutil.GenRandomByteArray(r, 32)}, nil
+		}).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).AnyTimes()
+	var stalledOnce sync.Once
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *btcec.PublicKey, height uint64) (uint64, error) {
+			if height < tipHeight {
+				stalledOnce.Do(func() { close(stalled) })
+				<-release
+			}
+			return 0, nil
+		}).AnyTimes()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	fpCfg.PollerConfig.AutoChainScanningMode = false
+	fpCfg.PollerConfig.StaticChainScanningStartHeight = 1
+	fpCfg.PollerConfig.PollInterval = 10 * time.Millisecond
+	fpCfg.LoopStuckTimeout = 500 * time.Millisecond
+	db, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	defer func() {
+		close(release)
+		require.NoError(t, app.Stop())
+		require.NoError(t, eotsdb.Close())
+		require.NoError(t, db.Close())
+	}()
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+	require.NoError(t, app.GetFinalityProviderStore().SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_REGISTERED))
+	require.NoError(t, app.StartHandlingFinalityProvider(fp.GetBIP340BTCPK(), passphrase))
+
+	// the loop is live while it waits for the blocks
+	res := serveHealth(t, app.LivenessHandler())
+	require.Equal(t, http.StatusOK, res.code)
+
+	select {
+	case <-stalled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no block was processed")
+	}
+	require.Eventually(t, func() bool {
+		res := serveHealth(t, app.LivenessHandler())
+		return res.code == http.StatusServiceUnavailable &&
+			strings.Contains(res.Checks[0].Error, "finality-sig-submission")
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+// TestLivenessOpenCircuit tests that the finality signature submission loop is
+// not reported as stuck while the submissions are paused by the circuit breaker
+// for longer than the stuck timeout
+func TestLivenessOpenCircuit(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	logger := zap.NewNop()
+
+	startingBlock := &types.BlockInfo{Height: 10, Hash: testutil.GenRandomByteArray(r, 32)}
+	mockClientController := testutil.PrepareMockedClientController(t, r, startingBlock.Height, startingBlock.Height+1)
+	// the breaker is tripped by the first vote and closed by the first probe
+	cc := clientcontroller.NewCircuitBreakerController(mockClientController, 1, 2*time.Second, logger)
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.NumPubRand = testutil.TestPubRandNum
+	fpCfg.PollerConfig.PollInterval = 10 * time.Millisecond
+	fpCfg.SubmissionRetryInterval = 100 * time.Millisecond
+	db, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, cc, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	defer func() {
+		require.NoError(t, app.Stop())
+		require.NoError(t, eotsdb.Close())
+		require.NoError(t, db.Close())
+	}()
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+	require.NoError(t, app.GetFinalityProviderStore().SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_REGISTERED))
+	fpIns, err := service.NewFinalityProviderInstance(fp.GetBIP340BTCPK(), &fpCfg, app.GetFinalityProviderStore(),
+		app.GetPubRandProofStore(), cc, em, metrics.NewFpMetrics(), passphrase, make(chan *service.CriticalError), logger)
+	require.NoError(t, err)
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil).Times(1)
+	_, err = fpIns.CommitPubRand(startingBlock.Height)
+	require.NoError(t, err)
+
+	nextBlock := &types.BlockInfo{Height: startingBlock.Height + 1, Hash: testutil.GenRandomByteArray(r, 32)}
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().SubmitFinalitySig(fpIns.GetBtcPk(), nextBlock, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("node unavailable")).Times(1)
+	mockClientController.EXPECT().SubmitFinalitySig(fpIns.GetBtcPk(), nextBlock, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+
+	heartbeats := service.NewHeartbeats(500 * time.Millisecond)
+	type result struct {
+		res *types.TxResponse
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := fpIns.RetrySubmitFinalitySignatureWithHeartbeats(heartbeats, nextBlock)
+		done <- result{res, err}
+	}()
+
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case res := <-done:
+			require.NoError(t, res.err)
+			require.Equal(t, expectedTxHash, res.res.TxHash)
+			require.Empty(t, heartbeats.StuckLoops())
+			return
+		case <-time.After(50 * time.Millisecond):
+			require.Empty(t, heartbeats.StuckLoops())
+		case <-timeout:
+			t.Fatal("the vote was not submitted once the circuit was closed")
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// EnterMaintenance stops generating new work, i.e., polling new blocks and
+// committing randomness or withdrawing rewards on schedule, and waits for the
+// submissions in flight to finish, which includes waiting for their txs to be
+// confirmed. The blocks already polled are kept until the maintenance is
+// exited. If the context is done first, the daemon stays in maintenance and
+// an error is returned, so that the call can be repeated to wait again
+func (app *FinalityProviderApp) EnterMaintenance(ctx context.Context) error {
+	drained, entered := app.maintenance.enter()
+	if entered {
+		app.events.Publish(&proto.Event{Type: EventMaintenanceEnter})
+		app.logger.Info("entered maintenance, waiting for the in-flight submissions to finish")
+	}
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return fmt.Errorf("the daemon is in maintenance but %d submissions are still in flight: %w",
+			app.maintenance.inFlight(), ctx.Err())
+	}
+
+	app.logger.Info("no submission is in flight, the daemon is ready for maintenance")
+
+	return nil
+}
+
+// ExitMaintenance resumes the work stopped by EnterMaintenance
+func (app *FinalityProviderApp) ExitMaintenance() error {
+	if !app.maintenance.exit() {
+		return fmt.Errorf("the daemon is not in maintenance")
+	}
+
+	app.events.Publish(&proto.Event{Type: EventMaintenanceExit})
+	app.logger.Info("exited maintenance", zap.Int("in_flight", app.maintenance.inFlight()))
+
+	return nil
+}
+
+// IsInMaintenance returns whether the daemon is in maintenance
+func (app *FinalityProviderApp) IsInMaintenance() bool {
+	return app.maintenance.active()
+}
+
+// maintenanceState tracks the work in flight, i.e., the processing of a block
+// or a scheduled submission, so that no new work is started while the daemon
+// is in maintenance and the work started before can be waited for. A nil
+// state never enters maintenance
+type maintenanceState struct {
+	mu       sync.Mutex
+	on       bool
+	inflight int
+	// exited is closed when the maintenance is exited
+	exited chan struct{}
+	// drained is closed once no work is in flight during the maintenance
+	drained chan struct{}
+}
+
+func newMaintenanceState() *maintenanceState {
+	return &maintenanceState{}
+}
+
+func (m *maintenanceState) active() bool {
+	if m == nil {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.on
+}
+
+func (m *maintenanceState) inFlight() int {
+	if m == nil {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.inflight
+}
+
+// begin blocks while in maintenance and then records the work in flight,
+// which must be followed by end. It returns false if quit is closed first
+func (m *maintenanceState) begin(quit <-chan struct{}) bool {
+	if m == nil {
+		return true
+	}
+
+	for {
+		m.mu.Lock()
+		if !m.on {
+			m.inflight++
+			m.mu.Unlock()
+			return true
+		}
+		exited := m.exited
+		m.mu.Unlock()
+
+		select {
+		case <-exited:
+		case <-quit:
+			return false
+		}
+	}
+}
+
+// tryBegin records the work in flight, which must be followed by end, unless
+// in maintenance, in which case it returns false and the work is skipped
+func (m *maintenanceState) tryBegin() bool {
+	if m == nil {
+		return true
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.on {
+		return false
+	}
+	m.inflight++
+
+	return true
+}
+
+// end records that the work begun before is finished
+func (m *maintenanceState) end() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inflight--
+	// no work is begun during the maintenance, so the count reaches zero
+	// at most once
+	if m.on && m.inflight == 0 {
+		close(m.drained)
+	}
+}
+
+// enter enters maintenance, if not already, and returns a channel closed once
+// no work is in flight, and whether the maintenance is entered by this call
+func (m *maintenanceState) enter() (<-chan struct{}, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.on {
+		return m.drained, false
+	}
+
+	m.on = true
+	m.exited = make(chan struct{})
+	m.drained = make(chan struct{})
+	if m.inflight == 0 {
+		close(m.drained)
+	}
+
+	return m.drained, true
+}
+
+// exit exits maintenance and returns false if not in maintenance
+func (m *maintenanceState) exit() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.on {
+		return false
+	}
+
+	m.on = false
+	close(m.exited)
+
+	return true
+}
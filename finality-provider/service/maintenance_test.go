@@ -0,0 +1,88 @@
+package service_test
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestMaintenance tests that entering maintenance waits for the withdrawal in
+// flight to finish and that no withdrawal is started until it is exited
+func TestMaintenance(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	logger := zap.NewNop()
+
+	// the first withdrawal is held in flight until it is released
+	var withdrawals atomic.Uint32
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(gomock.Any()).
+		DoAndReturn(func(_ string) (*types.TxResponse, sdk.Coins, error) {
+			if withdrawals.Inc() == 1 {
+				close(inFlight)
+			}
+			<-release
+			return &types.TxResponse{TxHash: "withdrawal"}, sdk.NewCoins(), nil
+		}).AnyTimes()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.RewardWithdrawInterval = 50 * time.Millisecond
+	fpCfg.RewardRecipient = "bbn1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5gua0e9"
+	db, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	defer func() {
+		require.NoError(t, app.Stop())
+		require.NoError(t, eotsdb.Close())
+		require.NoError(t, db.Close())
+	}()
+
+	select {
+	case <-inFlight:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no withdrawal was started")
+	}
+	require.Error(t, app.ExitMaintenance())
+
+	// the maintenance is entered even if the withdrawal is still in flight
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, app.EnterMaintenance(ctx), context.DeadlineExceeded)
+	require.True(t, app.IsInMaintenance())
+
+	close(release)
+	require.NoError(t, app.EnterMaintenance(context.Background()))
+	withdrawn := withdrawals.Load()
+	time.Sleep(300 * time.Millisecond)
+	require.Equal(t, withdrawn, withdrawals.Load())
+
+	require.NoError(t, app.ExitMaintenance())
+	require.False(t, app.IsInMaintenance())
+	require.Eventually(t, func() bool {
+		return withdrawals.Load() > withdrawn
+	}, 10*time.Second, 50*time.Millisecond)
+}
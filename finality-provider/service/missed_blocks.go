@@ -0,0 +1,129 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// noRandomnessErrors indicate that a finality signature cannot be submitted
+// because the public randomness of the block is not committed or stored
+var noRandomnessErrors = []error{
+	ftypes.ErrNoPubRandYet,
+	ftypes.ErrPubRandNotFound,
+	ftypes.ErrTooFewPubRand,
+	store.ErrPubRandProofNotFound,
+}
+
+// missedBlockReason classifies the error of a failed vote
+func missedBlockReason(err error) string {
+	if clientcontroller.IsPubRandNotTimestamped(err) {
+		return store.MissedReasonNotTimestamped
+	}
+	for _, e := range noRandomnessErrors {
+		// cannot use error.Is because the errors from the consumer chain
+		// are not wrapped
+		if strings.Contains(err.Error(), e.Error()) {
+			return store.MissedReasonNoRandomness
+		}
+	}
+
+	return store.MissedReasonRPCFailure
+}
+
+// recordMissedBlock persists a block that the finality provider failed to vote
+// for and updates the metrics. Failing to persist it is not critical, so the
+// error is only logged
+func (fp *FinalityProviderInstance) recordMissedBlock(height uint64, reason string, voteErr error) {
+	fp.metrics.IncrementFpTotalMissedBlocks(fp.GetBtcPkHex(), reason)
+	fp.logger.Warn(
+		"the finality provider missed a block",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", height),
+		zap.String("reason", reason),
+		zap.Error(voteErr),
+	)
+
+	if fp.missedBlocks == nil {
+		return
+	}
+	mb := &store.MissedBlock{
+		Height:    height,
+		Reason:    reason,
+		Timestamp: time.Now().Unix(),
+	}
+	if voteErr != nil {
+		mb.Error = voteErr.Error()
+	}
+	if err := fp.missedBlocks.AddMissedBlock(fp.GetBtcPk(), mb); err != nil {
+		fp.logger.Error("failed to save the missed block",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", height), zap.Error(err))
+	}
+}
+
+// recordSkippedBlock persists a block whose vote is skipped on purpose as it
+// is already finalized, so that it can be told apart from a block that was
+// never processed, without counting it as missed
+func (fp *FinalityProviderInstance) recordSkippedBlock(height uint64) {
+	fp.metrics.IncrementFpTotalSkippedFinalizedBlocks(fp.GetBtcPkHex())
+
+	if fp.missedBlocks == nil {
+		return
+	}
+	mb := &store.MissedBlock{
+		Height:    height,
+		Reason:    store.MissedReasonSkippedFinalized,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := fp.missedBlocks.AddMissedBlock(fp.GetBtcPk(), mb); err != nil {
+		fp.logger.Error("failed to save the skipped block",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", height), zap.Error(err))
+	}
+}
+
+// QueryMissedBlocks returns the blocks that the given finality provider failed
+// to vote for in [startHeight, endHeight], up to limit blocks, together with
+// the total number of blocks missed in the range. If skipped is set, the
+// blocks whose votes were skipped on purpose as already finalized are returned
+// instead
+func (app *FinalityProviderApp) QueryMissedBlocks(
+	fpPk *bbntypes.BIP340PubKey,
+	startHeight, endHeight, limit uint64,
+	skipped bool,
+) ([]*proto.MissedBlock, uint64, error) {
+	if endHeight > 0 && endHeight < startHeight {
+		return nil, 0, errors.New("the end height must not be lower than the start height")
+	}
+	if _, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK()); err != nil {
+		return nil, 0, err
+	}
+
+	getBlocks := app.missedBlocks.GetMissedBlocks
+	if skipped {
+		getBlocks = app.missedBlocks.GetSkippedBlocks
+	}
+	missedBlocks, total, err := getBlocks(fpPk.MustToBTCPK(), startHeight, endHeight, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res := make([]*proto.MissedBlock, 0, len(missedBlocks))
+	for _, mb := range missedBlocks {
+		res = append(res, &proto.MissedBlock{
+			Height:    mb.Height,
+			Reason:    mb.Reason,
+			Error:     mb.Error,
+			Timestamp: mb.Timestamp,
+		})
+	}
+
+	return res, total, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// PauseFinalityProvider stops the submissions of the finality provider by
+// stopping its instance if it is running. The paused state is persisted, so
+// the finality provider is not started again, even across restarts of the
+// daemon, until it is resumed
+func (app *FinalityProviderApp) PauseFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	if err := app.fps.SetFpPaused(fpPk.MustToBTCPK(), true); err != nil {
+		return fmt.Errorf("failed to pause the finality provider: %w", err)
+	}
+
+	if err := app.fpManager.pauseFinalityProvider(fpPk); err != nil {
+		return err
+	}
+
+	app.events.Publish(&proto.Event{Type: EventPaused, BtcPk: fpPk.MarshalHex()})
+	app.logger.Info("successfully paused the finality provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+	)
+
+	return nil
+}
+
+// ResumeFinalityProvider clears the paused state of the finality provider and
+// starts its instance again if its status allows it. The passphrase is used to
+// unlock the EOTS key if the instance was not started since the daemon started
+func (app *FinalityProviderApp) ResumeFinalityProvider(fpPk *bbntypes.BIP340PubKey, passphrase string) error {
+	if err := app.fps.SetFpPaused(fpPk.MustToBTCPK(), false); err != nil {
+		return fmt.Errorf("failed to resume the finality provider: %w", err)
+	}
+
+	app.events.Publish(&proto.Event{Type: EventResumed, BtcPk: fpPk.MarshalHex()})
+
+	fp, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK())
+	if err != nil {
+		return err
+	}
+
+	if app.fpManager.IsFinalityProviderRunning(fpPk) || !fp.ShouldStart() {
+		app.logger.Info("successfully resumed the finality provider without starting it",
+			zap.String("btc_pk", fpPk.MarshalHex()),
+			zap.String("status", fp.Status.String()),
+		)
+		return nil
+	}
+
+	if err := app.fpManager.StartFinalityProvider(fpPk, passphrase); err != nil {
+		return fmt.Errorf("failed to start the finality provider instance after resuming: %w", err)
+	}
+
+	app.logger.Info("successfully resumed the finality provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+	)
+
+	return nil
+}
+
+// pauseFinalityProvider stops the instance of the paused finality provider if
+// it is running. The instance is kept in the manager, so that it is restarted
+// with the same passphrase once the finality provider is resumed
+func (fpm *FinalityProviderManager) pauseFinalityProvider(fpPk *bbntypes.BIP340PubKey) error {
+	if !fpm.IsFinalityProviderRunning(fpPk) {
+		return nil
+	}
+
+	// the instance might have been stopped in the meantime
+	if err := fpm.fpIns.Stop(); err != nil && !errors.Is(err, ErrFinalityProviderStopped) {
+		return fmt.Errorf("failed to stop the finality provider instance %s: %w", fpPk.MarshalHex(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestPauseResumeFinalityProvider tests that a paused finality provider is
+// stopped and can't be started until it is resumed
+func TestPauseResumeFinalityProvider(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+	fpPk := fpIns.GetBtcPkBIP340()
+
+	require.NoError(t, app.StartHandlingFinalityProvider(fpPk, passphrase))
+	fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.True(t, fpInfo.IsRunning)
+	require.False(t, fpInfo.Paused)
+
+	require.NoError(t, app.PauseFinalityProvider(fpPk))
+	fpInfo, err = app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.False(t, fpInfo.IsRunning)
+	require.True(t, fpInfo.Paused)
+
+	err = app.StartHandlingFinalityProvider(fpPk, passphrase)
+	require.ErrorIs(t, err, service.ErrFinalityProviderPaused)
+
+	require.NoError(t, app.ResumeFinalityProvider(fpPk, passphrase))
+	fpInfo, err = app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.True(t, fpInfo.IsRunning)
+	require.False(t, fpInfo.Paused)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// pruneLoop periodically deletes the submissions, missed blocks, vote proofs,
+// and events below the configured depth behind the latest finalized height, so that the
+// database stays bounded
+func (app *FinalityProviderApp) pruneLoop() {
+	defer app.wg.Done()
+
+	cfg := app.config.PruneConfig
+	app.logger.Info("starting prune loop",
+		zap.Float64("interval seconds", cfg.Interval.Seconds()),
+		zap.Uint64("depth", cfg.Depth))
+	pruneTicker := time.NewTicker(cfg.Interval)
+	defer pruneTicker.Stop()
+	defer app.heartbeats.remove(pruneLoopName)
+
+	for {
+		app.heartbeats.beat(pruneLoopName, cfg.Interval)
+
+		select {
+		case <-pruneTicker.C:
+			app.prune()
+		case <-app.quit:
+			app.logger.Info("exiting prune loop")
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) prune() {
+	blocks, err := app.cc.QueryLatestFinalizedBlocks(1)
+	if err != nil {
+		app.logger.Error("failed to query the latest finalized block to prune the database", zap.Error(err))
+		return
+	}
+	if len(blocks) == 0 || blocks[0].Height <= app.config.PruneConfig.Depth {
+		return
+	}
+	height := blocks[0].Height - app.config.PruneConfig.Depth
+
+	for _, p := range []struct {
+		records string
+		fn      func(height uint64) (uint64, error)
+	}{
+		{"submissions", app.submissions.PruneSubmissions},
+		{"missed blocks", app.missedBlocks.PruneMissedBlocks},
+		{"vote proofs", app.voteProofs.PruneVoteProofs},
+		{"events", app.eventJournal.PruneEvents},
+	} {
+		deleted, err := p.fn(height)
+		if err != nil {
+			app.logger.Error("failed to prune the database", zap.String("records", p.records), zap.Error(err))
+			continue
+		}
+		if deleted > 0 {
+			app.logger.Info("pruned the database", zap.String("records", p.records),
+				zap.Uint64("below height", height), zap.Uint64("deleted", deleted))
+		}
+	}
+}
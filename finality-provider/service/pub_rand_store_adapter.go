@@ -14,11 +14,13 @@ func NewPubRandState(s *store.PubRandProofStore) *pubRandState {
 	return &pubRandState{s: s}
 }
 
-func (st *pubRandState) AddPubRandProofList(
+func (st *pubRandState) AddPubRandProofListAndCommit(
+	fpPk *btcec.PublicKey,
+	commit *store.PubRandCommit,
 	pubRandList []*btcec.FieldVal,
 	proofList []*merkle.Proof,
 ) error {
-	return st.s.AddPubRandProofList(pubRandList, proofList)
+	return st.s.AddPubRandProofListAndCommit(fpPk, commit, pubRandList, proofList)
 }
 
 func (st *pubRandState) GetPubRandProof(pubRand *btcec.FieldVal) ([]byte, error) {
@@ -28,3 +30,15 @@ func (st *pubRandState) GetPubRandProof(pubRand *btcec.FieldVal) ([]byte, error)
 func (st *pubRandState) GetPubRandProofList(pubRandList []*btcec.FieldVal) ([][]byte, error) {
 	return st.s.GetPubRandProofList(pubRandList)
 }
+
+func (st *pubRandState) AddPubRandCommit(fpPk *btcec.PublicKey, commit *store.PubRandCommit) error {
+	return st.s.AddPubRandCommit(fpPk, commit)
+}
+
+func (st *pubRandState) GetOverlappingPubRandCommits(fpPk *btcec.PublicKey, startHeight, endHeight uint64) ([]*store.PubRandCommit, error) {
+	return st.s.GetOverlappingPubRandCommits(fpPk, startHeight, endHeight)
+}
+
+func (st *pubRandState) DeletePubRandCommitsAfter(fpPk *btcec.PublicKey, height uint64) ([]*store.PubRandCommit, error) {
+	return st.s.DeletePubRandCommitsAfter(fpPk, height)
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// pubRandBatch is a batch of public randomness generated ahead of time
+// together with its commitment and inclusion proofs
+type pubRandBatch struct {
+	startHeight uint64
+	pubRandList []*btcec.FieldVal
+	commitment  []byte
+	proofList   []*merkle.Proof
+}
+
+func (b *pubRandBatch) endHeight() uint64 {
+	return b.startHeight + uint64(len(b.pubRandList)) - 1
+}
+
+// randPool keeps the consecutive batches of public randomness following the
+// last committed height, so that the next commitments do not wait for the
+// randomness to be generated
+type randPool struct {
+	mu      sync.Mutex
+	batches []*pubRandBatch
+}
+
+// take removes and returns the first batch if it starts at startHeight and has
+// numPubRand randomness. Otherwise, the batches are outdated, e.g., after the
+// commitments made by another process or a config change, so they are dropped
+// and nil is returned
+func (p *randPool) take(startHeight uint64, numPubRand uint32) *pubRandBatch {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.batches) == 0 {
+		return nil
+	}
+	b := p.batches[0]
+	if b.startHeight != startHeight || len(b.pubRandList) != int(numPubRand) {
+		p.batches = nil
+		return nil
+	}
+	p.batches = p.batches[1:]
+
+	return b
+}
+
+// nextStartHeight returns the height following the last batch, or 0 if the
+// pool is empty
+func (p *randPool) nextStartHeight() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.batches) == 0 {
+		return 0
+	}
+
+	return p.batches[len(p.batches)-1].endHeight() + 1
+}
+
+func (p *randPool) size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.batches)
+}
+
+// add appends the batch if it follows the last one, which may not be the case
+// if the pool was dropped while the batch was generated
+func (p *randPool) add(b *pubRandBatch) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.batches) > 0 && p.batches[len(p.batches)-1].endHeight()+1 != b.startHeight {
+		return false
+	}
+	p.batches = append(p.batches, b)
+
+	return true
+}
+
+// generatePubRandBatch generates numPubRand public randomness from startHeight
+// with their commitment and inclusion proofs
+func (fp *FinalityProviderInstance) generatePubRandBatch(startHeight uint64, numPubRand uint32) (*pubRandBatch, error) {
+	pubRandList, err := fp.getPubRandList(startHeight, numPubRand)
+	if err != nil {
+		return nil, err
+	}
+	commitment, proofList := types.GetPubRandCommitAndProofs(pubRandList)
+
+	return &pubRandBatch{
+		startHeight: startHeight,
+		pubRandList: pubRandList,
+		commitment:  commitment,
+		proofList:   proofList,
+	}, nil
+}
+
+// randomnessPoolLoop generates the batches of public randomness ahead of time
+// until the pool holds RandPoolSize of them, upon each commitment
+func (fp *FinalityProviderInstance) randomnessPoolLoop() {
+	defer fp.wg.Done()
+
+	for {
+		select {
+		case <-fp.randPoolRefillChan:
+			fp.refillRandPool()
+		case <-fp.quit:
+			fp.logger.Info("the randomness pool loop is closing")
+			return
+		}
+	}
+}
+
+func (fp *FinalityProviderInstance) refillRandPool() {
+	for fp.randPool.size() < int(fp.cfg.RandPoolSize) {
+		startHeight := fp.randPool.nextStartHeight()
+		if startHeight == 0 {
+			lastCommittedHeight := fp.lastCommittedHeight.Load()
+			if lastCommittedHeight == 0 {
+				// the start height of the first commitment depends on the
+				// tip at the time of committing
+				return
+			}
+			startHeight = lastCommittedHeight + 1
+		}
+
+		b, err := fp.generatePubRandBatch(startHeight, fp.numPubRandToCommit())
+		if err != nil {
+			fp.logger.Warn("failed to generate public randomness for the pool",
+				zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("start_height", startHeight), zap.Error(err))
+			return
+		}
+		if !fp.randPool.add(b) {
+			return
+		}
+		fp.logger.Debug("generated public randomness for the pool",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", b.startHeight),
+			zap.Uint64("end_height", b.endHeight()))
+
+		select {
+		case <-fp.quit:
+			return
+		default:
+		}
+	}
+}
+
+// requestRandPoolRefill wakes up the randomness pool loop without blocking
+func (fp *FinalityProviderInstance) requestRandPoolRefill() {
+	if fp.cfg.RandPoolSize == 0 {
+		return
+	}
+	select {
+	case fp.randPoolRefillChan <- struct{}{}:
+	default:
+	}
+}
+
+// triggerRandCommitIfLow wakes up the randomness commitment loop without
+// waiting for the ticker if the committed randomness is running out at the
+// height of a new block. It only applies with the randomness pool enabled,
+// and once the last committed height is known
+func (fp *FinalityProviderInstance) triggerRandCommitIfLow(b *types.BlockInfo) {
+	if fp.cfg.RandPoolSize == 0 {
+		return
+	}
+	lastCommittedHeight := fp.lastCommittedHeight.Load()
+	if lastCommittedHeight == 0 || lastCommittedHeight >= b.Height+uint64(fp.randCfg().MinRandHeightGap) {
+		return
+	}
+	select {
+	case fp.randCommitTriggerChan <- b:
+	default:
+	}
+}
+
+// requestPubRandCommit wakes up the randomness commitment loop to commit the
+// public randomness missing at the height of the given block right away. The
+// commitment starts after the last committed height as the consumer chain
+// requires, so it covers the block if the commitments merely fell behind
+func (fp *FinalityProviderInstance) requestPubRandCommit(b *types.BlockInfo) {
+	fp.logger.Warn(
+		"no public randomness is committed at the height, committing it right away",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", b.Height),
+	)
+	select {
+	case fp.randCommitTriggerChan <- b:
+	default:
+	}
+}
+
+// isPubRandMissing returns whether the vote failed as no public randomness is
+// committed at its height, either locally or on the consumer chain
+func isPubRandMissing(err error) bool {
+	return errors.Is(err, store.ErrPubRandProofNotFound) || clientcontroller.IsPubRandMissing(err)
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	"github.com/babylonlabs-io/finality-provider/eotsmanager/types"
+)
+
+// readOnlyEOTSManager stands in for the EOTS manager in read-only mode, e.g.,
+// for an inspection replica which has no access to the EOTS keys. It refuses
+// every operation on the keys
+type readOnlyEOTSManager struct{}
+
+var _ eotsmanager.EOTSManager = &readOnlyEOTSManager{}
+
+func (em *readOnlyEOTSManager) CreateKey(_, _, _ string) ([]byte, error) {
+	return nil, ErrReadOnly
+}
+
+func (em *readOnlyEOTSManager) CreateRandomnessPairList(_ []byte, _ []byte, _ uint64, _ uint32, _ string) ([]*btcec.FieldVal, error) {
+	return nil, ErrReadOnly
+}
+
+func (em *readOnlyEOTSManager) KeyRecord(_ []byte, _ string) (*types.KeyRecord, error) {
+	return nil, ErrReadOnly
+}
+
+func (em *readOnlyEOTSManager) SignEOTS(_ []byte, _ []byte, _ []byte, _ uint64, _ string) (*btcec.ModNScalar, error) {
+	return nil, ErrReadOnly
+}
+
+func (em *readOnlyEOTSManager) SignSchnorrSig(_ []byte, _ []byte, _ string) (*schnorr.Signature, error) {
+	return nil, ErrReadOnly
+}
+
+func (em *readOnlyEOTSManager) Close() error {
+	return nil
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// TestReadOnlyMode tests that the state is served in read-only mode while the
+// signing and the submissions are refused
+func TestReadOnlyMode(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	logger := zap.NewNop()
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), gomock.Any()).
+		Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryLatestFinalizedBlocks(gomock.Any()).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryBlock(gomock.Any()).Return(nil, errors.New("chain not online")).AnyTimes()
+
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.ReadOnly = true
+	db, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	// no EOTS manager is connected in read-only mode
+	em, err := service.NewEOTSManager(&fpCfg, logger)
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+	defer func() {
+		require.NoError(t, app.Stop())
+		require.NoError(t, db.Close())
+	}()
+
+	// the finality provider is stored as in a copied database
+	fp := testutil.GenRandomFinalityProvider(r, t)
+	fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+	require.NoError(t, err)
+	fpStore := app.GetFinalityProviderStore()
+	err = fpStore.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, nil,
+		fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+	require.NoError(t, err)
+	require.NoError(t, fpStore.SetFpStatus(fp.BtcPk, proto.FinalityProviderStatus_REGISTERED))
+	fpPk := fp.GetBIP340BTCPK()
+
+	fpInfo, err := app.GetFinalityProviderInfo(fpPk)
+	require.NoError(t, err)
+	require.Equal(t, fpPk.MarshalHex(), fpInfo.BtcPkHex)
+	require.False(t, fpInfo.IsRunning)
+
+	err = app.StartHandlingFinalityProvider(fpPk, passphrase)
+	require.ErrorIs(t, err, service.ErrReadOnly)
+	_, err = app.GetEOTSManager().SignSchnorrSig(fpPk.MustMarshal(), testutil.GenRandomByteArray(r, 32), passphrase)
+	require.ErrorIs(t, err, service.ErrReadOnly)
+	_, err = app.GetEOTSManager().CreateKey("key", passphrase, hdPath)
+	require.ErrorIs(t, err, service.ErrReadOnly)
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// Reconcile brings the local state of the finality provider in line with the
+// consumer chain upon start, as the daemon might have stopped in the middle
+// of a submission or been down while the finality provider was slashed or
+// jailed. Replaying is idempotent, so it is safe to reconcile after every
+// restart
+func (fp *FinalityProviderInstance) Reconcile() error {
+	if err := fp.reconcileStatus(); err != nil {
+		return err
+	}
+
+	if err := fp.reconcilePubRandCommit(); err != nil {
+		return fmt.Errorf("failed to reconcile the public randomness commitment: %w", err)
+	}
+
+	if err := fp.replayPendingVotes(); err != nil {
+		return fmt.Errorf("failed to replay the pending votes: %w", err)
+	}
+
+	if fp.cfg.VoteSyncDepth > 0 {
+		if err := fp.syncLastVotedHeight(); err != nil {
+			return fmt.Errorf("failed to sync the last voted height: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reconcileStatus records the finality provider as slashed or jailed if it is
+// so on the consumer chain, in which case it must not submit anything
+func (fp *FinalityProviderInstance) reconcileStatus() error {
+	slashed, jailed, err := fp.GetFinalityProviderSlashedOrJailedWithRetry()
+	if err != nil {
+		return fmt.Errorf("failed to query the status of the finality provider: %w", err)
+	}
+
+	if slashed {
+		if fp.GetStatus() != proto.FinalityProviderStatus_SLASHED {
+			fp.logger.Warn("the finality-provider is slashed on the consumer chain",
+				zap.String("pk", fp.GetBtcPkHex()), zap.String("old_status", fp.GetStatus().String()))
+			fp.MustSetStatus(proto.FinalityProviderStatus_SLASHED)
+		}
+		return fmt.Errorf("%w: %s", ErrFinalityProviderSlashed, fp.GetBtcPkHex())
+	}
+
+	if jailed {
+		if !fp.IsJailed() {
+			fp.logger.Warn("the finality-provider is jailed on the consumer chain",
+				zap.String("pk", fp.GetBtcPkHex()), zap.String("old_status", fp.GetStatus().String()))
+			fp.MustSetStatus(proto.FinalityProviderStatus_JAILED)
+		}
+		return fmt.Errorf("%w: %s", ErrFinalityProviderJailed, fp.GetBtcPkHex())
+	}
+
+	return nil
+}
+
+// reconcilePubRandCommit syncs the last committed height with the consumer
+// chain and repairs the local records of the commitments, as the daemon might
+// have stopped after a commitment landed on chain but before saving it, or the
+// other way around. Otherwise, the finality provider would only fail at vote
+// time for lack of the inclusion proofs, or fail to commit over the heights
+// of a commitment that can never land
+func (fp *FinalityProviderInstance) reconcilePubRandCommit() error {
+	pubRandCommitMap, err := fp.lastCommittedPublicRandWithRetry(1)
+	if err != nil {
+		return err
+	}
+	lastCommittedHeight, err := getLastCommittedHeight(pubRandCommitMap)
+	if err != nil {
+		return err
+	}
+	fp.lastCommittedHeight.Store(lastCommittedHeight)
+
+	// the commitment is not recorded in dry-run mode
+	if fp.cfg.BabylonConfig.DryRun {
+		return nil
+	}
+
+	for startHeight, resp := range pubRandCommitMap {
+		chainCommit := &store.PubRandCommit{
+			StartHeight: startHeight,
+			NumPubRand:  resp.NumPubRand,
+			Commitment:  resp.Commitment,
+		}
+		if err := fp.reconcileChainPubRandCommit(chainCommit); err != nil {
+			return err
+		}
+	}
+
+	// the local commitments following the last one on chain might still be
+	// in flight, and are sent again as is until they land, while the ones
+	// beyond them can never land and would block committing over their
+	// heights otherwise
+	inFlightHeight := lastCommittedHeight
+	for {
+		pending, err := fp.pendingPubRandCommit(inFlightHeight)
+		if err != nil {
+			return err
+		}
+		if pending == nil {
+			break
+		}
+		fp.logger.Info(
+			"the public randomness commitment is not on the consumer chain yet, it will be sent again",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", pending.StartHeight),
+			zap.Uint64("end_height", pending.EndHeight()),
+		)
+		inFlightHeight = pending.EndHeight()
+	}
+	staleCommits, err := fp.pubRandState.DeletePubRandCommitsAfter(fp.GetBtcPk(), inFlightHeight)
+	if err != nil {
+		return err
+	}
+	for _, c := range staleCommits {
+		fp.logger.Warn(
+			"the public randomness commitment is not on the consumer chain, removed it",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", c.StartHeight),
+			zap.Uint64("end_height", c.EndHeight()),
+		)
+	}
+
+	return nil
+}
+
+// reconcileChainPubRandCommit records the given commitment on the consumer
+// chain if it is missing locally, and re-derives the inclusion proofs of its
+// randomness if they are missing
+func (fp *FinalityProviderInstance) reconcileChainPubRandCommit(chainCommit *store.PubRandCommit) error {
+	localCommits, err := fp.pubRandState.GetOverlappingPubRandCommits(
+		fp.GetBtcPk(), chainCommit.StartHeight, chainCommit.EndHeight())
+	if err != nil {
+		return err
+	}
+	recorded := false
+	for _, c := range localCommits {
+		if c.Equal(chainCommit) {
+			recorded = true
+			break
+		}
+	}
+
+	// the proofs of a commitment are saved in a single transaction, so
+	// checking the proof of the last height is enough
+	pubRandList, err := fp.getPubRandList(chainCommit.EndHeight(), 1)
+	if err != nil {
+		return err
+	}
+	_, err = fp.pubRandState.GetPubRandProof(pubRandList[0])
+	if err != nil && !errors.Is(err, store.ErrPubRandProofNotFound) {
+		return err
+	}
+	proofsSaved := err == nil
+
+	if recorded && proofsSaved {
+		return nil
+	}
+
+	if proofsSaved {
+		fp.logger.Warn(
+			"the public randomness commitment on the consumer chain is not recorded, recording it",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("start_height", chainCommit.StartHeight),
+			zap.Uint64("end_height", chainCommit.EndHeight()),
+		)
+
+		return fp.pubRandState.AddPubRandCommit(fp.GetBtcPk(), chainCommit)
+	}
+
+	fp.logger.Warn(
+		"the proofs of the public randomness committed on the consumer chain are missing, re-deriving them",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("start_height", chainCommit.StartHeight),
+		zap.Uint64("end_height", chainCommit.EndHeight()),
+	)
+	batch, err := fp.generatePubRandBatch(chainCommit.StartHeight, uint32(chainCommit.NumPubRand))
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(batch.commitment, chainCommit.Commitment) {
+		return fmt.Errorf("the public randomness committed on the consumer chain over heights [%d, %d] cannot be re-derived from the EOTS key",
+			chainCommit.StartHeight, chainCommit.EndHeight())
+	}
+
+	return fp.pubRandState.AddPubRandProofListAndCommit(fp.GetBtcPk(), chainCommit, batch.pubRandList, batch.proofList)
+}
+
+// replayPendingVotes goes through the votes that were intended but not
+// confirmed before the restart. The votes that landed on the consumer chain
+// are confirmed locally, the ones over blocks that are finalized or replaced
+// since, or at which the finality provider has no voting power, are skipped,
+// and the others are re-submitted
+func (fp *FinalityProviderInstance) replayPendingVotes() error {
+	pendingVotes, err := fp.fpState.getPendingVotes()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range pendingVotes {
+		voted, err := fp.hasVotedOnChain(v.Height)
+		if err != nil {
+			return err
+		}
+		if voted {
+			fp.logger.Info(
+				"the pending vote is already on the consumer chain",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", v.Height),
+			)
+			fp.MustUpdateStateAfterFinalitySigSubmission(v.Height)
+			continue
+		}
+
+		b, err := fp.cc.QueryBlock(v.Height)
+		if err != nil {
+			return err
+		}
+		if b.Finalized || !bytes.Equal(b.Hash, v.BlockHash) {
+			fp.logger.Info(
+				"the pending vote is no longer needed, skip it",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", v.Height),
+				zap.Bool("finalized", b.Finalized),
+			)
+			continue
+		}
+
+		// the consumer chain rejects the votes without voting power
+		hasVp, err := fp.hasVotingPower(b)
+		if err != nil {
+			return err
+		}
+		if !hasVp {
+			fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
+			continue
+		}
+
+		res, err := fp.SubmitFinalitySignature(b)
+		if err != nil {
+			if clientcontroller.IsExpected(err) {
+				continue
+			}
+			// the poller will process the block again
+			fp.logger.Warn(
+				"failed to re-submit the pending vote",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", v.Height),
+				zap.Error(err),
+			)
+			continue
+		}
+		if res == nil {
+			continue
+		}
+		fp.logger.Info(
+			"successfully re-submitted the pending vote",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", v.Height),
+			zap.String("tx_hash", res.TxHash),
+		)
+	}
+
+	return nil
+}
+
+// syncLastVotedHeight raises the last voted height to the highest of the
+// recent heights the finality provider voted on the consumer chain, as another
+// instance might have voted with the same key, e.g., a backup one. Otherwise,
+// this daemon could vote again over those heights, possibly for a different
+// block
+func (fp *FinalityProviderInstance) syncLastVotedHeight() error {
+	latestBlock, err := fp.getLatestBlockWithRetry()
+	if err != nil {
+		return err
+	}
+
+	lowestHeight := fp.GetLastVotedHeight() + 1
+	if latestBlock.Height >= fp.cfg.VoteSyncDepth && latestBlock.Height-fp.cfg.VoteSyncDepth+1 > lowestHeight {
+		lowestHeight = latestBlock.Height - fp.cfg.VoteSyncDepth + 1
+	}
+
+	for height := latestBlock.Height; height >= lowestHeight; height-- {
+		voted, err := fp.hasVotedOnChain(height)
+		if err != nil {
+			return err
+		}
+		if !voted {
+			continue
+		}
+
+		fp.logger.Info(
+			"the finality provider voted on the consumer chain beyond the last voted height, syncing it",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("old_last_voted_height", fp.GetLastVotedHeight()),
+			zap.Uint64("last_voted_height", height),
+		)
+		fp.MustUpdateStateAfterFinalitySigSubmission(height)
+
+		return nil
+	}
+
+	return nil
+}
+
+func (fp *FinalityProviderInstance) hasVotedOnChain(height uint64) (bool, error) {
+	voters, err := fp.cc.QueryVotesAtHeight(height)
+	if err != nil {
+		return false, err
+	}
+	for _, pk := range voters {
+		if pk.Equals(fp.GetBtcPkBIP340()) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,221 @@
+package service_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestReconcile tests that the pending votes are confirmed if they landed on
+// the consumer chain and re-submitted otherwise, that the commitment on the
+// consumer chain is recorded with its proofs if they are missing locally, and
+// that the local commitments which can never land are removed
+func TestReconcile(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	startHeight := uint64(1)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, startHeight, startHeight)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(1), nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startHeight)
+	defer cleanUp()
+
+	// commit pub rand
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().CommitPubRandList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).Times(1)
+	_, err := fpIns.CommitPubRand(startHeight)
+	require.NoError(t, err)
+
+	// the next commitment landed on chain but neither it nor its proofs were
+	// saved locally
+	nextStartHeight := startHeight + 1 + testutil.TestPubRandNum
+	pubRandList, err := app.GetEOTSManager().CreateRandomnessPairList(
+		fpIns.GetBtcPkBIP340().MustMarshal(), fpIns.GetChainID(), nextStartHeight, uint32(testutil.TestPubRandNum), passphrase)
+	require.NoError(t, err)
+	commitment, _ := types.GetPubRandCommitAndProofs(pubRandList)
+	chainCommitMap := map[uint64]*ftypes.PubRandCommitResponse{
+		nextStartHeight: {
+			NumPubRand: testutil.TestPubRandNum,
+			Commitment: commitment,
+		},
+	}
+
+	// a later commitment was saved locally which cannot follow the last one
+	// on chain
+	staleCommit := &store.PubRandCommit{
+		StartHeight: nextStartHeight + testutil.TestPubRandNum + 1,
+		NumPubRand:  testutil.TestPubRandNum,
+		Commitment:  testutil.GenRandomByteArray(r, 32),
+	}
+	require.NoError(t, app.GetPubRandProofStore().AddPubRandCommit(fpIns.GetBtcPk(), staleCommit))
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(chainCommitMap, nil).AnyTimes()
+
+	// the first pending vote landed on chain while the second did not
+	fpStore := app.GetFinalityProviderStore()
+	landedBlock := &types.BlockInfo{Height: startHeight + 1, Hash: testutil.GenRandomByteArray(r, 32)}
+	pendingBlock := &types.BlockInfo{Height: startHeight + 2, Hash: testutil.GenRandomByteArray(r, 32)}
+	require.NoError(t, fpStore.GuardVote(fpIns.GetBtcPk(), landedBlock.Height, landedBlock.Hash))
+	require.NoError(t, fpStore.GuardVote(fpIns.GetBtcPk(), pendingBlock.Height, pendingBlock.Hash))
+
+	mockClientController.EXPECT().QueryVotesAtHeight(landedBlock.Height).
+		Return([]bbntypes.BIP340PubKey{*fpIns.GetBtcPkBIP340()}, nil).Times(1)
+	mockClientController.EXPECT().QueryVotesAtHeight(pendingBlock.Height).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().QueryBlock(pendingBlock.Height).Return(pendingBlock, nil).Times(1)
+	expectedTxHash := testutil.GenRandomHexStr(r, 32)
+	mockClientController.EXPECT().
+		SubmitFinalitySig(fpIns.GetBtcPk(), pendingBlock, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&types.TxResponse{TxHash: expectedTxHash}, nil).Times(1)
+
+	require.NoError(t, fpIns.Reconcile())
+	require.Equal(t, pendingBlock.Height, fpIns.GetLastVotedHeight())
+
+	commits, err := app.GetPubRandProofStore().GetOverlappingPubRandCommits(
+		fpIns.GetBtcPk(), nextStartHeight, nextStartHeight)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, chainCommitMap[nextStartHeight].Commitment, commits[0].Commitment)
+	proofs, err := app.GetPubRandProofStore().GetPubRandProofList(pubRandList)
+	require.NoError(t, err)
+	require.Len(t, proofs, len(pubRandList))
+	commits, err = app.GetPubRandProofStore().GetOverlappingPubRandCommits(
+		fpIns.GetBtcPk(), staleCommit.StartHeight, staleCommit.EndHeight())
+	require.NoError(t, err)
+	require.Empty(t, commits)
+
+	// reconciling again is a no-op
+	require.NoError(t, fpIns.Reconcile())
+}
+
+// TestReconcileInFlightPubRandCommit tests that a local commitment which is not
+// on the consumer chain yet, as its tx might still be in flight, is kept upon
+// reconciling and sent again as is
+func TestReconcileInFlightPubRandCommit(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	startHeight := uint64(1)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, startHeight, startHeight)
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startHeight)
+	defer cleanUp()
+
+	// the commitment is recorded locally but its tx is not included before
+	// the restart
+	var sentCommits [][]byte
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().CommitPubRandList(fpIns.GetBtcPk(), startHeight+1, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ *btcec.PublicKey, _ uint64, _ uint64, commitment []byte, _ *schnorr.Signature) (*types.TxResponse, error) {
+			sentCommits = append(sentCommits, commitment)
+			if len(sentCommits) == 1 {
+				return nil, errors.New("timed out waiting for the tx to be included")
+			}
+			return &types.TxResponse{TxHash: testutil.GenRandomHexStr(r, 32)}, nil
+		}).Times(2)
+	_, err := fpIns.CommitPubRand(startHeight)
+	require.Error(t, err)
+
+	require.NoError(t, fpIns.Reconcile())
+	commits, err := app.GetPubRandProofStore().GetOverlappingPubRandCommits(fpIns.GetBtcPk(), startHeight+1, startHeight+1)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, sentCommits[0], commits[0].Commitment)
+
+	_, err = fpIns.CommitPubRand(startHeight)
+	require.NoError(t, err)
+	require.Len(t, sentCommits, 2)
+	require.Equal(t, sentCommits[0], sentCommits[1])
+}
+
+// TestReconcileStatus tests that a finality provider which was slashed or
+// jailed on the consumer chain is recorded as such and not started
+func TestReconcileStatus(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		slashed        bool
+		jailed         bool
+		expectedErr    error
+		expectedStatus proto.FinalityProviderStatus
+	}{
+		{"slashed", true, false, service.ErrFinalityProviderSlashed, proto.FinalityProviderStatus_SLASHED},
+		{"jailed", false, true, service.ErrFinalityProviderJailed, proto.FinalityProviderStatus_JAILED},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(10))
+			startHeight := uint64(1)
+
+			ctl := gomock.NewController(t)
+			mockClientController := mocks.NewMockClientController(ctl)
+			mockClientController.EXPECT().Close().Return(nil).AnyTimes()
+			mockClientController.EXPECT().QueryBestBlock().
+				Return(&types.BlockInfo{Height: startHeight, Hash: testutil.GenRandomByteArray(r, 32)}, nil).AnyTimes()
+			mockClientController.EXPECT().QueryActivatedHeight().Return(uint64(1), nil).AnyTimes()
+			mockClientController.EXPECT().QueryFinalityProviderSlashedOrJailed(gomock.Any()).
+				Return(tc.slashed, tc.jailed, nil).AnyTimes()
+			_, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startHeight)
+			defer cleanUp()
+
+			err := fpIns.Start()
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.False(t, fpIns.IsRunning())
+			require.Equal(t, tc.expectedStatus, fpIns.GetStatus())
+		})
+	}
+}
+
+// TestReconcileVoteSync tests that the last voted height is raised to the
+// highest recent height the finality provider voted on chain
+func TestReconcileVoteSync(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	startHeight := uint64(1)
+	currentHeight := startHeight + 5
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, startHeight, currentHeight)
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startHeight)
+	defer cleanUp()
+	app.GetConfig().VoteSyncDepth = 3
+
+	// another instance voted up to the height below the tip
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryVotesAtHeight(currentHeight).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().QueryVotesAtHeight(currentHeight-1).
+		Return([]bbntypes.BIP340PubKey{*fpIns.GetBtcPkBIP340()}, nil).Times(1)
+
+	require.NoError(t, fpIns.Reconcile())
+	require.Equal(t, currentHeight-1, fpIns.GetLastVotedHeight())
+}
+
+// TestReconcileNoVotingPower tests that a pending vote is not re-submitted if
+// the finality provider has no voting power at its block
+func TestReconcileNoVotingPower(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	startHeight := uint64(1)
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, startHeight, startHeight)
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, startHeight)
+	defer cleanUp()
+
+	pendingBlock := &types.BlockInfo{Height: startHeight + 1, Hash: testutil.GenRandomByteArray(r, 32)}
+	require.NoError(t, app.GetFinalityProviderStore().GuardVote(fpIns.GetBtcPk(), pendingBlock.Height, pendingBlock.Hash))
+
+	mockClientController.EXPECT().QueryLastCommittedPublicRand(gomock.Any(), uint64(1)).Return(nil, nil).AnyTimes()
+	mockClientController.EXPECT().QueryVotesAtHeight(pendingBlock.Height).Return(nil, nil).Times(1)
+	mockClientController.EXPECT().QueryBlock(pendingBlock.Height).Return(pendingBlock, nil).Times(1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(fpIns.GetBtcPk(), pendingBlock.Height).
+		Return(uint64(0), nil).Times(1)
+
+	require.NoError(t, fpIns.Reconcile())
+	require.Zero(t, fpIns.GetLastVotedHeight())
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// maxWebhookReasonLen is the maximum length of the rejection reason read from
+// the response of the registration webhook
+const maxWebhookReasonLen = 1024
+
+// ErrRegistrationNotApproved is returned if the registration webhook did not
+// approve the registration of a finality provider
+var ErrRegistrationNotApproved = errors.New("the registration of the finality provider is not approved")
+
+// RegistrationApprovalRequest is the body posted to the registration webhook.
+// A 2xx response approves the registration, while any other response rejects
+// it with its body as the reason
+type RegistrationApprovalRequest struct {
+	BtcPkHex    string                    `json:"btc_pk_hex"`
+	FpAddr      string                    `json:"fp_addr"`
+	ChainID     string                    `json:"chain_id"`
+	KeyName     string                    `json:"key_name"`
+	Commission  string                    `json:"commission"`
+	Description *stakingtypes.Description `json:"description"`
+}
+
+// approveRegistration asks the registration webhook, if any, to approve the
+// registration of the given finality provider
+func (app *FinalityProviderApp) approveRegistration(fp *store.StoredFinalityProvider) error {
+	if app.config.RegistrationWebhookURL == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(&RegistrationApprovalRequest{
+		BtcPkHex:    fp.GetBIP340BTCPK().MarshalHex(),
+		FpAddr:      fp.FPAddr,
+		ChainID:     fp.ChainID,
+		KeyName:     fp.KeyName,
+		Commission:  fp.Commission.String(),
+		Description: fp.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), app.config.RegistrationWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, app.config.RegistrationWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if app.config.RegistrationWebhookToken != "" {
+		req.Header.Set("Authorization", "Bearer "+app.config.RegistrationWebhookToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: failed to call the registration webhook: %v", ErrRegistrationNotApproved, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		reason, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookReasonLen))
+		return fmt.Errorf("%w: the registration webhook responded %s: %s",
+			ErrRegistrationNotApproved, resp.Status, strings.TrimSpace(string(reason)))
+	}
+
+	app.logger.Info("the registration of the finality provider is approved by the webhook",
+		zap.String("pk", fp.GetBIP340BTCPK().MarshalHex()))
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// handleReorg re-evaluates the votes at the heights replaced by a chain
+// reorg. The slashing protection rules still apply: a height that was voted
+// for is never voted for again, and no vote is cast at or below the last
+// voted height. The heights that are not processed yet are replaced in place
+// of the stale blocks still buffered in the poller
+func (fp *FinalityProviderInstance) handleReorg(reorg *ReorgEvent) {
+	fp.logger.Warn(
+		"the consumer chain is reorganized, re-evaluating the affected heights",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("fork_height", reorg.ForkHeight),
+		zap.Int("reorged_blocks", len(reorg.Blocks)),
+	)
+	fp.alerter.Fire(AlertReorg, AlertSeverityWarning, fp.GetBtcPkHex(),
+		fmt.Sprintf("the consumer chain is reorganized from height %d", reorg.ForkHeight))
+
+	lastProcessedHeight := fp.GetLastProcessedHeight()
+	for h := range fp.reorgedBlocks {
+		if h <= lastProcessedHeight {
+			delete(fp.reorgedBlocks, h)
+		}
+	}
+
+	for _, b := range reorg.Blocks {
+		if b.Height > lastProcessedHeight {
+			fp.reorgedBlocks[b.Height] = b
+			continue
+		}
+		fp.reevaluateVote(b)
+	}
+}
+
+// replaceReorgedBlock returns the block that replaced the given one in a
+// chain reorg, or the given block if it is not replaced
+func (fp *FinalityProviderInstance) replaceReorgedBlock(b *types.BlockInfo) *types.BlockInfo {
+	reorged, ok := fp.reorgedBlocks[b.Height]
+	if !ok {
+		return b
+	}
+	delete(fp.reorgedBlocks, b.Height)
+
+	return reorged
+}
+
+// reevaluateVote votes for the block of the new chain at an already
+// processed height if slashing protection allows it
+func (fp *FinalityProviderInstance) reevaluateVote(b *types.BlockInfo) {
+	votedHash, err := fp.fpState.getVotedBlockHash(b.Height)
+	if err != nil {
+		fp.logger.Error(
+			"failed to get the voted block at the reorganized height",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", b.Height),
+			zap.Error(err),
+		)
+		return
+	}
+	if votedHash != nil {
+		if !bytes.Equal(votedHash, b.Hash) {
+			msg := fmt.Sprintf("voted for block %X at height %d, which is replaced by block %X in a reorg",
+				votedHash, b.Height, b.Hash)
+			fp.alerter.Fire(AlertReorg, AlertSeverityCritical, fp.GetBtcPkHex(), msg)
+			fp.logger.Error(
+				"the voted block is reorganized out, the height will not be voted for again",
+				zap.String("pk", fp.GetBtcPkHex()),
+				zap.Uint64("height", b.Height),
+			)
+		}
+		return
+	}
+	if b.Height <= fp.GetLastVotedHeight() {
+		fp.logger.Warn(
+			"the reorganized height is not above the last voted height, skip voting",
+			zap.String("pk", fp.GetBtcPkHex()),
+			zap.Uint64("height", b.Height),
+			zap.Uint64("last_voted_height", fp.GetLastVotedHeight()),
+		)
+		return
+	}
+
+	hasVp, err := fp.hasVotingPower(b)
+	if err != nil {
+		fp.reportCriticalErr(err)
+		return
+	}
+	if !hasVp {
+		fp.metrics.IncrementFpTotalBlocksWithoutVotingPower(fp.GetBtcPkHex())
+		return
+	}
+
+	nextBlock := *b
+	res, err := fp.retrySubmitFinalitySignatureUntilBlockFinalized(&nextBlock)
+	if err != nil {
+		fp.metrics.IncrementFpTotalFailedVotes(fp.GetBtcPkHex())
+		if !errors.Is(err, ErrFinalityProviderShutDown) {
+			fp.recordMissedBlock(b.Height, missedBlockReason(err), err)
+			fp.reportCriticalErr(err)
+		}
+		return
+	}
+	if res == nil {
+		return
+	}
+	fp.logger.Info(
+		"successfully submitted a finality signature for the reorganized block",
+		zap.String("pk", fp.GetBtcPkHex()),
+		zap.Uint64("height", b.Height),
+		zap.String("tx_hash", res.TxHash),
+	)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	incentivetypes "github.com/babylonlabs-io/babylon/x/incentive/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"go.uber.org/zap"
+)
+
+// SetRewardAddress sets the Babylon address the rewards of the finality
+// provider are sent to upon withdrawal, or clears it if empty so that the
+// configured rewardrecipient is used
+func (app *FinalityProviderApp) SetRewardAddress(fpPk *bbntypes.BIP340PubKey, rewardAddress string) error {
+	if rewardAddress != "" {
+		if err := app.validateBabylonAddress(rewardAddress); err != nil {
+			return err
+		}
+	}
+
+	if err := app.fps.SetFpRewardAddress(fpPk.MustToBTCPK(), rewardAddress); err != nil {
+		return fmt.Errorf("failed to set the reward address: %w", err)
+	}
+
+	app.logger.Info("successfully set the reward address of the finality provider",
+		zap.String("btc_pk", fpPk.MarshalHex()),
+		zap.String("reward_address", rewardAddress),
+	)
+
+	return nil
+}
+
+// WithdrawRewards withdraws the accumulated finality provider rewards and sends
+// them to the given recipient address, or to the reward recipient of the
+// stored finality providers if empty. It returns the hash of the withdrawal tx
+// and the withdrawn rewards, or an error wrapping
+// incentivetypes.ErrNoWithdrawableCoins if there are none
+func (app *FinalityProviderApp) WithdrawRewards(recipient string) (string, sdk.Coins, error) {
+	if recipient == "" {
+		var err error
+		if recipient, err = app.rewardRecipient(); err != nil {
+			return "", nil, err
+		}
+	} else if err := app.validateBabylonAddress(recipient); err != nil {
+		return "", nil, err
+	}
+
+	res, rewards, err := app.cc.WithdrawFinalityProviderRewards(recipient)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to withdraw the rewards: %w", err)
+	}
+
+	app.logger.Info("successfully withdrew the finality provider rewards",
+		zap.String("rewards", rewards.String()),
+		zap.String("recipient", recipient),
+		zap.String("txHash", res.TxHash),
+	)
+
+	return res.TxHash, rewards, nil
+}
+
+// rewardRecipient returns the reward address set for the stored finality
+// providers, or the configured rewardrecipient if there is none. As the rewards
+// of the finality providers sharing the Babylon key are accumulated together,
+// they can't be sent to different reward addresses
+func (app *FinalityProviderApp) rewardRecipient() (string, error) {
+	storedFps, err := app.fps.GetAllStoredFinalityProviders()
+	if err != nil {
+		return "", err
+	}
+
+	recipient := ""
+	for _, fp := range storedFps {
+		if fp.RewardAddress == "" || fp.RewardAddress == recipient {
+			continue
+		}
+		if recipient != "" {
+			return "", fmt.Errorf("the finality providers have different reward addresses %s and %s: specify the recipient of the withdrawal",
+				recipient, fp.RewardAddress)
+		}
+		recipient = fp.RewardAddress
+	}
+	if recipient == "" {
+		recipient = app.config.RewardRecipient
+	}
+
+	return recipient, nil
+}
+
+func (app *FinalityProviderApp) validateBabylonAddress(addr string) error {
+	prefix := app.config.BabylonConfig.AccountPrefix
+	if hrp, _, err := bech32.DecodeAndConvert(addr); err != nil || hrp != prefix {
+		return fmt.Errorf("invalid address %s: expected a Babylon address with the prefix %s", addr, prefix)
+	}
+
+	return nil
+}
+
+// rewardWithdrawalLoop periodically withdraws the accumulated rewards to the
+// reward recipient
+func (app *FinalityProviderApp) rewardWithdrawalLoop() {
+	defer app.wg.Done()
+
+	interval := app.config.RewardWithdrawInterval
+	app.logger.Info("starting reward withdrawal loop",
+		zap.Float64("interval seconds", interval.Seconds()))
+	withdrawTicker := time.NewTicker(interval)
+	defer withdrawTicker.Stop()
+	defer app.heartbeats.remove(rewardWithdrawalLoopName)
+
+	for {
+		app.heartbeats.beat(rewardWithdrawalLoopName, interval)
+
+		select {
+		case <-withdrawTicker.C:
+			// no rewards are withdrawn in maintenance
+			if !app.maintenance.tryBegin() {
+				continue
+			}
+			_, _, err := app.WithdrawRewards("")
+			app.maintenance.end()
+			if errors.Is(err, incentivetypes.ErrNoWithdrawableCoins) {
+				app.logger.Debug("no rewards to withdraw")
+				continue
+			}
+			if err != nil {
+				app.logger.Error("failed to withdraw the rewards", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting reward withdrawal loop")
+			return
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package service_test
+
+import (
+	"math/rand"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestWithdrawRewards tests that the rewards are withdrawn to the given
+// recipient, or else to the reward address of the finality provider, or else
+// to the configured one
+func TestWithdrawRewards(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderVotingPower(gomock.Any(), gomock.Any()).
+		Return(uint64(0), nil).AnyTimes()
+	app, fpIns, cleanUp := startFinalityProviderAppWithRegisteredFp(t, r, mockClientController, 1)
+	defer cleanUp()
+
+	configuredRecipient := "bbn1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5gua0e9"
+	givenRecipient := "bbn1errud3wyc0pvrs9lh67mewa6hxut0d44mvwv25"
+	app.GetConfig().RewardRecipient = configuredRecipient
+	rewards := sdk.NewCoins(sdk.NewCoin("ubbn", sdkmath.NewInt(r.Int63n(1000)+1)))
+
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(configuredRecipient).
+		Return(&types.TxResponse{TxHash: "configured"}, rewards, nil).Times(1)
+	txHash, withdrawn, err := app.WithdrawRewards("")
+	require.NoError(t, err)
+	require.Equal(t, "configured", txHash)
+	require.Equal(t, rewards, withdrawn)
+
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(givenRecipient).
+		Return(&types.TxResponse{TxHash: "given"}, rewards, nil).Times(1)
+	txHash, _, err = app.WithdrawRewards(givenRecipient)
+	require.NoError(t, err)
+	require.Equal(t, "given", txHash)
+
+	rewardAddress := "bbn1zg23sxc7yyjzw23dxqenvwfu8apy2jzt46md4g"
+	require.NoError(t, app.SetRewardAddress(fpIns.GetBtcPkBIP340(), rewardAddress))
+	fpInfo, err := app.GetFinalityProviderInfo(fpIns.GetBtcPkBIP340())
+	require.NoError(t, err)
+	require.Equal(t, rewardAddress, fpInfo.RewardAddress)
+	mockClientController.EXPECT().WithdrawFinalityProviderRewards(rewardAddress).
+		Return(&types.TxResponse{TxHash: "fp"}, rewards, nil).Times(1)
+	txHash, _, err = app.WithdrawRewards("")
+	require.NoError(t, err)
+	require.Equal(t, "fp", txHash)
+
+	err = app.SetRewardAddress(fpIns.GetBtcPkBIP340(), "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu")
+	require.ErrorContains(t, err, "invalid address")
+	_, _, err = app.WithdrawRewards("cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu")
+	require.ErrorContains(t, err, "invalid address")
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"cosmossdk.io/math"
 	sdkmath "cosmossdk.io/math"
+	"encoding/hex"
 	"fmt"
 	bbntypes "github.com/babylonlabs-io/babylon/types"
+	bstypes "github.com/babylonlabs-io/babylon/x/btcstaking/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"google.golang.org/grpc"
-	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
 	"sync"
 	"sync/atomic"
 
@@ -77,7 +82,8 @@ func (r *rpcServer) RegisterWithGrpcServer(grpcServer *grpc.Server) error {
 func (r *rpcServer) GetInfo(context.Context, *proto.GetInfoRequest) (*proto.GetInfoResponse, error) {
 
 	return &proto.GetInfoResponse{
-		Version: version.Version(),
+		Version:     version.Version(),
+		Maintenance: r.app.IsInMaintenance(),
 	}, nil
 }
 
@@ -91,6 +97,15 @@ func (r *rpcServer) CreateFinalityProvider(
 		return nil, err
 	}
 
+	var maxCommissionRate *math.LegacyDec
+	if req.MaxCommission != "" {
+		rate, err := math.LegacyNewDecFromStr(req.MaxCommission)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max commission rate: %w", err)
+		}
+		maxCommissionRate = &rate
+	}
+
 	var description stakingtypes.Description
 	if err := description.Unmarshal(req.Description); err != nil {
 		return nil, err
@@ -101,14 +116,24 @@ func (r *rpcServer) CreateFinalityProvider(
 		return nil, err
 	}
 
+	var pop *bstypes.ProofOfPossessionBTC
+	if req.PopHex != "" {
+		pop, err = bstypes.NewPoPBTCFromHex(req.PopHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof of possession: %w", err)
+		}
+	}
+
 	result, err := r.app.CreateFinalityProvider(
 		req.KeyName,
 		req.ChainId,
 		req.Passphrase,
 		req.HdPath,
 		eotsPk,
+		pop,
 		&description,
 		&commissionRate,
+		maxCommissionRate,
 	)
 
 	if err != nil {
@@ -216,6 +241,138 @@ func (r *rpcServer) UnjailFinalityProvider(ctx context.Context, req *proto.Unjai
 	return &proto.UnjailFinalityProviderResponse{TxHash: txHash}, nil
 }
 
+// PauseFinalityProvider stops the submissions of a finality provider until it
+// is resumed
+func (r *rpcServer) PauseFinalityProvider(ctx context.Context, req *proto.PauseFinalityProviderRequest) (
+	*proto.EmptyResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.PauseFinalityProvider(fpPk); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// ResumeFinalityProvider restarts the submissions of a paused finality provider
+func (r *rpcServer) ResumeFinalityProvider(ctx context.Context, req *proto.ResumeFinalityProviderRequest) (
+	*proto.EmptyResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.ResumeFinalityProvider(fpPk, req.Passphrase); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// EmergencyStop halts all the signing and the submissions of the daemon
+func (r *rpcServer) EmergencyStop(ctx context.Context, req *proto.EmergencyStopRequest) (
+	*proto.EmptyResponse, error) {
+
+	if err := r.authenticateAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := r.app.EmergencyStop(req.Reason); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// Resume lifts the emergency stop of the daemon
+func (r *rpcServer) Resume(ctx context.Context, req *proto.ResumeRequest) (
+	*proto.EmptyResponse, error) {
+
+	if err := r.authenticateAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := r.app.Resume(req.Reason, req.Passphrase); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// EnterMaintenance stops generating new work and returns once the in-flight
+// submissions are finished
+func (r *rpcServer) EnterMaintenance(ctx context.Context, _ *proto.EnterMaintenanceRequest) (
+	*proto.EmptyResponse, error) {
+
+	if err := r.app.EnterMaintenance(ctx); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// ExitMaintenance resumes the work stopped by EnterMaintenance
+func (r *rpcServer) ExitMaintenance(_ context.Context, _ *proto.ExitMaintenanceRequest) (
+	*proto.EmptyResponse, error) {
+
+	if err := r.app.ExitMaintenance(); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// authenticateAdmin checks that the request carries the admin token as a
+// bearer token in its authorization metadata
+func (r *rpcServer) authenticateAdmin(ctx context.Context) error {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(AdminAuthorizationKey); len(values) > 0 {
+			token = strings.TrimPrefix(values[0], "Bearer ")
+		}
+	}
+
+	if err := r.app.AuthenticateAdmin(token); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return nil
+}
+
+// SetRewardAddress sets the address the rewards of the finality provider are
+// sent to upon withdrawal
+func (r *rpcServer) SetRewardAddress(ctx context.Context, req *proto.SetRewardAddressRequest) (
+	*proto.EmptyResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.app.SetRewardAddress(fpPk, req.RewardAddress); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// WithdrawRewards withdraws the accumulated finality provider rewards
+func (r *rpcServer) WithdrawRewards(ctx context.Context, req *proto.WithdrawRewardsRequest) (
+	*proto.WithdrawRewardsResponse, error) {
+
+	txHash, rewards, err := r.app.WithdrawRewards(req.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.WithdrawRewardsResponse{TxHash: txHash, Rewards: rewards.String()}, nil
+}
+
 // QueryFinalityProvider queries the information of the finality-provider
 func (r *rpcServer) QueryFinalityProvider(ctx context.Context, req *proto.QueryFinalityProviderRequest) (
 	*proto.QueryFinalityProviderResponse, error) {
@@ -238,39 +395,154 @@ func (r *rpcServer) EditFinalityProvider(ctx context.Context, req *proto.EditFin
 		return nil, err
 	}
 
-	rate, err := sdkmath.LegacyNewDecFromStr(req.Commission)
+	// the commission is kept unchanged if not set
+	var rate *sdkmath.LegacyDec
+	if req.Commission != "" {
+		commission, err := sdkmath.LegacyNewDecFromStr(req.Commission)
+		if err != nil {
+			return nil, fmt.Errorf("invalid commission rate: %w", err)
+		}
+		rate = &commission
+	}
+
+	desc := req.Description
+	if desc == nil {
+		desc = &proto.Description{}
+	}
+
+	if err := r.app.EditFinalityProvider(fpPk, desc, rate); err != nil {
+		return nil, err
+	}
+
+	return &proto.EmptyResponse{}, nil
+}
+
+// QueryFinalityProviderList queries the information of a list of finality providers
+func (r *rpcServer) QueryFinalityProviderList(ctx context.Context, req *proto.QueryFinalityProviderListRequest) (
+	*proto.QueryFinalityProviderListResponse, error) {
+
+	fps, err := r.app.ListAllFinalityProvidersInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryFinalityProviderListResponse{FinalityProviders: fps}, nil
+}
+
+// QueryFinalityProviderStatus queries the status of the stored finality providers
+func (r *rpcServer) QueryFinalityProviderStatus(ctx context.Context, req *proto.QueryFinalityProviderStatusRequest) (
+	*proto.QueryFinalityProviderStatusResponse, error) {
+
+	fps, err := r.app.FinalityProvidersStatus()
 	if err != nil {
 		return nil, err
 	}
 
-	descBytes, err := protobuf.Marshal(req.Description)
+	return &proto.QueryFinalityProviderStatusResponse{FinalityProviders: fps}, nil
+}
+
+// QueryMissedBlocks queries the blocks that the finality provider failed to vote for,
+// or skipped on purpose
+func (r *rpcServer) QueryMissedBlocks(ctx context.Context, req *proto.QueryMissedBlocksRequest) (
+	*proto.QueryMissedBlocksResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
 	if err != nil {
 		return nil, err
 	}
+	missedBlocks, total, err := r.app.QueryMissedBlocks(fpPk, req.StartHeight, req.EndHeight, req.Limit, req.Skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryMissedBlocksResponse{MissedBlocks: missedBlocks, Total: total}, nil
+}
+
+// QuerySubmissions queries the txs submitted on behalf of the finality provider
+func (r *rpcServer) QuerySubmissions(ctx context.Context, req *proto.QuerySubmissionsRequest) (
+	*proto.QuerySubmissionsResponse, error) {
 
-	fpPub := fpPk.MustToBTCPK()
-	updatedMsg, err := r.app.cc.EditFinalityProvider(fpPub, &rate, descBytes)
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	submissions, err := r.app.QuerySubmissions(fpPk, req.Type, req.Limit)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := r.app.fps.SetFpDescription(fpPub, updatedMsg.Description, updatedMsg.Commission); err != nil {
+	return &proto.QuerySubmissionsResponse{Submissions: submissions}, nil
+}
+
+// ProveVote returns the proof of the vote of the finality provider at the height
+func (r *rpcServer) ProveVote(ctx context.Context, req *proto.ProveVoteRequest) (*proto.ProveVoteResponse, error) {
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	return r.app.ProveVote(fpPk, req.Height)
 }
 
-// QueryFinalityProviderList queries the information of a list of finality providers
-func (r *rpcServer) QueryFinalityProviderList(ctx context.Context, req *proto.QueryFinalityProviderListRequest) (
-	*proto.QueryFinalityProviderListResponse, error) {
+// QueryVotedBlock queries the block the daemon signed the vote of the
+// finality provider for at the height, if any
+func (r *rpcServer) QueryVotedBlock(ctx context.Context, req *proto.QueryVotedBlockRequest) (
+	*proto.QueryVotedBlockResponse, error) {
 
-	fps, err := r.app.ListAllFinalityProvidersInfo()
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	blockHash, err := r.app.QueryVotedBlock(fpPk, req.Height)
 	if err != nil {
 		return nil, err
 	}
+	if blockHash == nil {
+		return &proto.QueryVotedBlockResponse{}, nil
+	}
 
-	return &proto.QueryFinalityProviderListResponse{FinalityProviders: fps}, nil
+	return &proto.QueryVotedBlockResponse{Voted: true, BlockHash: hex.EncodeToString(blockHash)}, nil
+}
+
+// QueryLastVotedHeight queries the height of the last block the finality
+// provider voted for
+func (r *rpcServer) QueryLastVotedHeight(ctx context.Context, req *proto.QueryLastVotedHeightRequest) (
+	*proto.QueryLastVotedHeightResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	fp, err := r.app.GetFinalityProviderInfo(fpPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryLastVotedHeightResponse{LastVotedHeight: fp.LastVotedHeight}, nil
+}
+
+// ListDelegations lists the BTC delegations to the finality provider
+func (r *rpcServer) ListDelegations(ctx context.Context, req *proto.ListDelegationsRequest) (
+	*proto.ListDelegationsResponse, error) {
+
+	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+	dels, nextPageKey, err := r.app.ListDelegations(fpPk, req.Status, req.PageKey, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.ListDelegationsResponse{Delegations: dels, NextPageKey: nextPageKey}, nil
+}
+
+// QueryBlockFinalization queries whether the block at a height is finalized
+// and which finality providers voted for it
+func (r *rpcServer) QueryBlockFinalization(ctx context.Context, req *proto.QueryBlockFinalizationRequest) (
+	*proto.QueryBlockFinalizationResponse, error) {
+
+	return r.app.QueryBlockFinalization(req.Height)
 }
 
 // SignMessageFromChainKey signs a message from the chain keyring.
@@ -284,6 +556,85 @@ func (r *rpcServer) SignMessageFromChainKey(ctx context.Context, req *proto.Sign
 	return &proto.SignMessageFromChainKeyResponse{Signature: signature}, nil
 }
 
+// StreamEvents streams the events of the daemon, optionally of the given
+// finality provider and types only, until the client cancels the stream. The
+// events of the journal from the given sequence number on are replayed first
+func (r *rpcServer) StreamEvents(req *proto.StreamEventsRequest, stream proto.FinalityProviders_StreamEventsServer) error {
+	fpPk, err := parseOptEotsPk(req.BtcPk)
+	if err != nil {
+		return err
+	}
+	types := make(map[string]struct{}, len(req.Types))
+	for _, t := range req.Types {
+		types[t] = struct{}{}
+	}
+
+	// subscribe before replaying the journal not to miss the events
+	// published in the meantime
+	events, unsubscribe := r.app.SubscribeEvents()
+	defer unsubscribe()
+
+	nextSeq := req.FromSeq
+	for nextSeq > 0 {
+		replayed, seq, err := r.app.QueryEvents(nextSeq, fpPk, req.Types, journalPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to replay the event journal: %w", err)
+		}
+		for _, ev := range replayed {
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+		if seq == nextSeq {
+			break
+		}
+		nextSeq = seq
+	}
+
+	for {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				return fmt.Errorf("the event stream is closed as the events are not received fast enough")
+			}
+			if ev.Seq != 0 && ev.Seq < nextSeq {
+				// already replayed
+				continue
+			}
+			if fpPk != nil && ev.BtcPk != fpPk.MarshalHex() {
+				continue
+			}
+			if _, ok := types[ev.Type]; len(types) > 0 && !ok {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-r.quit:
+			return nil
+		}
+	}
+}
+
+// QueryEvents queries the events recorded in the event journal
+func (r *rpcServer) QueryEvents(ctx context.Context, req *proto.QueryEventsRequest) (
+	*proto.QueryEventsResponse, error) {
+
+	fpPk, err := parseOptEotsPk(req.BtcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	events, nextSeq, err := r.app.QueryEvents(req.FromSeq, fpPk, req.Types, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.QueryEventsResponse{Events: events, NextSeq: nextSeq}, nil
+}
+
 func parseOptEotsPk(eotsPkHex string) (*bbntypes.BIP340PubKey, error) {
 	if len(eotsPkHex) > 0 {
 		return bbntypes.NewBIP340PubKeyFromHex(eotsPkHex)
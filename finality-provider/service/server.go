@@ -4,15 +4,25 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"os"
+	ossignal "os/signal"
+	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/lightningnetwork/lnd/signal"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	fpcfg "github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/log"
 	"github.com/babylonlabs-io/finality-provider/metrics"
 )
 
@@ -25,9 +35,15 @@ type Server struct {
 	cfg    *fpcfg.Config
 	logger *zap.Logger
 
-	rpcServer   *rpcServer
-	db          kvdb.Backend
-	interceptor signal.Interceptor
+	rpcServer     *rpcServer
+	metricsServer *metrics.Server
+	db            kvdb.Backend
+	interceptor   signal.Interceptor
+
+	// loadConfig and logLevel are set if the config can be reloaded
+	// upon SIGHUP
+	loadConfig func() (*fpcfg.Config, error)
+	logLevel   zap.AtomicLevel
 
 	quit chan struct{}
 }
@@ -44,6 +60,22 @@ func NewFinalityProviderServer(cfg *fpcfg.Config, l *zap.Logger, fpa *FinalityPr
 	}
 }
 
+// EnableConfigReload makes the server reload the config using loadConfig upon
+// SIGHUP. Only the options that are safe to change at runtime are applied,
+// see fpcfg.Config.ApplyReloadable, and logLevel is updated to the reloaded
+// log level.
+func (s *Server) EnableConfigReload(loadConfig func() (*fpcfg.Config, error), logLevel zap.AtomicLevel) {
+	s.loadConfig = loadConfig
+	s.logLevel = logLevel
+}
+
+// UseMetricsServer makes the server use the given metrics server, which is
+// already running, instead of starting a new one. The metrics server is
+// stopped upon shutdown.
+func (s *Server) UseMetricsServer(metricsServer *metrics.Server) {
+	s.metricsServer = metricsServer
+}
+
 // RunUntilShutdown runs the main EOTS manager server loop until a signal is
 // received to shut down the process.
 func (s *Server) RunUntilShutdown() error {
@@ -51,12 +83,18 @@ func (s *Server) RunUntilShutdown() error {
 		return nil
 	}
 
-	// Start the metrics server.
-	promAddr, err := s.cfg.Metrics.Address()
-	if err != nil {
-		return fmt.Errorf("failed to get prometheus address: %w", err)
+	// Start the metrics server unless it was started beforehand.
+	metricsServer := s.metricsServer
+	if metricsServer == nil {
+		promAddr, err := s.cfg.Metrics.Address()
+		if err != nil {
+			return fmt.Errorf("failed to get prometheus address: %w", err)
+		}
+		metricsServer = metrics.Start(promAddr, s.logger)
 	}
-	metricsServer := metrics.Start(promAddr, s.logger)
+
+	metricsServer.Handle(LivenessPath, s.rpcServer.app.LivenessHandler())
+	metricsServer.Handle(ReadinessPath, s.rpcServer.app.ReadinessHandler())
 
 	defer func() {
 		s.logger.Info("Shutdown complete")
@@ -99,6 +137,28 @@ func (s *Server) RunUntilShutdown() error {
 		return fmt.Errorf("failed to start gRPC listener: %v", err)
 	}
 
+	// The REST gateway is a reverse proxy in front of the gRPC server, so it
+	// can only be started once the gRPC listener is up.
+	if s.cfg.RestListener != "" {
+		restServer, err := s.startRestGateway(s.cfg.RestListener, listenAddr)
+		if err != nil {
+			return fmt.Errorf("failed to start REST gateway: %w", err)
+		}
+		defer func() {
+			if err := restServer.Shutdown(context.Background()); err != nil {
+				s.logger.Error("Failed to stop REST gateway", zap.Error(err))
+			}
+		}()
+	}
+
+	if s.loadConfig != nil {
+		sighup := make(chan os.Signal, 1)
+		ossignal.Notify(sighup, syscall.SIGHUP)
+		defer ossignal.Stop(sighup)
+
+		go s.reloadConfigLoop(sighup)
+	}
+
 	s.logger.Info("Finality Provider Daemon is fully active!")
 
 	// Wait for shutdown signal from either a graceful server stop or from
@@ -108,6 +168,45 @@ func (s *Server) RunUntilShutdown() error {
 	return nil
 }
 
+// reloadConfigLoop reloads the config every time a signal is received until
+// the server is shut down. An invalid config is reported and ignored, so the
+// daemon keeps running with the current options
+func (s *Server) reloadConfigLoop(sighup <-chan os.Signal) {
+	for {
+		select {
+		case <-sighup:
+			s.reloadConfig()
+		case <-s.interceptor.ShutdownChannel():
+			return
+		}
+	}
+}
+
+func (s *Server) reloadConfig() {
+	s.logger.Info("Received SIGHUP, reloading the config")
+
+	newCfg, err := s.loadConfig()
+	if err != nil {
+		s.logger.Error("Failed to reload the config, keeping the current one", zap.Error(err))
+		return
+	}
+
+	lvl, err := log.ParseLevel(newCfg.LogLevel)
+	if err != nil {
+		s.logger.Error("Failed to reload the config, keeping the current one", zap.Error(err))
+		return
+	}
+
+	updated := s.cfg.ApplyReloadable(newCfg)
+	s.logLevel.SetLevel(lvl)
+
+	if len(updated) == 0 {
+		s.logger.Info("Config reloaded, no reloadable option was changed")
+		return
+	}
+	s.logger.Info("Config reloaded", zap.String("updated_options", strings.Join(updated, ",")))
+}
+
 // startGrpcListen starts the GRPC server on the passed listeners.
 func (s *Server) startGrpcListen(grpcServer *grpc.Server, listeners []net.Listener) error {
 
@@ -133,3 +232,38 @@ func (s *Server) startGrpcListen(grpcServer *grpc.Server, listeners []net.Listen
 
 	return nil
 }
+
+// startRestGateway starts an HTTP server on the given address that translates
+// REST/JSON requests into gRPC calls against the RPC server listening on rpcAddr.
+func (s *Server) startRestGateway(restAddr, rpcAddr string) (*http.Server, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{OrigName: true, EmitDefaults: true}),
+	)
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if err := proto.RegisterFinalityProvidersHandlerFromEndpoint(context.Background(), mux, rpcAddr, opts); err != nil {
+		return nil, fmt.Errorf("failed to register REST gateway handlers: %w", err)
+	}
+
+	lis, err := net.Listen("tcp", restAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", restAddr, err)
+	}
+
+	restServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 2 * time.Second,
+		ReadTimeout:       5 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       30 * time.Second,
+	}
+
+	go func() {
+		s.logger.Info("REST gateway listening", zap.String("address", lis.Addr().String()))
+		if err := restServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("REST gateway stopped unexpectedly", zap.Error(err))
+		}
+	}()
+
+	return restServer, nil
+}
@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lightningnetwork/lnd/signal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/eotsmanager"
+	eotscfg "github.com/babylonlabs-io/finality-provider/eotsmanager/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TestRestGateway tests that the last voted height and the pending delegations
+// of a finality provider are served over HTTP by the REST gateway in front of
+// the RPC server
+func TestRestGateway(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	logger := zap.NewNop()
+
+	mockClientController := testutil.PrepareMockedClientController(t, r, 1, 1)
+	mockClientController.EXPECT().QueryFinalityProviderDelegations(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]*types.Delegation{
+			{StakingTxHash: "a", TotalSat: 1, Status: "ACTIVE"},
+			{StakingTxHash: "b", TotalSat: 2, Status: "VERIFIED"},
+		}, nil, nil).AnyTimes()
+
+	eotsHomeDir := filepath.Join(t.TempDir(), "eots-home")
+	eotsCfg := eotscfg.DefaultConfigWithHomePath(eotsHomeDir)
+	eotsdb, err := eotsCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	em, err := eotsmanager.NewLocalEOTSManager(eotsHomeDir, eotsCfg.KeyringBackend, eotsdb, logger)
+	require.NoError(t, err)
+	fpCfg := config.DefaultConfigWithHome(filepath.Join(t.TempDir(), "fp-home"))
+	fpCfg.RpcListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
+	fpCfg.RestListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
+	fpCfg.Metrics.Port = testutil.AllocateUniquePort(t)
+	db, err := fpCfg.DatabaseConfig.GetDbBackend()
+	require.NoError(t, err)
+	app, err := service.NewFinalityProviderApp(&fpCfg, mockClientController, em, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, app.Start())
+
+	fp := testutil.GenStoredFinalityProvider(r, t, app, passphrase, hdPath, nil)
+	lastVotedHeight := uint64(r.Int63n(1000) + 1)
+	require.NoError(t, app.GetFinalityProviderStore().SetFpLastVotedHeight(fp.BtcPk, lastVotedHeight))
+
+	// the database is closed by the server upon shutdown
+	interceptor, err := signal.Intercept()
+	require.NoError(t, err)
+	server := service.NewFinalityProviderServer(&fpCfg, logger, app, db, interceptor)
+	done := make(chan error, 1)
+	go func() {
+		done <- server.RunUntilShutdown()
+	}()
+	defer func() {
+		require.NoError(t, app.Stop())
+		interceptor.RequestShutdown()
+		require.NoError(t, <-done)
+		require.NoError(t, eotsdb.Close())
+	}()
+
+	fpURL := fmt.Sprintf("http://%s/v1/finality-providers/%s", fpCfg.RestListener, fp.GetBIP340BTCPK().MarshalHex())
+
+	var lastVoted struct {
+		LastVotedHeight string `json:"last_voted_height"`
+	}
+	getJSON(t, fpURL+"/last-voted", &lastVoted)
+	require.Equal(t, strconv.FormatUint(lastVotedHeight, 10), lastVoted.LastVotedHeight)
+
+	var pending struct {
+		Delegations []struct {
+			StakingTxHash string `json:"staking_tx_hash"`
+			Status        string `json:"status"`
+		} `json:"delegations"`
+	}
+	getJSON(t, fpURL+"/delegations/pending", &pending)
+	require.Len(t, pending.Delegations, 1)
+	require.Equal(t, "b", pending.Delegations[0].StakingTxHash)
+	require.Equal(t, service.DelegationStatusPending, pending.Delegations[0].Status)
+}
+
+// getJSON decodes the JSON body of the response to a GET request to url, which
+// is retried until the server accepts connections
+func getJSON(t *testing.T, url string, v interface{}) {
+	var res *http.Response
+	require.Eventually(t, func() bool {
+		var err error
+		res, err = http.Get(url)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	ftypes "github.com/babylonlabs-io/babylon/x/finality/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// verifyFinalitySig verifies the finality signature over the given block
+// before it is sent to the consumer chain, so that a faulty signer is caught
+// with an actionable error rather than rejected by the consumer chain. The
+// inclusion proof of the public randomness is verified against the recorded
+// commitment covering the height, if any, and the signature against the
+// public randomness and the BTC public key of the finality provider
+func (fp *FinalityProviderInstance) verifyFinalitySig(
+	b *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proofBytes []byte,
+	sig *btcec.ModNScalar,
+) error {
+	commits, err := fp.pubRandState.GetOverlappingPubRandCommits(fp.GetBtcPk(), b.Height, b.Height)
+	if err != nil {
+		return fmt.Errorf("failed to get public randomness commitments from DB: %w", err)
+	}
+
+	return verifyVote(fp.GetBtcPkBIP340(), b, pubRand, proofBytes, sig, commits)
+}
+
+// verifyVote verifies the finality signature of the given finality provider
+// over the given block, and the inclusion proof of the public randomness
+// against the first of the given commitments covering the height, if any
+func verifyVote(
+	fpPk *bbntypes.BIP340PubKey,
+	b *types.BlockInfo,
+	pubRand *btcec.FieldVal,
+	proofBytes []byte,
+	sig *btcec.ModNScalar,
+	commits []*store.PubRandCommit,
+) error {
+	proof := cmtcrypto.Proof{}
+	if err := proof.Unmarshal(proofBytes); err != nil {
+		return fmt.Errorf("%w at height %d: invalid inclusion proof: %v", ErrInvalidFinalitySig, b.Height, err)
+	}
+	msg := &ftypes.MsgAddFinalitySig{
+		FpBtcPk:      fpPk,
+		BlockHeight:  b.Height,
+		PubRand:      bbntypes.NewSchnorrPubRandFromFieldVal(pubRand),
+		Proof:        &proof,
+		BlockAppHash: b.Hash,
+		FinalitySig:  bbntypes.NewSchnorrEOTSSigFromModNScalar(sig),
+	}
+
+	if len(commits) == 0 {
+		// the commitment is not recorded, e.g., in dry-run mode or if it was
+		// made by an earlier release, so only the signature can be verified
+		if err := verifyEOTSSig(msg); err != nil {
+			return fmt.Errorf("%w at height %d: %v", ErrInvalidFinalitySig, b.Height, err)
+		}
+		return nil
+	}
+
+	prCommit := &ftypes.PubRandCommit{
+		StartHeight: commits[0].StartHeight,
+		NumPubRand:  commits[0].NumPubRand,
+		Commitment:  commits[0].Commitment,
+	}
+	if err := ftypes.VerifyFinalitySig(msg, prCommit); err != nil {
+		return fmt.Errorf("%w at height %d: %v", ErrInvalidFinalitySig, b.Height, err)
+	}
+
+	return nil
+}
+
+func verifyEOTSSig(msg *ftypes.MsgAddFinalitySig) error {
+	pk, err := msg.FpBtcPk.ToBTCPK()
+	if err != nil {
+		return err
+	}
+
+	return eots.Verify(pk, msg.PubRand.ToFieldVal(), msg.MsgToSign(), msg.FinalitySig.ToModNScalar())
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type SubsystemState string
+
+const (
+	SubsystemPending  SubsystemState = "pending"
+	SubsystemStarting SubsystemState = "starting"
+	SubsystemReady    SubsystemState = "ready"
+	SubsystemFailed   SubsystemState = "failed"
+)
+
+// SubsystemStatus is the readiness of a subsystem at startup
+type SubsystemStatus struct {
+	Name    string         `json:"name"`
+	State   SubsystemState `json:"state"`
+	Elapsed string         `json:"elapsed,omitempty"`
+	Error   string         `json:"error,omitempty"`
+}
+
+type subsystem struct {
+	name    string
+	state   SubsystemState
+	started time.Time
+	elapsed time.Duration
+	err     error
+}
+
+// StartupTracker runs the startup of each subsystem of the daemon under a
+// common deadline and keeps track of their readiness, so that a stuck
+// startup can be diagnosed
+type StartupTracker struct {
+	mu         sync.Mutex
+	subsystems []*subsystem
+
+	logger *zap.Logger
+}
+
+// NewStartupTracker creates a tracker with the given subsystems, which are
+// pending until they are run
+func NewStartupTracker(logger *zap.Logger, names ...string) *StartupTracker {
+	t := &StartupTracker{logger: logger}
+	for _, name := range names {
+		t.subsystems = append(t.subsystems, &subsystem{name: name, state: SubsystemPending})
+	}
+
+	return t
+}
+
+// Run runs fn as the startup of the given subsystem and waits until it returns
+// or the context is done, whichever happens first. In the latter case, fn keeps
+// running in the background and the subsystem is reported as failed
+func (t *StartupTracker) Run(ctx context.Context, name string, fn func() error) error {
+	s := t.start(name)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("not ready after %v: %w", time.Since(s.started).Round(time.Millisecond), ctx.Err())
+	}
+
+	t.finish(s, err)
+	if err != nil {
+		return fmt.Errorf("failed to start %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (t *StartupTracker) start(name string) *subsystem {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var s *subsystem
+	for _, sub := range t.subsystems {
+		if sub.name == name {
+			s = sub
+			break
+		}
+	}
+	if s == nil {
+		s = &subsystem{name: name}
+		t.subsystems = append(t.subsystems, s)
+	}
+	s.state = SubsystemStarting
+	s.started = time.Now()
+
+	t.logger.Info("starting subsystem", zap.String("subsystem", name))
+
+	return s
+}
+
+func (t *StartupTracker) finish(s *subsystem, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s.elapsed = time.Since(s.started)
+	s.err = err
+	if err != nil {
+		s.state = SubsystemFailed
+		t.logger.Error("subsystem failed to start",
+			zap.String("subsystem", s.name),
+			zap.Duration("elapsed", s.elapsed),
+			zap.Error(err),
+		)
+		return
+	}
+
+	s.state = SubsystemReady
+	t.logger.Info("subsystem is ready",
+		zap.String("subsystem", s.name),
+		zap.Duration("elapsed", s.elapsed),
+	)
+}
+
+// Report returns the current readiness of all the subsystems
+func (t *StartupTracker) Report() []SubsystemStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := make([]SubsystemStatus, 0, len(t.subsystems))
+	for _, s := range t.subsystems {
+		status := SubsystemStatus{Name: s.name, State: s.state}
+		switch s.state {
+		case SubsystemStarting:
+			status.Elapsed = time.Since(s.started).Round(time.Millisecond).String()
+		case SubsystemReady, SubsystemFailed:
+			status.Elapsed = s.elapsed.Round(time.Millisecond).String()
+		}
+		if s.err != nil {
+			status.Error = s.err.Error()
+		}
+		report = append(report, status)
+	}
+
+	return report
+}
+
+// Ready returns true if all the subsystems are ready
+func (t *StartupTracker) Ready() bool {
+	for _, s := range t.Report() {
+		if s.State != SubsystemReady {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a human-readable readiness report with one subsystem per line
+func (t *StartupTracker) String() string {
+	var sb strings.Builder
+	for _, s := range t.Report() {
+		fmt.Fprintf(&sb, "  %-28s %-9s", s.Name, s.State)
+		if s.Elapsed != "" {
+			fmt.Fprintf(&sb, " (%s)", s.Elapsed)
+		}
+		if s.Error != "" {
+			fmt.Fprintf(&sb, ": %s", s.Error)
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// ServeHTTP writes the readiness report as JSON, with the status code 200 if
+// all the subsystems are ready or 503 otherwise
+func (t *StartupTracker) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	report := t.Report()
+	ready := true
+	for _, s := range report {
+		if s.State != SubsystemReady {
+			ready = false
+			break
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(struct {
+		Ready      bool              `json:"ready"`
+		Subsystems []SubsystemStatus `json:"subsystems"`
+	}{ready, report})
+}
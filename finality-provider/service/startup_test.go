@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+)
+
+func TestStartupTracker(t *testing.T) {
+	tracker := service.NewStartupTracker(zap.NewNop(), "database", "consumer chain", "EOTS manager")
+	require.False(t, tracker.Ready())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := tracker.Run(ctx, "database", func() error { return nil })
+	require.NoError(t, err)
+
+	// a subsystem that does not become ready before the deadline fails
+	blockCh := make(chan struct{})
+	defer close(blockCh)
+	err = tracker.Run(ctx, "consumer chain", func() error {
+		<-blockCh
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	report := tracker.Report()
+	require.Len(t, report, 3)
+	require.Equal(t, service.SubsystemReady, report[0].State)
+	require.Equal(t, service.SubsystemFailed, report[1].State)
+	require.Contains(t, report[1].Error, "not ready after")
+	require.Equal(t, service.SubsystemPending, report[2].State)
+	require.Contains(t, tracker.String(), "consumer chain")
+
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/startup", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// the errors of the subsystems are reported as is
+	tracker = service.NewStartupTracker(zap.NewNop())
+	err = tracker.Run(context.Background(), "EOTS manager", func() error { return errors.New("connection refused") })
+	require.ErrorContains(t, err, "connection refused")
+	require.False(t, tracker.Ready())
+
+	tracker = service.NewStartupTracker(zap.NewNop(), "database")
+	require.NoError(t, tracker.Run(context.Background(), "database", func() error { return nil }))
+	require.True(t, tracker.Ready())
+	rec = httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/startup", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// newSubmission returns the record of a tx submitted for the blocks or the
+// randomness in [height, endHeight] given the result of the submission. The
+// client controller returns neither a response nor an error if the tx was
+// rejected with an expected error
+func newSubmission(txType string, height, endHeight uint64, res *types.TxResponse, err error) *store.Submission {
+	sub := &store.Submission{
+		Type:      txType,
+		Height:    height,
+		EndHeight: endHeight,
+		Timestamp: time.Now().Unix(),
+	}
+	switch {
+	case err != nil:
+		sub.Status = store.SubmissionStatusFailed
+		sub.Error = err.Error()
+	case res == nil:
+		sub.Status = store.SubmissionStatusSkipped
+	default:
+		sub.Status = store.SubmissionStatusSuccess
+		sub.TxHash = res.TxHash
+		sub.GasUsed = res.GasUsed
+		sub.Signer = res.Signer
+	}
+
+	return sub
+}
+
+// recordSubmission persists a tx submitted on behalf of the finality provider.
+// Failing to persist it is not critical, so the error is only logged
+func (fp *FinalityProviderInstance) recordSubmission(txType string, height, endHeight uint64, res *types.TxResponse, err error) {
+	sub := newSubmission(txType, height, endHeight, res, err)
+	fp.events.Publish(submissionEvent(fp.GetBtcPkHex(), sub))
+	if fp.submissions == nil {
+		return
+	}
+	if err := fp.submissions.AddSubmission(fp.GetBtcPk(), sub); err != nil {
+		fp.logger.Error("failed to save the submission",
+			zap.String("pk", fp.GetBtcPkHex()), zap.String("type", txType), zap.Uint64("height", height), zap.Error(err))
+	}
+}
+
+func (app *FinalityProviderApp) recordSubmission(fpPk *btcec.PublicKey, txType string, res *types.TxResponse, err error) {
+	sub := newSubmission(txType, 0, 0, res, err)
+	app.events.Publish(submissionEvent(bbntypes.NewBIP340PubKeyFromBTCPK(fpPk).MarshalHex(), sub))
+	if err := app.submissions.AddSubmission(fpPk, sub); err != nil {
+		app.logger.Error("failed to save the submission", zap.String("type", txType), zap.Error(err))
+	}
+}
+
+// QuerySubmissions returns the txs submitted on behalf of the given finality
+// provider from the latest, up to limit txs, optionally of the given type only
+func (app *FinalityProviderApp) QuerySubmissions(
+	fpPk *bbntypes.BIP340PubKey,
+	txType string,
+	limit uint64,
+) ([]*proto.Submission, error) {
+	if _, err := app.fps.GetFinalityProvider(fpPk.MustToBTCPK()); err != nil {
+		return nil, err
+	}
+
+	submissions, err := app.submissions.GetSubmissions(fpPk.MustToBTCPK(), txType, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*proto.Submission, 0, len(submissions))
+	for _, sub := range submissions {
+		res = append(res, &proto.Submission{
+			Type:      sub.Type,
+			Height:    sub.Height,
+			EndHeight: sub.EndHeight,
+			TxHash:    sub.TxHash,
+			Status:    sub.Status,
+			GasUsed:   sub.GasUsed,
+			Signer:    sub.Signer,
+			Error:     sub.Error,
+			Timestamp: sub.Timestamp,
+		})
+	}
+
+	return res, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// TipCache keeps the last known tip of the consumer chain for a short time so
+// that the loops querying the tip share the result instead of each querying
+// the chain. Concurrent queries of an outdated tip are deduplicated into one
+type TipCache struct {
+	mu       sync.Mutex
+	tip      *types.BlockInfo
+	cachedAt time.Time
+	inflight *tipQuery
+	// generation is increased upon each invalidation so that the result of a
+	// query started before the invalidation is not cached
+	generation uint64
+
+	ttl time.Duration
+}
+
+type tipQuery struct {
+	done chan struct{}
+	tip  *types.BlockInfo
+	err  error
+}
+
+// NewTipCache creates a cache which keeps the tip for the given ttl. With a
+// zero ttl, the tip is not cached but concurrent queries are still deduplicated
+func NewTipCache(ttl time.Duration) *TipCache {
+	return &TipCache{ttl: ttl}
+}
+
+// Query returns the cached tip if it is not older than the ttl, or queries it
+// with the given client controller. It queries the chain directly on a nil
+// receiver
+func (c *TipCache) Query(cc clientcontroller.ClientController) (*types.BlockInfo, error) {
+	if c == nil {
+		return cc.QueryBestBlock()
+	}
+
+	c.mu.Lock()
+	if c.tip != nil && time.Since(c.cachedAt) < c.ttl {
+		tip := c.tip
+		c.mu.Unlock()
+		return tip, nil
+	}
+	if q := c.inflight; q != nil {
+		c.mu.Unlock()
+		<-q.done
+		return q.tip, q.err
+	}
+	q := &tipQuery{done: make(chan struct{})}
+	c.inflight = q
+	generation := c.generation
+	c.mu.Unlock()
+
+	q.tip, q.err = cc.QueryBestBlock()
+
+	c.mu.Lock()
+	c.inflight = nil
+	if q.err == nil && generation == c.generation {
+		c.setLocked(q.tip)
+	}
+	c.mu.Unlock()
+	close(q.done)
+
+	return q.tip, q.err
+}
+
+// update caches the tip queried by the poller, unless a higher tip is cached.
+// It is a no-op on a nil receiver
+func (c *TipCache) update(tip *types.BlockInfo) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tip == nil || tip.Height >= c.tip.Height {
+		c.setLocked(tip)
+	}
+}
+
+// observe invalidates the cached tip if the poller received a block above it.
+// It is a no-op on a nil receiver
+func (c *TipCache) observe(b *types.BlockInfo) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tip != nil && b.Height > c.tip.Height {
+		c.invalidateLocked()
+	}
+}
+
+// Invalidate drops the cached tip so that the next query hits the chain
+func (c *TipCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidateLocked()
+}
+
+func (c *TipCache) setLocked(tip *types.BlockInfo) {
+	c.tip = tip
+	c.cachedAt = time.Now()
+}
+
+func (c *TipCache) invalidateLocked() {
+	c.tip = nil
+	c.generation++
+}
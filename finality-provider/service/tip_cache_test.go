@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+func TestTipCache(t *testing.T) {
+	ctl := gomock.NewController(t)
+	mockClientController := mocks.NewMockClientController(ctl)
+
+	tipCache := service.NewTipCache(time.Minute)
+
+	// concurrent queries are deduplicated into one
+	mockClientController.EXPECT().QueryBestBlock().DoAndReturn(func() (*types.BlockInfo, error) {
+		time.Sleep(100 * time.Millisecond)
+		return &types.BlockInfo{Height: 10}, nil
+	}).Times(1)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tip, err := tipCache.Query(mockClientController)
+			require.NoError(t, err)
+			require.Equal(t, uint64(10), tip.Height)
+		}()
+	}
+	wg.Wait()
+
+	// the tip is cached within the ttl
+	tip, err := tipCache.Query(mockClientController)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), tip.Height)
+
+	// the tip is queried again after invalidation
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: 11}, nil).Times(1)
+	tipCache.Invalidate()
+	tip, err = tipCache.Query(mockClientController)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), tip.Height)
+
+	// the tip is not cached with a zero ttl
+	noCache := service.NewTipCache(0)
+	mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: 12}, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		tip, err = noCache.Query(mockClientController)
+		require.NoError(t, err)
+		require.Equal(t, uint64(12), tip.Height)
+	}
+}
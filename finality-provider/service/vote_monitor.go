@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/clientcontroller"
+)
+
+// maxVoteMonitorBlocks is the maximum number of blocks scanned by the vote
+// monitor at once, so that a monitor far behind the tip catches up gradually
+const maxVoteMonitorBlocks = 1000
+
+// VoteRecords returns the hash of the block the daemon signed the vote of a
+// finality provider for at a height, or nil if it signed none
+type VoteRecords interface {
+	GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error)
+}
+
+// UnknownVote is a vote of a managed finality provider on the consumer chain
+// that the daemon did not sign
+type UnknownVote struct {
+	FpBtcPkHex string
+	Height     uint64
+	// BlockHash is the hash of the block voted for on the consumer chain
+	BlockHash []byte
+	// SignedHash is the hash of the block the daemon signed the vote for at
+	// the height, nil if it signed none
+	SignedHash []byte
+}
+
+func (v *UnknownVote) String() string {
+	if v.SignedHash == nil {
+		return fmt.Sprintf("the finality provider voted for block %X at height %d on the consumer chain, but the daemon signed no vote at this height",
+			v.BlockHash, v.Height)
+	}
+
+	return fmt.Sprintf("the finality provider voted for block %X at height %d on the consumer chain, but the daemon signed the vote for block %X",
+		v.BlockHash, v.Height, v.SignedHash)
+}
+
+// VoteMonitor scans the votes on the consumer chain for the votes of the
+// managed finality providers that the daemon did not sign. As every vote is
+// recorded before it is signed, such a vote can only be signed with a leaked
+// key, so it is the earliest warning of a compromise, before any slashing
+type VoteMonitor struct {
+	cc      clientcontroller.ClientController
+	records VoteRecords
+	// fpPks returns the BTC public keys of the managed finality providers
+	fpPks   func() ([]*bbntypes.BIP340PubKey, error)
+	alerter *Alerter
+	logger  *zap.Logger
+
+	// nextHeight is the next height to scan, 0 to start from the tip
+	nextHeight uint64
+}
+
+// NewVoteMonitor returns a monitor which scans the votes from the given
+// height, or from the tip of the consumer chain if startHeight is 0
+func NewVoteMonitor(
+	cc clientcontroller.ClientController,
+	records VoteRecords,
+	fpPks func() ([]*bbntypes.BIP340PubKey, error),
+	startHeight uint64,
+	alerter *Alerter,
+	logger *zap.Logger,
+) *VoteMonitor {
+	return &VoteMonitor{
+		cc:         cc,
+		records:    records,
+		fpPks:      fpPks,
+		alerter:    alerter,
+		logger:     logger,
+		nextHeight: startHeight,
+	}
+}
+
+// Scan checks the votes from the last scanned height up to the tip of the
+// consumer chain, at most maxVoteMonitorBlocks at once. Each unknown vote is
+// logged and alerted as critical before it is returned. Upon error, the next
+// scan resumes from the height that failed
+func (m *VoteMonitor) Scan() ([]*UnknownVote, error) {
+	tip, err := m.cc.QueryBestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the tip of the consumer chain: %w", err)
+	}
+	if m.nextHeight == 0 {
+		m.nextHeight = tip.Height
+	}
+	pks, err := m.fpPks()
+	if err != nil {
+		return nil, err
+	}
+
+	endHeight := tip.Height
+	if endHeight >= m.nextHeight+maxVoteMonitorBlocks {
+		endHeight = m.nextHeight + maxVoteMonitorBlocks - 1
+	}
+
+	var unknown []*UnknownVote
+	for ; m.nextHeight <= endHeight; m.nextHeight++ {
+		votes, err := m.scanHeight(m.nextHeight, pks)
+		if err != nil {
+			return unknown, err
+		}
+		for _, v := range votes {
+			m.logger.Error("found a vote of the finality provider that the daemon did not sign, its key might be compromised",
+				zap.String("pk", v.FpBtcPkHex), zap.Uint64("height", v.Height),
+				zap.String("block_hash", fmt.Sprintf("%X", v.BlockHash)))
+			m.alerter.Fire(AlertUnknownVote, AlertSeverityCritical, v.FpBtcPkHex, v.String())
+		}
+		unknown = append(unknown, votes...)
+	}
+
+	return unknown, nil
+}
+
+func (m *VoteMonitor) scanHeight(height uint64, pks []*bbntypes.BIP340PubKey) ([]*UnknownVote, error) {
+	voters, err := m.cc.QueryVotesAtHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the votes at height %d: %w", height, err)
+	}
+
+	var (
+		block   []byte
+		unknown []*UnknownVote
+	)
+	for _, pk := range pks {
+		if !containsPk(voters, pk) {
+			continue
+		}
+		if block == nil {
+			b, err := m.cc.QueryBlock(height)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query the block at height %d: %w", height, err)
+			}
+			block = b.Hash
+		}
+
+		signedHash, err := m.records.GetVotedBlockHash(pk.MustToBTCPK(), height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the block voted for by %s at height %d: %w", pk.MarshalHex(), height, err)
+		}
+		if bytes.Equal(signedHash, block) {
+			continue
+		}
+		unknown = append(unknown, &UnknownVote{
+			FpBtcPkHex: pk.MarshalHex(),
+			Height:     height,
+			BlockHash:  block,
+			SignedHash: signedHash,
+		})
+	}
+
+	return unknown, nil
+}
+
+func containsPk(pks []bbntypes.BIP340PubKey, pk *bbntypes.BIP340PubKey) bool {
+	for _, p := range pks {
+		if p.Equals(pk) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// voteMonitorLoop periodically scans the votes of the managed finality
+// providers on the consumer chain for the ones the daemon did not sign
+func (app *FinalityProviderApp) voteMonitorLoop() {
+	defer app.wg.Done()
+
+	interval := app.config.VoteMonitorInterval
+	app.logger.Info("starting vote monitor loop", zap.Float64("interval seconds", interval.Seconds()))
+	monitorTicker := time.NewTicker(interval)
+	defer monitorTicker.Stop()
+	defer app.heartbeats.remove(voteMonitorLoopName)
+
+	monitor := NewVoteMonitor(app.cc, app.fps, app.managedFpPks, 0, app.alerter, app.logger)
+	for {
+		app.heartbeats.beat(voteMonitorLoopName, interval)
+
+		select {
+		case <-monitorTicker.C:
+			if _, err := monitor.Scan(); err != nil {
+				app.logger.Warn("failed to scan the votes on the consumer chain", zap.Error(err))
+			}
+		case <-app.quit:
+			app.logger.Info("exiting vote monitor loop")
+			return
+		}
+	}
+}
+
+func (app *FinalityProviderApp) managedFpPks() ([]*bbntypes.BIP340PubKey, error) {
+	storedFps, err := app.fps.GetAllStoredFinalityProviders()
+	if err != nil {
+		return nil, err
+	}
+	pks := make([]*bbntypes.BIP340PubKey, 0, len(storedFps))
+	for _, fp := range storedFps {
+		pks = append(pks, fp.GetBIP340BTCPK())
+	}
+
+	return pks, nil
+}
+
+// QueryVotedBlock returns the hash of the block the daemon signed the vote of
+// the finality provider for at the height, or nil if it signed none
+func (app *FinalityProviderApp) QueryVotedBlock(fpPk *bbntypes.BIP340PubKey, height uint64) ([]byte, error) {
+	btcPk := fpPk.MustToBTCPK()
+	if _, err := app.fps.GetFinalityProvider(btcPk); err != nil {
+		return nil, err
+	}
+
+	return app.fps.GetVotedBlockHash(btcPk, height)
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/service"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+	"github.com/babylonlabs-io/finality-provider/testutil/mocks"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+type voteRecords map[string][]byte
+
+func voteRecordKey(btcPk *btcec.PublicKey, height uint64) string {
+	return fmt.Sprintf("%x/%d", schnorr.SerializePubKey(btcPk), height)
+}
+
+func (v voteRecords) GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error) {
+	return v[voteRecordKey(btcPk, height)], nil
+}
+
+// FuzzVoteMonitor tests that the monitor reports the votes of the managed
+// finality providers that were not signed for the block on the consumer chain
+func FuzzVoteMonitor(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		startHeight := uint64(r.Int63n(1000) + 1)
+		fpPk := bbntypes.NewBIP340PubKeyFromBTCPK(testutil.GenRandomFinalityProvider(r, t).BtcPk)
+		otherPk := bbntypes.NewBIP340PubKeyFromBTCPK(testutil.GenRandomFinalityProvider(r, t).BtcPk)
+
+		ctl := gomock.NewController(t)
+		mockClientController := mocks.NewMockClientController(ctl)
+		mockClientController.EXPECT().QueryBestBlock().Return(&types.BlockInfo{Height: startHeight + 3}, nil).AnyTimes()
+		records := voteRecords{}
+		blocks := make([][]byte, 4)
+		for i := range blocks {
+			height := startHeight + uint64(i)
+			blocks[i] = testutil.GenRandomByteArray(r, 32)
+			mockClientController.EXPECT().QueryBlock(height).Return(&types.BlockInfo{Height: height, Hash: blocks[i]}, nil).AnyTimes()
+		}
+		// the first vote is signed, the second one is not, the third one is
+		// signed for another block, and the last one is only cast by another
+		// finality provider
+		records[voteRecordKey(fpPk.MustToBTCPK(), startHeight)] = blocks[0]
+		otherHash := testutil.GenRandomByteArray(r, 32)
+		records[voteRecordKey(fpPk.MustToBTCPK(), startHeight+2)] = otherHash
+		for i := uint64(0); i < 3; i++ {
+			mockClientController.EXPECT().QueryVotesAtHeight(startHeight+i).
+				Return([]bbntypes.BIP340PubKey{*otherPk, *fpPk}, nil).AnyTimes()
+		}
+		mockClientController.EXPECT().QueryVotesAtHeight(startHeight+3).
+			Return([]bbntypes.BIP340PubKey{*otherPk}, nil).AnyTimes()
+
+		fpPks := func() ([]*bbntypes.BIP340PubKey, error) {
+			return []*bbntypes.BIP340PubKey{fpPk}, nil
+		}
+		monitor := service.NewVoteMonitor(mockClientController, records, fpPks, startHeight, nil, zap.NewNop())
+		unknown, err := monitor.Scan()
+		require.NoError(t, err)
+		require.Len(t, unknown, 2)
+		require.Equal(t, fpPk.MarshalHex(), unknown[0].FpBtcPkHex)
+		require.Equal(t, startHeight+1, unknown[0].Height)
+		require.Equal(t, blocks[1], unknown[0].BlockHash)
+		require.Nil(t, unknown[0].SignedHash)
+		require.Equal(t, startHeight+2, unknown[1].Height)
+		require.Equal(t, blocks[2], unknown[1].BlockHash)
+		require.Equal(t, otherHash, unknown[1].SignedHash)
+
+		// the scanned heights are not scanned again
+		unknown, err = monitor.Scan()
+		require.NoError(t, err)
+		require.Empty(t, unknown)
+	})
+}
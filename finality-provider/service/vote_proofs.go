@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	bbntypes "github.com/babylonlabs-io/babylon/types"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"go.uber.org/zap"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/types"
+)
+
+// recordVoteProofs persists the proofs of the votes submitted for the given
+// blocks, unless the submission failed or was only simulated. Failing to
+// persist them is not critical, so the error is only logged
+func (fp *FinalityProviderInstance) recordVoteProofs(
+	blocks []*types.BlockInfo,
+	pubRandList []*btcec.FieldVal,
+	proofList [][]byte,
+	sigs []*btcec.ModNScalar,
+	res *types.TxResponse,
+	err error,
+) {
+	if err != nil || fp.voteProofs == nil || fp.cfg.BabylonConfig.DryRun {
+		return
+	}
+
+	now := time.Now().Unix()
+	proofs := make([]*store.VoteProof, 0, len(blocks))
+	for i, b := range blocks {
+		pubRand := pubRandList[i].Bytes()
+		sig := sigs[i].Bytes()
+		p := &store.VoteProof{
+			Height:    b.Height,
+			BlockHash: b.Hash,
+			PubRand:   pubRand[:],
+			Proof:     proofList[i],
+			Signature: sig[:],
+			Timestamp: now,
+		}
+		// the client controller returns no response if the vote was
+		// already submitted
+		if res != nil {
+			p.TxHash = res.TxHash
+		}
+		proofs = append(proofs, p)
+	}
+
+	if err := fp.voteProofs.AddVoteProofs(fp.GetBtcPk(), proofs); err != nil {
+		fp.logger.Error("failed to save the vote proofs",
+			zap.String("pk", fp.GetBtcPkHex()), zap.Uint64("height", blocks[0].Height), zap.Error(err))
+	}
+}
+
+// ProveVote returns the proof of the vote of the given finality provider for
+// the block at the given height, with the commitment of the public randomness
+// covering the height if it is recorded. The proof is verified before it is
+// returned, so that a third party can verify it as well
+func (app *FinalityProviderApp) ProveVote(fpPk *bbntypes.BIP340PubKey, height uint64) (*proto.ProveVoteResponse, error) {
+	btcPk := fpPk.MustToBTCPK()
+	storedFp, err := app.fps.GetFinalityProvider(btcPk)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := app.voteProofs.GetVoteProof(btcPk, height)
+	if errors.Is(err, store.ErrVoteProofNotFound) {
+		return nil, fmt.Errorf("no vote of the finality provider %s is recorded at height %d: %w", fpPk.MarshalHex(), height, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	commits, err := app.pubRandStore.GetOverlappingPubRandCommits(btcPk, height, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public randomness commitments from DB: %w", err)
+	}
+
+	var pubRand btcec.FieldVal
+	if overflow := pubRand.SetByteSlice(p.PubRand); overflow {
+		return nil, fmt.Errorf("invalid public randomness of the vote at height %d", height)
+	}
+	var sig btcec.ModNScalar
+	if overflow := sig.SetByteSlice(p.Signature); overflow {
+		return nil, fmt.Errorf("invalid signature of the vote at height %d", height)
+	}
+	b := &types.BlockInfo{Height: p.Height, Hash: p.BlockHash}
+	if err := verifyVote(fpPk, b, &pubRand, p.Proof, &sig, commits); err != nil {
+		return nil, fmt.Errorf("the recorded vote does not verify: %w", err)
+	}
+
+	res := &proto.ProveVoteResponse{
+		BtcPk:     fpPk.MarshalHex(),
+		ChainId:   storedFp.ChainID,
+		Height:    p.Height,
+		BlockHash: hex.EncodeToString(p.BlockHash),
+		PubRand:   hex.EncodeToString(p.PubRand),
+		Proof:     hex.EncodeToString(p.Proof),
+		Signature: hex.EncodeToString(p.Signature),
+		TxHash:    p.TxHash,
+		Timestamp: p.Timestamp,
+	}
+	if len(commits) > 0 {
+		res.CommitStartHeight = commits[0].StartHeight
+		res.NumPubRand = commits[0].NumPubRand
+		res.Commitment = hex.EncodeToString(commits[0].Commitment)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+const (
+	backupFilePrefix = "finality-provider-"
+	backupFileSuffix = ".db"
+	// backupTimeLayout sorts the backup files by time when sorted by name
+	backupTimeLayout = "20060102T150405.000Z"
+)
+
+// WriteBackup writes a consistent snapshot of the given db into a new file of
+// the given directory while the db is in use, and returns the path of the
+// file. The file is only visible under its final name once it is complete, so
+// that a crash never leaves a truncated backup behind
+func WriteBackup(db kvdb.Backend, dir string, now time.Time) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create the backup directory %s: %w", dir, err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-"+backupFilePrefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the backup file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if err := db.Copy(tmp); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("failed to copy the database: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return "", fmt.Errorf("failed to write the backup file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write the backup file: %w", err)
+	}
+
+	path := filepath.Join(dir, backupFilePrefix+now.UTC().Format(backupTimeLayout)+backupFileSuffix)
+	if err := os.Rename(tmpPath, path); err != nil {
+		return "", fmt.Errorf("failed to write the backup file: %w", err)
+	}
+
+	return path, nil
+}
+
+// ListBackups returns the paths of the backup files in the given directory
+// from the oldest to the latest
+func ListBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.Type().IsRegular() && strings.HasPrefix(name, backupFilePrefix) && strings.HasSuffix(name, backupFileSuffix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, filepath.Join(dir, name))
+	}
+
+	return paths, nil
+}
+
+// PruneBackups deletes the backup files of the given directory but the
+// latest retention ones and returns the paths of the deleted files. Nothing
+// is deleted if retention is 0
+func PruneBackups(dir string, retention uint32) ([]string, error) {
+	if retention == 0 {
+		return nil, nil
+	}
+
+	paths, err := ListBackups(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) <= int(retention) {
+		return nil, nil
+	}
+
+	stale := paths[:len(paths)-int(retention)]
+	for _, path := range stale {
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to delete the backup file %s: %w", path, err)
+		}
+	}
+
+	return stale, nil
+}
@@ -0,0 +1,84 @@
+package store_test
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzBackups tests that the backups are hot snapshots of the database which
+// are listed from the oldest to the latest and pruned down to the retention
+func FuzzBackups(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewFinalityProviderStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		err = s.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.Commission,
+			fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+
+		backupDir := filepath.Join(t.TempDir(), "backups")
+		paths, err := fpstore.ListBackups(backupDir)
+		require.NoError(t, err)
+		require.Empty(t, paths)
+
+		numBackups := int(r.Int31n(10) + 2)
+		start := time.Unix(r.Int63n(1<<32), 0)
+		var written []string
+		for i := 0; i < numBackups; i++ {
+			require.NoError(t, s.SetFpLastVotedHeight(fp.BtcPk, uint64(i+1)))
+			path, err := fpstore.WriteBackup(db, backupDir, start.Add(time.Duration(i)*time.Hour))
+			require.NoError(t, err)
+			written = append(written, path)
+		}
+
+		paths, err = fpstore.ListBackups(backupDir)
+		require.NoError(t, err)
+		require.Equal(t, written, paths)
+
+		// the latest backup holds the state at the time it was written
+		backupCfg := *cfg
+		backupCfg.DBPath = backupDir
+		backupCfg.DBFileName = filepath.Base(paths[len(paths)-1])
+		backupDb, err := backupCfg.GetDbBackend()
+		require.NoError(t, err)
+		backupStore, err := fpstore.NewFinalityProviderStore(backupDb)
+		require.NoError(t, err)
+		backupFp, err := backupStore.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.Equal(t, uint64(numBackups), backupFp.LastVotedHeight)
+		require.NoError(t, backupDb.Close())
+
+		pruned, err := fpstore.PruneBackups(backupDir, 0)
+		require.NoError(t, err)
+		require.Empty(t, pruned)
+
+		retention := uint32(r.Int31n(int32(numBackups)) + 1)
+		pruned, err = fpstore.PruneBackups(backupDir, retention)
+		require.NoError(t, err)
+		require.ElementsMatch(t, written[:numBackups-int(retention)], pruned)
+		paths, err = fpstore.ListBackups(backupDir)
+		require.NoError(t, err)
+		require.Equal(t, written[numBackups-int(retention):], paths)
+	})
+}
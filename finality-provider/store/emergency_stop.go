@@ -0,0 +1,97 @@
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: emergencyStopKey -> emergency stop
+	emergencyStopBucketName = []byte("emergency_stop")
+
+	emergencyStopKey = []byte("emergency_stop")
+)
+
+// EmergencyStop is the record of the emergency stop of the daemon, which
+// halts all the signing and the submissions until it is lifted
+type EmergencyStop struct {
+	Reason string `json:"reason"`
+	// Timestamp is the unix time in seconds at which the daemon was stopped
+	Timestamp int64 `json:"timestamp"`
+}
+
+type EmergencyStopStore struct {
+	db kvdb.Backend
+}
+
+// NewEmergencyStopStore returns a new store backed by db
+func NewEmergencyStopStore(db kvdb.Backend) (*EmergencyStopStore, error) {
+	store := &EmergencyStopStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *EmergencyStopStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(emergencyStopBucketName)
+		return err
+	})
+}
+
+// SetEmergencyStop records the emergency stop, replacing the previous one if
+// any, or lifts it if stop is nil
+func (s *EmergencyStopStore) SetEmergencyStop(stop *EmergencyStop) error {
+	var v []byte
+	if stop != nil {
+		var err error
+		if v, err = json.Marshal(stop); err != nil {
+			return err
+		}
+	}
+
+	return kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(emergencyStopBucketName)
+		if bucket == nil {
+			return ErrCorruptedEmergencyStopDb
+		}
+		if v == nil {
+			return bucket.Delete(emergencyStopKey)
+		}
+
+		return bucket.Put(emergencyStopKey, v)
+	}, func() {})
+}
+
+// GetEmergencyStop returns the emergency stop, or nil if the daemon is not
+// stopped
+func (s *EmergencyStopStore) GetEmergencyStop() (*EmergencyStop, error) {
+	var stop *EmergencyStop
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(emergencyStopBucketName)
+		if bucket == nil {
+			return ErrCorruptedEmergencyStopDb
+		}
+
+		v := bucket.Get(emergencyStopKey)
+		if v == nil {
+			return nil
+		}
+		stop = &EmergencyStop{}
+		if err := json.Unmarshal(v, stop); err != nil {
+			return newErrCorruptRecord(emergencyStopBucketName, emergencyStopKey, err)
+		}
+
+		return nil
+	}, func() {
+		stop = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stop, nil
+}
@@ -0,0 +1,38 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+)
+
+// TestEmergencyStopStore tests that the emergency stop is persisted until it
+// is lifted
+func TestEmergencyStopStore(t *testing.T) {
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	db, err := cfg.GetDbBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	s, err := fpstore.NewEmergencyStopStore(db)
+	require.NoError(t, err)
+
+	stop, err := s.GetEmergencyStop()
+	require.NoError(t, err)
+	require.Nil(t, stop)
+
+	expected := &fpstore.EmergencyStop{Reason: "suspected key leak", Timestamp: 1700000000}
+	require.NoError(t, s.SetEmergencyStop(expected))
+	stop, err = s.GetEmergencyStop()
+	require.NoError(t, err)
+	require.Equal(t, expected, stop)
+
+	require.NoError(t, s.SetEmergencyStop(nil))
+	stop, err = s.GetEmergencyStop()
+	require.NoError(t, err)
+	require.Nil(t, stop)
+}
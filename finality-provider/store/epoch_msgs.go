@@ -0,0 +1,194 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: fp_pk || sequence -> epoch message
+	epochMsgBucketName = []byte("epoch_msgs")
+)
+
+// EpochMsg is a tx submitted on behalf of a finality provider which is
+// included on Babylon but is only final once its epoch is finalized on
+// Bitcoin, e.g., a public randomness commitment whose randomness can only be
+// voted with from then on
+type EpochMsg struct {
+	// Type is the type of the submission, e.g., pub_rand_commit
+	Type string `json:"type"`
+	// Height is the start height of the committed randomness, 0 if not
+	// applicable
+	Height uint64 `json:"height,omitempty"`
+	// EndHeight is the height of the last committed randomness
+	EndHeight uint64 `json:"end_height,omitempty"`
+	TxHash    string `json:"tx_hash"`
+	// Epoch is the epoch in which the tx is included, or 0 until it is
+	// resolved
+	Epoch uint64 `json:"epoch,omitempty"`
+	// Timestamp is the unix time in seconds at which the tx was submitted
+	Timestamp int64 `json:"timestamp"`
+}
+
+type EpochMsgStore struct {
+	db kvdb.Backend
+}
+
+// NewEpochMsgStore returns a new store backed by db
+func NewEpochMsgStore(db kvdb.Backend) (*EpochMsgStore, error) {
+	store := &EpochMsgStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *EpochMsgStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(epochMsgBucketName)
+		return err
+	})
+}
+
+// AddEpochMsg queues a tx submitted on behalf of the given finality provider
+// until its epoch is finalized
+func (s *EpochMsgStore) AddEpochMsg(fpPk *btcec.PublicKey, msg *EpochMsg) error {
+	v, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(epochMsgBucketName)
+		if bucket == nil {
+			return ErrCorruptedEpochMsgDb
+		}
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(epochMsgKey(fpPk, seq), v)
+	})
+}
+
+// GetEpochMsgs returns the txs of the given finality provider whose epoch is
+// not finalized yet, from the earliest to the latest
+func (s *EpochMsgStore) GetEpochMsgs(fpPk *btcec.PublicKey) ([]*EpochMsg, error) {
+	var msgs []*EpochMsg
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(epochMsgBucketName)
+		if bucket == nil {
+			return ErrCorruptedEpochMsgDb
+		}
+
+		return forEachEpochMsg(bucket, fpPk, func(_ []byte, msg *EpochMsg) error {
+			msgs = append(msgs, msg)
+			return nil
+		})
+	}, func() {
+		msgs = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+// ResolveEpochMsgs sets the given epoch to the txs of the given finality
+// provider whose epoch is not resolved yet, and removes the txs whose epoch
+// is finalized, i.e., not after the given last finalized epoch. It returns
+// the removed txs, which are final
+func (s *EpochMsgStore) ResolveEpochMsgs(
+	fpPk *btcec.PublicKey,
+	currentEpoch, lastFinalizedEpoch uint64,
+) ([]*EpochMsg, error) {
+	var finalized []*EpochMsg
+
+	err := kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(epochMsgBucketName)
+		if bucket == nil {
+			return ErrCorruptedEpochMsgDb
+		}
+
+		// the bucket is not modified while iterating over it
+		var keys [][]byte
+		var msgs []*EpochMsg
+		err := forEachEpochMsg(bucket, fpPk, func(k []byte, msg *EpochMsg) error {
+			keys = append(keys, k)
+			msgs = append(msgs, msg)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for i, msg := range msgs {
+			unresolved := msg.Epoch == 0
+			if unresolved {
+				msg.Epoch = currentEpoch
+			}
+			if msg.Epoch <= lastFinalizedEpoch {
+				finalized = append(finalized, msg)
+				if err := bucket.Delete(keys[i]); err != nil {
+					return err
+				}
+				continue
+			}
+			if !unresolved {
+				continue
+			}
+			v, err := json.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(keys[i], v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {
+		finalized = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return finalized, nil
+}
+
+func forEachEpochMsg(bucket kvdb.RBucket, fpPk *btcec.PublicKey, fn func(k []byte, msg *EpochMsg) error) error {
+	prefix := schnorr.SerializePubKey(fpPk)
+	c := bucket.ReadCursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if len(k) != len(prefix)+8 {
+			return newErrCorruptRecord(epochMsgBucketName, k, fmt.Errorf("invalid key length"))
+		}
+		var msg EpochMsg
+		if err := json.Unmarshal(v, &msg); err != nil {
+			return newErrCorruptRecord(epochMsgBucketName, k, err)
+		}
+		// the key is copied as it is only valid during the iteration
+		if err := fn(append([]byte(nil), k...), &msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func epochMsgKey(fpPk *btcec.PublicKey, seq uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, seq)
+}
@@ -0,0 +1,95 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzEpochMsgStore tests that the epochs of the queued txs are resolved once
+// and that the txs are removed once their epoch is finalized, separately for
+// each finality provider
+func FuzzEpochMsgStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewEpochMsgStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		otherFp := testutil.GenRandomFinalityProvider(r, t)
+
+		registration := &fpstore.EpochMsg{
+			Type:   fpstore.SubmissionTypeRegistration,
+			TxHash: testutil.GenRandomHexStr(r, 32),
+		}
+		require.NoError(t, s.AddEpochMsg(fp.BtcPk, registration))
+		require.NoError(t, s.AddEpochMsg(otherFp.BtcPk, &fpstore.EpochMsg{
+			Type:   fpstore.SubmissionTypeRegistration,
+			TxHash: testutil.GenRandomHexStr(r, 32),
+		}))
+
+		// the registration is included in the current epoch, which is not
+		// finalized yet
+		epoch := uint64(r.Int63n(1000) + 2)
+		finalized, err := s.ResolveEpochMsgs(fp.BtcPk, epoch, epoch-1)
+		require.NoError(t, err)
+		require.Empty(t, finalized)
+
+		// the commitment is resolved in a later epoch
+		commit := &fpstore.EpochMsg{
+			Type:      fpstore.SubmissionTypePubRandCommit,
+			Height:    uint64(r.Int63n(1000) + 1),
+			EndHeight: uint64(r.Int63n(1000) + 1001),
+			TxHash:    testutil.GenRandomHexStr(r, 32),
+		}
+		require.NoError(t, s.AddEpochMsg(fp.BtcPk, commit))
+		finalized, err = s.ResolveEpochMsgs(fp.BtcPk, epoch+1, epoch-1)
+		require.NoError(t, err)
+		require.Empty(t, finalized)
+
+		msgs, err := s.GetEpochMsgs(fp.BtcPk)
+		require.NoError(t, err)
+		require.Len(t, msgs, 2)
+		require.Equal(t, registration.TxHash, msgs[0].TxHash)
+		require.Equal(t, epoch, msgs[0].Epoch)
+		require.Equal(t, commit.TxHash, msgs[1].TxHash)
+		require.Equal(t, epoch+1, msgs[1].Epoch)
+
+		// only the registration takes effect once its epoch is finalized
+		finalized, err = s.ResolveEpochMsgs(fp.BtcPk, epoch+2, epoch)
+		require.NoError(t, err)
+		require.Len(t, finalized, 1)
+		require.Equal(t, registration.TxHash, finalized[0].TxHash)
+		msgs, err = s.GetEpochMsgs(fp.BtcPk)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Equal(t, commit.TxHash, msgs[0].TxHash)
+
+		finalized, err = s.ResolveEpochMsgs(fp.BtcPk, epoch+2, epoch+1)
+		require.NoError(t, err)
+		require.Len(t, finalized, 1)
+		require.Equal(t, commit.TxHash, finalized[0].TxHash)
+		msgs, err = s.GetEpochMsgs(fp.BtcPk)
+		require.NoError(t, err)
+		require.Empty(t, msgs)
+
+		// the tx of the other finality provider is still queued
+		msgs, err = s.GetEpochMsgs(otherFp.BtcPk)
+		require.NoError(t, err)
+		require.Len(t, msgs, 1)
+		require.Zero(t, msgs[0].Epoch)
+	})
+}
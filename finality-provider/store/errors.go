@@ -1,6 +1,9 @@
 package store
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrCorruptedFinalityProviderDb For some reason, db on disk representation have changed
@@ -17,4 +20,61 @@ var (
 
 	// ErrPubRandProofNotFound The finality provider we try update is not found in db
 	ErrPubRandProofNotFound = errors.New("public randomness proof not found")
+
+	// ErrCorruptedMissedBlockDb For some reason, db on disk representation have changed
+	ErrCorruptedMissedBlockDb = errors.New("missed block db is corrupted")
+
+	// ErrCorruptedSubmissionDb For some reason, db on disk representation have changed
+	ErrCorruptedSubmissionDb = errors.New("submission db is corrupted")
+
+	// ErrCorruptedEpochMsgDb For some reason, db on disk representation have changed
+	ErrCorruptedEpochMsgDb = errors.New("epoch message db is corrupted")
+
+	// ErrCorruptedEventDb For some reason, db on disk representation have changed
+	ErrCorruptedEventDb = errors.New("event db is corrupted")
+
+	// ErrCorruptedVoteProofDb For some reason, db on disk representation have changed
+	ErrCorruptedVoteProofDb = errors.New("vote proof db is corrupted")
+
+	// ErrVoteProofNotFound No vote of the finality provider is recorded at the height
+	ErrVoteProofNotFound = errors.New("vote proof not found")
+
+	// ErrCorruptedEmergencyStopDb For some reason, db on disk representation have changed
+	ErrCorruptedEmergencyStopDb = errors.New("emergency stop db is corrupted")
+
+	// ErrConflictingVote The finality provider would vote for a block that
+	// conflicts with a previous vote, which would leak its EOTS key
+	ErrConflictingVote = errors.New("refusing to vote for a block conflicting with a previous vote")
+
+	// ErrUnsupportedRecordVersion The record was written by a newer release
+	// in a format this release cannot read
+	ErrUnsupportedRecordVersion = errors.New("unsupported record version")
+
+	// ErrSnapshotBehind The imported snapshot is behind the state it would overwrite
+	ErrSnapshotBehind = errors.New("the snapshot is behind the current state")
 )
+
+// ErrCorruptRecord is returned when a record read from the db cannot be decoded
+// into a valid value. It carries the bucket and the key of the offending record
+// so that the operator can locate it.
+type ErrCorruptRecord struct {
+	Bucket string
+	Key    []byte
+	Err    error
+}
+
+func newErrCorruptRecord(bucket, key []byte, err error) *ErrCorruptRecord {
+	return &ErrCorruptRecord{
+		Bucket: string(bucket),
+		Key:    key,
+		Err:    err,
+	}
+}
+
+func (e *ErrCorruptRecord) Error() string {
+	return fmt.Sprintf("corrupted record in bucket %s with key %x: %v", e.Bucket, e.Key, e.Err)
+}
+
+func (e *ErrCorruptRecord) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,160 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: sequence -> event
+	eventBucketName = []byte("events")
+)
+
+// Event is a significant event of the daemon recorded in the event journal
+type Event struct {
+	// Seq is the sequence number of the event in the journal, starting at 1
+	Seq  uint64 `json:"seq"`
+	Type string `json:"type"`
+	// BtcPkHex is the hex BTC public key of the concerned finality provider,
+	// which is empty if the event concerns the daemon
+	BtcPkHex   string `json:"btc_pk,omitempty"`
+	Height     uint64 `json:"height,omitempty"`
+	EndHeight  uint64 `json:"end_height,omitempty"`
+	TxHash     string `json:"tx_hash,omitempty"`
+	Status     string `json:"status,omitempty"`
+	PrevStatus string `json:"prev_status,omitempty"`
+	Message    string `json:"message,omitempty"`
+	// Timestamp is the unix time in seconds at which the event happened
+	Timestamp int64 `json:"timestamp"`
+}
+
+// EventStore is the append-only journal of the events of the daemon
+type EventStore struct {
+	db kvdb.Backend
+}
+
+// NewEventStore returns a new store backed by db
+func NewEventStore(db kvdb.Backend) (*EventStore, error) {
+	store := &EventStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *EventStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(eventBucketName)
+		return err
+	})
+}
+
+// AppendEvent appends the event to the journal and sets its sequence number
+func (s *EventStore) AppendEvent(ev *Event) error {
+	var seq uint64
+	err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(eventBucketName)
+		if bucket == nil {
+			return ErrCorruptedEventDb
+		}
+
+		var err error
+		seq, err = bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		rec := *ev
+		rec.Seq = seq
+		v, err := json.Marshal(&rec)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(eventKey(seq), v)
+	})
+	if err != nil {
+		return err
+	}
+
+	ev.Seq = seq
+
+	return nil
+}
+
+// GetEvents returns the events of the journal from the given sequence number
+// on, from the earliest to the latest, up to limit events, or all of them if
+// limit is 0. If fpPkHex is not empty, only the events of that finality
+// provider are returned, and if types is not empty, only the events of these
+// types are. It also returns the sequence number to get the next events from
+func (s *EventStore) GetEvents(fromSeq uint64, fpPkHex string, types []string, limit uint64) ([]*Event, uint64, error) {
+	var events []*Event
+	nextSeq := fromSeq
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(eventBucketName)
+		if bucket == nil {
+			return ErrCorruptedEventDb
+		}
+
+		c := bucket.ReadCursor()
+		for k, v := c.Seek(eventKey(fromSeq)); k != nil; k, v = c.Next() {
+			if limit > 0 && uint64(len(events)) >= limit {
+				break
+			}
+			if len(k) != 8 {
+				return newErrCorruptRecord(eventBucketName, k, fmt.Errorf("invalid key length"))
+			}
+			nextSeq = binary.BigEndian.Uint64(k) + 1
+			var ev Event
+			if err := json.Unmarshal(v, &ev); err != nil {
+				return newErrCorruptRecord(eventBucketName, k, err)
+			}
+			if fpPkHex != "" && ev.BtcPkHex != fpPkHex {
+				continue
+			}
+			if len(types) > 0 && !slices.Contains(types, ev.Type) {
+				continue
+			}
+			events = append(events, &ev)
+		}
+
+		return nil
+	}, func() {
+		events = nil
+		nextSeq = fromSeq
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, nextSeq, nil
+}
+
+// PruneEvents deletes the events of the journal up to the last one below
+// the given height, i.e., whose end height, or height if it has none, is
+// below it. The earlier events without height, e.g., the restarts of the
+// daemon, are deleted too, so that the remaining journal is contiguous. It
+// returns the number of deleted events
+func (s *EventStore) PruneEvents(height uint64) (uint64, error) {
+	return pruneHistory(s.db, eventBucketName, ErrCorruptedEventDb, 0, height,
+		func(k, v []byte) (uint64, error) {
+			if len(k) != 8 {
+				return 0, fmt.Errorf("invalid key length")
+			}
+			var ev Event
+			if err := json.Unmarshal(v, &ev); err != nil {
+				return 0, err
+			}
+			return max(ev.Height, ev.EndHeight), nil
+		})
+}
+
+func eventKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, seq)
+}
@@ -0,0 +1,99 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzEventStore tests that the events are listed in the order they were
+// appended with increasing sequence numbers, by finality provider, type, and
+// limit, that the listing can be resumed from the returned sequence number,
+// and that the journal is pruned below a height
+func FuzzEventStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewEventStore(db)
+		require.NoError(t, err)
+
+		events, nextSeq, err := s.GetEvents(1, "", nil, 0)
+		require.NoError(t, err)
+		require.Empty(t, events)
+		require.Equal(t, uint64(1), nextSeq)
+
+		fpPkHex := testutil.GenRandomHexStr(r, 32)
+		numEvents := int(r.Int63n(30) + 5)
+		var numFpEvents, numStatusChanges int
+		for i := 0; i < numEvents; i++ {
+			ev := &fpstore.Event{
+				Type:      fpstore.SubmissionTypeFinalitySig,
+				Height:    uint64(i + 1),
+				Timestamp: int64(i),
+			}
+			if r.Int31n(2) == 0 {
+				ev.BtcPkHex = fpPkHex
+				numFpEvents++
+				if i%3 == 0 {
+					ev.Type = "status_change"
+					numStatusChanges++
+				}
+			}
+			require.NoError(t, s.AppendEvent(ev))
+			require.Equal(t, uint64(i+1), ev.Seq)
+		}
+
+		events, nextSeq, err = s.GetEvents(1, "", nil, 0)
+		require.NoError(t, err)
+		require.Len(t, events, numEvents)
+		require.Equal(t, uint64(numEvents+1), nextSeq)
+		for i, ev := range events {
+			require.Equal(t, uint64(i+1), ev.Seq)
+			require.Equal(t, uint64(i+1), ev.Height)
+		}
+
+		events, _, err = s.GetEvents(1, fpPkHex, nil, 0)
+		require.NoError(t, err)
+		require.Len(t, events, numFpEvents)
+		events, _, err = s.GetEvents(1, fpPkHex, []string{"status_change"}, 0)
+		require.NoError(t, err)
+		require.Len(t, events, numStatusChanges)
+
+		// page through the events
+		var paged []*fpstore.Event
+		for seq := uint64(1); ; {
+			page, next, err := s.GetEvents(seq, "", nil, 4)
+			require.NoError(t, err)
+			if len(page) == 0 {
+				require.Equal(t, seq, next)
+				break
+			}
+			paged = append(paged, page...)
+			seq = next
+		}
+		require.Len(t, paged, numEvents)
+
+		// the events up to the last one below the height are pruned, while
+		// the following ones without height are kept
+		require.NoError(t, s.AppendEvent(&fpstore.Event{Type: "daemon_started"}))
+		deleted, err := s.PruneEvents(uint64(numEvents + 1))
+		require.NoError(t, err)
+		require.Equal(t, uint64(numEvents), deleted)
+		events, _, err = s.GetEvents(1, "", nil, 0)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Equal(t, uint64(numEvents+1), events[0].Seq)
+	})
+}
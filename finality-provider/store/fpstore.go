@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 
 	sdkmath "cosmossdk.io/math"
@@ -20,6 +21,19 @@ var (
 	finalityProviderBucketName = []byte("finalityProviders")
 )
 
+// FinalityProviderRecordVersion is the version of the format of the finality
+// provider records written to the db
+const FinalityProviderRecordVersion uint32 = 1
+
+// fpRecordUpgrades upgrades a finality provider record from the version of its
+// index to the next one. A new version of the format must come with the
+// upgrade from the previous one, so that the records written by the earlier
+// releases can still be read
+var fpRecordUpgrades = []func(fp *proto.FinalityProvider) error{
+	// 0 -> 1: the records written before the versioning have the same fields
+	func(fp *proto.FinalityProvider) error { return nil },
+}
+
 type FinalityProviderStore struct {
 	db kvdb.Backend
 }
@@ -30,13 +44,86 @@ func NewFinalityProviderStore(db kvdb.Backend) (*FinalityProviderStore, error) {
 	if err := store.initBuckets(); err != nil {
 		return nil, err
 	}
+	if _, err := store.MigrateFinalityProviders(); err != nil {
+		return nil, fmt.Errorf("failed to migrate the finality provider records: %w", err)
+	}
 
 	return store, nil
 }
 
+// MigrateFinalityProviders rewrites the finality provider records written in
+// an earlier format in the current one in a single transaction, and returns
+// the number of migrated records. The records written by a newer release are
+// left untouched
+func (s *FinalityProviderStore) MigrateFinalityProviders() (int, error) {
+	var migrated int
+	err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		migrated = 0
+		fpBucket := tx.ReadWriteBucket(finalityProviderBucketName)
+		if fpBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+
+		var outdated []*proto.FinalityProvider
+		err := fpBucket.ForEach(func(k, v []byte) error {
+			var fp proto.FinalityProvider
+			if err := pm.Unmarshal(v, &fp); err != nil {
+				return newErrCorruptRecord(finalityProviderBucketName, k, err)
+			}
+			if fp.Version >= FinalityProviderRecordVersion {
+				return nil
+			}
+			upgraded, err := decodeFinalityProvider(k, v)
+			if err != nil {
+				return err
+			}
+			outdated = append(outdated, upgraded)
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// the bucket must not be written while iterating over it
+		for _, fp := range outdated {
+			if err := saveFinalityProvider(fpBucket, fp); err != nil {
+				return err
+			}
+		}
+		migrated = len(outdated)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return migrated, nil
+}
+
+// upgradeFinalityProvider upgrades the given record to the current format
+func upgradeFinalityProvider(fp *proto.FinalityProvider) error {
+	if fp.Version > FinalityProviderRecordVersion {
+		return fmt.Errorf("%w: version %d is newer than the supported version %d, upgrade the daemon",
+			ErrUnsupportedRecordVersion, fp.Version, FinalityProviderRecordVersion)
+	}
+	for fp.Version < FinalityProviderRecordVersion {
+		if err := fpRecordUpgrades[fp.Version](fp); err != nil {
+			return fmt.Errorf("failed to upgrade from version %d: %w", fp.Version, err)
+		}
+		fp.Version++
+	}
+
+	return nil
+}
+
 func (s *FinalityProviderStore) initBuckets() error {
 	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(finalityProviderBucketName)
+		if _, err := tx.CreateTopLevelBucket(finalityProviderBucketName); err != nil {
+			return err
+		}
+		_, err := tx.CreateTopLevelBucket(votedBlockBucketName)
 		return err
 	})
 }
@@ -45,15 +132,38 @@ func (s *FinalityProviderStore) CreateFinalityProvider(
 	fpAddr sdk.AccAddress,
 	btcPk *btcec.PublicKey,
 	description *stakingtypes.Description,
-	commission *sdkmath.LegacyDec,
+	commission, maxCommission *sdkmath.LegacyDec,
 	keyName, chainId string,
 	btcSig []byte,
 ) error {
+	fp, err := newProtoFinalityProvider(fpAddr, btcPk, description, commission, maxCommission, keyName, chainId, btcSig)
+	if err != nil {
+		return err
+	}
+
+	return s.createFinalityProviderInternal(fp)
+}
+
+func newProtoFinalityProvider(
+	fpAddr sdk.AccAddress,
+	btcPk *btcec.PublicKey,
+	description *stakingtypes.Description,
+	commission, maxCommission *sdkmath.LegacyDec,
+	keyName, chainId string,
+	btcSig []byte,
+) (*proto.FinalityProvider, error) {
 	desBytes, err := description.Marshal()
 	if err != nil {
-		return fmt.Errorf("invalid description: %w", err)
+		return nil, fmt.Errorf("invalid description: %w", err)
+	}
+
+	// the max commission is left empty if there is no limit
+	var maxCommissionStr string
+	if maxCommission != nil {
+		maxCommissionStr = maxCommission.String()
 	}
-	fp := &proto.FinalityProvider{
+
+	return &proto.FinalityProvider{
 		FpAddr:      fpAddr.String(),
 		BtcPk:       schnorr.SerializePubKey(btcPk),
 		Description: desBytes,
@@ -61,12 +171,11 @@ func (s *FinalityProviderStore) CreateFinalityProvider(
 		Pop: &proto.ProofOfPossession{
 			BtcSig: btcSig,
 		},
-		KeyName: keyName,
-		ChainId: chainId,
-		Status:  proto.FinalityProviderStatus_CREATED,
-	}
-
-	return s.createFinalityProviderInternal(fp)
+		KeyName:       keyName,
+		ChainId:       chainId,
+		Status:        proto.FinalityProviderStatus_CREATED,
+		MaxCommission: maxCommissionStr,
+	}, nil
 }
 
 func (s *FinalityProviderStore) createFinalityProviderInternal(
@@ -95,6 +204,7 @@ func saveFinalityProvider(
 		return fmt.Errorf("cannot save nil finality provider")
 	}
 
+	fp.Version = FinalityProviderRecordVersion
 	marshalled, err := pm.Marshal(fp)
 	if err != nil {
 		return err
@@ -104,12 +214,14 @@ func saveFinalityProvider(
 }
 
 func (s *FinalityProviderStore) SetFpStatus(btcPk *btcec.PublicKey, status proto.FinalityProviderStatus) error {
-	setFpStatus := func(fp *proto.FinalityProvider) error {
+	return s.setFinalityProviderState(btcPk, setFpStatus(status))
+}
+
+func setFpStatus(status proto.FinalityProviderStatus) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
 		fp.Status = status
 		return nil
 	}
-
-	return s.setFinalityProviderState(btcPk, setFpStatus)
 }
 
 // UpdateFpStatusFromVotingPower based on the current voting power of the finality provider
@@ -118,6 +230,14 @@ func (s *FinalityProviderStore) UpdateFpStatusFromVotingPower(
 	vp uint64,
 	fp *StoredFinalityProvider,
 ) (newStatus proto.FinalityProviderStatus, err error) {
+	return updateFpStatusFromVotingPower(s, vp, fp)
+}
+
+func updateFpStatusFromVotingPower(
+	s FpStore,
+	vp uint64,
+	fp *StoredFinalityProvider,
+) (proto.FinalityProviderStatus, error) {
 	if fp.Status == proto.FinalityProviderStatus_SLASHED {
 		// Slashed FP should not update status
 		return proto.FinalityProviderStatus_SLASHED, nil
@@ -143,7 +263,11 @@ func (s *FinalityProviderStore) UpdateFpStatusFromVotingPower(
 // SetFpLastVotedHeight sets the last voted height to the stored last voted height and last processed height
 // only if it is larger than the stored one. This is to ensure the stored state to increase monotonically
 func (s *FinalityProviderStore) SetFpLastVotedHeight(btcPk *btcec.PublicKey, lastVotedHeight uint64) error {
-	setFpLastVotedHeight := func(fp *proto.FinalityProvider) error {
+	return s.setFinalityProviderState(btcPk, setFpLastVotedHeight(lastVotedHeight))
+}
+
+func setFpLastVotedHeight(lastVotedHeight uint64) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
 		if fp.LastVotedHeight < lastVotedHeight {
 			fp.LastVotedHeight = lastVotedHeight
 		}
@@ -153,22 +277,22 @@ func (s *FinalityProviderStore) SetFpLastVotedHeight(btcPk *btcec.PublicKey, las
 
 		return nil
 	}
-
-	return s.setFinalityProviderState(btcPk, setFpLastVotedHeight)
 }
 
 // SetFpLastProcessedHeight sets the last processed height to the stored last processed height
 // only if it is larger than the stored one. This is to ensure the stored state to increase monotonically
 func (s *FinalityProviderStore) SetFpLastProcessedHeight(btcPk *btcec.PublicKey, lastProcessedHeight uint64) error {
-	setFpLastProcessedHeight := func(fp *proto.FinalityProvider) error {
+	return s.setFinalityProviderState(btcPk, setFpLastProcessedHeight(lastProcessedHeight))
+}
+
+func setFpLastProcessedHeight(lastProcessedHeight uint64) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
 		if fp.LastProcessedHeight < lastProcessedHeight {
 			fp.LastProcessedHeight = lastProcessedHeight
 		}
 
 		return nil
 	}
-
-	return s.setFinalityProviderState(btcPk, setFpLastProcessedHeight)
 }
 
 func (s *FinalityProviderStore) setFinalityProviderState(
@@ -187,19 +311,72 @@ func (s *FinalityProviderStore) setFinalityProviderState(
 			return ErrFinalityProviderNotFound
 		}
 
-		var storedFp proto.FinalityProvider
-		if err := pm.Unmarshal(fpFromDb, &storedFp); err != nil {
-			return ErrCorruptedFinalityProviderDb
+		storedFp, err := decodeFinalityProvider(pkBytes, fpFromDb)
+		if err != nil {
+			return err
 		}
 
-		if err := stateTransitionFn(&storedFp); err != nil {
+		if err := stateTransitionFn(storedFp); err != nil {
 			return err
 		}
 
-		return saveFinalityProvider(fpBucket, &storedFp)
+		return saveFinalityProvider(fpBucket, storedFp)
 	})
 }
 
+// decodeFinalityProvider decodes the finality provider record stored under the
+// given key, upgrades it to the current format and checks that the decoded
+// fields are consistent. Any failure is reported as ErrCorruptRecord instead of
+// yielding a zero-valued record, except for the records written by a newer
+// release, which are reported as ErrUnsupportedRecordVersion
+func decodeFinalityProvider(key, v []byte) (*proto.FinalityProvider, error) {
+	if len(v) == 0 {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key, fmt.Errorf("empty value"))
+	}
+
+	var fp proto.FinalityProvider
+	if err := pm.Unmarshal(v, &fp); err != nil {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key, err)
+	}
+	if err := upgradeFinalityProvider(&fp); err != nil {
+		return nil, err
+	}
+
+	if len(fp.BtcPk) != schnorr.PubKeyBytesLen {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key,
+			fmt.Errorf("invalid BTC public key length %d", len(fp.BtcPk)))
+	}
+
+	if !bytes.Equal(fp.BtcPk, key) {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key,
+			fmt.Errorf("BTC public key %x does not match the record key", fp.BtcPk))
+	}
+
+	if fp.Pop == nil {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key, fmt.Errorf("missing proof of possession"))
+	}
+
+	if _, ok := proto.FinalityProviderStatus_name[int32(fp.Status)]; !ok {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key, fmt.Errorf("unknown status %d", fp.Status))
+	}
+
+	return &fp, nil
+}
+
+func decodeStoredFinalityProvider(key, v []byte) (*StoredFinalityProvider, error) {
+	fpProto, err := decodeFinalityProvider(key, v)
+	if err != nil {
+		return nil, err
+	}
+
+	storedFp, err := protoFpToStoredFinalityProvider(fpProto)
+	if err != nil {
+		return nil, newErrCorruptRecord(finalityProviderBucketName, key, err)
+	}
+
+	return storedFp, nil
+}
+
 func (s *FinalityProviderStore) GetFinalityProvider(btcPk *btcec.PublicKey) (*StoredFinalityProvider, error) {
 	var storedFp *StoredFinalityProvider
 	pkBytes := schnorr.SerializePubKey(btcPk)
@@ -215,12 +392,7 @@ func (s *FinalityProviderStore) GetFinalityProvider(btcPk *btcec.PublicKey) (*St
 			return ErrFinalityProviderNotFound
 		}
 
-		var fpProto proto.FinalityProvider
-		if err := pm.Unmarshal(fpBytes, &fpProto); err != nil {
-			return ErrCorruptedFinalityProviderDb
-		}
-
-		fpFromDb, err := protoFpToStoredFinalityProvider(&fpProto)
+		fpFromDb, err := decodeStoredFinalityProvider(pkBytes, fpBytes)
 		if err != nil {
 			return err
 		}
@@ -249,12 +421,7 @@ func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinali
 		}
 
 		return fpBucket.ForEach(func(k, v []byte) error {
-			var fpProto proto.FinalityProvider
-			if err := pm.Unmarshal(v, &fpProto); err != nil {
-				return ErrCorruptedFinalityProviderDb
-			}
-
-			fpFromDb, err := protoFpToStoredFinalityProvider(&fpProto)
+			fpFromDb, err := decodeStoredFinalityProvider(k, v)
 			if err != nil {
 				return err
 			}
@@ -273,7 +440,11 @@ func (s *FinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinali
 
 // SetFpDescription updates description of finality provider
 func (s *FinalityProviderStore) SetFpDescription(btcPk *btcec.PublicKey, desc *stakingtypes.Description, rate *sdkmath.LegacyDec) error {
-	setDescription := func(fp *proto.FinalityProvider) error {
+	return s.setFinalityProviderState(btcPk, setFpDescription(desc, rate))
+}
+
+func setFpDescription(desc *stakingtypes.Description, rate *sdkmath.LegacyDec) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
 		descBytes, err := desc.Marshal()
 		if err != nil {
 			return err
@@ -284,6 +455,30 @@ func (s *FinalityProviderStore) SetFpDescription(btcPk *btcec.PublicKey, desc *s
 
 		return nil
 	}
+}
+
+// SetFpRewardAddress sets the address the rewards of the finality provider are
+// sent to upon withdrawal, or clears it if empty
+func (s *FinalityProviderStore) SetFpRewardAddress(btcPk *btcec.PublicKey, rewardAddress string) error {
+	return s.setFinalityProviderState(btcPk, setFpRewardAddress(rewardAddress))
+}
+
+func setFpRewardAddress(rewardAddress string) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
+		fp.RewardAddress = rewardAddress
+		return nil
+	}
+}
 
-	return s.setFinalityProviderState(btcPk, setDescription)
+// SetFpPaused sets whether the submissions of the finality provider are
+// paused, so that it is not started until it is resumed
+func (s *FinalityProviderStore) SetFpPaused(btcPk *btcec.PublicKey, paused bool) error {
+	return s.setFinalityProviderState(btcPk, setFpPaused(paused))
+}
+
+func setFpPaused(paused bool) func(fp *proto.FinalityProvider) error {
+	return func(fp *proto.FinalityProvider) error {
+		fp.Paused = paused
+		return nil
+	}
 }
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
 	"github.com/stretchr/testify/require"
+	pm "google.golang.org/protobuf/proto"
 
 	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
 	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
@@ -46,6 +49,7 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 			fp.BtcPk,
 			fp.Description,
 			fp.Commission,
+			fp.Commission,
 			fp.KeyName,
 			fp.ChainID,
 			fp.Pop.BtcSig,
@@ -59,6 +63,7 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 			fp.BtcPk,
 			fp.Description,
 			fp.Commission,
+			nil,
 			fp.KeyName,
 			fp.ChainID,
 			fp.Pop.BtcSig,
@@ -72,6 +77,7 @@ func FuzzFinalityProvidersStore(f *testing.F) {
 		actualFp, err := vs.GetFinalityProvider(fp.BtcPk)
 		require.NoError(t, err)
 		require.Equal(t, fp.BtcPk, actualFp.BtcPk)
+		require.True(t, fp.Commission.Equal(*actualFp.MaxCommission))
 
 		_, randomBtcPk, err := datagen.GenRandomBTCKeyPair(r)
 		require.NoError(t, err)
@@ -195,6 +201,7 @@ func TestUpdateFpStatusFromVotingPower(t *testing.T) {
 					fp.BtcPk,
 					fp.Description,
 					fp.Commission,
+					nil,
 					fp.KeyName,
 					fp.ChainID,
 					fp.Pop.BtcSig,
@@ -219,3 +226,189 @@ func TestUpdateFpStatusFromVotingPower(t *testing.T) {
 		})
 	}
 }
+
+// TestFinalityProviderStoreCorruptedRecord injects corrupted finality provider
+// records into the db and checks that reading them returns ErrCorruptRecord
+func TestFinalityProviderStoreCorruptedRecord(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	bucketName := []byte("finalityProviders")
+
+	tcs := []struct {
+		name    string
+		corrupt func(key []byte, fp *proto.FinalityProvider) []byte
+	}{
+		{
+			"invalid proto encoding",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				return []byte{0xff, 0xff, 0xff}
+			},
+		},
+		{
+			"truncated record",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				fpBytes, err := pm.Marshal(fp)
+				require.NoError(t, err)
+				return fpBytes[:len(fpBytes)/2]
+			},
+		},
+		{
+			"BTC public key mismatching the key",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				_, otherPk, err := datagen.GenRandomBTCKeyPair(r)
+				require.NoError(t, err)
+				fp.BtcPk = schnorr.SerializePubKey(otherPk)
+				fpBytes, err := pm.Marshal(fp)
+				require.NoError(t, err)
+				return fpBytes
+			},
+		},
+		{
+			"missing proof of possession",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				fp.Pop = nil
+				fpBytes, err := pm.Marshal(fp)
+				require.NoError(t, err)
+				return fpBytes
+			},
+		},
+		{
+			"unknown status",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				fp.Status = proto.FinalityProviderStatus(100)
+				fpBytes, err := pm.Marshal(fp)
+				require.NoError(t, err)
+				return fpBytes
+			},
+		},
+		{
+			"invalid commission",
+			func(key []byte, fp *proto.FinalityProvider) []byte {
+				fp.Commission = "not-a-number"
+				fpBytes, err := pm.Marshal(fp)
+				require.NoError(t, err)
+				return fpBytes
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			homePath := t.TempDir()
+			cfg := config.DefaultDBConfigWithHomePath(homePath)
+			fpdb, err := cfg.GetDbBackend()
+			require.NoError(t, err)
+			defer func() {
+				err := fpdb.Close()
+				require.NoError(t, err)
+			}()
+			fps, err := fpstore.NewFinalityProviderStore(fpdb)
+			require.NoError(t, err)
+
+			fp := testutil.GenRandomFinalityProvider(r, t)
+			err = fps.CreateFinalityProvider(
+				sdk.MustAccAddressFromBech32(fp.FPAddr),
+				fp.BtcPk,
+				fp.Description,
+				fp.Commission,
+				nil,
+				fp.KeyName,
+				fp.ChainID,
+				fp.Pop.BtcSig,
+			)
+			require.NoError(t, err)
+
+			// overwrite the stored record with the corrupted one
+			key := schnorr.SerializePubKey(fp.BtcPk)
+			err = kvdb.Update(fpdb, func(tx kvdb.RwTx) error {
+				bucket := tx.ReadWriteBucket(bucketName)
+				var fpProto proto.FinalityProvider
+				if err := pm.Unmarshal(bucket.Get(key), &fpProto); err != nil {
+					return err
+				}
+				return bucket.Put(key, tc.corrupt(key, &fpProto))
+			}, func() {})
+			require.NoError(t, err)
+
+			var corruptErr *fpstore.ErrCorruptRecord
+
+			_, err = fps.GetFinalityProvider(fp.BtcPk)
+			require.ErrorAs(t, err, &corruptErr)
+			require.Equal(t, string(bucketName), corruptErr.Bucket)
+			require.Equal(t, key, corruptErr.Key)
+
+			_, err = fps.GetAllStoredFinalityProviders()
+			require.ErrorAs(t, err, &corruptErr)
+		})
+	}
+}
+
+// TestFinalityProviderRecordVersion tests that the records written before the
+// versioning are migrated when the store is opened and that the records
+// written by a newer release are refused
+func TestFinalityProviderRecordVersion(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	bucketName := []byte("finalityProviders")
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	fpdb, err := cfg.GetDbBackend()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, fpdb.Close())
+	}()
+	fps, err := fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+
+	oldFp := testutil.GenRandomFinalityProvider(r, t)
+	newFp := testutil.GenRandomFinalityProvider(r, t)
+	for _, fp := range []*fpstore.StoredFinalityProvider{oldFp, newFp} {
+		err = fps.CreateFinalityProvider(sdk.MustAccAddressFromBech32(fp.FPAddr), fp.BtcPk, fp.Description,
+			fp.Commission, nil, fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+	}
+
+	setVersion := func(fp *fpstore.StoredFinalityProvider, version uint32) {
+		key := schnorr.SerializePubKey(fp.BtcPk)
+		err := kvdb.Update(fpdb, func(tx kvdb.RwTx) error {
+			bucket := tx.ReadWriteBucket(bucketName)
+			var fpProto proto.FinalityProvider
+			if err := pm.Unmarshal(bucket.Get(key), &fpProto); err != nil {
+				return err
+			}
+			fpProto.Version = version
+			fpBytes, err := pm.Marshal(&fpProto)
+			if err != nil {
+				return err
+			}
+			return bucket.Put(key, fpBytes)
+		}, func() {})
+		require.NoError(t, err)
+	}
+	getVersion := func(fp *fpstore.StoredFinalityProvider) uint32 {
+		var fpProto proto.FinalityProvider
+		err := kvdb.View(fpdb, func(tx kvdb.RTx) error {
+			return pm.Unmarshal(tx.ReadBucket(bucketName).Get(schnorr.SerializePubKey(fp.BtcPk)), &fpProto)
+		}, func() {})
+		require.NoError(t, err)
+		return fpProto.Version
+	}
+	require.Equal(t, fpstore.FinalityProviderRecordVersion, getVersion(oldFp))
+
+	setVersion(oldFp, 0)
+	setVersion(newFp, fpstore.FinalityProviderRecordVersion+1)
+
+	// the outdated record is still readable before the migration
+	storedFp, err := fps.GetFinalityProvider(oldFp.BtcPk)
+	require.NoError(t, err)
+	require.Equal(t, oldFp.KeyName, storedFp.KeyName)
+	_, err = fps.GetFinalityProvider(newFp.BtcPk)
+	require.ErrorIs(t, err, fpstore.ErrUnsupportedRecordVersion)
+
+	// reopening the store migrates the outdated record only
+	fps, err = fpstore.NewFinalityProviderStore(fpdb)
+	require.NoError(t, err)
+	require.Equal(t, fpstore.FinalityProviderRecordVersion, getVersion(oldFp))
+	require.Equal(t, fpstore.FinalityProviderRecordVersion+1, getVersion(newFp))
+	migrated, err := fps.MigrateFinalityProviders()
+	require.NoError(t, err)
+	require.Zero(t, migrated)
+}
@@ -0,0 +1,43 @@
+package store
+
+import (
+	sdkmath "cosmossdk.io/math"
+	"github.com/btcsuite/btcd/btcec/v2"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// FpStore keeps the finality providers managed by the daemon together with
+// the blocks they voted for. FinalityProviderStore persists them in the
+// database while MemFinalityProviderStore keeps them in memory
+type FpStore interface {
+	CreateFinalityProvider(
+		fpAddr sdk.AccAddress,
+		btcPk *btcec.PublicKey,
+		description *stakingtypes.Description,
+		commission, maxCommission *sdkmath.LegacyDec,
+		keyName, chainId string,
+		btcSig []byte,
+	) error
+	GetFinalityProvider(btcPk *btcec.PublicKey) (*StoredFinalityProvider, error)
+	GetAllStoredFinalityProviders() ([]*StoredFinalityProvider, error)
+
+	SetFpStatus(btcPk *btcec.PublicKey, status proto.FinalityProviderStatus) error
+	UpdateFpStatusFromVotingPower(vp uint64, fp *StoredFinalityProvider) (proto.FinalityProviderStatus, error)
+	SetFpLastVotedHeight(btcPk *btcec.PublicKey, lastVotedHeight uint64) error
+	SetFpLastProcessedHeight(btcPk *btcec.PublicKey, lastProcessedHeight uint64) error
+	SetFpDescription(btcPk *btcec.PublicKey, desc *stakingtypes.Description, rate *sdkmath.LegacyDec) error
+	SetFpRewardAddress(btcPk *btcec.PublicKey, rewardAddress string) error
+	SetFpPaused(btcPk *btcec.PublicKey, paused bool) error
+
+	GuardVote(btcPk *btcec.PublicKey, height uint64, blockHash []byte) error
+	GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error)
+	GetPendingVotes(btcPk *btcec.PublicKey) ([]*VotedBlock, error)
+}
+
+var (
+	_ FpStore = (*FinalityProviderStore)(nil)
+	_ FpStore = (*MemFinalityProviderStore)(nil)
+)
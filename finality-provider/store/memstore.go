@@ -0,0 +1,210 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// MemFinalityProviderStore is an FpStore that keeps the finality providers in
+// memory, e.g., for unit tests. Its content is lost when the process exits
+type MemFinalityProviderStore struct {
+	mu sync.Mutex
+	// mapping: serialized pk -> finality provider
+	fps map[string]*proto.FinalityProvider
+	// mapping: serialized pk -> height -> hash of the block voted for
+	votedBlocks map[string]map[uint64][]byte
+}
+
+// NewMemFinalityProviderStore returns a new empty in-memory store
+func NewMemFinalityProviderStore() *MemFinalityProviderStore {
+	return &MemFinalityProviderStore{
+		fps:         make(map[string]*proto.FinalityProvider),
+		votedBlocks: make(map[string]map[uint64][]byte),
+	}
+}
+
+func (s *MemFinalityProviderStore) CreateFinalityProvider(
+	fpAddr sdk.AccAddress,
+	btcPk *btcec.PublicKey,
+	description *stakingtypes.Description,
+	commission, maxCommission *sdkmath.LegacyDec,
+	keyName, chainId string,
+	btcSig []byte,
+) error {
+	fp, err := newProtoFinalityProvider(fpAddr, btcPk, description, commission, maxCommission, keyName, chainId, btcSig)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.fps[string(fp.BtcPk)]; ok {
+		return ErrDuplicateFinalityProvider
+	}
+	s.fps[string(fp.BtcPk)] = fp
+
+	return nil
+}
+
+func (s *MemFinalityProviderStore) GetFinalityProvider(btcPk *btcec.PublicKey) (*StoredFinalityProvider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fp, ok := s.fps[string(schnorr.SerializePubKey(btcPk))]
+	if !ok {
+		return nil, ErrFinalityProviderNotFound
+	}
+
+	return protoFpToStoredFinalityProvider(fp)
+}
+
+// GetAllStoredFinalityProviders returns all the finality providers in the
+// order of their keys, as the database does
+func (s *MemFinalityProviderStore) GetAllStoredFinalityProviders() ([]*StoredFinalityProvider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.fps))
+	for k := range s.fps {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var storedFps []*StoredFinalityProvider
+	for _, k := range keys {
+		storedFp, err := protoFpToStoredFinalityProvider(s.fps[k])
+		if err != nil {
+			return nil, err
+		}
+		storedFps = append(storedFps, storedFp)
+	}
+
+	return storedFps, nil
+}
+
+func (s *MemFinalityProviderStore) SetFpStatus(btcPk *btcec.PublicKey, status proto.FinalityProviderStatus) error {
+	return s.setFinalityProviderState(btcPk, setFpStatus(status))
+}
+
+func (s *MemFinalityProviderStore) UpdateFpStatusFromVotingPower(
+	vp uint64,
+	fp *StoredFinalityProvider,
+) (proto.FinalityProviderStatus, error) {
+	return updateFpStatusFromVotingPower(s, vp, fp)
+}
+
+func (s *MemFinalityProviderStore) SetFpLastVotedHeight(btcPk *btcec.PublicKey, lastVotedHeight uint64) error {
+	return s.setFinalityProviderState(btcPk, setFpLastVotedHeight(lastVotedHeight))
+}
+
+func (s *MemFinalityProviderStore) SetFpLastProcessedHeight(btcPk *btcec.PublicKey, lastProcessedHeight uint64) error {
+	return s.setFinalityProviderState(btcPk, setFpLastProcessedHeight(lastProcessedHeight))
+}
+
+func (s *MemFinalityProviderStore) SetFpDescription(btcPk *btcec.PublicKey, desc *stakingtypes.Description, rate *sdkmath.LegacyDec) error {
+	return s.setFinalityProviderState(btcPk, setFpDescription(desc, rate))
+}
+
+func (s *MemFinalityProviderStore) SetFpRewardAddress(btcPk *btcec.PublicKey, rewardAddress string) error {
+	return s.setFinalityProviderState(btcPk, setFpRewardAddress(rewardAddress))
+}
+
+func (s *MemFinalityProviderStore) SetFpPaused(btcPk *btcec.PublicKey, paused bool) error {
+	return s.setFinalityProviderState(btcPk, setFpPaused(paused))
+}
+
+// setFinalityProviderState applies the transition to a copy of the finality
+// provider, so that a failed transition leaves it untouched as the database
+// transaction would
+func (s *MemFinalityProviderStore) setFinalityProviderState(
+	btcPk *btcec.PublicKey,
+	stateTransitionFn func(provider *proto.FinalityProvider) error,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := string(schnorr.SerializePubKey(btcPk))
+	fp, ok := s.fps[key]
+	if !ok {
+		return ErrFinalityProviderNotFound
+	}
+
+	updated := pm.Clone(fp).(*proto.FinalityProvider)
+	if err := stateTransitionFn(updated); err != nil {
+		return err
+	}
+	s.fps[key] = updated
+
+	return nil
+}
+
+func (s *MemFinalityProviderStore) GuardVote(btcPk *btcec.PublicKey, height uint64, blockHash []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := string(schnorr.SerializePubKey(btcPk))
+	fp, ok := s.fps[key]
+	if !ok {
+		return ErrFinalityProviderNotFound
+	}
+
+	votedHash := s.votedBlocks[key][height]
+	if err := checkVote(votedHash, height, fp.LastVotedHeight, blockHash); err != nil {
+		return err
+	}
+	if votedHash != nil {
+		return nil
+	}
+
+	if s.votedBlocks[key] == nil {
+		s.votedBlocks[key] = make(map[uint64][]byte)
+	}
+	s.votedBlocks[key][height] = append([]byte{}, blockHash...)
+
+	return nil
+}
+
+func (s *MemFinalityProviderStore) GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := s.votedBlocks[string(schnorr.SerializePubKey(btcPk))][height]
+	if v == nil {
+		return nil, nil
+	}
+
+	return append([]byte{}, v...), nil
+}
+
+func (s *MemFinalityProviderStore) GetPendingVotes(btcPk *btcec.PublicKey) ([]*VotedBlock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := string(schnorr.SerializePubKey(btcPk))
+	fp, ok := s.fps[key]
+	if !ok {
+		return nil, ErrFinalityProviderNotFound
+	}
+
+	var votes []*VotedBlock
+	for height, blockHash := range s.votedBlocks[key] {
+		if height > fp.LastVotedHeight {
+			votes = append(votes, &VotedBlock{Height: height, BlockHash: append([]byte{}, blockHash...)})
+		}
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].Height < votes[j].Height
+	})
+
+	return votes, nil
+}
@@ -0,0 +1,85 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzMemFinalityProviderStore tests that the in-memory store behaves as the
+// one backed by the database given the same operations
+func FuzzMemFinalityProviderStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		dbStore, err := fpstore.NewFinalityProviderStore(db)
+		require.NoError(t, err)
+		memStore := fpstore.NewMemFinalityProviderStore()
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		height := uint64(r.Int63n(1000) + 1)
+		blockHash := testutil.GenRandomByteArray(r, 32)
+		lastVotedHeight := height + uint64(r.Int63n(10)+1)
+		pendingHash := testutil.GenRandomByteArray(r, 32)
+
+		results := make([][]interface{}, 0, 2)
+		for _, s := range []fpstore.FpStore{dbStore, memStore} {
+			var res []interface{}
+			_, err := s.GetFinalityProvider(fp.BtcPk)
+			res = append(res, err)
+			res = append(res, s.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, nil, fp.KeyName, fp.ChainID, fp.Pop.BtcSig))
+			res = append(res, s.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, nil, fp.KeyName, fp.ChainID, fp.Pop.BtcSig))
+
+			storedFp, err := s.GetFinalityProvider(fp.BtcPk)
+			require.NoError(t, err)
+			newStatus, err := s.UpdateFpStatusFromVotingPower(0, storedFp)
+			res = append(res, newStatus, err)
+			res = append(res, s.SetFpRewardAddress(fp.BtcPk, fp.FPAddr))
+			res = append(res, s.SetFpPaused(fp.BtcPk, true))
+
+			res = append(res, s.GuardVote(fp.BtcPk, height, blockHash))
+			res = append(res, s.GuardVote(fp.BtcPk, height, testutil.GenRandomByteArray(r, 32)) != nil)
+			res = append(res, s.SetFpLastVotedHeight(fp.BtcPk, lastVotedHeight))
+			res = append(res, s.SetFpLastProcessedHeight(fp.BtcPk, lastVotedHeight-1))
+			res = append(res, s.GuardVote(fp.BtcPk, lastVotedHeight, blockHash) != nil)
+			res = append(res, s.GuardVote(fp.BtcPk, lastVotedHeight+1, pendingHash))
+
+			votedHash, err := s.GetVotedBlockHash(fp.BtcPk, height)
+			res = append(res, votedHash, err)
+			pending, err := s.GetPendingVotes(fp.BtcPk)
+			res = append(res, pending, err)
+
+			storedFp, err = s.GetFinalityProvider(fp.BtcPk)
+			require.NoError(t, err)
+			res = append(res, storedFp.ToFinalityProviderInfo())
+			allFps, err := s.GetAllStoredFinalityProviders()
+			require.NoError(t, err)
+			res = append(res, len(allFps))
+
+			results = append(results, res)
+		}
+		require.Equal(t, results[0], results[1])
+
+		storedFp, err := memStore.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.Equal(t, proto.FinalityProviderStatus_REGISTERED, storedFp.Status)
+		require.Equal(t, lastVotedHeight, storedFp.LastProcessedHeight)
+		require.True(t, storedFp.Paused)
+	})
+}
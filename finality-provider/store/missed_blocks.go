@@ -0,0 +1,185 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// The reasons why a finality provider failed to vote for a block
+const (
+	// MissedReasonNoRandomness means that no public randomness was committed
+	// or stored for the height of the block
+	MissedReasonNoRandomness = "no_randomness"
+	// MissedReasonNotTimestamped means that the public randomness of the
+	// block was committed in a Babylon epoch not finalized on Bitcoin yet
+	MissedReasonNotTimestamped = "not_timestamped"
+	// MissedReasonRPCFailure means that the finality signature could not be
+	// submitted to the consumer chain
+	MissedReasonRPCFailure = "rpc_failure"
+	// MissedReasonLate means that the block was finalized before the finality
+	// signature was submitted
+	MissedReasonLate = "late"
+	// MissedReasonSkippedFinalized means that the vote was skipped on purpose
+	// as the block was already finalized, so the block is recorded but not
+	// counted as missed
+	MissedReasonSkippedFinalized = "skipped_finalized"
+)
+
+var (
+	// mapping: fp_pk || height -> missed block
+	missedBlockBucketName = []byte("missed_blocks")
+)
+
+// MissedBlock is a block that a finality provider failed to vote for
+type MissedBlock struct {
+	Height uint64 `json:"height"`
+	Reason string `json:"reason"`
+	// Error is the error of the last attempt to vote, if any
+	Error string `json:"error,omitempty"`
+	// Timestamp is the unix time in seconds at which the block was missed
+	Timestamp int64 `json:"timestamp"`
+}
+
+type MissedBlockStore struct {
+	db kvdb.Backend
+}
+
+// NewMissedBlockStore returns a new store backed by db
+func NewMissedBlockStore(db kvdb.Backend) (*MissedBlockStore, error) {
+	store := &MissedBlockStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *MissedBlockStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(missedBlockBucketName)
+		return err
+	})
+}
+
+// AddMissedBlock records a block that the given finality provider failed to
+// vote for. It overwrites any record at the same height
+func (s *MissedBlockStore) AddMissedBlock(fpPk *btcec.PublicKey, mb *MissedBlock) error {
+	v, err := json.Marshal(mb)
+	if err != nil {
+		return err
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(missedBlockBucketName)
+		if bucket == nil {
+			return ErrCorruptedMissedBlockDb
+		}
+
+		return bucket.Put(missedBlockKey(fpPk, mb.Height), v)
+	})
+}
+
+// GetMissedBlocks returns the blocks that the given finality provider missed
+// in [startHeight, endHeight] in the ascending order of height, up to limit
+// blocks, together with the total number of blocks missed in the range. An
+// endHeight or limit of 0 means no bound. The blocks skipped on purpose are
+// not returned, see GetSkippedBlocks
+func (s *MissedBlockStore) GetMissedBlocks(
+	fpPk *btcec.PublicKey,
+	startHeight, endHeight, limit uint64,
+) ([]*MissedBlock, uint64, error) {
+	return s.getBlocks(fpPk, startHeight, endHeight, limit, false)
+}
+
+// GetSkippedBlocks returns the blocks whose votes the given finality provider
+// skipped on purpose as they were already finalized, like GetMissedBlocks
+func (s *MissedBlockStore) GetSkippedBlocks(
+	fpPk *btcec.PublicKey,
+	startHeight, endHeight, limit uint64,
+) ([]*MissedBlock, uint64, error) {
+	return s.getBlocks(fpPk, startHeight, endHeight, limit, true)
+}
+
+func (s *MissedBlockStore) getBlocks(
+	fpPk *btcec.PublicKey,
+	startHeight, endHeight, limit uint64,
+	skipped bool,
+) ([]*MissedBlock, uint64, error) {
+	prefix := schnorr.SerializePubKey(fpPk)
+	var (
+		missedBlocks []*MissedBlock
+		total        uint64
+	)
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(missedBlockBucketName)
+		if bucket == nil {
+			return ErrCorruptedMissedBlockDb
+		}
+
+		c := bucket.ReadCursor()
+		for k, v := c.Seek(missedBlockKey(fpPk, startHeight)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if len(k) != len(prefix)+8 {
+				return newErrCorruptRecord(missedBlockBucketName, k, fmt.Errorf("invalid key length"))
+			}
+			if endHeight > 0 && binary.BigEndian.Uint64(k[len(prefix):]) > endHeight {
+				break
+			}
+
+			var mb MissedBlock
+			if err := json.Unmarshal(v, &mb); err != nil {
+				return newErrCorruptRecord(missedBlockBucketName, k, err)
+			}
+			if (mb.Reason == MissedReasonSkippedFinalized) != skipped {
+				continue
+			}
+			total++
+			if limit > 0 && uint64(len(missedBlocks)) >= limit {
+				continue
+			}
+			missedBlocks = append(missedBlocks, &mb)
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return missedBlocks, total, nil
+}
+
+// PruneMissedBlocks deletes the records of the blocks below the given height
+// missed by any finality provider and returns the number of deleted records
+func (s *MissedBlockStore) PruneMissedBlocks(height uint64) (uint64, error) {
+	var deleted uint64
+	err := kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(missedBlockBucketName)
+		if bucket == nil {
+			return ErrCorruptedMissedBlockDb
+		}
+
+		return deleteKeys(bucket, func(k []byte) bool {
+			return len(k) == schnorr.PubKeyBytesLen+8 &&
+				binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:]) < height
+		}, &deleted)
+	}, func() {
+		deleted = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func missedBlockKey(fpPk *btcec.PublicKey, height uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, height)
+}
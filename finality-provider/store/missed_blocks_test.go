@@ -0,0 +1,88 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzMissedBlockStore tests that the missed blocks are listed by range and
+// limit, and separately for each finality provider
+func FuzzMissedBlockStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewMissedBlockStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		otherFp := testutil.GenRandomFinalityProvider(r, t)
+		reasons := []string{fpstore.MissedReasonNoRandomness, fpstore.MissedReasonRPCFailure, fpstore.MissedReasonLate}
+
+		// miss every other block starting from a random height
+		startHeight := uint64(r.Int63n(1000) + 1)
+		numMissed := uint64(r.Int63n(20) + 5)
+		for i := uint64(0); i < numMissed; i++ {
+			err := s.AddMissedBlock(fp.BtcPk, &fpstore.MissedBlock{
+				Height: startHeight + 2*i,
+				Reason: reasons[r.Intn(len(reasons))],
+				Error:  testutil.GenRandomHexStr(r, 8),
+			})
+			require.NoError(t, err)
+		}
+		err = s.AddMissedBlock(otherFp.BtcPk, &fpstore.MissedBlock{Height: startHeight, Reason: fpstore.MissedReasonLate})
+		require.NoError(t, err)
+		// a block skipped on purpose is not listed as missed
+		err = s.AddMissedBlock(fp.BtcPk, &fpstore.MissedBlock{Height: startHeight + 1, Reason: fpstore.MissedReasonSkippedFinalized})
+		require.NoError(t, err)
+		skippedBlocks, total, err := s.GetSkippedBlocks(fp.BtcPk, 0, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), total)
+		require.Equal(t, startHeight+1, skippedBlocks[0].Height)
+
+		missedBlocks, total, err := s.GetMissedBlocks(fp.BtcPk, 0, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, numMissed, total)
+		require.Len(t, missedBlocks, int(numMissed))
+		for i, mb := range missedBlocks {
+			require.Equal(t, startHeight+2*uint64(i), mb.Height)
+		}
+
+		// the range is inclusive and the total ignores the limit
+		missedBlocks, total, err = s.GetMissedBlocks(fp.BtcPk, startHeight+2, startHeight+6, 2)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), total)
+		require.Len(t, missedBlocks, 2)
+		require.Equal(t, startHeight+2, missedBlocks[0].Height)
+		require.Equal(t, startHeight+4, missedBlocks[1].Height)
+
+		missedBlocks, total, err = s.GetMissedBlocks(otherFp.BtcPk, 0, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), total)
+		require.Equal(t, fpstore.MissedReasonLate, missedBlocks[0].Reason)
+
+		// the blocks below the height are pruned for all the finality providers
+		deleted, err := s.PruneMissedBlocks(startHeight + 4)
+		require.NoError(t, err)
+		require.Equal(t, uint64(4), deleted)
+		missedBlocks, total, err = s.GetMissedBlocks(fp.BtcPk, 0, 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, numMissed-2, total)
+		require.Equal(t, startHeight+4, missedBlocks[0].Height)
+		_, total, err = s.GetMissedBlocks(otherFp.BtcPk, 0, 0, 0)
+		require.NoError(t, err)
+		require.Zero(t, total)
+	})
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"bytes"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// pruneHistory deletes the records of the given bucket up to the last one
+// below the given height in each group of records, which share the first
+// groupLen bytes of their keys. heightOf returns the height of a record and
+// fails if its key is shorter than groupLen or it can't be decoded. The records without height, as returned by
+// heightOf, are deleted only if they precede a record below the height, so
+// that the remaining history of each group stays contiguous. It returns the
+// number of deleted records
+func pruneHistory(
+	db kvdb.Backend,
+	bucketName []byte,
+	corruptedErr error,
+	groupLen int,
+	height uint64,
+	heightOf func(k, v []byte) (uint64, error),
+) (uint64, error) {
+	var deleted uint64
+	err := kvdb.Update(db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(bucketName)
+		if bucket == nil {
+			return corruptedErr
+		}
+
+		// the last key below the height of each group
+		lastBelow := make(map[string][]byte)
+		err := bucket.ForEach(func(k, v []byte) error {
+			h, err := heightOf(k, v)
+			if err != nil {
+				return newErrCorruptRecord(bucketName, k, err)
+			}
+			if h > 0 && h < height {
+				lastBelow[string(k[:groupLen])] = append([]byte{}, k...)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return deleteKeys(bucket, func(k []byte) bool {
+			last, ok := lastBelow[string(k[:groupLen])]
+			return ok && bytes.Compare(k, last) <= 0
+		}, &deleted)
+	}, func() {
+		deleted = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// deleteKeys deletes the keys of the given bucket matched by the given
+// function and adds their number to deleted. The keys are collected first
+// as the bucket can't be modified while it is iterated
+func deleteKeys(bucket walletdb.ReadWriteBucket, match func(k []byte) bool, deleted *uint64) error {
+	var keys [][]byte
+	err := bucket.ForEach(func(k, _ []byte) error {
+		if match(k) {
+			keys = append(keys, append([]byte{}, k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := bucket.Delete(k); err != nil {
+			return err
+		}
+	}
+	*deleted += uint64(len(keys))
+
+	return nil
+}
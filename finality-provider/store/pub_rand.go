@@ -1,18 +1,46 @@
 package store
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/cometbft/cometbft/crypto/merkle"
+	cmtcrypto "github.com/cometbft/cometbft/proto/tendermint/crypto"
 	"github.com/lightningnetwork/lnd/kvdb"
 )
 
 var (
 	// mapping: pub_rand -> proof
 	pubRandProofBucketName = []byte("pub_rand_proof")
+
+	// mapping: fp_pk || start_height -> num_pub_rand || commitment
+	pubRandCommitBucketName = []byte("pub_rand_commit")
 )
 
+// PubRandCommit is a commitment of public randomness made by a finality
+// provider over the heights [StartHeight, StartHeight+NumPubRand-1]
+type PubRandCommit struct {
+	StartHeight uint64
+	NumPubRand  uint64
+	Commitment  []byte
+}
+
+// EndHeight returns the last height covered by the commitment
+func (c *PubRandCommit) EndHeight() uint64 {
+	return c.StartHeight + c.NumPubRand - 1
+}
+
+// Equal returns whether both commitments cover the same heights with the same
+// randomness
+func (c *PubRandCommit) Equal(other *PubRandCommit) bool {
+	return c.StartHeight == other.StartHeight &&
+		c.NumPubRand == other.NumPubRand &&
+		bytes.Equal(c.Commitment, other.Commitment)
+}
+
 type PubRandProofStore struct {
 	db kvdb.Backend
 }
@@ -29,7 +57,10 @@ func NewPubRandProofStore(db kvdb.Backend) (*PubRandProofStore, error) {
 
 func (s *PubRandProofStore) initBuckets() error {
 	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
-		_, err := tx.CreateTopLevelBucket(pubRandProofBucketName)
+		if _, err := tx.CreateTopLevelBucket(pubRandProofBucketName); err != nil {
+			return err
+		}
+		_, err := tx.CreateTopLevelBucket(pubRandCommitBucketName)
 		return err
 	})
 }
@@ -37,10 +68,26 @@ func (s *PubRandProofStore) initBuckets() error {
 func (s *PubRandProofStore) AddPubRandProofList(
 	pubRandList []*btcec.FieldVal,
 	proofList []*merkle.Proof,
+) error {
+	return s.AddPubRandProofListAndCommit(nil, nil, pubRandList, proofList)
+}
+
+// AddPubRandProofListAndCommit saves the inclusion proofs of the given public
+// randomness together with the commitment over them in a single transaction,
+// so that a crash cannot leave the proofs saved without the commitment or the
+// other way around. Only the proofs are saved if the commitment is nil
+func (s *PubRandProofStore) AddPubRandProofListAndCommit(
+	fpPk *btcec.PublicKey,
+	commit *PubRandCommit,
+	pubRandList []*btcec.FieldVal,
+	proofList []*merkle.Proof,
 ) error {
 	if len(pubRandList) != len(proofList) {
 		return fmt.Errorf("the number of public randomness is not same as the number of proofs")
 	}
+	if commit != nil && commit.NumPubRand == 0 {
+		return fmt.Errorf("the commitment must cover at least one height")
+	}
 
 	pubRandBytesList := [][]byte{}
 	proofBytesList := [][]byte{}
@@ -71,7 +118,11 @@ func (s *PubRandProofStore) AddPubRandProofList(
 			}
 		}
 
-		return nil
+		if commit == nil {
+			return nil
+		}
+
+		return putPubRandCommit(tx, fpPk, commit)
 	})
 }
 
@@ -90,7 +141,7 @@ func (s *PubRandProofStore) GetPubRandProof(pubRand *btcec.FieldVal) ([]byte, er
 			return ErrPubRandProofNotFound
 		}
 
-		return nil
+		return validatePubRandProof(pubRandBytes[:], proofBytes)
 	}, func() {})
 
 	if err != nil {
@@ -120,6 +171,9 @@ func (s *PubRandProofStore) GetPubRandProofList(pubRandList []*btcec.FieldVal) (
 			if proofBytes == nil {
 				return ErrPubRandProofNotFound
 			}
+			if err := validatePubRandProof(pubRandBytesList[i], proofBytes); err != nil {
+				return err
+			}
 			proofBytesList = append(proofBytesList, proofBytes)
 		}
 
@@ -133,4 +187,143 @@ func (s *PubRandProofStore) GetPubRandProofList(pubRandList []*btcec.FieldVal) (
 	return proofBytesList, nil
 }
 
+// AddPubRandCommit records the commitment of public randomness made by the
+// given finality provider. It overwrites any commitment with the same start
+// height
+func (s *PubRandProofStore) AddPubRandCommit(fpPk *btcec.PublicKey, commit *PubRandCommit) error {
+	if commit.NumPubRand == 0 {
+		return fmt.Errorf("the commitment must cover at least one height")
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		return putPubRandCommit(tx, fpPk, commit)
+	})
+}
+
+func putPubRandCommit(tx kvdb.RwTx, fpPk *btcec.PublicKey, commit *PubRandCommit) error {
+	bucket := tx.ReadWriteBucket(pubRandCommitBucketName)
+	if bucket == nil {
+		return ErrCorruptedPubRandProofDb
+	}
+
+	v := make([]byte, 8, 8+len(commit.Commitment))
+	binary.BigEndian.PutUint64(v, commit.NumPubRand)
+	v = append(v, commit.Commitment...)
+
+	return bucket.Put(pubRandCommitKey(fpPk, commit.StartHeight), v)
+}
+
+// GetOverlappingPubRandCommits returns the recorded commitments of the given
+// finality provider that cover any height in [startHeight, endHeight]
+func (s *PubRandProofStore) GetOverlappingPubRandCommits(
+	fpPk *btcec.PublicKey,
+	startHeight, endHeight uint64,
+) ([]*PubRandCommit, error) {
+	prefix := schnorr.SerializePubKey(fpPk)
+	var commits []*PubRandCommit
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(pubRandCommitBucketName)
+		if bucket == nil {
+			return ErrCorruptedPubRandProofDb
+		}
+
+		// the commitments are sorted by their start heights, so the scan
+		// stops at the first one starting after the end height
+		c := bucket.ReadCursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if len(k) != len(prefix)+8 || len(v) < 8 {
+				return newErrCorruptRecord(pubRandCommitBucketName, k, fmt.Errorf("invalid length"))
+			}
+			commit := &PubRandCommit{
+				StartHeight: binary.BigEndian.Uint64(k[len(prefix):]),
+				NumPubRand:  binary.BigEndian.Uint64(v[:8]),
+				Commitment:  append([]byte{}, v[8:]...),
+			}
+			if commit.StartHeight > endHeight {
+				break
+			}
+			if commit.NumPubRand > 0 && commit.EndHeight() >= startHeight {
+				commits = append(commits, commit)
+			}
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
+
+// DeletePubRandCommitsAfter removes the recorded commitments of the given
+// finality provider that start after the given height and returns them. The
+// inclusion proofs are kept, as the randomness of each height is derived
+// deterministically and thus remains the same across commitments
+func (s *PubRandProofStore) DeletePubRandCommitsAfter(fpPk *btcec.PublicKey, height uint64) ([]*PubRandCommit, error) {
+	prefix := schnorr.SerializePubKey(fpPk)
+	var deleted []*PubRandCommit
+
+	err := kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		deleted = nil
+		bucket := tx.ReadWriteBucket(pubRandCommitBucketName)
+		if bucket == nil {
+			return ErrCorruptedPubRandProofDb
+		}
+
+		var keys [][]byte
+		c := bucket.ReadWriteCursor()
+		for k, v := c.Seek(pubRandCommitKey(fpPk, height+1)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if len(k) != len(prefix)+8 || len(v) < 8 {
+				return newErrCorruptRecord(pubRandCommitBucketName, k, fmt.Errorf("invalid length"))
+			}
+			deleted = append(deleted, &PubRandCommit{
+				StartHeight: binary.BigEndian.Uint64(k[len(prefix):]),
+				NumPubRand:  binary.BigEndian.Uint64(v[:8]),
+				Commitment:  append([]byte{}, v[8:]...),
+			})
+			keys = append(keys, append([]byte{}, k...))
+		}
+
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+func pubRandCommitKey(fpPk *btcec.PublicKey, startHeight uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, startHeight)
+}
+
+// validatePubRandProof checks that the proof stored under the given public
+// randomness decodes into a valid merkle proof. Any failure is reported as
+// ErrCorruptRecord
+func validatePubRandProof(key, v []byte) error {
+	if len(v) == 0 {
+		return newErrCorruptRecord(pubRandProofBucketName, key, fmt.Errorf("empty value"))
+	}
+
+	var proofProto cmtcrypto.Proof
+	if err := proofProto.Unmarshal(v); err != nil {
+		return newErrCorruptRecord(pubRandProofBucketName, key, err)
+	}
+
+	if _, err := merkle.ProofFromProto(&proofProto); err != nil {
+		return newErrCorruptRecord(pubRandProofBucketName, key, err)
+	}
+
+	return nil
+}
+
 // TODO: delete function?
@@ -0,0 +1,137 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	"github.com/babylonlabs-io/babylon/testutil/datagen"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzPubRandProofStore tests that stored proofs can be retrieved and that
+// corrupted proofs are reported as ErrCorruptRecord
+func FuzzPubRandProofStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		homePath := t.TempDir()
+		cfg := config.DefaultDBConfigWithHomePath(homePath)
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			err := db.Close()
+			require.NoError(t, err)
+		}()
+		s, err := fpstore.NewPubRandProofStore(db)
+		require.NoError(t, err)
+
+		numPubRand := int(r.Int31n(10) + 2)
+		pubRandList := make([]*btcec.FieldVal, 0, numPubRand)
+		leaves := make([][]byte, 0, numPubRand)
+		for i := 0; i < numPubRand; i++ {
+			_, pubRand, err := eots.RandGen(r)
+			require.NoError(t, err)
+			pubRandList = append(pubRandList, pubRand)
+			pubRandBytes := *pubRand.Bytes()
+			leaves = append(leaves, pubRandBytes[:])
+		}
+		_, proofList := merkle.ProofsFromByteSlices(leaves)
+
+		err = s.AddPubRandProofList(pubRandList, proofList)
+		require.NoError(t, err)
+
+		proofBytesList, err := s.GetPubRandProofList(pubRandList)
+		require.NoError(t, err)
+		require.Len(t, proofBytesList, numPubRand)
+
+		// corrupt the proof of a random public randomness
+		corrupted := pubRandList[r.Intn(numPubRand)]
+		corruptedBytes := *corrupted.Bytes()
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			bucket := tx.ReadWriteBucket([]byte("pub_rand_proof"))
+			return bucket.Put(corruptedBytes[:], testutil.GenRandomByteArray(r, 7))
+		}, func() {})
+		require.NoError(t, err)
+
+		var corruptErr *fpstore.ErrCorruptRecord
+		_, err = s.GetPubRandProof(corrupted)
+		require.ErrorAs(t, err, &corruptErr)
+		require.Equal(t, corruptedBytes[:], corruptErr.Key)
+
+		_, err = s.GetPubRandProofList(pubRandList)
+		require.ErrorAs(t, err, &corruptErr)
+	})
+}
+
+// FuzzAddPubRandProofListAndCommit tests that the proofs of public randomness
+// and the commitment over them are saved all together or not at all
+func FuzzAddPubRandProofListAndCommit(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewPubRandProofStore(db)
+		require.NoError(t, err)
+
+		fpPk, err := datagen.GenRandomBIP340PubKey(r)
+		require.NoError(t, err)
+
+		numPubRand := int(r.Int31n(10) + 2)
+		pubRandList := make([]*btcec.FieldVal, 0, numPubRand)
+		leaves := make([][]byte, 0, numPubRand)
+		for i := 0; i < numPubRand; i++ {
+			_, pubRand, err := eots.RandGen(r)
+			require.NoError(t, err)
+			pubRandList = append(pubRandList, pubRand)
+			pubRandBytes := *pubRand.Bytes()
+			leaves = append(leaves, pubRandBytes[:])
+		}
+		commitment, proofList := merkle.ProofsFromByteSlices(leaves)
+		commit := &fpstore.PubRandCommit{
+			StartHeight: uint64(r.Int63n(1000) + 1),
+			NumPubRand:  uint64(numPubRand),
+			Commitment:  commitment,
+		}
+
+		// failing to save the commitment leaves no proof behind
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			return tx.DeleteTopLevelBucket([]byte("pub_rand_commit"))
+		}, func() {})
+		require.NoError(t, err)
+		err = s.AddPubRandProofListAndCommit(fpPk.MustToBTCPK(), commit, pubRandList, proofList)
+		require.ErrorIs(t, err, fpstore.ErrCorruptedPubRandProofDb)
+		_, err = s.GetPubRandProofList(pubRandList)
+		require.ErrorIs(t, err, fpstore.ErrPubRandProofNotFound)
+
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			_, err := tx.CreateTopLevelBucket([]byte("pub_rand_commit"))
+			return err
+		}, func() {})
+		require.NoError(t, err)
+		err = s.AddPubRandProofListAndCommit(fpPk.MustToBTCPK(), commit, pubRandList, proofList)
+		require.NoError(t, err)
+
+		proofBytesList, err := s.GetPubRandProofList(pubRandList)
+		require.NoError(t, err)
+		require.Len(t, proofBytesList, numPubRand)
+		commits, err := s.GetOverlappingPubRandCommits(fpPk.MustToBTCPK(), commit.StartHeight, commit.EndHeight())
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+		require.True(t, commit.Equal(commits[0]))
+	})
+}
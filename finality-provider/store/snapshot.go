@@ -0,0 +1,172 @@
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+	pm "google.golang.org/protobuf/proto"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// StateSnapshotVersion is the version of the state snapshot format
+const StateSnapshotVersion = 1
+
+// StateSnapshot is a portable copy of the state of the finality provider db
+// used for migration and disaster recovery. The last voted heights of the
+// finality provider records serve as the slashing protection records
+type StateSnapshot struct {
+	Version uint32 `json:"version"`
+	// FinalityProviders are the marshalled finality provider records
+	FinalityProviders [][]byte `json:"finality_providers"`
+	// PubRandProofs maps the hex encoded public randomness to its marshalled proof
+	PubRandProofs map[string][]byte `json:"pub_rand_proofs"`
+}
+
+// ExportState reads the state of the given db into a snapshot. The records
+// are validated so that a corrupted db is not carried over
+func ExportState(db kvdb.Backend) (*StateSnapshot, error) {
+	snapshot := &StateSnapshot{
+		Version:       StateSnapshotVersion,
+		PubRandProofs: make(map[string][]byte),
+	}
+
+	err := db.View(func(tx kvdb.RTx) error {
+		fpBucket := tx.ReadBucket(finalityProviderBucketName)
+		if fpBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+		if err := fpBucket.ForEach(func(k, v []byte) error {
+			if _, err := decodeFinalityProvider(k, v); err != nil {
+				return err
+			}
+			snapshot.FinalityProviders = append(snapshot.FinalityProviders, copyBytes(v))
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		proofBucket := tx.ReadBucket(pubRandProofBucketName)
+		if proofBucket == nil {
+			return ErrCorruptedPubRandProofDb
+		}
+		return proofBucket.ForEach(func(k, v []byte) error {
+			if err := validatePubRandProof(k, v); err != nil {
+				return err
+			}
+			snapshot.PubRandProofs[hex.EncodeToString(k)] = copyBytes(v)
+			return nil
+		})
+	}, func() {
+		snapshot.FinalityProviders = nil
+		snapshot.PubRandProofs = make(map[string][]byte)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// DecodeFinalityProviders decodes and validates the finality provider records
+// of the snapshot
+func (s *StateSnapshot) DecodeFinalityProviders() ([]*StoredFinalityProvider, error) {
+	fps := make([]*StoredFinalityProvider, 0, len(s.FinalityProviders))
+	for _, v := range s.FinalityProviders {
+		var fp proto.FinalityProvider
+		if err := pm.Unmarshal(v, &fp); err != nil {
+			return nil, fmt.Errorf("invalid finality provider record: %w", err)
+		}
+		storedFp, err := decodeStoredFinalityProvider(fp.BtcPk, v)
+		if err != nil {
+			return nil, err
+		}
+		fps = append(fps, storedFp)
+	}
+
+	return fps, nil
+}
+
+// ImportState writes the records of the snapshot into the given db in a
+// single transaction. It refuses to overwrite a finality provider which has
+// voted above the last voted height in the snapshot, as it could then vote
+// again at the heights in between
+func ImportState(db kvdb.Backend, snapshot *StateSnapshot) error {
+	if snapshot.Version != StateSnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, StateSnapshotVersion)
+	}
+
+	fps, err := snapshot.DecodeFinalityProviders()
+	if err != nil {
+		return err
+	}
+
+	pubRandKeys := make(map[string][]byte, len(snapshot.PubRandProofs))
+	for k, v := range snapshot.PubRandProofs {
+		key, err := hex.DecodeString(k)
+		if err != nil {
+			return fmt.Errorf("invalid public randomness %s: %w", k, err)
+		}
+		if err := validatePubRandProof(key, v); err != nil {
+			return err
+		}
+		pubRandKeys[k] = key
+	}
+
+	return kvdb.Update(db, func(tx kvdb.RwTx) error {
+		fpBucket, err := tx.CreateTopLevelBucket(finalityProviderBucketName)
+		if err != nil {
+			return err
+		}
+		for i, fp := range fps {
+			if err := checkNotBehind(fpBucket, fp); err != nil {
+				return err
+			}
+			if err := fpBucket.Put(schnorr.SerializePubKey(fp.BtcPk), snapshot.FinalityProviders[i]); err != nil {
+				return err
+			}
+		}
+
+		proofBucket, err := tx.CreateTopLevelBucket(pubRandProofBucketName)
+		if err != nil {
+			return err
+		}
+		for k, v := range snapshot.PubRandProofs {
+			if err := proofBucket.Put(pubRandKeys[k], v); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+}
+
+// checkNotBehind returns ErrSnapshotBehind if the stored record of the given
+// finality provider has voted above it
+func checkNotBehind(fpBucket walletdb.ReadWriteBucket, fp *StoredFinalityProvider) error {
+	key := schnorr.SerializePubKey(fp.BtcPk)
+	v := fpBucket.Get(key)
+	if v == nil {
+		return nil
+	}
+
+	existing, err := decodeStoredFinalityProvider(key, v)
+	if err != nil {
+		return err
+	}
+	if existing.LastVotedHeight > fp.LastVotedHeight {
+		return fmt.Errorf("%w: finality provider %s has voted at height %d while the snapshot is at height %d",
+			ErrSnapshotBehind, fp.GetBIP340BTCPK().MarshalHex(), existing.LastVotedHeight, fp.LastVotedHeight)
+	}
+
+	return nil
+}
+
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
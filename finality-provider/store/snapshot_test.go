@@ -0,0 +1,95 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/babylonlabs-io/babylon/crypto/eots"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/cometbft/cometbft/crypto/merkle"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzStateSnapshot tests that an exported state is imported into another db
+// and that a snapshot behind the stored last voted height is refused
+func FuzzStateSnapshot(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		openDb := func() kvdb.Backend {
+			cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+			db, err := cfg.GetDbBackend()
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, db.Close())
+			})
+			return db
+		}
+
+		srcDb := openDb()
+		fps, err := fpstore.NewFinalityProviderStore(srcDb)
+		require.NoError(t, err)
+		proofs, err := fpstore.NewPubRandProofStore(srcDb)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		err = fps.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, nil, fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+		lastVotedHeight := uint64(r.Int63n(1000) + 1)
+		err = fps.SetFpLastVotedHeight(fp.BtcPk, lastVotedHeight)
+		require.NoError(t, err)
+
+		numPubRand := int(r.Int31n(10) + 2)
+		pubRandList := make([]*btcec.FieldVal, 0, numPubRand)
+		leaves := make([][]byte, 0, numPubRand)
+		for i := 0; i < numPubRand; i++ {
+			_, pubRand, err := eots.RandGen(r)
+			require.NoError(t, err)
+			pubRandList = append(pubRandList, pubRand)
+			pubRandBytes := *pubRand.Bytes()
+			leaves = append(leaves, pubRandBytes[:])
+		}
+		_, proofList := merkle.ProofsFromByteSlices(leaves)
+		err = proofs.AddPubRandProofList(pubRandList, proofList)
+		require.NoError(t, err)
+
+		snapshot, err := fpstore.ExportState(srcDb)
+		require.NoError(t, err)
+		require.Len(t, snapshot.FinalityProviders, 1)
+		require.Len(t, snapshot.PubRandProofs, numPubRand)
+
+		// the snapshot is imported into an empty db
+		dstDb := openDb()
+		err = fpstore.ImportState(dstDb, snapshot)
+		require.NoError(t, err)
+		dstFps, err := fpstore.NewFinalityProviderStore(dstDb)
+		require.NoError(t, err)
+		importedFp, err := dstFps.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.Equal(t, fp.FPAddr, importedFp.FPAddr)
+		require.Equal(t, lastVotedHeight, importedFp.LastVotedHeight)
+		dstProofs, err := fpstore.NewPubRandProofStore(dstDb)
+		require.NoError(t, err)
+		proofBytesList, err := dstProofs.GetPubRandProofList(pubRandList)
+		require.NoError(t, err)
+		require.Len(t, proofBytesList, numPubRand)
+
+		// the snapshot is refused once the finality provider voted above it
+		err = dstFps.SetFpLastVotedHeight(fp.BtcPk, lastVotedHeight+1)
+		require.NoError(t, err)
+		err = fpstore.ImportState(dstDb, snapshot)
+		require.ErrorIs(t, err, fpstore.ErrSnapshotBehind)
+		importedFp, err = dstFps.GetFinalityProvider(fp.BtcPk)
+		require.NoError(t, err)
+		require.Equal(t, lastVotedHeight+1, importedFp.LastVotedHeight)
+	})
+}
@@ -17,15 +17,22 @@ type StoredFinalityProvider struct {
 	BtcPk               *btcec.PublicKey
 	Description         *stakingtypes.Description
 	Commission          *sdkmath.LegacyDec
+	MaxCommission       *sdkmath.LegacyDec
 	Pop                 *proto.ProofOfPossession
 	KeyName             string
 	ChainID             string
 	LastVotedHeight     uint64
 	LastProcessedHeight uint64
 	Status              proto.FinalityProviderStatus
+	RewardAddress       string
+	Paused              bool
 }
 
 func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalityProvider, error) {
+	if fp.Pop == nil {
+		return nil, fmt.Errorf("missing proof of possession")
+	}
+
 	btcPk, err := schnorr.ParsePubKey(fp.BtcPk)
 	if err != nil {
 		return nil, fmt.Errorf("invalid BTC public key: %w", err)
@@ -41,11 +48,21 @@ func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalit
 		return nil, fmt.Errorf("invalid commission: %w", err)
 	}
 
+	var maxCommission *sdkmath.LegacyDec
+	if fp.MaxCommission != "" {
+		rate, err := sdkmath.LegacyNewDecFromStr(fp.MaxCommission)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max commission: %w", err)
+		}
+		maxCommission = &rate
+	}
+
 	return &StoredFinalityProvider{
-		FPAddr:      fp.FpAddr,
-		BtcPk:       btcPk,
-		Description: &des,
-		Commission:  &commission,
+		FPAddr:        fp.FpAddr,
+		BtcPk:         btcPk,
+		Description:   &des,
+		Commission:    &commission,
+		MaxCommission: maxCommission,
 		Pop: &proto.ProofOfPossession{
 			BtcSig: fp.Pop.BtcSig,
 		},
@@ -54,6 +71,8 @@ func protoFpToStoredFinalityProvider(fp *proto.FinalityProvider) (*StoredFinalit
 		LastVotedHeight:     fp.LastVotedHeight,
 		LastProcessedHeight: fp.LastProcessedHeight,
 		Status:              fp.Status,
+		RewardAddress:       fp.RewardAddress,
+		Paused:              fp.Paused,
 	}, nil
 }
 
@@ -62,6 +81,11 @@ func (sfp *StoredFinalityProvider) GetBIP340BTCPK() *bbn.BIP340PubKey {
 }
 
 func (sfp *StoredFinalityProvider) ToFinalityProviderInfo() *proto.FinalityProviderInfo {
+	var maxCommission string
+	if sfp.MaxCommission != nil {
+		maxCommission = sfp.MaxCommission.String()
+	}
+
 	return &proto.FinalityProviderInfo{
 		FpAddr:   sfp.FPAddr,
 		BtcPkHex: sfp.GetBIP340BTCPK().MarshalHex(),
@@ -75,15 +99,23 @@ func (sfp *StoredFinalityProvider) ToFinalityProviderInfo() *proto.FinalityProvi
 		Commission:      sfp.Commission.String(),
 		LastVotedHeight: sfp.LastVotedHeight,
 		Status:          sfp.Status.String(),
+		RewardAddress:   sfp.RewardAddress,
+		MaxCommission:   maxCommission,
+		Paused:          sfp.Paused,
 	}
 }
 
 // ShouldStart returns true if the finality provider should start his instance
 // based on the current status of the finality provider.
 //
-// It returns false if the status is either 'CREATED', 'JAILED' or 'SLASHED'.
+// It returns false if the status is either 'CREATED', 'JAILED' or 'SLASHED',
+// or if the finality provider is paused by the operator.
 // It returs true for all the other status.
 func (sfp *StoredFinalityProvider) ShouldStart() bool {
+	if sfp.Paused {
+		return false
+	}
+
 	if sfp.Status == proto.FinalityProviderStatus_CREATED ||
 		sfp.Status == proto.FinalityProviderStatus_SLASHED ||
 		sfp.Status == proto.FinalityProviderStatus_JAILED {
@@ -50,6 +50,10 @@ func TestShouldStart(t *testing.T) {
 
 			shouldStart := fp.ShouldStart()
 			require.Equal(t, tc.expShouldStart, shouldStart)
+
+			// a paused finality provider never starts
+			fp.Paused = true
+			require.False(t, fp.ShouldStart())
 		})
 	}
 }
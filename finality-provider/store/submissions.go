@@ -0,0 +1,177 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+// The types of the submitted transactions
+const (
+	SubmissionTypeFinalitySig   = "finality_sig"
+	SubmissionTypePubRandCommit = "pub_rand_commit"
+	SubmissionTypeRegistration  = "registration"
+	SubmissionTypeUnjail        = "unjail"
+)
+
+// The statuses of the submitted transactions
+const (
+	// SubmissionStatusSuccess means that the tx is included on the consumer chain
+	SubmissionStatusSuccess = "success"
+	// SubmissionStatusFailed means that the tx could not be submitted or
+	// failed to execute
+	SubmissionStatusFailed = "failed"
+	// SubmissionStatusSkipped means that the tx was rejected with an expected
+	// error, e.g., as the finality signature was already submitted
+	SubmissionStatusSkipped = "skipped"
+)
+
+var (
+	// mapping: fp_pk || sequence -> submission
+	submissionBucketName = []byte("submissions")
+)
+
+// Submission is a tx submitted to the consumer chain on behalf of a finality
+// provider
+type Submission struct {
+	Type string `json:"type"`
+	// Height is the height of the first block voted for, or the start height
+	// of the committed randomness, 0 if not applicable
+	Height uint64 `json:"height,omitempty"`
+	// EndHeight is the height of the last block voted for, or of the last
+	// committed randomness
+	EndHeight uint64 `json:"end_height,omitempty"`
+	TxHash    string `json:"tx_hash,omitempty"`
+	Status    string `json:"status"`
+	GasUsed   int64  `json:"gas_used,omitempty"`
+	// Signer is the address of the key that signed the tx, if it was
+	// included
+	Signer string `json:"signer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Timestamp is the unix time in seconds at which the tx was submitted
+	Timestamp int64 `json:"timestamp"`
+}
+
+type SubmissionStore struct {
+	db kvdb.Backend
+}
+
+// NewSubmissionStore returns a new store backed by db
+func NewSubmissionStore(db kvdb.Backend) (*SubmissionStore, error) {
+	store := &SubmissionStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *SubmissionStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(submissionBucketName)
+		return err
+	})
+}
+
+// AddSubmission appends a tx submitted on behalf of the given finality provider
+func (s *SubmissionStore) AddSubmission(fpPk *btcec.PublicKey, sub *Submission) error {
+	v, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(submissionBucketName)
+		if bucket == nil {
+			return ErrCorruptedSubmissionDb
+		}
+
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(submissionKey(fpPk, seq), v)
+	})
+}
+
+// GetSubmissions returns the txs submitted on behalf of the given finality
+// provider from the latest to the earliest, up to limit txs, or all of them
+// if limit is 0. If txType is not empty, only the txs of that type are
+// returned
+func (s *SubmissionStore) GetSubmissions(fpPk *btcec.PublicKey, txType string, limit uint64) ([]*Submission, error) {
+	prefix := schnorr.SerializePubKey(fpPk)
+	var submissions []*Submission
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(submissionBucketName)
+		if bucket == nil {
+			return ErrCorruptedSubmissionDb
+		}
+
+		// the sequence never reaches the maximum, so seeking it positions
+		// the cursor after the submissions of the finality provider
+		c := bucket.ReadCursor()
+		k, v := c.Seek(submissionKey(fpPk, math.MaxUint64))
+		if k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		for ; k != nil && bytes.HasPrefix(k, prefix); k, v = c.Prev() {
+			if limit > 0 && uint64(len(submissions)) >= limit {
+				break
+			}
+			if len(k) != len(prefix)+8 {
+				return newErrCorruptRecord(submissionBucketName, k, fmt.Errorf("invalid key length"))
+			}
+			var sub Submission
+			if err := json.Unmarshal(v, &sub); err != nil {
+				return newErrCorruptRecord(submissionBucketName, k, err)
+			}
+			if txType != "" && sub.Type != txType {
+				continue
+			}
+			submissions = append(submissions, &sub)
+		}
+
+		return nil
+	}, func() {
+		submissions = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return submissions, nil
+}
+
+// PruneSubmissions deletes the txs submitted on behalf of each finality
+// provider up to its last tx below the given height, i.e., whose end height,
+// or height if it has none, is below it. The earlier txs without height,
+// e.g., the registration, are deleted too, so that the remaining history is
+// contiguous. It returns the number of deleted txs
+func (s *SubmissionStore) PruneSubmissions(height uint64) (uint64, error) {
+	return pruneHistory(s.db, submissionBucketName, ErrCorruptedSubmissionDb, schnorr.PubKeyBytesLen, height,
+		func(k, v []byte) (uint64, error) {
+			if len(k) != schnorr.PubKeyBytesLen+8 {
+				return 0, fmt.Errorf("invalid key length")
+			}
+			var sub Submission
+			if err := json.Unmarshal(v, &sub); err != nil {
+				return 0, err
+			}
+			return max(sub.Height, sub.EndHeight), nil
+		})
+}
+
+func submissionKey(fpPk *btcec.PublicKey, seq uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, seq)
+}
@@ -0,0 +1,101 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzSubmissionStore tests that the submissions are listed from the latest,
+// by type and limit, and separately for each finality provider, and that they
+// are pruned below a height
+func FuzzSubmissionStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewSubmissionStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		otherFp := testutil.GenRandomFinalityProvider(r, t)
+
+		// interleave the submissions of the two finality providers, with a
+		// randomness commitment every few votes
+		numSubmissions := int(r.Int63n(20) + 5)
+		var numCommits int
+		for i := 0; i < numSubmissions; i++ {
+			sub := &fpstore.Submission{
+				Type:    fpstore.SubmissionTypeFinalitySig,
+				Height:  uint64(i + 1),
+				TxHash:  testutil.GenRandomHexStr(r, 32),
+				Status:  fpstore.SubmissionStatusSuccess,
+				GasUsed: r.Int63n(100000),
+				Signer:  "bbn1" + testutil.GenRandomHexStr(r, 19),
+			}
+			if i%3 == 0 {
+				sub.Type = fpstore.SubmissionTypePubRandCommit
+				numCommits++
+			}
+			require.NoError(t, s.AddSubmission(fp.BtcPk, sub))
+			require.NoError(t, s.AddSubmission(otherFp.BtcPk, &fpstore.Submission{
+				Type:   fpstore.SubmissionTypeFinalitySig,
+				Status: fpstore.SubmissionStatusFailed,
+			}))
+		}
+
+		submissions, err := s.GetSubmissions(fp.BtcPk, "", 0)
+		require.NoError(t, err)
+		require.Len(t, submissions, numSubmissions)
+		for i, sub := range submissions {
+			require.Equal(t, uint64(numSubmissions-i), sub.Height)
+			require.Equal(t, fpstore.SubmissionStatusSuccess, sub.Status)
+			require.NotEmpty(t, sub.Signer)
+		}
+
+		submissions, err = s.GetSubmissions(fp.BtcPk, "", 2)
+		require.NoError(t, err)
+		require.Len(t, submissions, 2)
+		require.Equal(t, uint64(numSubmissions), submissions[0].Height)
+
+		submissions, err = s.GetSubmissions(fp.BtcPk, fpstore.SubmissionTypePubRandCommit, 0)
+		require.NoError(t, err)
+		require.Len(t, submissions, numCommits)
+		for _, sub := range submissions {
+			require.Equal(t, fpstore.SubmissionTypePubRandCommit, sub.Type)
+		}
+
+		submissions, err = s.GetSubmissions(otherFp.BtcPk, "", 0)
+		require.NoError(t, err)
+		require.Len(t, submissions, numSubmissions)
+		for _, sub := range submissions {
+			require.Equal(t, fpstore.SubmissionStatusFailed, sub.Status)
+		}
+
+		// the submissions up to the last one below the height are pruned,
+		// while the ones without height of the other finality provider are
+		// kept
+		pruneHeight := uint64(r.Int63n(int64(numSubmissions)) + 1)
+		deleted, err := s.PruneSubmissions(pruneHeight)
+		require.NoError(t, err)
+		require.Equal(t, pruneHeight-1, deleted)
+		submissions, err = s.GetSubmissions(fp.BtcPk, "", 0)
+		require.NoError(t, err)
+		require.Len(t, submissions, numSubmissions-int(pruneHeight-1))
+		require.Equal(t, pruneHeight, submissions[len(submissions)-1].Height)
+		submissions, err = s.GetSubmissions(otherFp.BtcPk, "", 0)
+		require.NoError(t, err)
+		require.Len(t, submissions, numSubmissions)
+	})
+}
@@ -0,0 +1,314 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/proto"
+)
+
+// Anomaly is an inconsistency found in a record of the db
+type Anomaly struct {
+	Bucket string
+	Key    []byte
+	Msg    string
+}
+
+func (a *Anomaly) String() string {
+	return fmt.Sprintf("bucket %s, key %x: %s", a.Bucket, a.Key, a.Msg)
+}
+
+// verifier collects the anomalies found while walking the db
+type verifier struct {
+	anomalies []*Anomaly
+	// fps are the decoded finality provider records by their key
+	fps map[string]*proto.FinalityProvider
+	// lastCommittedHeights are the highest heights covered by the recorded
+	// commitments of public randomness of each finality provider
+	lastCommittedHeights map[string]uint64
+}
+
+func (v *verifier) report(bucket, key []byte, format string, args ...interface{}) {
+	v.anomalies = append(v.anomalies, &Anomaly{
+		Bucket: string(bucket),
+		Key:    append([]byte{}, key...),
+		Msg:    fmt.Sprintf(format, args...),
+	})
+}
+
+// VerifyDb walks all the records of the given db and returns the anomalies
+// found. Each record is checked to decode, the commitments of public
+// randomness of each finality provider to be contiguous, and the records of
+// the voted blocks, which protect against double signing, to refer to a
+// stored finality provider and to be covered by its committed randomness.
+// It only fails if the db itself can't be read
+func VerifyDb(db kvdb.Backend) ([]*Anomaly, error) {
+	var v *verifier
+	err := db.View(func(tx kvdb.RTx) error {
+		v = &verifier{
+			fps:                  make(map[string]*proto.FinalityProvider),
+			lastCommittedHeights: make(map[string]uint64),
+		}
+
+		for _, check := range []struct {
+			bucket []byte
+			fn     func(bucket walletdb.ReadBucket) error
+		}{
+			// the finality providers and the commitments are checked first
+			// as the other records are cross-referenced with them
+			{finalityProviderBucketName, v.verifyFinalityProviders},
+			{pubRandCommitBucketName, v.verifyPubRandCommits},
+			{pubRandProofBucketName, v.verifyPubRandProofs},
+			{votedBlockBucketName, v.verifyVotedBlocks},
+			{missedBlockBucketName, v.verifyMissedBlocks},
+			{voteProofBucketName, v.verifyVoteProofs},
+			{submissionBucketName, v.verifySubmissions},
+			{epochMsgBucketName, v.verifyEpochMsgs},
+			{eventBucketName, v.verifyEvents},
+			{emergencyStopBucketName, v.verifyEmergencyStop},
+		} {
+			bucket := tx.ReadBucket(check.bucket)
+			if bucket == nil {
+				// the buckets are created by the stores, so a missing one
+				// only means that the store was never opened
+				continue
+			}
+			if err := check.fn(bucket); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.anomalies, nil
+}
+
+func (v *verifier) verifyFinalityProviders(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		fp, err := decodeFinalityProvider(k, val)
+		if err != nil {
+			v.report(finalityProviderBucketName, k, "%v", err)
+			return nil
+		}
+		if fp.LastVotedHeight > fp.LastProcessedHeight {
+			v.report(finalityProviderBucketName, k, "the last voted height %d is above the last processed height %d",
+				fp.LastVotedHeight, fp.LastProcessedHeight)
+		}
+		v.fps[string(k)] = fp
+		return nil
+	})
+}
+
+func (v *verifier) verifyPubRandCommits(bucket walletdb.ReadBucket) error {
+	err := bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 || len(val) < 8 {
+			v.report(pubRandCommitBucketName, k, "invalid length")
+			return nil
+		}
+		fpKey := string(k[:schnorr.PubKeyBytesLen])
+		if _, ok := v.fps[fpKey]; !ok {
+			v.report(pubRandCommitBucketName, k, "the finality provider is not stored")
+		}
+		commit := &PubRandCommit{
+			StartHeight: binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:]),
+			NumPubRand:  binary.BigEndian.Uint64(val[:8]),
+		}
+		if commit.NumPubRand == 0 {
+			v.report(pubRandCommitBucketName, k, "the commitment covers no height")
+			return nil
+		}
+
+		// the keys are sorted by finality provider and start height
+		if prevEnd, ok := v.lastCommittedHeights[fpKey]; ok {
+			if commit.StartHeight <= prevEnd {
+				v.report(pubRandCommitBucketName, k, "the commitment starting at height %d overlaps the previous one ending at height %d",
+					commit.StartHeight, prevEnd)
+			} else if commit.StartHeight != prevEnd+1 {
+				v.report(pubRandCommitBucketName, k, "the commitment starting at height %d is not contiguous to the previous one ending at height %d",
+					commit.StartHeight, prevEnd)
+			}
+		}
+		if commit.EndHeight() > v.lastCommittedHeights[fpKey] {
+			v.lastCommittedHeights[fpKey] = commit.EndHeight()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for fpKey, fp := range v.fps {
+		lastCommittedHeight, ok := v.lastCommittedHeights[fpKey]
+		if ok && fp.LastVotedHeight > lastCommittedHeight {
+			v.report(finalityProviderBucketName, []byte(fpKey),
+				"the last voted height %d is above the last committed height %d", fp.LastVotedHeight, lastCommittedHeight)
+		}
+	}
+
+	return nil
+}
+
+func (v *verifier) verifyPubRandProofs(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if err := validatePubRandProof(k, val); err != nil {
+			v.report(pubRandProofBucketName, k, "%v", err)
+		}
+		return nil
+	})
+}
+
+func (v *verifier) verifyVotedBlocks(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 {
+			v.report(votedBlockBucketName, k, "invalid key length")
+			return nil
+		}
+		if len(val) == 0 {
+			v.report(votedBlockBucketName, k, "empty block hash")
+		}
+		fpKey := string(k[:schnorr.PubKeyBytesLen])
+		if _, ok := v.fps[fpKey]; !ok {
+			v.report(votedBlockBucketName, k, "the finality provider is not stored")
+			return nil
+		}
+
+		// the finality provider can only vote with committed randomness, but
+		// the commitments are only checked if any is recorded, e.g., not for
+		// the finality providers imported from a snapshot
+		height := binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:])
+		if lastCommittedHeight, ok := v.lastCommittedHeights[fpKey]; ok && height > lastCommittedHeight {
+			v.report(votedBlockBucketName, k, "the voted height %d is above the last committed height %d",
+				height, lastCommittedHeight)
+		}
+		return nil
+	})
+}
+
+func (v *verifier) verifyMissedBlocks(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 {
+			v.report(missedBlockBucketName, k, "invalid key length")
+			return nil
+		}
+		var mb MissedBlock
+		if err := json.Unmarshal(val, &mb); err != nil {
+			v.report(missedBlockBucketName, k, "%v", err)
+			return nil
+		}
+		if height := binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:]); mb.Height != height {
+			v.report(missedBlockBucketName, k, "the height %d does not match the record key height %d", mb.Height, height)
+		}
+		v.checkFpStored(missedBlockBucketName, k)
+		return nil
+	})
+}
+
+func (v *verifier) verifyVoteProofs(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 {
+			v.report(voteProofBucketName, k, "invalid key length")
+			return nil
+		}
+		var p VoteProof
+		if err := json.Unmarshal(val, &p); err != nil {
+			v.report(voteProofBucketName, k, "%v", err)
+			return nil
+		}
+		if height := binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:]); p.Height != height {
+			v.report(voteProofBucketName, k, "the height %d does not match the record key height %d", p.Height, height)
+		}
+		if len(p.PubRand) != 32 || len(p.Signature) != 32 {
+			v.report(voteProofBucketName, k, "invalid length of the public randomness or the signature")
+		}
+		v.checkFpStored(voteProofBucketName, k)
+		return nil
+	})
+}
+
+func (v *verifier) verifyEmergencyStop(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if string(k) != string(emergencyStopKey) {
+			v.report(emergencyStopBucketName, k, "unknown key")
+			return nil
+		}
+		var stop EmergencyStop
+		if err := json.Unmarshal(val, &stop); err != nil {
+			v.report(emergencyStopBucketName, k, "%v", err)
+		}
+		return nil
+	})
+}
+
+func (v *verifier) verifySubmissions(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 {
+			v.report(submissionBucketName, k, "invalid key length")
+			return nil
+		}
+		var sub Submission
+		if err := json.Unmarshal(val, &sub); err != nil {
+			v.report(submissionBucketName, k, "%v", err)
+			return nil
+		}
+		v.checkFpStored(submissionBucketName, k)
+		return nil
+	})
+}
+
+func (v *verifier) verifyEpochMsgs(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != schnorr.PubKeyBytesLen+8 {
+			v.report(epochMsgBucketName, k, "invalid key length")
+			return nil
+		}
+		var msg EpochMsg
+		if err := json.Unmarshal(val, &msg); err != nil {
+			v.report(epochMsgBucketName, k, "%v", err)
+			return nil
+		}
+		v.checkFpStored(epochMsgBucketName, k)
+		return nil
+	})
+}
+
+func (v *verifier) verifyEvents(bucket walletdb.ReadBucket) error {
+	return bucket.ForEach(func(k, val []byte) error {
+		if len(k) != 8 {
+			v.report(eventBucketName, k, "invalid key length")
+			return nil
+		}
+		var ev Event
+		if err := json.Unmarshal(val, &ev); err != nil {
+			v.report(eventBucketName, k, "%v", err)
+			return nil
+		}
+		if seq := binary.BigEndian.Uint64(k); ev.Seq != seq {
+			v.report(eventBucketName, k, "the sequence number %d does not match the record key %d", ev.Seq, seq)
+		}
+		if ev.BtcPkHex != "" {
+			if pk, err := hex.DecodeString(ev.BtcPkHex); err != nil || len(pk) != schnorr.PubKeyBytesLen {
+				v.report(eventBucketName, k, "invalid BTC public key %s", ev.BtcPkHex)
+			}
+		}
+		return nil
+	})
+}
+
+// checkFpStored reports the record if it is keyed by a finality provider
+// which is not stored. The records of the finality providers are never
+// deleted, so such records are orphaned
+func (v *verifier) checkFpStored(bucket, k []byte) {
+	if _, ok := v.fps[string(k[:schnorr.PubKeyBytesLen])]; !ok {
+		v.report(bucket, k, "the finality provider is not stored")
+	}
+}
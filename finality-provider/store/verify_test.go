@@ -0,0 +1,102 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzVerifyDb tests that a consistent db has no anomaly and that the
+// inconsistent records are reported
+func FuzzVerifyDb(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		fpStore, err := fpstore.NewFinalityProviderStore(db)
+		require.NoError(t, err)
+		pubRandStore, err := fpstore.NewPubRandProofStore(db)
+		require.NoError(t, err)
+		submissions, err := fpstore.NewSubmissionStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		err = fpStore.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, fp.Commission,
+			fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+
+		// contiguous commitments, votes within them, and a submission
+		startHeight := uint64(r.Int63n(1000) + 1)
+		numPubRand := uint64(r.Int63n(100) + 10)
+		numCommits := int(r.Int31n(5) + 1)
+		for i := 0; i < numCommits; i++ {
+			err = pubRandStore.AddPubRandCommit(fp.BtcPk, &fpstore.PubRandCommit{
+				StartHeight: startHeight + uint64(i)*numPubRand,
+				NumPubRand:  numPubRand,
+				Commitment:  testutil.GenRandomByteArray(r, 32),
+			})
+			require.NoError(t, err)
+		}
+		lastCommittedHeight := startHeight + uint64(numCommits)*numPubRand - 1
+		votedHeight := startHeight + uint64(r.Int63n(int64(lastCommittedHeight-startHeight+1)))
+		require.NoError(t, fpStore.GuardVote(fp.BtcPk, votedHeight, testutil.GenRandomByteArray(r, 32)))
+		require.NoError(t, fpStore.SetFpLastVotedHeight(fp.BtcPk, votedHeight))
+		require.NoError(t, submissions.AddSubmission(fp.BtcPk, &fpstore.Submission{
+			Type:   fpstore.SubmissionTypeFinalitySig,
+			Height: votedHeight,
+			Status: fpstore.SubmissionStatusSuccess,
+		}))
+
+		anomalies, err := fpstore.VerifyDb(db)
+		require.NoError(t, err)
+		require.Empty(t, anomalies)
+
+		// a commitment leaving a gap after the last one
+		gapStart := lastCommittedHeight + 2
+		err = pubRandStore.AddPubRandCommit(fp.BtcPk, &fpstore.PubRandCommit{
+			StartHeight: gapStart,
+			NumPubRand:  numPubRand,
+			Commitment:  testutil.GenRandomByteArray(r, 32),
+		})
+		require.NoError(t, err)
+		// a vote of an unknown finality provider
+		otherFp := testutil.GenRandomFinalityProvider(r, t)
+		require.ErrorIs(t, fpStore.GuardVote(otherFp.BtcPk, votedHeight, testutil.GenRandomByteArray(r, 32)),
+			fpstore.ErrFinalityProviderNotFound)
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			key := append(schnorr.SerializePubKey(otherFp.BtcPk), make([]byte, 8)...)
+			return tx.ReadWriteBucket([]byte("voted_blocks")).Put(key, testutil.GenRandomByteArray(r, 32))
+		}, func() {})
+		require.NoError(t, err)
+		// a corrupted proof
+		err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+			return tx.ReadWriteBucket([]byte("pub_rand_proof")).Put(testutil.GenRandomByteArray(r, 32), []byte{0xff})
+		}, func() {})
+		require.NoError(t, err)
+
+		anomalies, err = fpstore.VerifyDb(db)
+		require.NoError(t, err)
+		require.Len(t, anomalies, 3)
+		require.Equal(t, "pub_rand_commit", anomalies[0].Bucket)
+		require.Contains(t, anomalies[0].Msg, "not contiguous")
+		require.Equal(t, "pub_rand_proof", anomalies[1].Bucket)
+		require.Equal(t, "voted_blocks", anomalies[2].Bucket)
+		require.Contains(t, anomalies[2].Msg, "not stored")
+	})
+}
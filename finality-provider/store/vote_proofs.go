@@ -0,0 +1,143 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: fp_pk || height -> vote proof
+	voteProofBucketName = []byte("vote_proofs")
+)
+
+// VoteProof is what a finality provider submitted to vote for a block, which
+// proves the vote to a third party together with the commitment of the public
+// randomness
+type VoteProof struct {
+	Height    uint64 `json:"height"`
+	BlockHash []byte `json:"block_hash"`
+	PubRand   []byte `json:"pub_rand"`
+	// Proof is the encoded inclusion proof of the public randomness in the
+	// commitment
+	Proof     []byte `json:"proof"`
+	Signature []byte `json:"signature"`
+	// TxHash is the hash of the tx including the vote, empty if the vote was
+	// already submitted
+	TxHash string `json:"tx_hash,omitempty"`
+	// Timestamp is the unix time in seconds at which the vote was submitted
+	Timestamp int64 `json:"timestamp"`
+}
+
+type VoteProofStore struct {
+	db kvdb.Backend
+}
+
+// NewVoteProofStore returns a new store backed by db
+func NewVoteProofStore(db kvdb.Backend) (*VoteProofStore, error) {
+	store := &VoteProofStore{db}
+	if err := store.initBuckets(); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (s *VoteProofStore) initBuckets() error {
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		_, err := tx.CreateTopLevelBucket(voteProofBucketName)
+		return err
+	})
+}
+
+// AddVoteProofs records the votes of the given finality provider. It
+// overwrites any vote recorded at the same heights, which can only be a
+// resubmission of the same vote as the conflicting votes are refused
+func (s *VoteProofStore) AddVoteProofs(fpPk *btcec.PublicKey, proofs []*VoteProof) error {
+	values := make([][]byte, 0, len(proofs))
+	for _, p := range proofs {
+		v, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		values = append(values, v)
+	}
+
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(voteProofBucketName)
+		if bucket == nil {
+			return ErrCorruptedVoteProofDb
+		}
+
+		for i, p := range proofs {
+			if err := bucket.Put(voteProofKey(fpPk, p.Height), values[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetVoteProof returns the vote of the given finality provider at the given
+// height, or ErrVoteProofNotFound if none is recorded
+func (s *VoteProofStore) GetVoteProof(fpPk *btcec.PublicKey, height uint64) (*VoteProof, error) {
+	var proof *VoteProof
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(voteProofBucketName)
+		if bucket == nil {
+			return ErrCorruptedVoteProofDb
+		}
+
+		key := voteProofKey(fpPk, height)
+		v := bucket.Get(key)
+		if v == nil {
+			return ErrVoteProofNotFound
+		}
+		proof = &VoteProof{}
+		if err := json.Unmarshal(v, proof); err != nil {
+			return newErrCorruptRecord(voteProofBucketName, key, err)
+		}
+
+		return nil
+	}, func() {
+		proof = nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// PruneVoteProofs deletes the votes below the given height of any finality
+// provider and returns the number of deleted votes
+func (s *VoteProofStore) PruneVoteProofs(height uint64) (uint64, error) {
+	var deleted uint64
+	err := kvdb.Update(s.db, func(tx kvdb.RwTx) error {
+		bucket := tx.ReadWriteBucket(voteProofBucketName)
+		if bucket == nil {
+			return ErrCorruptedVoteProofDb
+		}
+
+		return deleteKeys(bucket, func(k []byte) bool {
+			return len(k) == schnorr.PubKeyBytesLen+8 &&
+				binary.BigEndian.Uint64(k[schnorr.PubKeyBytesLen:]) < height
+		}, &deleted)
+	}, func() {
+		deleted = 0
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func voteProofKey(fpPk *btcec.PublicKey, height uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, height)
+}
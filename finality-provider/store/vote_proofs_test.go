@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzVoteProofStore tests that the votes are recorded and pruned separately
+// for each finality provider
+func FuzzVoteProofStore(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewVoteProofStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		otherFp := testutil.GenRandomFinalityProvider(r, t)
+
+		startHeight := uint64(r.Int63n(1000) + 1)
+		numVotes := uint64(r.Int63n(20) + 5)
+		proofs := make([]*fpstore.VoteProof, 0, numVotes)
+		for i := uint64(0); i < numVotes; i++ {
+			proofs = append(proofs, &fpstore.VoteProof{
+				Height:    startHeight + i,
+				BlockHash: testutil.GenRandomByteArray(r, 32),
+				PubRand:   testutil.GenRandomByteArray(r, 32),
+				Proof:     testutil.GenRandomByteArray(r, 64),
+				Signature: testutil.GenRandomByteArray(r, 32),
+				TxHash:    testutil.GenRandomHexStr(r, 32),
+				Timestamp: r.Int63(),
+			})
+		}
+		err = s.AddVoteProofs(fp.BtcPk, proofs)
+		require.NoError(t, err)
+		err = s.AddVoteProofs(otherFp.BtcPk, proofs[:1])
+		require.NoError(t, err)
+
+		for _, p := range proofs {
+			got, err := s.GetVoteProof(fp.BtcPk, p.Height)
+			require.NoError(t, err)
+			require.Equal(t, p, got)
+		}
+		_, err = s.GetVoteProof(fp.BtcPk, startHeight+numVotes)
+		require.True(t, errors.Is(err, fpstore.ErrVoteProofNotFound))
+		_, err = s.GetVoteProof(otherFp.BtcPk, startHeight+1)
+		require.True(t, errors.Is(err, fpstore.ErrVoteProofNotFound))
+
+		// the votes below the height are pruned for all the finality providers
+		deleted, err := s.PruneVoteProofs(startHeight + 2)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), deleted)
+		_, err = s.GetVoteProof(fp.BtcPk, startHeight+1)
+		require.True(t, errors.Is(err, fpstore.ErrVoteProofNotFound))
+		_, err = s.GetVoteProof(otherFp.BtcPk, startHeight)
+		require.True(t, errors.Is(err, fpstore.ErrVoteProofNotFound))
+		got, err := s.GetVoteProof(fp.BtcPk, startHeight+2)
+		require.NoError(t, err)
+		require.Equal(t, proofs[2], got)
+	})
+}
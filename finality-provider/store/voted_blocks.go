@@ -0,0 +1,155 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/lightningnetwork/lnd/kvdb"
+)
+
+var (
+	// mapping: fp_pk || height -> hash of the block voted for
+	votedBlockBucketName = []byte("voted_blocks")
+)
+
+// GuardVote records that the finality provider is about to vote for the block
+// of the given height and hash, unless it would conflict with a previous vote.
+// It returns ErrConflictingVote if a different block was recorded at the same
+// height, or if no block was recorded at a height that is not above the last
+// voted height, as the block voted for then is unknown. Recording the same
+// block again is allowed so that a failed submission can be retried
+func (s *FinalityProviderStore) GuardVote(btcPk *btcec.PublicKey, height uint64, blockHash []byte) error {
+	pkBytes := schnorr.SerializePubKey(btcPk)
+	return kvdb.Batch(s.db, func(tx kvdb.RwTx) error {
+		fpBucket := tx.ReadWriteBucket(finalityProviderBucketName)
+		if fpBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+		votedBucket := tx.ReadWriteBucket(votedBlockBucketName)
+		if votedBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+
+		fpFromDb := fpBucket.Get(pkBytes)
+		if fpFromDb == nil {
+			return ErrFinalityProviderNotFound
+		}
+		storedFp, err := decodeFinalityProvider(pkBytes, fpFromDb)
+		if err != nil {
+			return err
+		}
+
+		key := votedBlockKey(btcPk, height)
+		votedHash := votedBucket.Get(key)
+		if err := checkVote(votedHash, height, storedFp.LastVotedHeight, blockHash); err != nil {
+			return err
+		}
+		if votedHash != nil {
+			return nil
+		}
+
+		return votedBucket.Put(key, blockHash)
+	})
+}
+
+// checkVote returns ErrConflictingVote if voting for the given block would
+// conflict with the block voted for at the same height, if any, or with the
+// last voted height
+func checkVote(votedHash []byte, height, lastVotedHeight uint64, blockHash []byte) error {
+	if votedHash != nil {
+		if !bytes.Equal(votedHash, blockHash) {
+			return fmt.Errorf("%w: already voted for block %X at height %d, refusing block %X",
+				ErrConflictingVote, votedHash, height, blockHash)
+		}
+		return nil
+	}
+	if height <= lastVotedHeight {
+		return fmt.Errorf("%w: height %d is not above the last voted height %d",
+			ErrConflictingVote, height, lastVotedHeight)
+	}
+
+	return nil
+}
+
+// GetVotedBlockHash returns the hash of the block the finality provider voted
+// for at the given height, or nil if none was recorded
+func (s *FinalityProviderStore) GetVotedBlockHash(btcPk *btcec.PublicKey, height uint64) ([]byte, error) {
+	var blockHash []byte
+	err := s.db.View(func(tx kvdb.RTx) error {
+		bucket := tx.ReadBucket(votedBlockBucketName)
+		if bucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+		if v := bucket.Get(votedBlockKey(btcPk, height)); v != nil {
+			blockHash = append([]byte{}, v...)
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return blockHash, nil
+}
+
+// VotedBlock is a block the finality provider was about to vote for
+type VotedBlock struct {
+	Height    uint64
+	BlockHash []byte
+}
+
+// GetPendingVotes returns the blocks the finality provider was about to vote
+// for above its last voted height in the ascending order of height. These
+// votes were intended but not confirmed, e.g., as the daemon crashed before
+// the submission succeeded
+func (s *FinalityProviderStore) GetPendingVotes(btcPk *btcec.PublicKey) ([]*VotedBlock, error) {
+	pkBytes := schnorr.SerializePubKey(btcPk)
+	var votes []*VotedBlock
+
+	err := s.db.View(func(tx kvdb.RTx) error {
+		fpBucket := tx.ReadBucket(finalityProviderBucketName)
+		if fpBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+		votedBucket := tx.ReadBucket(votedBlockBucketName)
+		if votedBucket == nil {
+			return ErrCorruptedFinalityProviderDb
+		}
+
+		fpFromDb := fpBucket.Get(pkBytes)
+		if fpFromDb == nil {
+			return ErrFinalityProviderNotFound
+		}
+		storedFp, err := decodeFinalityProvider(pkBytes, fpFromDb)
+		if err != nil {
+			return err
+		}
+
+		c := votedBucket.ReadCursor()
+		for k, v := c.Seek(votedBlockKey(btcPk, storedFp.LastVotedHeight+1)); k != nil && bytes.HasPrefix(k, pkBytes); k, v = c.Next() {
+			if len(k) != len(pkBytes)+8 {
+				return newErrCorruptRecord(votedBlockBucketName, k, fmt.Errorf("invalid key length"))
+			}
+			votes = append(votes, &VotedBlock{
+				Height:    binary.BigEndian.Uint64(k[len(pkBytes):]),
+				BlockHash: append([]byte{}, v...),
+			})
+		}
+
+		return nil
+	}, func() {})
+	if err != nil {
+		return nil, err
+	}
+
+	return votes, nil
+}
+
+func votedBlockKey(fpPk *btcec.PublicKey, height uint64) []byte {
+	key := schnorr.SerializePubKey(fpPk)
+	return binary.BigEndian.AppendUint64(key, height)
+}
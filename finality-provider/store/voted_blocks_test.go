@@ -0,0 +1,69 @@
+package store_test
+
+import (
+	"math/rand"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	fpstore "github.com/babylonlabs-io/finality-provider/finality-provider/store"
+	"github.com/babylonlabs-io/finality-provider/testutil"
+)
+
+// FuzzGuardVote tests that a finality provider cannot vote for two different
+// blocks at the same height, nor at a height not above its last voted height
+func FuzzGuardVote(f *testing.F) {
+	testutil.AddRandomSeedsToFuzzer(f, 10)
+	f.Fuzz(func(t *testing.T, seed int64) {
+		r := rand.New(rand.NewSource(seed))
+
+		cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+		db, err := cfg.GetDbBackend()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, db.Close())
+		}()
+		s, err := fpstore.NewFinalityProviderStore(db)
+		require.NoError(t, err)
+
+		fp := testutil.GenRandomFinalityProvider(r, t)
+		fpAddr, err := sdk.AccAddressFromBech32(fp.FPAddr)
+		require.NoError(t, err)
+		err = s.CreateFinalityProvider(fpAddr, fp.BtcPk, fp.Description, fp.Commission, nil, fp.KeyName, fp.ChainID, fp.Pop.BtcSig)
+		require.NoError(t, err)
+
+		height := uint64(r.Int63n(1000) + 1)
+		blockHash := testutil.GenRandomByteArray(r, 32)
+		require.NoError(t, s.GuardVote(fp.BtcPk, height, blockHash))
+		votedHash, err := s.GetVotedBlockHash(fp.BtcPk, height)
+		require.NoError(t, err)
+		require.Equal(t, blockHash, votedHash)
+
+		// the same block can be signed again, e.g., to retry the submission
+		require.NoError(t, s.GuardVote(fp.BtcPk, height, blockHash))
+		err = s.GuardVote(fp.BtcPk, height, testutil.GenRandomByteArray(r, 32))
+		require.ErrorIs(t, err, fpstore.ErrConflictingVote)
+
+		// no unknown block can be signed up to the last voted height
+		lastVotedHeight := height + uint64(r.Int63n(10)+1)
+		require.NoError(t, s.SetFpLastVotedHeight(fp.BtcPk, lastVotedHeight))
+		err = s.GuardVote(fp.BtcPk, lastVotedHeight, testutil.GenRandomByteArray(r, 32))
+		require.ErrorIs(t, err, fpstore.ErrConflictingVote)
+		require.NoError(t, s.GuardVote(fp.BtcPk, height, blockHash))
+		pendingHash := testutil.GenRandomByteArray(r, 32)
+		require.NoError(t, s.GuardVote(fp.BtcPk, lastVotedHeight+1, pendingHash))
+
+		// only the vote above the last voted height is pending
+		pending, err := s.GetPendingVotes(fp.BtcPk)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		require.Equal(t, lastVotedHeight+1, pending[0].Height)
+		require.Equal(t, pendingHash, pending[0].BlockHash)
+
+		votedHash, err = s.GetVotedBlockHash(fp.BtcPk, lastVotedHeight)
+		require.NoError(t, err)
+		require.Nil(t, votedHash)
+	})
+}
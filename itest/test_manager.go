@@ -140,6 +140,7 @@ func StartManager(t *testing.T) *TestManager {
 	eh := NewEOTSServerHandler(t, eotsCfg, eotsHomeDir, shutdownInterceptor)
 	eh.Start()
 	cfg.RpcListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
+	cfg.RestListener = fmt.Sprintf("127.0.0.1:%d", testutil.AllocateUniquePort(t))
 	eotsCli, err := client.NewEOTSManagerGRpcClient(eotsCfg.RpcListener)
 	require.NoError(t, err)
 
@@ -211,7 +212,7 @@ func StartManagerWithFinalityProvider(t *testing.T) (*TestManager, *service.Fina
 	_, _, err = tm.manager.BabylondTxBankSend(t, fpBbnKeyInfo.AccAddress.String(), "1000000ubbn", "node0")
 	require.NoError(t, err)
 
-	res, err := app.CreateFinalityProvider(testFpName, testChainID, passphrase, hdPath, nil, desc, &commission)
+	res, err := app.CreateFinalityProvider(testFpName, testChainID, passphrase, hdPath, nil, nil, desc, &commission, nil)
 	require.NoError(t, err)
 	fpPk, err := bbntypes.NewBIP340PubKeyFromHex(res.FpInfo.BtcPkHex)
 	require.NoError(t, err)
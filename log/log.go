@@ -16,6 +16,21 @@ import (
 )
 
 func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return newRootLogger(format, lvl, w)
+}
+
+// NewRootLoggerWithLevel creates a root logger whose level can be changed at
+// runtime through the given atomic level
+func NewRootLoggerWithLevel(format string, level zap.AtomicLevel, w io.Writer) (*zap.Logger, error) {
+	return newRootLogger(format, level, w)
+}
+
+func newRootLogger(format string, level zapcore.LevelEnabler, w io.Writer) (*zap.Logger, error) {
 	cfg := zap.NewProductionEncoderConfig()
 	cfg.EncodeTime = func(ts time.Time, encoder zapcore.PrimitiveArrayEncoder) {
 		encoder.AppendString(ts.UTC().Format("2006-01-02T15:04:05.000000Z07:00"))
@@ -34,41 +49,38 @@ func NewRootLogger(format string, level string, w io.Writer) (*zap.Logger, error
 		return nil, fmt.Errorf("unrecognized log format %q", format)
 	}
 
-	var lvl zapcore.Level
+	return zap.New(zapcore.NewCore(
+		enc,
+		zapcore.AddSync(w),
+		level,
+	)), nil
+}
+
+// ParseLevel parses the given log level name
+func ParseLevel(level string) (zapcore.Level, error) {
 	switch strings.ToLower(level) {
 	case "panic":
-		lvl = zap.PanicLevel
+		return zap.PanicLevel, nil
 	case "fatal":
-		lvl = zap.FatalLevel
+		return zap.FatalLevel, nil
 	case "error":
-		lvl = zap.ErrorLevel
+		return zap.ErrorLevel, nil
 	case "warn", "warning":
-		lvl = zap.WarnLevel
+		return zap.WarnLevel, nil
 	case "info":
-		lvl = zap.InfoLevel
+		return zap.InfoLevel, nil
 	case "debug":
-		lvl = zap.DebugLevel
+		return zap.DebugLevel, nil
 	default:
-		return nil, fmt.Errorf("unsupported log level: %s", level)
+		return zap.InfoLevel, fmt.Errorf("unsupported log level: %s", level)
 	}
-
-	return zap.New(zapcore.NewCore(
-		enc,
-		zapcore.AddSync(w),
-		lvl,
-	)), nil
 }
 
 func NewRootLoggerWithFile(logFile string, level string) (*zap.Logger, error) {
-	if err := util.MakeDirectory(filepath.Dir(logFile)); err != nil {
-		return nil, err
-	}
-	// #nosec G304 - The log file path is provided by the user and not externally
-	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	mw, err := newLogFileWriter(logFile)
 	if err != nil {
 		return nil, err
 	}
-	mw := io.MultiWriter(os.Stdout, f)
 
 	logger, err := NewRootLogger("console", level, mw)
 	if err != nil {
@@ -76,3 +88,30 @@ func NewRootLoggerWithFile(logFile string, level string) (*zap.Logger, error) {
 	}
 	return logger, nil
 }
+
+// NewRootLoggerWithFileAndLevel is the same as NewRootLoggerWithFile, except
+// that the level of the logger can be changed at runtime through the given
+// atomic level
+func NewRootLoggerWithFileAndLevel(logFile string, level zap.AtomicLevel) (*zap.Logger, error) {
+	mw, err := newLogFileWriter(logFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRootLoggerWithLevel("console", level, mw)
+}
+
+// newLogFileWriter opens the log file for appending and returns a writer that
+// writes to both the log file and stdout
+func newLogFileWriter(logFile string) (io.Writer, error) {
+	if err := util.MakeDirectory(filepath.Dir(logFile)); err != nil {
+		return nil, err
+	}
+	// #nosec G304 - The log file path is provided by the user and not externally
+	f, err := os.OpenFile(logFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return io.MultiWriter(os.Stdout, f), nil
+}
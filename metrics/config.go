@@ -11,12 +11,16 @@ const (
 	defaultEotsMetricsPort       = 2113
 	defaultMetricsHost           = "127.0.0.1"
 	defaultMetricsUpdateInterval = 100 * time.Millisecond
+	defaultDbStatsInterval       = time.Minute
 )
 
 type Config struct {
 	Host           string        `long:"host" description:"IP of the Prometheus server"`
 	Port           int           `long:"port" description:"Port of the Prometheus server"`
 	UpdateInterval time.Duration `long:"updateinterval" description:"The interval of Prometheus metrics updated"`
+	// DbStatsInterval is longer than UpdateInterval as the size metrics walk
+	// all the keys of the database
+	DbStatsInterval time.Duration `long:"dbstatsinterval" description:"The interval of the database size and bucket key count metrics updated, 0 to disable them"`
 }
 
 func (cfg *Config) Validate() error {
@@ -29,6 +33,10 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("invalid host: %v", cfg.Host)
 	}
 
+	if cfg.DbStatsInterval < 0 {
+		return fmt.Errorf("dbstatsinterval should not be negative")
+	}
+
 	return nil
 }
 
@@ -41,16 +49,18 @@ func (cfg *Config) Address() (string, error) {
 
 func DefaultFpConfig() *Config {
 	return &Config{
-		Port:           defaultFpMetricsPort,
-		Host:           defaultMetricsHost,
-		UpdateInterval: defaultMetricsUpdateInterval,
+		Port:            defaultFpMetricsPort,
+		Host:            defaultMetricsHost,
+		UpdateInterval:  defaultMetricsUpdateInterval,
+		DbStatsInterval: defaultDbStatsInterval,
 	}
 }
 
 func DefaultEotsConfig() *Config {
 	return &Config{
-		Port:           defaultEotsMetricsPort,
-		Host:           defaultMetricsHost,
-		UpdateInterval: defaultMetricsUpdateInterval,
+		Port:            defaultEotsMetricsPort,
+		Host:            defaultMetricsHost,
+		UpdateInterval:  defaultMetricsUpdateInterval,
+		DbStatsInterval: defaultDbStatsInterval,
 	}
 }
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcwallet/walletdb"
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// FpDbName and EotsDbName are the values of the db label of the metrics
+	// of the finality provider and the EOTS manager databases
+	FpDbName   = "finality-provider"
+	EotsDbName = "eots"
+
+	txTypeRead  = "read"
+	txTypeWrite = "write"
+)
+
+type DbMetrics struct {
+	dbSizeBytes  *prometheus.GaugeVec
+	dbBucketKeys *prometheus.GaugeVec
+	dbTxDuration *prometheus.HistogramVec
+	dbTxFailures *prometheus.CounterVec
+}
+
+var dbMetricsRegisterOnce sync.Once
+
+var dbMetricsInstance *DbMetrics
+
+// NewDbMetrics initializes and registers the metrics of the databases, using
+// sync.Once to ensure it's done only once
+func NewDbMetrics() *DbMetrics {
+	dbMetricsRegisterOnce.Do(func() {
+		dbMetricsInstance = &DbMetrics{
+			dbSizeBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "db_size_bytes",
+				Help: "The size of the database file in bytes",
+			}, []string{"db"}),
+			dbBucketKeys: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Name: "db_bucket_keys",
+				Help: "The number of keys of each top-level bucket of the database",
+			}, []string{"db", "bucket"}),
+			dbTxDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "db_tx_duration_seconds",
+				Help:    "The duration of the read and write transactions of the database, including the wait for the lock",
+				Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+			}, []string{"db", "type"}),
+			dbTxFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "db_tx_failures_total",
+				Help: "The number of the read and write transactions of the database that failed to begin or commit",
+			}, []string{"db", "type"}),
+		}
+
+		prometheus.MustRegister(dbMetricsInstance.dbSizeBytes)
+		prometheus.MustRegister(dbMetricsInstance.dbBucketKeys)
+		prometheus.MustRegister(dbMetricsInstance.dbTxDuration)
+		prometheus.MustRegister(dbMetricsInstance.dbTxFailures)
+	})
+
+	return dbMetricsInstance
+}
+
+// ObserveTxDuration records the duration of a transaction of the database
+func (dm *DbMetrics) ObserveTxDuration(dbName, txType string, d time.Duration) {
+	dm.dbTxDuration.WithLabelValues(dbName, txType).Observe(d.Seconds())
+}
+
+// IncrementTxFailures increments the number of the failed transactions of the database
+func (dm *DbMetrics) IncrementTxFailures(dbName, txType string) {
+	dm.dbTxFailures.WithLabelValues(dbName, txType).Inc()
+}
+
+// UpdateDbStats records the size of the database file at the given path and
+// the number of keys of each top-level bucket of the database. The nested
+// buckets count as one key of their parent bucket. The buckets are walked in
+// a single read transaction, so it should not be called too often on large
+// databases
+func (dm *DbMetrics) UpdateDbStats(dbName string, db kvdb.Backend, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the database file %s: %w", path, err)
+	}
+	dm.dbSizeBytes.WithLabelValues(dbName).Set(float64(info.Size()))
+
+	counts := make(map[string]int)
+	err = db.View(func(tx kvdb.RTx) error {
+		return tx.ForEachBucket(func(name []byte) error {
+			bucket := tx.ReadBucket(name)
+			if bucket == nil {
+				return nil
+			}
+			n := 0
+			if err := bucket.ForEach(func(_, _ []byte) error {
+				n++
+				return nil
+			}); err != nil {
+				return err
+			}
+			counts[string(name)] = n
+			return nil
+		})
+	}, func() {
+		counts = make(map[string]int)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to count the keys of the database: %w", err)
+	}
+
+	for name, n := range counts {
+		dm.dbBucketKeys.WithLabelValues(dbName, name).Set(float64(n))
+	}
+
+	return nil
+}
+
+// instrumentedDb records the duration and the failures of the transactions of
+// the wrapped database
+type instrumentedDb struct {
+	kvdb.Backend
+
+	name    string
+	metrics *DbMetrics
+}
+
+// InstrumentDb returns the given database recording the duration and the
+// failures of its transactions under the given name. Only the failures of
+// the database itself are counted, not the errors returned by the functions
+// run in the transactions, e.g., when a record is not found
+func InstrumentDb(db kvdb.Backend, name string) kvdb.Backend {
+	return &instrumentedDb{
+		Backend: db,
+		name:    name,
+		metrics: NewDbMetrics(),
+	}
+}
+
+func (db *instrumentedDb) BeginReadTx() (walletdb.ReadTx, error) {
+	tx, err := db.Backend.BeginReadTx()
+	if err != nil {
+		db.metrics.IncrementTxFailures(db.name, txTypeRead)
+	}
+	return tx, err
+}
+
+func (db *instrumentedDb) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
+	tx, err := db.Backend.BeginReadWriteTx()
+	if err != nil {
+		db.metrics.IncrementTxFailures(db.name, txTypeWrite)
+	}
+	return tx, err
+}
+
+func (db *instrumentedDb) View(f func(tx walletdb.ReadTx) error, reset func()) error {
+	var fErr error
+	start := time.Now()
+	err := db.Backend.View(func(tx walletdb.ReadTx) error {
+		fErr = f(tx)
+		return fErr
+	}, reset)
+	db.record(txTypeRead, start, err, fErr)
+	return err
+}
+
+func (db *instrumentedDb) Update(f func(tx walletdb.ReadWriteTx) error, reset func()) error {
+	var fErr error
+	start := time.Now()
+	err := db.Backend.Update(func(tx walletdb.ReadWriteTx) error {
+		fErr = f(tx)
+		return fErr
+	}, reset)
+	db.record(txTypeWrite, start, err, fErr)
+	return err
+}
+
+// Batch keeps the batching of the wrapped database, if it supports it
+func (db *instrumentedDb) Batch(f func(tx walletdb.ReadWriteTx) error) error {
+	var fErr error
+	start := time.Now()
+	err := kvdb.Batch(db.Backend, func(tx walletdb.ReadWriteTx) error {
+		fErr = f(tx)
+		return fErr
+	})
+	db.record(txTypeWrite, start, err, fErr)
+	return err
+}
+
+// record records the duration of the transaction and counts it as failed if
+// its error does not come from the function run in it
+func (db *instrumentedDb) record(txType string, start time.Time, err, fErr error) {
+	db.metrics.ObserveTxDuration(db.name, txType, time.Since(start))
+	if err != nil && err != fErr {
+		db.metrics.IncrementTxFailures(db.name, txType)
+	}
+}
@@ -0,0 +1,113 @@
+package metrics_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lightningnetwork/lnd/kvdb"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/babylonlabs-io/finality-provider/finality-provider/config"
+	"github.com/babylonlabs-io/finality-provider/metrics"
+)
+
+// TestDbMetrics tests that the transactions of an instrumented db are timed,
+// that only the failures of the db are counted, and that the size and the key
+// counts of the buckets are recorded
+func TestDbMetrics(t *testing.T) {
+	const dbName = "test"
+
+	cfg := config.DefaultDBConfigWithHomePath(t.TempDir())
+	rawDb, err := cfg.GetDbBackend()
+	require.NoError(t, err)
+	db := metrics.InstrumentDb(rawDb, dbName)
+
+	bucketName := []byte("bucket")
+	err = kvdb.Batch(db, func(tx kvdb.RwTx) error {
+		bucket, err := tx.CreateTopLevelBucket(bucketName)
+		if err != nil {
+			return err
+		}
+		for _, k := range []string{"a", "b", "c"} {
+			if err := bucket.Put([]byte(k), []byte(k)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	errNotFound := errors.New("not found")
+	err = kvdb.View(db, func(tx kvdb.RTx) error {
+		return errNotFound
+	}, func() {})
+	require.ErrorIs(t, err, errNotFound)
+
+	require.Equal(t, uint64(1), histogramCount(t, "db_tx_duration_seconds", dbName, "write"))
+	require.Equal(t, uint64(1), histogramCount(t, "db_tx_duration_seconds", dbName, "read"))
+	require.Zero(t, metricValue(t, "db_tx_failures_total", dbName, "read"))
+
+	err = metrics.NewDbMetrics().UpdateDbStats(dbName, db, cfg.DBFilePath())
+	require.NoError(t, err)
+	require.Positive(t, metricValue(t, "db_size_bytes", dbName))
+	require.Equal(t, float64(3), metricValue(t, "db_bucket_keys", string(bucketName), dbName))
+
+	// the transactions fail once the db is closed
+	require.NoError(t, rawDb.Close())
+	err = kvdb.Update(db, func(tx kvdb.RwTx) error {
+		return nil
+	}, func() {})
+	require.Error(t, err)
+	require.Equal(t, float64(1), metricValue(t, "db_tx_failures_total", dbName, "write"))
+}
+
+// findMetric returns the metric of the given name whose label values are the
+// given ones, in the order of the sorted label names, or nil if there is none
+func findMetric(t *testing.T, name string, labelValues ...string) *dto.Metric {
+	families, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if len(m.GetLabel()) != len(labelValues) {
+				continue
+			}
+			match := true
+			for i, l := range m.GetLabel() {
+				if l.GetValue() != labelValues[i] {
+					match = false
+				}
+			}
+			if match {
+				return m
+			}
+		}
+	}
+
+	return nil
+}
+
+func metricValue(t *testing.T, name string, labelValues ...string) float64 {
+	m := findMetric(t, name, labelValues...)
+	switch {
+	case m == nil:
+		return 0
+	case m.GetGauge() != nil:
+		return m.GetGauge().GetValue()
+	default:
+		return m.GetCounter().GetValue()
+	}
+}
+
+func histogramCount(t *testing.T, name string, labelValues ...string) uint64 {
+	m := findMetric(t, name, labelValues...)
+	if m == nil {
+		return 0
+	}
+	return m.GetHistogram().GetSampleCount()
+}
@@ -17,6 +17,10 @@ type FpMetrics struct {
 	babylonTipHeight     prometheus.Gauge
 	lastPolledHeight     prometheus.Gauge
 	pollerStartingHeight prometheus.Gauge
+	pollerQueueDepth     prometheus.Gauge
+	pollerDroppedBlocks  prometheus.Counter
+	// submission metrics
+	submissionsPaused prometheus.Gauge
 	// single finality provider metrics
 	fpStatus                        *prometheus.GaugeVec
 	fpSecondsSinceLastVote          *prometheus.GaugeVec
@@ -25,10 +29,12 @@ type FpMetrics struct {
 	fpLastProcessedHeight           *prometheus.GaugeVec
 	fpLastCommittedRandomnessHeight *prometheus.GaugeVec
 	fpTotalBlocksWithoutVotingPower *prometheus.CounterVec
+	fpTotalSkippedFinalizedBlocks   *prometheus.CounterVec
 	fpTotalVotedBlocks              *prometheus.GaugeVec
 	fpTotalCommittedRandomness      *prometheus.GaugeVec
 	fpTotalFailedVotes              *prometheus.CounterVec
 	fpTotalFailedRandomness         *prometheus.CounterVec
+	fpTotalMissedBlocks             *prometheus.CounterVec
 	// time keeper
 	mu                     sync.Mutex
 	previousVoteByFp       map[string]*time.Time
@@ -57,6 +63,10 @@ func NewFpMetrics() *FpMetrics {
 				Name: "babylon_tip_height",
 				Help: "The current tip height of the Babylon network",
 			}),
+			submissionsPaused: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "submissions_paused",
+				Help: "Whether the submissions to the consumer chain are paused by the circuit breaker after consecutive failures",
+			}),
 			lastPolledHeight: prometheus.NewGauge(prometheus.GaugeOpts{
 				Name: "last_polled_height",
 				Help: "The most recent block height checked by the poller",
@@ -65,6 +75,14 @@ func NewFpMetrics() *FpMetrics {
 				Name: "poller_starting_height",
 				Help: "The initial block height when the poller started operation",
 			}),
+			pollerQueueDepth: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "poller_queue_depth",
+				Help: "The number of polled blocks waiting to be processed",
+			}),
+			pollerDroppedBlocks: prometheus.NewCounter(prometheus.CounterOpts{
+				Name: "poller_dropped_blocks",
+				Help: "The total number of polled blocks dropped from the full buffer to be processed later",
+			}),
 			fpSecondsSinceLastVote: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
 					Name: "fp_seconds_since_last_vote",
@@ -100,6 +118,13 @@ func NewFpMetrics() *FpMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			fpTotalSkippedFinalizedBlocks: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_skipped_finalized_blocks",
+					Help: "The total number of blocks a finality provider skipped voting for as they were already finalized.",
+				},
+				[]string{"fp_btc_pk_hex"},
+			),
 			fpTotalVotedBlocks: prometheus.NewGaugeVec(
 				prometheus.GaugeOpts{
 					Name: "fp_total_voted_blocks",
@@ -135,6 +160,13 @@ func NewFpMetrics() *FpMetrics {
 				},
 				[]string{"fp_btc_pk_hex"},
 			),
+			fpTotalMissedBlocks: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Name: "fp_total_missed_blocks",
+					Help: "The total number of blocks a finality provider failed to vote for by reason.",
+				},
+				[]string{"fp_btc_pk_hex", "reason"},
+			),
 			mu: sync.Mutex{},
 		}
 
@@ -144,16 +176,21 @@ func NewFpMetrics() *FpMetrics {
 		prometheus.MustRegister(fpMetricsInstance.babylonTipHeight)
 		prometheus.MustRegister(fpMetricsInstance.lastPolledHeight)
 		prometheus.MustRegister(fpMetricsInstance.pollerStartingHeight)
+		prometheus.MustRegister(fpMetricsInstance.pollerQueueDepth)
+		prometheus.MustRegister(fpMetricsInstance.pollerDroppedBlocks)
+		prometheus.MustRegister(fpMetricsInstance.submissionsPaused)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastVote)
 		prometheus.MustRegister(fpMetricsInstance.fpSecondsSinceLastRandomness)
 		prometheus.MustRegister(fpMetricsInstance.fpLastVotedHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpLastProcessedHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalBlocksWithoutVotingPower)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalSkippedFinalizedBlocks)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalVotedBlocks)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalCommittedRandomness)
 		prometheus.MustRegister(fpMetricsInstance.fpLastCommittedRandomnessHeight)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedVotes)
 		prometheus.MustRegister(fpMetricsInstance.fpTotalFailedRandomness)
+		prometheus.MustRegister(fpMetricsInstance.fpTotalMissedBlocks)
 	})
 	return fpMetricsInstance
 }
@@ -178,11 +215,32 @@ func (fm *FpMetrics) RecordBabylonTipHeight(height uint64) {
 	fm.babylonTipHeight.Set(float64(height))
 }
 
+// RecordSubmissionsPaused records whether the submissions to the consumer chain
+// are paused by the circuit breaker
+func (fm *FpMetrics) RecordSubmissionsPaused(paused bool) {
+	if paused {
+		fm.submissionsPaused.Set(1)
+	} else {
+		fm.submissionsPaused.Set(0)
+	}
+}
+
 // RecordLastPolledHeight records the most recent block height checked by the poller
 func (fm *FpMetrics) RecordLastPolledHeight(height uint64) {
 	fm.lastPolledHeight.Set(float64(height))
 }
 
+// RecordPollerQueueDepth records the number of polled blocks waiting to be processed
+func (fm *FpMetrics) RecordPollerQueueDepth(depth int) {
+	fm.pollerQueueDepth.Set(float64(depth))
+}
+
+// IncrementPollerDroppedBlocks increments the number of polled blocks dropped
+// from the full buffer
+func (fm *FpMetrics) IncrementPollerDroppedBlocks() {
+	fm.pollerDroppedBlocks.Inc()
+}
+
 // RecordPollerStartingHeight records the initial block height when the poller started operation
 func (fm *FpMetrics) RecordPollerStartingHeight(height uint64) {
 	fm.pollerStartingHeight.Set(float64(height))
@@ -218,6 +276,11 @@ func (fm *FpMetrics) IncrementFpTotalBlocksWithoutVotingPower(fpBtcPkHex string)
 	fm.fpTotalBlocksWithoutVotingPower.WithLabelValues(fpBtcPkHex).Inc()
 }
 
+// IncrementFpTotalSkippedFinalizedBlocks increments the total number of already finalized blocks skipped by a finality provider
+func (fm *FpMetrics) IncrementFpTotalSkippedFinalizedBlocks(fpBtcPkHex string) {
+	fm.fpTotalSkippedFinalizedBlocks.WithLabelValues(fpBtcPkHex).Inc()
+}
+
 // IncrementFpTotalVotedBlocks increments the total number of blocks voted by a finality provider
 func (fm *FpMetrics) IncrementFpTotalVotedBlocks(fpBtcPkHex string) {
 	fm.fpTotalVotedBlocks.WithLabelValues(fpBtcPkHex).Inc()
@@ -243,6 +306,11 @@ func (fm *FpMetrics) IncrementFpTotalFailedRandomness(fpBtcPkHex string) {
 	fm.fpTotalFailedRandomness.WithLabelValues(fpBtcPkHex).Inc()
 }
 
+// IncrementFpTotalMissedBlocks increments the total number of blocks a finality provider failed to vote for
+func (fm *FpMetrics) IncrementFpTotalMissedBlocks(fpBtcPkHex, reason string) {
+	fm.fpTotalMissedBlocks.WithLabelValues(fpBtcPkHex, reason).Inc()
+}
+
 // RecordFpVoteTime records the time of a finality sig vote by a finality provider
 func (fm *FpMetrics) RecordFpVoteTime(fpBtcPkHex string) {
 	fm.mu.Lock()
@@ -12,6 +12,7 @@ import (
 // Server represents the metrics server.
 type Server struct {
 	httpServer *http.Server
+	mux        *http.ServeMux
 	logger     *zap.Logger
 }
 
@@ -32,6 +33,7 @@ func Start(addr string, logger *zap.Logger) *Server {
 	// Store the logger in the server struct
 	s := &Server{
 		httpServer: server,
+		mux:        mux,
 		logger:     logger,
 	}
 
@@ -46,6 +48,12 @@ func Start(addr string, logger *zap.Logger) *Server {
 	return s
 }
 
+// Handle registers an additional handler for the given pattern, e.g., to
+// serve health reports next to the metrics.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
 // Stop gracefully shuts down the metrics server.
 func (s *Server) Stop(ctx context.Context) {
 	s.logger.Info("Stopping metrics server")
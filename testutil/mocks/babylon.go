@@ -8,11 +8,13 @@ import (
 	reflect "reflect"
 
 	math "cosmossdk.io/math"
-	types "github.com/babylonlabs-io/babylon/x/btcstaking/types"
-	types0 "github.com/babylonlabs-io/babylon/x/finality/types"
-	types1 "github.com/babylonlabs-io/finality-provider/types"
+	types "github.com/babylonlabs-io/babylon/types"
+	types0 "github.com/babylonlabs-io/babylon/x/btcstaking/types"
+	types1 "github.com/babylonlabs-io/babylon/x/finality/types"
+	types2 "github.com/babylonlabs-io/finality-provider/types"
 	btcec "github.com/btcsuite/btcd/btcec/v2"
 	schnorr "github.com/btcsuite/btcd/btcec/v2/schnorr"
+	types3 "github.com/cosmos/cosmos-sdk/types"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -54,10 +56,10 @@ func (mr *MockClientControllerMockRecorder) Close() *gomock.Call {
 }
 
 // CommitPubRandList mocks base method.
-func (m *MockClientController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types1.TxResponse, error) {
+func (m *MockClientController) CommitPubRandList(fpPk *btcec.PublicKey, startHeight, numPubRand uint64, commitment []byte, sig *schnorr.Signature) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CommitPubRandList", fpPk, startHeight, numPubRand, commitment, sig)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -69,10 +71,10 @@ func (mr *MockClientControllerMockRecorder) CommitPubRandList(fpPk, startHeight,
 }
 
 // EditFinalityProvider mocks base method.
-func (m *MockClientController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types.MsgEditFinalityProvider, error) {
+func (m *MockClientController) EditFinalityProvider(fpPk *btcec.PublicKey, commission *math.LegacyDec, description []byte) (*types0.MsgEditFinalityProvider, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "EditFinalityProvider", fpPk, commission, description)
-	ret0, _ := ret[0].(*types.MsgEditFinalityProvider)
+	ret0, _ := ret[0].(*types0.MsgEditFinalityProvider)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -99,10 +101,10 @@ func (mr *MockClientControllerMockRecorder) QueryActivatedHeight() *gomock.Call
 }
 
 // QueryBestBlock mocks base method.
-func (m *MockClientController) QueryBestBlock() (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBestBlock() (*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBestBlock")
-	ret0, _ := ret[0].(*types1.BlockInfo)
+	ret0, _ := ret[0].(*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -114,10 +116,10 @@ func (mr *MockClientControllerMockRecorder) QueryBestBlock() *gomock.Call {
 }
 
 // QueryBlock mocks base method.
-func (m *MockClientController) QueryBlock(height uint64) (*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlock(height uint64) (*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBlock", height)
-	ret0, _ := ret[0].(*types1.BlockInfo)
+	ret0, _ := ret[0].(*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -129,10 +131,10 @@ func (mr *MockClientControllerMockRecorder) QueryBlock(height interface{}) *gomo
 }
 
 // QueryBlocks mocks base method.
-func (m *MockClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryBlocks(startHeight, endHeight uint64, limit uint32) ([]*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryBlocks", startHeight, endHeight, limit)
-	ret0, _ := ret[0].([]*types1.BlockInfo)
+	ret0, _ := ret[0].([]*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -143,6 +145,82 @@ func (mr *MockClientControllerMockRecorder) QueryBlocks(startHeight, endHeight,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryBlocks", reflect.TypeOf((*MockClientController)(nil).QueryBlocks), startHeight, endHeight, limit)
 }
 
+// QueryChainID mocks base method.
+func (m *MockClientController) QueryChainID() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryChainID")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryChainID indicates an expected call of QueryChainID.
+func (mr *MockClientControllerMockRecorder) QueryChainID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryChainID", reflect.TypeOf((*MockClientController)(nil).QueryChainID))
+}
+
+// QueryCurrentEpoch mocks base method.
+func (m *MockClientController) QueryCurrentEpoch() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryCurrentEpoch")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryCurrentEpoch indicates an expected call of QueryCurrentEpoch.
+func (mr *MockClientControllerMockRecorder) QueryCurrentEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryCurrentEpoch", reflect.TypeOf((*MockClientController)(nil).QueryCurrentEpoch))
+}
+
+// QueryFinalityParams mocks base method.
+func (m *MockClientController) QueryFinalityParams() (*types1.Params, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityParams")
+	ret0, _ := ret[0].(*types1.Params)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityParams indicates an expected call of QueryFinalityParams.
+func (mr *MockClientControllerMockRecorder) QueryFinalityParams() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityParams", reflect.TypeOf((*MockClientController)(nil).QueryFinalityParams))
+}
+
+// QueryFinalityProviderDelegations mocks base method.
+func (m *MockClientController) QueryFinalityProviderDelegations(fpPk *btcec.PublicKey, pageKey []byte, limit uint64) ([]*types2.Delegation, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderDelegations", fpPk, pageKey, limit)
+	ret0, _ := ret[0].([]*types2.Delegation)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// QueryFinalityProviderDelegations indicates an expected call of QueryFinalityProviderDelegations.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderDelegations(fpPk, pageKey, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderDelegations", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderDelegations), fpPk, pageKey, limit)
+}
+
+// QueryFinalityProviderRewards mocks base method.
+func (m *MockClientController) QueryFinalityProviderRewards() (types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryFinalityProviderRewards")
+	ret0, _ := ret[0].(types3.Coins)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryFinalityProviderRewards indicates an expected call of QueryFinalityProviderRewards.
+func (mr *MockClientControllerMockRecorder) QueryFinalityProviderRewards() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderRewards", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderRewards))
+}
+
 // QueryFinalityProviderSlashedOrJailed mocks base method.
 func (m *MockClientController) QueryFinalityProviderSlashedOrJailed(fpPk *btcec.PublicKey) (bool, bool, error) {
 	m.ctrl.T.Helper()
@@ -174,11 +252,26 @@ func (mr *MockClientControllerMockRecorder) QueryFinalityProviderVotingPower(fpP
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryFinalityProviderVotingPower", reflect.TypeOf((*MockClientController)(nil).QueryFinalityProviderVotingPower), fpPk, blockHeight)
 }
 
+// QueryGenesisBlockHash mocks base method.
+func (m *MockClientController) QueryGenesisBlockHash() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryGenesisBlockHash")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryGenesisBlockHash indicates an expected call of QueryGenesisBlockHash.
+func (mr *MockClientControllerMockRecorder) QueryGenesisBlockHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryGenesisBlockHash", reflect.TypeOf((*MockClientController)(nil).QueryGenesisBlockHash))
+}
+
 // QueryLastCommittedPublicRand mocks base method.
-func (m *MockClientController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*types0.PubRandCommitResponse, error) {
+func (m *MockClientController) QueryLastCommittedPublicRand(fpPk *btcec.PublicKey, count uint64) (map[uint64]*types1.PubRandCommitResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryLastCommittedPublicRand", fpPk, count)
-	ret0, _ := ret[0].(map[uint64]*types0.PubRandCommitResponse)
+	ret0, _ := ret[0].(map[uint64]*types1.PubRandCommitResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -189,11 +282,26 @@ func (mr *MockClientControllerMockRecorder) QueryLastCommittedPublicRand(fpPk, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastCommittedPublicRand", reflect.TypeOf((*MockClientController)(nil).QueryLastCommittedPublicRand), fpPk, count)
 }
 
+// QueryLastFinalizedEpoch mocks base method.
+func (m *MockClientController) QueryLastFinalizedEpoch() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryLastFinalizedEpoch")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryLastFinalizedEpoch indicates an expected call of QueryLastFinalizedEpoch.
+func (mr *MockClientControllerMockRecorder) QueryLastFinalizedEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLastFinalizedEpoch", reflect.TypeOf((*MockClientController)(nil).QueryLastFinalizedEpoch))
+}
+
 // QueryLatestFinalizedBlocks mocks base method.
-func (m *MockClientController) QueryLatestFinalizedBlocks(count uint64) ([]*types1.BlockInfo, error) {
+func (m *MockClientController) QueryLatestFinalizedBlocks(count uint64) ([]*types2.BlockInfo, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "QueryLatestFinalizedBlocks", count)
-	ret0, _ := ret[0].([]*types1.BlockInfo)
+	ret0, _ := ret[0].([]*types2.BlockInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -204,11 +312,41 @@ func (mr *MockClientControllerMockRecorder) QueryLatestFinalizedBlocks(count int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryLatestFinalizedBlocks", reflect.TypeOf((*MockClientController)(nil).QueryLatestFinalizedBlocks), count)
 }
 
+// QueryNodeVersion mocks base method.
+func (m *MockClientController) QueryNodeVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryNodeVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryNodeVersion indicates an expected call of QueryNodeVersion.
+func (mr *MockClientControllerMockRecorder) QueryNodeVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryNodeVersion", reflect.TypeOf((*MockClientController)(nil).QueryNodeVersion))
+}
+
+// QueryVotesAtHeight mocks base method.
+func (m *MockClientController) QueryVotesAtHeight(height uint64) ([]types.BIP340PubKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryVotesAtHeight", height)
+	ret0, _ := ret[0].([]types.BIP340PubKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryVotesAtHeight indicates an expected call of QueryVotesAtHeight.
+func (mr *MockClientControllerMockRecorder) QueryVotesAtHeight(height interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryVotesAtHeight", reflect.TypeOf((*MockClientController)(nil).QueryVotesAtHeight), height)
+}
+
 // RegisterFinalityProvider mocks base method.
-func (m *MockClientController) RegisterFinalityProvider(fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types1.TxResponse, error) {
+func (m *MockClientController) RegisterFinalityProvider(fpPk *btcec.PublicKey, pop []byte, commission *math.LegacyDec, description []byte) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "RegisterFinalityProvider", fpPk, pop, commission, description)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -220,10 +358,10 @@ func (mr *MockClientControllerMockRecorder) RegisterFinalityProvider(fpPk, pop,
 }
 
 // SubmitBatchFinalitySigs mocks base method.
-func (m *MockClientController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types1.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitBatchFinalitySigs(fpPk *btcec.PublicKey, blocks []*types2.BlockInfo, pubRandList []*btcec.FieldVal, proofList [][]byte, sigs []*btcec.ModNScalar) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SubmitBatchFinalitySigs", fpPk, blocks, pubRandList, proofList, sigs)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -235,10 +373,10 @@ func (mr *MockClientControllerMockRecorder) SubmitBatchFinalitySigs(fpPk, blocks
 }
 
 // SubmitFinalitySig mocks base method.
-func (m *MockClientController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types1.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types1.TxResponse, error) {
+func (m *MockClientController) SubmitFinalitySig(fpPk *btcec.PublicKey, block *types2.BlockInfo, pubRand *btcec.FieldVal, proof []byte, sig *btcec.ModNScalar) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SubmitFinalitySig", fpPk, block, pubRand, proof, sig)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -250,10 +388,10 @@ func (mr *MockClientControllerMockRecorder) SubmitFinalitySig(fpPk, block, pubRa
 }
 
 // UnjailFinalityProvider mocks base method.
-func (m *MockClientController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types1.TxResponse, error) {
+func (m *MockClientController) UnjailFinalityProvider(fpPk *btcec.PublicKey) (*types2.TxResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UnjailFinalityProvider", fpPk)
-	ret0, _ := ret[0].(*types1.TxResponse)
+	ret0, _ := ret[0].(*types2.TxResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -263,3 +401,19 @@ func (mr *MockClientControllerMockRecorder) UnjailFinalityProvider(fpPk interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnjailFinalityProvider", reflect.TypeOf((*MockClientController)(nil).UnjailFinalityProvider), fpPk)
 }
+
+// WithdrawFinalityProviderRewards mocks base method.
+func (m *MockClientController) WithdrawFinalityProviderRewards(recipient string) (*types2.TxResponse, types3.Coins, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawFinalityProviderRewards", recipient)
+	ret0, _ := ret[0].(*types2.TxResponse)
+	ret1, _ := ret[1].(types3.Coins)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// WithdrawFinalityProviderRewards indicates an expected call of WithdrawFinalityProviderRewards.
+func (mr *MockClientControllerMockRecorder) WithdrawFinalityProviderRewards(recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawFinalityProviderRewards", reflect.TypeOf((*MockClientController)(nil).WithdrawFinalityProviderRewards), recipient)
+}
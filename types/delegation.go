@@ -0,0 +1,19 @@
+package types
+
+// Delegation is a BTC delegation to a finality provider
+type Delegation struct {
+	// StakerAddr is the Babylon address of the staker
+	StakerAddr string
+	// BtcPkHex is the hex string of the BTC public key of the staker
+	BtcPkHex string
+	// StakingTxHash is the hash of the staking tx
+	StakingTxHash string
+	// TotalSat is the staked amount in satoshis
+	TotalSat uint64
+	// StartHeight and EndHeight are the BTC heights of the staking timelock
+	StartHeight uint64
+	EndHeight   uint64
+	// Status is the status of the delegation on the consumer chain, e.g.,
+	// PENDING, VERIFIED, ACTIVE, or UNBONDED
+	Status string
+}
@@ -7,4 +7,9 @@ import (
 type TxResponse struct {
 	TxHash string
 	Events []provider.RelayerEvent
+	// GasUsed is the gas used by the transaction, if known
+	GasUsed int64
+	// Signer is the address of the account that signed the transaction and
+	// paid its fees
+	Signer string
 }
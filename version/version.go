@@ -28,8 +28,16 @@ var (
 	// GoVersion stores the go version that the executable was compiled
 	// with.
 	GoVersion string
+
+	// babylonVersion stores the version of the Babylon module that the
+	// executable was compiled against.
+	babylonVersion string
 )
 
+// babylonModulePath is the path of the Babylon module, whose protobuf
+// messages the executable exchanges with the Babylon node.
+const babylonModulePath = "github.com/babylonlabs-io/babylon"
+
 // semanticAlphabet is the set of characters that are permitted for use in an
 // AppPreRelease.
 const semanticAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-."
@@ -126,6 +134,15 @@ func init() {
 				RawTags = setting.Value
 			}
 		}
+		for _, dep := range info.Deps {
+			if dep.Path != babylonModulePath {
+				continue
+			}
+			babylonVersion = dep.Version
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				babylonVersion = dep.Replace.Version
+			}
+		}
 	}
 }
 
@@ -135,6 +152,12 @@ func Version() string {
 	return fmt.Sprintf("%s commit=%s", semanticVersion(), Commit)
 }
 
+// BabylonVersion returns the version of Babylon that the executable was
+// compiled against, e.g., v0.12.0, or an empty string if it is unknown.
+func BabylonVersion() string {
+	return babylonVersion
+}
+
 // Tags returns the list of build tags that were compiled into the executable.
 func Tags() []string {
 	if len(RawTags) == 0 {